@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/natefinch/lumberjack"
@@ -85,6 +86,39 @@ func (h *loggerHook) Fire(entry *logrus.Entry) error {
 	return nil
 }
 
+// consoleLevel is the level of the console hook, stored atomically as the level of the global config
+// is only known once the config is loaded, after the hook has been added
+var consoleLevel = uint32(logrus.InfoLevel)
+
+// consoleHook writes the log entries up to the console level, unlike loggerHook its level can be
+// changed after the hook is added
+type consoleHook struct {
+	*loggerHook
+}
+
+// AddConsoleHook writes the log entries up to the level to the console
+func AddConsoleHook(out io.Writer, level logrus.Level, formatter logrus.Formatter) {
+	SetConsoleLevel(level)
+	logrus.AddHook(&consoleHook{loggerHook: NewloggerHook(out, logrus.TraceLevel, formatter)})
+}
+
+// SetConsoleLevel changes the level of the console output, the log file keeps all levels
+func SetConsoleLevel(level logrus.Level) {
+	atomic.StoreUint32(&consoleLevel, uint32(level))
+}
+
+// ConsoleLevel returns the level of the console output
+func ConsoleLevel() logrus.Level {
+	return logrus.Level(atomic.LoadUint32(&consoleLevel))
+}
+
+func (h *consoleHook) Fire(entry *logrus.Entry) error {
+	if entry.Level > ConsoleLevel() {
+		return nil
+	}
+	return h.loggerHook.Fire(entry)
+}
+
 // 设置日志文件的基本配置，包括创建日志目录，打开日志文件、设置日志格式等
 func SetuploggerHook(baseDir string) func() {
 	if err := os.MkdirAll(baseDir, 0755); err != nil {
@@ -16,6 +16,14 @@ limitations under the License.
 
 package opts
 
+import "os"
+
+const (
+	// PersistDirEnv overrides the default persist directory, the --persist-dir flag takes precedence
+	PersistDirEnv     = "NKD_PERSIST_DIR"
+	DefaultPersistDir = "/etc/nkd"
+)
+
 var Opts OptionsList
 
 var RootOpts struct {
@@ -50,6 +58,7 @@ type OptionsList struct {
 	CertificateKey       string
 	PreHookScript        string
 	PostHookYaml         string
+	UnitDropIns          string
 
 	NetWork NetworkConfig
 	Housekeeper
@@ -120,3 +129,15 @@ type Housekeeper struct {
 	MaxUnavailable     uint
 	OSImageURL         string
 }
+
+// ResolvePersistDir returns the persist directory given by the flag,
+// then by the NKD_PERSIST_DIR environment variable, then the default one
+func ResolvePersistDir(flagDir string) string {
+	if flagDir != "" {
+		return flagDir
+	}
+	if dir := os.Getenv(PersistDirEnv); dir != "" {
+		return dir
+	}
+	return DefaultPersistDir
+}
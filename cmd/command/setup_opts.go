@@ -41,7 +41,7 @@ func SetupDeployCmdOpts(deployCmd *cobra.Command) {
 	flags.UintVar(&opts.Opts.Worker.RAM, "worker-ram", 0, "RAM allocation for worker nodes (units: MB)")
 	flags.UintVar(&opts.Opts.Worker.Disk, "worker-disk", 0, "Disk size allocation for worker nodes (units: GB)")
 	flags.StringArrayVarP(&opts.Opts.Worker.IP, "worker-ips", "", []string{}, "IP addresses of worker nodes (e.g., --worker-ips [worker-ip-01] --worker-ips [worker-ip-02] ...)")
-	flags.StringVarP(&opts.Opts.Runtime, "runtime", "", "", "Container runtime type (docker, isulad or crio)")
+	flags.StringVarP(&opts.Opts.Runtime, "runtime", "", "", "Container runtime type (docker, containerd, isulad or crio)")
 	flags.StringVarP(&opts.Opts.ImageRegistry, "image-registry", "", "", "Registry address for Kubernetes component container images")
 	flags.StringVarP(&opts.Opts.PauseImage, "pause-image", "", "", "Image for the pause container (e.g., pause:TAG)")
 	flags.StringVarP(&opts.Opts.ReleaseImageUrl, "release-image-url", "", "", "URL of the NestOS container image containing Kubernetes component")
@@ -50,7 +50,8 @@ func SetupDeployCmdOpts(deployCmd *cobra.Command) {
 		"Sets the Kubernetes API version. Acceptable reference values:\n"+
 			"  - 1 for Kubernetes versions < v1.15.0,\n"+
 			"  - 2 for Kubernetes versions >= v1.15.0 && < v1.22.0,\n"+
-			"  - 3 for Kubernetes versions >= v1.22.0")
+			"  - 3 for Kubernetes versions >= v1.22.0 && < v1.31.0,\n"+
+			"  - 4 for Kubernetes versions >= v1.31.0")
 	flags.StringVarP(&opts.Opts.Token, "token", "", "", "Used to validate the cluster information obtained from the control plane, with non-control plane nodes used for joining the cluster")
 	flags.StringVarP(&opts.Opts.CertificateKey, "certificateKey", "", "", "The key that is used for decryption of certificates after they are downloaded from the secret upon joining a new master node.(the certificate key is a hex encoded string that is an AES key of size 32 bytes)")
 	flags.StringVarP(&opts.Opts.NetWork.ServiceSubnet, "service-subnet", "", "", "Subnet used by Kubernetes services. (default: 10.96.0.0/16)")
@@ -63,6 +64,7 @@ func SetupDeployCmdOpts(deployCmd *cobra.Command) {
 	flags.StringVarP(&opts.Opts.NKD.BootstrapIgnPort, "bootstrap-ign-port", "", "", "Ignition service port (default: 9080)")
 	flags.StringVarP(&opts.Opts.PreHookScript, "prehook-script", "", "", "Specify a script file or directory to execute before cluster deployment as hooks")
 	flags.StringVarP(&opts.Opts.PostHookYaml, "posthook-yaml", "", "", "Specify a YAML file or directory to apply after cluster deployment using 'kubectl apply'")
+	flags.StringVarP(&opts.Opts.UnitDropIns, "unit-dropins", "", "", "Specify a directory of systemd drop-ins laid out as <unit>.d/<name>.conf, added to all nodes")
 }
 
 func SetupDestroyCmdOpts(destroyCmd *cobra.Command) {
@@ -90,3 +92,15 @@ func SetupTemplateCmdOpts(templateCmd *cobra.Command) {
 	flags := templateCmd.Flags()
 	flags.StringVarP(&opts.Opts.ClusterConfigFile, "output", "o", "", "Generates a default configuration template at the specified location")
 }
+
+func SetupDiffCmdOpts(diffCmd *cobra.Command) {
+	flags := diffCmd.Flags()
+	flags.StringVarP(&opts.Opts.ClusterConfigFile, "file", "f", "", "Location of the changed cluster config file")
+	flags.StringVarP(&opts.Opts.ClusterID, "cluster-id", "", "", "Unique identifier for the cluster")
+}
+
+func SetupKubeadmConfigCmdOpts(kubeadmConfigCmd *cobra.Command) {
+	flags := kubeadmConfigCmd.Flags()
+	flags.StringVarP(&opts.Opts.ClusterConfigFile, "file", "f", "", "Location of a cluster config file rendered instead of the deployed cluster config")
+	flags.StringVarP(&opts.Opts.ClusterID, "cluster-id", "", "", "Unique identifier for the cluster")
+}
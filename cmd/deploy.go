@@ -24,11 +24,12 @@ import (
 	"nestos-kubernetes-deployer/data"
 	"nestos-kubernetes-deployer/pkg/configmanager"
 	"nestos-kubernetes-deployer/pkg/configmanager/asset"
-	"nestos-kubernetes-deployer/pkg/httpserver"
+	"nestos-kubernetes-deployer/pkg/ignition"
 	"nestos-kubernetes-deployer/pkg/ignition/machine"
 	"nestos-kubernetes-deployer/pkg/infra"
 	"nestos-kubernetes-deployer/pkg/kubeclient"
 	"nestos-kubernetes-deployer/pkg/osmanager"
+	"nestos-kubernetes-deployer/pkg/preflight"
 	"nestos-kubernetes-deployer/pkg/utils"
 	"net/http"
 	"os"
@@ -74,6 +75,9 @@ func runDeployCmd(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if err := checkClusterImages(); err != nil {
+		return err
+	}
 
 	if err := deployCluster(config); err != nil {
 		logrus.Errorf("Failed to deploy %s cluster: %v", clusterID, err)
@@ -111,7 +115,8 @@ func getClusterConfig(options *opts.OptionsList) (*asset.ClusterAsset, error) {
 		return nil, err
 	}
 
-	config, err := configmanager.GetClusterConfig(clusterID)
+	// generation changes the config, the copy is stored once the resource files are generated
+	config, err := configmanager.GetClusterConfigCopy(clusterID)
 	if err != nil {
 		logrus.Errorf("Failed to get cluster config using the cluster id: %v", err)
 		return nil, err
@@ -119,30 +124,40 @@ func getClusterConfig(options *opts.OptionsList) (*asset.ClusterAsset, error) {
 	return config, nil
 }
 
-// startHttpService initializes the HTTP file service, adds files to the cache, and starts the service.
-func startHttpService(conf *asset.ClusterAsset) (*httpserver.HttpFileService, error) {
-	fileService := httpserver.NewFileService(configmanager.GetBootstrapIgnPort())
-
-	// Ignition files are divided into three types:
-	// control plane ignition files for initializing the cluster,
-	// master ignition files for master node joining the cluster,
-	// and worker ignition files for worker node joining the cluster.
-	if len(conf.Master) > 0 {
-		fileService.AddFileToCache(machine.ControlplaneIgnFilename, conf.Master[0].CreateIgnContent)
-	}
-	if len(conf.Master) > 1 {
-		fileService.AddFileToCache(machine.MasterIgnFilename, conf.Master[1].CreateIgnContent)
+// checkClusterImages fails the deployment when an image of the cluster is missing or not accessible,
+// images that cannot be checked only produce a warning since the nodes may reach registries this host does not
+func checkClusterImages() error {
+	var unavailable []string
+	for _, result := range preflight.CheckImages(clusterID) {
+		switch result.Status {
+		case preflight.ImageFound:
+		case preflight.ImageNotFound, preflight.ImageUnauthorized:
+			logrus.Errorf("Image %s is not available: %s", result.Image, result.Status)
+			unavailable = append(unavailable, result.Image)
+		default:
+			logrus.Warnf("Unable to check image %s: %v", result.Image, result.Err)
+		}
 	}
-	if len(conf.Worker) > 0 {
-		fileService.AddFileToCache(machine.WorkerIgnFilename, conf.Worker[0].CreateIgnContent)
+	if len(unavailable) > 0 {
+		return fmt.Errorf("images not available: %s", strings.Join(unavailable, ", "))
 	}
+	return nil
+}
 
-	// Start the HTTP file service
-	if err := fileService.Start(); err != nil {
-		return nil, fmt.Errorf("error starting file service: %v", err)
+// startBootstrapServer serves the ignition of every master and worker at the path its merge ignition fetches
+func startBootstrapServer(conf *asset.ClusterAsset) (*ignition.BootstrapServer, error) {
+	// Every joining master has its own ignition file and
+	// workers of different node pools use different ignition files
+	configs, err := machine.NodeIgnitionConfigs(conf)
+	if err != nil {
+		return nil, err
 	}
 
-	return fileService, nil
+	server := &ignition.BootstrapServer{}
+	if err := server.Serve(":"+configmanager.GetBootstrapIgnPort(), configs); err != nil {
+		return nil, fmt.Errorf("error starting bootstrap server: %v", err)
+	}
+	return server, nil
 }
 
 func deployCluster(conf *asset.ClusterAsset) error {
@@ -155,13 +170,14 @@ func deployCluster(conf *asset.ClusterAsset) error {
 		logrus.Errorf("Error generating NestOS resource files: %v", err)
 		return err
 	}
+	configmanager.SetClusterConfig(conf)
 
-	// Start HTTP service
-	fileService, err := startHttpService(conf)
+	// Serve the ignition of the nodes until the cluster is created
+	bootstrapServer, err := startBootstrapServer(conf)
 	if err != nil {
 		return err
 	}
-	defer fileService.Stop()
+	defer bootstrapServer.Shutdown(context.Background())
 
 	if err := createCluster(conf); err != nil {
 		logrus.Errorf("Failed to create cluster: %v", err)
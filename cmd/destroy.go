@@ -50,22 +50,8 @@ func runDestroyCmd(cmd *cobra.Command, args []string) error {
 		logrus.Errorf("Failed to initialize configuration parameters: %v", err)
 		return err
 	}
-	persistDir := configmanager.GetPersistDir()
-
-	workerInfra := infra.InstanceCluster(persistDir, clusterID, "worker", 0)
-	if err := workerInfra.Destroy(); err != nil {
-		logrus.Errorf("Failed to perform the destroy worker nodes:%v", err)
-		return err
-	}
-	masterInfra := infra.InstanceCluster(persistDir, clusterID, "master", 0)
-	if err := masterInfra.Destroy(); err != nil {
-		logrus.Errorf("Failed to perform the destroy master nodes:%v", err)
-		return err
-	}
-
-	// delete asset files
-	if err := configmanager.Delete(clusterID); err != nil {
-		logrus.Errorf("Failed to clean the asset files")
+	if err := infra.DestroyCluster(clusterID); err != nil {
+		logrus.Errorf("Failed to destroy cluster %s: %v", clusterID, err)
 		return err
 	}
 
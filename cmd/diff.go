@@ -0,0 +1,72 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"nestos-kubernetes-deployer/cmd/command"
+	"nestos-kubernetes-deployer/cmd/command/opts"
+	"nestos-kubernetes-deployer/pkg/configmanager"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func NewDiffCommand() *cobra.Command {
+	diffCmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show the changes of a cluster config file against the deployed cluster",
+		RunE:  runDiffCmd,
+	}
+	command.SetupDiffCmdOpts(diffCmd)
+
+	return diffCmd
+}
+
+func runDiffCmd(cmd *cobra.Command, args []string) error {
+	if opts.Opts.ClusterID == "" || opts.Opts.ClusterConfigFile == "" {
+		err := errors.New("both cluster-id and file must be provided")
+		logrus.Errorf("Failed to diff cluster config: %v", err)
+		return err
+	}
+
+	// the config file is loaded after the persisted configs and replaces the one of the same cluster
+	if err := configmanager.Initial(&opts.Opts); err != nil {
+		logrus.Errorf("Failed to initialize configuration parameters: %v", err)
+		return err
+	}
+	proposed, err := configmanager.GetClusterConfig(opts.Opts.ClusterID)
+	if err != nil {
+		logrus.Errorf("Failed to get cluster config: %v", err)
+		return err
+	}
+
+	changes, err := configmanager.DiffClusterConfig(opts.Opts.ClusterID, proposed)
+	if err != nil {
+		logrus.Errorf("Failed to diff cluster config: %v", err)
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Println("No changes")
+		return nil
+	}
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+
+	return nil
+}
@@ -23,10 +23,10 @@ import (
 	"nestos-kubernetes-deployer/pkg/configmanager"
 	"nestos-kubernetes-deployer/pkg/configmanager/asset"
 	"nestos-kubernetes-deployer/pkg/httpserver"
-	"nestos-kubernetes-deployer/pkg/ignition/machine"
 	"nestos-kubernetes-deployer/pkg/infra"
 	"nestos-kubernetes-deployer/pkg/kubeclient"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -69,7 +69,7 @@ func runExtendCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	clusterConfig, err := configmanager.GetClusterConfig(clusterID)
+	clusterConfig, err := configmanager.GetClusterConfigCopy(clusterID)
 	if err != nil {
 		logrus.Errorf("Failed to get cluster config using the cluster id: %v", err)
 		return err
@@ -83,6 +83,8 @@ func runExtendCmd(cmd *cobra.Command, args []string) error {
 		logrus.Errorf("Failed to extend %s cluster: %v", clusterID, err)
 		return err
 	}
+	// the copy is only stored once the extended nodes are generated
+	configmanager.SetClusterConfig(clusterConfig)
 	if err := configmanager.Persist(); err != nil {
 		logrus.Errorf("Failed to persist the cluster asset: %v", err)
 		return err
@@ -112,6 +114,7 @@ func extendArray(c *asset.ClusterAsset, count int) []string {
 				Disk: c.Worker[i].Disk,
 			},
 			Ignitions: c.Worker[i].Ignitions,
+			Pool:      c.Worker[i].Pool,
 		})
 		newHostnames = append(newHostnames, hostname)
 	}
@@ -119,13 +122,15 @@ func extendArray(c *asset.ClusterAsset, count int) []string {
 }
 
 func extendCluster(conf *asset.ClusterAsset, fileService *httpserver.HttpFileService) error {
-	data, err := os.ReadFile(conf.Worker[0].CreateIgnPath)
-	if err != nil {
-		logrus.Errorf("error reading Ignition file: %v", err)
-		return err
+	// Workers of different node pools use different ignition files
+	for _, worker := range conf.Worker {
+		data, err := os.ReadFile(worker.CreateIgnPath)
+		if err != nil {
+			logrus.Errorf("error reading Ignition file: %v", err)
+			return err
+		}
+		fileService.AddFileToCache(filepath.Base(worker.CreateIgnPath), data)
 	}
-
-	fileService.AddFileToCache(machine.WorkerIgnFilename, data)
 	if err := fileService.Start(); err != nil {
 		logrus.Errorf("error starting file service: %v", err)
 		return err
@@ -0,0 +1,64 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"errors"
+	"nestos-kubernetes-deployer/cmd/command"
+	"nestos-kubernetes-deployer/cmd/command/opts"
+	"nestos-kubernetes-deployer/pkg/configmanager"
+	"nestos-kubernetes-deployer/pkg/ignition"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func NewKubeadmConfigCommand() *cobra.Command {
+	kubeadmConfigCmd := &cobra.Command{
+		Use:   "kubeadm-config",
+		Short: "Print the kubeadm config rendered into the ignition of the first master",
+		RunE:  runKubeadmConfigCmd,
+	}
+	command.SetupKubeadmConfigCmdOpts(kubeadmConfigCmd)
+
+	return kubeadmConfigCmd
+}
+
+func runKubeadmConfigCmd(cmd *cobra.Command, args []string) error {
+	if opts.Opts.ClusterID == "" {
+		err := errors.New("cluster-id must be provided")
+		logrus.Errorf("Failed to export kubeadm config: %v", err)
+		return err
+	}
+
+	// the config file, when given, replaces the persisted config of the cluster so it can be checked before deploying
+	if err := configmanager.Initial(&opts.Opts); err != nil {
+		logrus.Errorf("Failed to initialize configuration parameters: %v", err)
+		return err
+	}
+	config, err := ignition.RenderKubeadmConfig(opts.Opts.ClusterID, ignition.NodeRoleMaster)
+	if err != nil {
+		logrus.Errorf("Failed to export kubeadm config: %v", err)
+		return err
+	}
+	if _, err := os.Stdout.Write(config); err != nil {
+		logrus.Errorf("Failed to write kubeadm config: %v", err)
+		return err
+	}
+
+	return nil
+}
@@ -20,411 +20,483 @@ var Assets = func() http.FileSystem {
 	fs := vfsgen۰FS{
 		"/": &vfsgen۰DirInfo{
 			name:    "/",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime: time.Date(2026, 10, 16, 13, 22, 56, 0, time.UTC),
 		},
 		"/housekeeper": &vfsgen۰DirInfo{
 			name:    "housekeeper",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 188819507, time.UTC),
+			modTime: time.Date(2026, 10, 16, 12, 54, 1, 0, time.UTC),
 		},
 		"/housekeeper/1housekeeper.io_updates.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "1housekeeper.io_updates.yaml",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 188819507, time.UTC),
-			uncompressedSize: 2294,
+			modTime:          time.Date(2026, 10, 16, 12, 54, 1, 0, time.UTC),
+			uncompressedSize: 10675,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x54\x4d\x93\xe3\x44\x0c\xbd\xe7\x57\xa8\x8a\xc3\x5e\x88\xc3\xc0\x65\xf1\x6d\x6b\x80\xaa\x14\xcb\x32\x35\x99\xd9\xbb\xec\x56\x9c\x26\xed\xee\x46\x52\xa7\x76\xa0\xf8\xef\x54\xb7\x9d\x89\xed\xd9\xf9\xe0\x80\x6f\x96\xd4\x7a\x92\x9e\x9e\x30\xda\xcf\xc4\x62\x83\xaf\x01\xa3\xa5\x2f\x4a\x3e\xff\x49\x75\x7c\x2f\x95\x0d\x9b\xd3\xd5\xea\x68\xbd\xa9\xe1\x3a\x89\x86\xfe\x96\x24\x24\x6e\xe9\x27\xda\x5b\x6f\xd5\x06\xbf\xea\x49\xd1\xa0\x62\xbd\x02\x40\xef\x83\x62\x36\x4b\xfe\x05\x68\x83\x57\x0e\xce\x11\xaf\x3b\xf2\xd5\x31\x35\xd4\x24\xeb\x0c\x71\x49\x7e\x86\x3e\x7d\x57\xfd\x58\x7d\xbf\x02\x68\x99\xca\xf3\x3b\xdb\x93\x28\xf6\xb1\x06\x9f\x9c\x5b\x01\x78\xec\xa9\x86\x14\x0d\x2a\x49\x75\x08\x49\xe8\x48\x14\x4b\xa2\x95\x44\x6a\x33\x60\xc7\x21\xc5\x1a\x16\xde\xe1\xf1\x58\xd1\xd0\xcd\x7d\xc9\x53\x0c\xce\x8a\xfe\x3a\x31\x7e\xb4\xa2\xc5\x11\x5d\x62\x74\x8f\x98\xc5\x26\xd6\x77\xc9\x21\x9f\xad\x2b\x00\x69\x43\xa4\x1a\x3e\x65\x88\x88\x2d\x99\x15\xc0\xd8\x58\x81\x5c\x8f\xa5\x9f\xae\xd0\xc5\x03\x5e\x0d\x79\xda\x03\xf5\x38\x54\x04\x10\x22\xf9\x0f\x37\xdb\xcf\x3f\xec\x66\x66\x00\x43\xd2\xb2\x8d\x5a\x86\x34\x94\x07\x56\x40\x0f\x04\x43\x28\xec\x03\x97\xdf\xb1\x48\xf8\x70\xb3\x7d\x7c\x1d\x39\x44\x62\xb5\xe7\xd6\x87\x6f\x42\xf9\xc4\xba\xc0\x7a\x97\xcb\x19\xa2\xc0\x64\xae\x69\x40\x1d\x1b\x23\x33\x76\x00\x61\x0f\x7a\xb0\x02\x4c\x91\x49\xc8\x0f\xec\xcf\x12\x43\x0e\x42\x0f\xa1\xf9\x83\x5a\xad\x60\x47\x9c\xd3\x80\x1c\x42\x72\x26\xaf\xc8\x89\x58\x81\xa9\x0d\x9d\xb7\x7f\x3d\xe6\x16\xd0\x50\x40\x5d\xee\x4c\x17\x39\xad\x57\x62\x8f\x0e\x4e\xe8\x12\x7d\x0b\xe8\x0d\xf4\xf8\x00\x4c\x19\x05\x92\x9f\xe4\x2b\x21\x52\xc1\x6f\x81\x09\xac\xdf\x87\x1a\x0e\xaa\x51\xea\xcd\xa6\xb3\x7a\x5e\xf5\x36\xf4\x7d\xf2\x56\x1f\x36\x65\x6b\x6d\x93\x34\xb0\x6c\x0c\x9d\xc8\x6d\xc4\x76\x6b\xe4\xf6\x60\x95\x5a\x4d\x4c\x1b\x8c\x76\x5d\x4a\xf7\x65\xdd\xab\xde\x7c\xc3\xa3\x38\xe4\xdd\xac\x56\x7d\xc8\xfb\x21\xca\xd6\x77\x13\x47\x59\xc4\x17\x18\xc8\x3b\x99\xc9\xc6\xf1\xe9\xd0\xc5\x65\xd0\xd9\x94\xa7\x73\xfb\xf3\xee\x0e\xce\xd0\x85\x8c\xe5\xf4\xcb\xdc\x2f\x0f\xe5\x42\x41\x1e\x98\xf5\x7b\xe2\x81\xc4\x3d\x87\xbe\xe4\x24\x6f\x62\xb0\x5e\xcb\x4f\xeb\x2c\xf9\xe5\xf8\x25\x35\xbd\xd5\xcc\xfb\x9f\x89\x44\x33\x57\x15\x5c\x17\xfd\x43\x73\x5e\x47\x53\xc1\xd6\xc3\x35\xf6\xe4\xae\x51\xe8\x7f\x27\x20\x4f\x5a\xd6\x79\xb0\x6f\xa3\x60\x7a\xba\x96\xc1\xc3\xd4\x26\x8e\xf3\x8d\x79\x86\xaf\x41\x9d\xbb\x48\xed\x4c\x30\x86\xc4\x72\x5e\x69\xcd\xda\x0d\xfb\xe9\xe5\x79\x59\xa7\x65\x47\x52\x43\x5f\x15\xeb\xd3\x75\xb9\xbb\xa8\x13\x92\x90\xc9\xe2\x49\xb1\x63\x34\x04\xc7\xf7\x8b\x79\xbc\x30\x93\xb2\x31\xb2\xed\xb1\xa3\xfb\xdb\x8f\x6f\x41\xb5\x39\x16\x12\xbb\x27\xb8\xbf\xef\xfe\x13\x2c\x9d\x6c\xab\x37\xc1\xfc\x12\xb8\xa5\xd7\x90\xb7\x7b\x50\xce\xc2\xdf\xe7\xe8\xe1\x6d\x99\x78\x0c\xe6\x39\xd4\x26\x04\x47\xb8\xbc\x4e\x3d\x7e\xb9\xf7\x78\x42\xeb\xb0\x71\xaf\xe2\x7e\x4a\x7d\x43\x9c\x99\xf4\xc1\x14\x92\x51\x01\x99\xa0\xa1\xac\xc8\xb1\x75\x03\x38\x54\x23\xd8\x13\xa8\xed\xe9\xb9\x9a\xf2\x1d\xeb\x88\x67\xde\x2c\xaa\xbc\x35\xf3\x5a\xd6\xd3\x75\x58\x78\x2e\x8c\x2d\x1c\xb3\x99\x2e\x7c\xf3\xc6\xdf\xa4\x01\x45\x4d\xf2\xba\x0a\x4a\xd8\x4c\x07\xa1\x91\x7c\x73\x5e\x16\xc2\x57\x51\x9f\x18\x87\x44\x75\xa1\x7f\x30\x68\x60\xec\x68\x6a\x49\xcd\xe3\x31\x3e\x57\x3b\xd6\x0e\x7f\xff\xb3\xfa\x37\x00\x00\xff\xff\x66\xcf\xb8\xbd\xf6\x08\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xcc\x5a\x6b\x6f\x1b\xb9\x15\xfd\xae\x5f\x71\x80\x2e\xe0\xb6\x90\x64\x7b\xb7\x59\x6c\x84\x20\x45\xea\x34\x59\x23\xeb\xc4\xf0\x63\x17\xa8\x91\x02\xd4\xf0\x8e\xc4\x9a\xc3\x3b\x25\x39\x92\xb5\x6d\xff\x7b\x41\xce\xe8\x61\x59\xf3\x90\x12\xb7\xfb\x51\x7c\xdd\x73\xcf\xbd\x3c\x97\xe4\x48\xe4\xea\x67\xb2\x4e\xb1\x19\x41\xe4\x8a\x1e\x3c\x99\xf0\xcb\x0d\xef\x7f\x70\x43\xc5\xc7\xb3\xd3\xde\xbd\x32\x72\x84\xb3\xc2\x79\xce\xae\xc8\x71\x61\x13\x7a\x4b\xa9\x32\xca\x2b\x36\xbd\x8c\xbc\x90\xc2\x8b\x51\x0f\x10\xc6\xb0\x17\xa1\xd9\x85\x9f\x40\xc2\xc6\x5b\xd6\x9a\xec\x60\x42\x66\x78\x5f\x8c\x69\x5c\x28\x2d\xc9\xc6\xc5\x97\xa6\x67\x27\xc3\x97\xc3\x6f\x7b\x40\x62\x29\x4e\xbf\x51\x19\x39\x2f\xb2\x7c\x04\x53\x68\xdd\x03\x8c\xc8\x68\x84\x22\x97\xc2\x93\x1b\x4e\xb9\x70\x74\x4f\x94\xc7\x85\x7a\x2e\xa7\x24\x18\x9c\x58\x2e\xf2\x11\xb6\x7a\xcb\xc9\x15\xa2\xd2\x9b\xdb\xb8\x4e\x0f\x00\xb4\x72\xfe\xc3\x46\xe3\x4f\xca\xf9\x1e\x00\xe4\xba\xb0\x42\xaf\x6c\xf6\x00\xc0\x29\x33\x29\xb4\xb0\xcb\xd6\x1e\xe0\x12\xce\x69\x84\x8f\xc1\x44\x2e\x12\x92\x3d\xa0\x72\x2c\x9a\x1c\x54\xd0\x67\xa7\x42\xe7\x53\x71\x5a\xae\x93\x4c\x29\x8b\x94\x01\x00\xe7\x64\xde\x5c\x9e\xff\xfc\xdd\xf5\xa3\x66\x40\x92\x4b\xac\xca\x7d\x24\xa9\x84\x07\xe5\xe0\xa7\x84\x72\x28\x52\xb6\xf1\x67\x05\x12\x6f\x2e\xcf\x57\xb3\x73\xcb\x39\x59\xaf\x96\xae\x03\x00\xb0\x11\xf2\x8d\xd6\x2d\x5b\x47\x01\x4e\x39\x0a\x32\xc4\x9a\x4a\xab\x95\x63\x24\x2b\x0f\xc0\x29\xfc\x54\x39\x58\xca\x2d\x39\x32\x65\xf4\x1f\x2d\x8c\x30\x48\x18\xf0\xf8\x1f\x94\xf8\x21\xae\xc9\x86\x65\xe0\xa6\x5c\x68\x19\x52\x64\x46\xd6\xc3\x52\xc2\x13\xa3\x7e\x5d\xad\xed\xe0\x39\x1a\xd5\xc1\x33\xbf\xb5\xa6\x32\x9e\xac\x11\x1a\x33\xa1\x0b\xea\x43\x18\x89\x4c\x2c\x60\x29\x58\x41\x61\x36\xd6\x8b\x43\xdc\x10\x17\x6c\x09\xca\xa4\x3c\xc2\xd4\xfb\xdc\x8d\x8e\x8f\x27\xca\x2f\x53\x3d\xe1\x2c\x2b\x8c\xf2\x8b\xe3\x98\xb5\x6a\x5c\x78\xb6\xee\x58\xd2\x8c\xf4\xb1\x53\x93\x81\xb0\xc9\x54\x79\x4a\x7c\x61\xe9\x58\xe4\x6a\x10\xa1\x9b\x98\xee\xc3\x4c\xfe\xce\x56\x9b\xc3\x1d\x3d\xc2\xea\x17\x21\x3f\x9c\xb7\xca\x4c\x36\x3a\x62\x22\x36\x44\x20\xe4\x24\x94\x83\xa8\xa6\x96\x5e\xac\x89\x0e\x4d\x81\x9d\xab\xbf\x5e\xdf\x60\x69\x3a\x06\x63\x9b\xfd\xc8\xfb\x7a\xa2\x5b\x87\x20\x10\xa6\x4c\x4a\x36\xce\x43\x6a\x39\x8b\x6b\x92\x91\x39\x2b\xe3\xe3\x8f\x44\x2b\x32\xdb\xf4\xbb\x62\x9c\x29\x1f\xe2\xfe\xcf\x82\x9c\x0f\xb1\x1a\xe2\x2c\xee\x7f\x8c\x97\xe9\x28\x87\x38\x37\x38\x13\x19\xe9\x33\xe1\xe8\xd9\x03\x10\x98\x76\x83\x40\x6c\xb7\x10\x6c\x4a\xd7\xf6\xe0\x92\xb5\x8d\x8e\xa5\xc6\x00\x0d\xbb\xf3\x3a\xa7\xe4\xd1\x86\x91\xe4\x94\x0d\x29\xed\x85\x27\x70\xba\xa9\x3c\xcd\xfb\x14\x00\x82\x5e\xee\xdc\xac\x4f\xd3\xe5\x66\xbd\x3b\x51\x38\x92\xf0\x8c\x22\x9f\x58\x21\x09\xf7\x3f\xb8\xa3\x27\xd3\x6b\x38\x01\x00\x76\xe7\x99\x98\xd0\xed\xd5\x4f\x5d\xac\xaa\x30\x16\x85\xd5\x4f\xec\x7e\xba\xee\x83\x1e\x44\xe2\xf5\x02\x6c\xa2\xfb\xeb\xa5\xe3\x8e\x65\x77\x45\x29\xb2\xc2\xc5\xac\x71\xe4\xf7\xc4\x79\x45\x69\x17\x88\xec\xbc\x25\x82\xa5\x14\xc2\xe1\x95\xa5\x8c\x3d\xbd\x1e\xbd\x1a\x5b\x61\x92\xe9\xeb\x1d\xc0\xa1\x8c\xf3\x24\xe4\x63\xcc\x87\xb0\xf8\x56\x4d\xc8\xf9\x2e\x28\x65\x1c\x09\x57\x24\x53\x88\xa0\x8f\xe2\xdb\x17\xdf\x8f\x5e\x4d\xe9\xe1\x35\xfc\x8a\xe8\x47\x80\x4a\xea\xa6\x62\x46\x7b\x42\xbb\x2c\xb4\x7e\xab\xdc\xfd\x2f\x56\x85\x92\x97\xa9\x56\x84\x61\x34\xe6\x61\x38\x6c\x95\xcb\x36\xcf\x06\x25\xb5\x91\xbd\x63\xb7\x70\x96\xd9\x63\x3e\x55\x9a\x22\x64\x76\x15\x6a\xe5\x90\x17\x5a\x93\x8c\x61\x97\x94\x6b\x5e\x90\xec\x43\x19\x8c\x17\x9e\x1c\x72\xb2\x70\x94\xb0\x91\x98\x2b\x3f\x8d\x05\x23\x9a\x16\x1a\x1f\xfa\xb8\xe8\xe3\x3d\xd8\xe2\x06\xae\x48\x53\xf5\xb0\x62\xe9\xf4\xe4\xa2\x0f\xc3\x1e\x3a\x38\x41\x12\xf3\x29\x19\x14\xc6\x91\x1f\xe2\xdc\x43\x32\xb9\x75\x7f\x04\x65\xc8\xcf\xd9\xde\x63\x2c\x8c\x9c\x2b\xe9\xa7\x4f\xb9\xcb\x85\x0f\xe5\x65\x84\xa3\xbf\xdf\x9d\x0e\x5e\x7e\xbe\x3b\x19\xbc\xfc\xfc\xc7\xbb\x0f\x17\xef\x6f\x3e\xff\xf9\x9b\x03\xb8\xfe\xcb\xd2\x56\x27\xae\x3f\x56\x08\x23\xd1\xdb\x3c\x4a\x9e\x1b\xcd\x42\x52\xc5\x14\x1b\x90\x48\xa6\x30\x2c\xe9\x79\x09\x7d\x56\x9e\x68\xa6\x12\x7f\xc9\xf2\x1d\xdb\x84\xda\x08\x3a\x4f\xe1\x6d\x28\xfb\x69\x18\x5d\xce\x8d\x44\xe5\x2c\xeb\xac\x8e\x99\x35\x89\xed\xb3\x49\x26\x1e\x6e\x8d\x98\x09\xa5\xc5\x58\xb7\xda\xfd\x58\x64\x63\xb2\xe0\x34\xb2\x1d\x24\x5e\x78\x08\x4b\x18\x53\xa8\xc7\x95\x7e\x48\x88\x12\x8d\x13\x19\xc1\xab\xac\x76\x77\x86\x53\xcc\x84\xec\x56\xaf\xb4\x42\xc5\x23\x30\x17\xfe\x3a\x86\xd0\xb5\xea\x47\x39\x1a\xca\x54\x41\x77\x81\x9a\x72\xa5\x80\x4c\x54\xe9\x21\x29\x15\x85\x8e\xf5\x1a\xa7\x2f\x90\x29\x53\x78\x72\x07\xc0\x7b\x6f\x45\x42\x97\x64\x15\xcb\x8e\x10\xe3\x8c\x90\x96\x8a\xe5\x36\xce\x18\x40\x92\x21\x7c\xae\xbf\x0a\xe4\x91\x03\xcf\x4d\x75\xf0\x51\xae\x94\xe9\xa6\x74\x6c\x42\x3d\xd6\x9c\xdc\x7f\x32\x67\x56\x79\x95\x08\x7d\xc9\xd2\x75\xce\x32\xc9\x71\x47\x44\xc7\xab\xc8\x4f\xd9\xc5\x23\x58\x52\xad\x17\xaf\x06\x9a\x3c\x9b\xe8\xc4\x7e\x49\x18\x4f\x66\x76\x46\x1f\x59\xd2\xc5\xce\x43\x49\x03\x38\x4b\xce\xb3\xa5\xea\xa8\x3c\x26\xed\xfa\x9b\x17\xb1\x28\xb8\x5e\x28\xe3\xdd\x3a\x6f\x2d\x0d\x2c\x4d\x94\xf3\x64\x43\xb6\xa6\x9e\xca\x6b\xc4\xa7\xeb\x65\x0e\xc3\xd2\x98\xd9\xef\xe7\x47\xdc\x8d\xb7\xe5\x02\x9d\xf1\x2f\x0d\x96\xc8\xc4\x24\x70\x4c\x33\x32\xf0\x53\x2e\x26\xd3\x80\x4b\xd9\xd5\xa8\x78\x2b\x44\xaa\x34\x39\xd0\x83\x72\xbe\x0f\x36\x65\x5e\x61\x42\x86\x6c\xf4\x7a\x88\x5b\x47\x60\xa3\x17\x95\x73\xca\x84\xf3\xdb\xea\xd0\x1c\x6d\xed\xe7\x5b\xc2\x56\xb2\xf9\x64\xf4\xa2\xb3\x67\xd1\x7e\x39\x6f\x6d\xb5\x8c\x11\xc9\x40\xd6\xd2\xab\x98\xf2\x0b\x08\x4b\x65\x8e\x55\x65\x72\x25\x27\xd1\x43\xe5\xa1\x1c\x12\x4d\xc2\xd2\x9e\x22\x97\x33\xeb\x4b\xd6\x2a\xd9\x71\xc6\xdc\x46\x7f\xc5\x5a\x07\x25\xc9\xab\xf1\xe5\x05\x37\x16\xf8\x80\x3f\xae\xb5\x83\x3a\xe5\x29\xdb\xb1\x76\xd3\xe9\x76\x8d\x6d\x77\xcf\x13\xf1\x0d\x6a\xca\xe9\x8a\xca\x38\xf5\xa8\x66\x6a\x43\xa5\xe9\x26\xfc\x5d\xe4\x5f\x99\x4a\xab\x58\x7f\x41\x29\x68\xd7\x2e\x00\x00\x12\x61\x84\x5d\x7c\x39\xd2\x8d\xb4\x22\x04\xd0\x11\x17\xc6\x94\x2e\x65\x24\x48\xca\x92\xe9\x76\x92\x9b\x30\xcf\x95\x91\x3c\xef\x86\xf9\x22\x88\x14\x19\x11\x92\xbd\x9c\x57\x81\xdf\x40\x02\xe7\x85\xf5\x95\x0b\x81\x67\x65\x10\x76\x17\xe7\x61\x54\xe5\xd9\x2f\x71\x72\x3f\xfa\x66\x16\xa5\x77\x4d\x65\xa3\x5b\xa2\x02\x28\xad\xd7\x77\xef\xaa\xca\x01\x97\x14\x8b\xe8\x41\xe5\x55\x78\xef\x71\x10\x0e\x3f\xfe\x38\xba\xb8\x38\x6a\x58\x6e\xe3\x7c\xf5\xfb\xbb\x93\xd3\xf2\x7c\xf5\xef\x6f\xef\x4e\x06\xdf\x7d\xfe\xc3\xe8\xee\x64\xf0\xa2\x6c\xfa\xa6\x69\x91\xd6\xad\x00\x00\xb4\xfd\x1a\x71\x80\x5f\x89\x66\x47\x6b\xc7\xfa\x9b\x7d\x14\x4a\xfd\x52\x07\xe9\xc1\xc7\xb9\x31\x28\xa5\xae\x55\xd9\x17\x09\xfe\x6d\x30\x22\xc5\xc2\x75\xa7\xe4\xad\x58\xac\x12\x75\x4e\x74\xff\x34\xde\x6c\xfa\xab\x43\xf6\x05\x1b\xb0\xc5\xb5\xf0\xfd\x50\xeb\xec\x62\x4d\x07\x65\xb9\x5f\x34\x81\xaf\x55\xda\x3d\xfd\x5b\x0e\x13\xd6\x8a\x45\xed\xa8\xb0\x79\xfe\xc6\x86\xba\x33\x71\xfe\xe6\xe3\x9b\x38\x0d\xbf\x56\x97\xfe\x18\xd4\x58\xd1\xc8\xc8\xc7\x67\xd0\xdb\x9b\xb3\x2f\x0c\x54\x78\x81\x0a\x4f\x2c\x75\x00\x07\xa5\xf9\xda\x5e\x32\xb2\x51\xdd\x9e\x3c\x04\x75\x31\x3c\x88\x62\xb5\xb3\xe3\x71\xdd\xe9\xed\x69\xb6\x3e\x64\x8f\xb4\xaf\xf5\x32\x2f\x94\x5e\x20\xab\x13\xdc\xda\x83\x88\x32\xfd\x8d\x21\x9b\x35\xae\x93\xd0\x36\x4b\x6c\x83\xb8\x7e\x35\x59\xfd\x42\xf9\x68\xc9\xc7\x5a\x11\xfd\x0d\xc8\xe7\xf3\x7a\x5e\x2f\x96\xff\x7b\x99\x6c\x14\xc8\x16\x3f\xda\x44\xb1\x59\x0e\x9f\x43\x08\x1b\x01\xd7\x6b\x50\x9d\xec\xed\x16\xbc\x5a\xcd\x61\x43\x9f\xb6\x9e\x58\x07\xb5\x56\x07\x1b\x2f\x93\x7b\x4c\xb9\xa2\xb4\xd7\xee\xd4\x60\xf3\x35\x7c\xab\xe7\xd1\xdb\x51\xaf\xb3\xde\xd6\x78\x1d\xde\xea\x0b\xd7\xfe\xd6\x1f\x87\x3d\x7a\xed\xe7\x71\xbc\xc6\x1f\xfa\xdc\x1f\xde\x43\xd4\xc6\xd7\xd3\xfa\xd4\x3a\x5b\x8d\x5c\x6e\xa6\x4a\x8b\x97\x3f\x13\x5d\x84\xcb\x7d\xd8\x4a\x59\x26\x6c\xfc\x0c\x36\x2e\xd5\x26\x58\x17\x9e\xed\xfa\x73\x4f\xf2\x64\xb5\x9a\xfb\xf1\xc1\x97\xbc\xc8\x74\xa7\x4b\xc0\xdb\xea\xc5\xaa\x8f\xdb\xe5\x19\xbf\x8f\x33\xce\x72\x4d\x9e\x24\xd8\xe2\x9d\x50\x7a\xd7\x05\xb8\xe3\xfe\xde\x15\xdd\x5a\xa1\x8e\xd7\xf8\x77\x42\x3b\x02\x5b\xdc\x9a\x7b\xc3\x73\x73\xb0\xe9\x65\x7a\xbc\x5f\xbd\x53\xd4\xc1\x48\xd9\x66\xc2\xc7\xab\xd5\xf7\x7f\x3a\xf8\xf2\xa5\x85\xf3\x37\x56\x18\xa7\x96\xdf\xd4\xdb\xec\x85\x64\x1d\x04\xc9\x3a\xd4\x45\x4b\xc2\xd5\xbb\xd5\x7e\x29\x27\xe7\xc4\x84\x0e\x9c\xdf\x7c\x22\x0b\x93\x7b\x35\xe7\x43\x5f\xb8\x9d\x5d\x4f\x19\xdc\x39\xac\xf4\x7a\x67\x57\xe5\xd1\xd7\x3b\xe7\x01\x0f\x83\xa0\x84\xd6\x90\x27\x37\x08\x7f\x5f\x18\x64\x22\x1f\xdc\xd3\xae\x0a\x5c\xe3\xf6\xd3\x25\x4a\x83\x99\xc8\xb7\xc6\x46\x25\x68\x93\xa3\x4a\x0a\x39\x5d\x7f\x89\x80\x70\x50\x1e\x19\xcf\xa2\x36\xda\xf8\x94\xb7\x7e\x8e\x5e\x1d\x2a\xe3\xfe\x76\xe4\x97\xea\xb4\xfe\xc7\xc8\xa6\x16\x7d\x45\x29\x8a\xff\xc6\x38\xe8\xbd\xe9\xe0\x7d\xdf\xa4\xeb\x07\xa9\x7b\x13\x9a\x96\xeb\x61\x1b\x3d\xed\x7a\xfd\x1c\xaa\xdd\x91\xc8\x2e\x0a\xfe\xe5\x3a\xbe\x17\x98\xee\x9a\xde\x5d\xd9\xbb\xea\xfb\xbe\x2a\xbf\x9f\xd6\xef\x45\x43\xb3\xee\xef\xb5\x54\x4b\x0d\xd8\x63\xad\xb6\xa7\x81\x86\xaa\xd0\x5a\x1b\xf6\xaa\x10\xad\x75\xa2\xbd\x5a\x74\xa8\x19\xed\x95\x63\xff\xfa\xd1\x81\xa6\xee\xb5\xa4\xbd\x46\x07\x71\xfe\xff\x95\xca\x9d\xd6\xbb\xba\xb7\x13\xe3\x93\xc6\x52\x2c\x46\xf1\x03\x51\xd9\xe0\xd9\x86\x6c\xdf\x68\x29\xc6\xab\x7f\x92\x2d\x51\x56\x92\x87\x7f\xfd\xa7\xf7\xdf\x01\x00\xd4\xb2\x36\x31\xb3\x29\x00\x00"),
 		},
 		"/housekeeper/2namespace.yaml": &vfsgen۰FileInfo{
 			name:    "2namespace.yaml",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 188819507, time.UTC),
+			modTime: time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			content: []byte("\x61\x70\x69\x56\x65\x72\x73\x69\x6f\x6e\x3a\x20\x76\x31\x0a\x6b\x69\x6e\x64\x3a\x20\x4e\x61\x6d\x65\x73\x70\x61\x63\x65\x0a\x6d\x65\x74\x61\x64\x61\x74\x61\x3a\x0a\x20\x20\x6e\x61\x6d\x65\x3a\x20\x68\x6f\x75\x73\x65\x6b\x65\x65\x70\x65\x72\x2d\x73\x79\x73\x74\x65\x6d"),
 		},
 		"/housekeeper/3role.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "3role.yaml",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 188819507, time.UTC),
-			uncompressedSize: 773,
+			modTime:          time.Date(2026, 10, 16, 10, 0, 5, 0, time.UTC),
+			uncompressedSize: 847,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x92\x41\x6b\xfb\x30\x0c\xc5\xef\xfe\x14\xa2\xf7\xa4\xfc\x6f\x7f\x72\xdd\x61\xf7\x31\x76\x57\xe3\xb7\x56\xd4\xb1\x8d\x64\x77\xd0\x4f\x3f\xea\x66\x30\x92\x6e\x8c\x9c\xfc\x90\xd1\xef\x3d\xd9\xe2\x2c\x6f\x50\x93\x14\x07\xd2\x03\x8f\x3d\xd7\x72\x4a\x2a\x57\x2e\x92\x62\x7f\xfe\x6f\xbd\xa4\xfd\xe5\x9f\x3b\x4b\xf4\x03\x3d\x85\x6a\x05\xfa\x92\x02\xdc\x84\xc2\x9e\x0b\x0f\x8e\x68\x54\xb4\x86\x57\x99\x60\x85\xa7\x3c\x50\xac\x21\x38\xa2\xc8\x13\x06\xaa\xd9\x73\x41\x37\x71\xe4\x23\xb4\xd3\x5b\xbf\xd6\x00\x1b\x5c\x47\x9c\xe5\x59\x53\xcd\x76\x23\x75\x74\x4a\xd5\x70\x06\x32\xb4\x97\xe4\x88\x14\x96\xaa\x8e\x98\xef\xef\x2c\x73\x44\x17\xe8\x61\x2e\xb6\x00\x68\xd2\x23\x60\x96\x47\x94\x76\x06\xb1\xbb\xc8\x5c\xc6\xd3\x37\x4a\x93\x1f\xad\xb8\x35\xc7\xfe\x5d\x22\x07\xb9\x42\x17\x91\x66\x87\xcd\x5c\x2b\x5c\xea\x82\xf9\x35\xd0\x6a\x8e\x95\xcb\x6e\xb7\x26\xc7\xe4\xf1\x03\x70\xd3\x0b\x3d\xf2\xc8\xc9\xff\x66\xf1\x67\xc6\x1e\x17\x19\x6f\x2b\xf5\xf0\x9f\x57\x18\xce\xd9\xd6\x20\xcf\x98\x52\x34\x94\x45\xa4\xc5\x8a\x7c\x06\x00\x00\xff\xff\xe4\x20\x3c\x4b\x05\x03\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x92\xb1\x6e\xf3\x30\x0c\x84\x77\x3d\x05\x91\xdd\x0e\xfe\xed\x87\xd7\x0e\xdd\x8b\xa2\x3b\x63\x5d\x13\x22\xb2\x24\x90\x92\x0b\xe4\xe9\x8b\x38\x1e\x0a\xdb\x29\x0a\x4f\x3e\xc8\xe0\x77\xc7\x03\x39\xcb\x07\xd4\x24\xc5\x8e\xf4\xc4\x7d\xcb\xb5\x5c\x92\xca\x8d\x8b\xa4\xd8\x5e\xff\x5b\x2b\xe9\x38\xfe\x73\x57\x89\xbe\xa3\x97\x50\xad\x40\xdf\x52\x80\x1b\x50\xd8\x73\xe1\xce\x11\xf5\x8a\x69\xe0\x5d\x06\x58\xe1\x21\x77\x14\x6b\x08\x8e\x28\xf2\x80\x8e\x6a\xf6\x5c\xd0\x0c\x1c\xf9\x0c\x6d\xf4\x3e\xaf\x35\xc0\x3a\xd7\x10\x67\x79\xd5\x54\xb3\xdd\x49\x0d\x5d\x52\x35\x5c\x81\x0c\x6d\x25\x39\x22\x85\xa5\xaa\x3d\xe6\xff\x0f\x96\x39\xa2\x11\x7a\x9a\x1f\xa7\x00\x98\xa4\x47\xc0\x2c\xcf\x28\xd3\x37\x88\x3d\x44\xe6\xd2\x5f\x7e\x50\x26\xf9\x35\x3d\xee\xcd\x71\xfc\x94\xc8\x41\x6e\xd0\x45\xa4\xd9\x61\x37\xd7\x0a\x97\xba\x60\x9e\xf1\x64\x8f\x95\xcb\xe1\xb0\x26\x63\x44\x2c\xcf\x8b\xcb\xdb\x3d\x6c\x91\x62\xf2\x78\x12\x6d\x57\xd7\x5b\x1e\x39\xf9\xdf\x2c\xfe\xcc\x38\x62\x94\xfe\x7e\x9c\x9b\x8b\xaf\x30\x9c\xb3\xad\x41\x9e\x31\xa4\x68\x58\xd6\xb7\x38\xb6\xef\x01\x00\x90\x8e\x31\xee\x4f\x03\x00\x00"),
 		},
 		"/housekeeper/4role_binding.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "4role_binding.yaml",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 188819507, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 286,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x8d\xbd\x4a\x03\x41\x14\x85\xfb\x79\x8a\x79\x81\x5d\xb1\x93\xe9\xd4\xc2\x3e\x82\xfd\xdd\x99\x93\xe4\xba\xbb\x73\x87\xfb\x13\xd0\xa7\x97\x40\xb4\x11\xd2\x9d\x03\xdf\xc7\x47\x83\x3f\xa0\xc6\xd2\x4b\xd6\x85\xea\x4c\xe1\x67\x51\xfe\x26\x67\xe9\xf3\xfa\x64\x33\xcb\xc3\xe5\x31\xad\xdc\x5b\xc9\xaf\x5b\x98\x43\x0f\xb2\xe1\x85\x7b\xe3\x7e\x4a\x3b\x9c\x1a\x39\x95\x94\x73\xa7\x1d\x25\xc7\x68\xe4\x98\x76\xea\x74\x82\x4e\x2a\x1b\x96\x1b\x7c\xdd\x07\x1c\xaf\x2c\x0d\x7e\x53\x89\x71\xa7\x9b\x72\xfe\x97\xbd\x57\x49\x16\xcb\x27\xaa\x5b\x49\xd3\xcd\x7c\x87\x5e\xb8\xe2\xb9\x56\x89\xee\x7f\x72\xc3\x91\x62\xfb\xfd\x36\xa8\xa2\xe4\xb3\x84\x61\x05\x06\x74\xb2\x2f\x73\xec\x3f\x01\x00\x00\xff\xff\x54\x83\xe7\x45\x1e\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x8d\xbd\x4a\x03\x41\x14\x85\xfb\x79\x8a\x79\x81\x5d\xb1\x93\xe9\xd4\xc2\x3e\x82\xfd\xdd\x99\x93\xe4\xba\xbb\x73\x87\xfb\x13\xd0\xa7\x97\x40\xb4\x11\xd2\x9d\x03\xdf\xc7\x47\x83\x3f\xa0\xc6\xd2\x4b\xd6\x85\xea\x4c\xe1\x67\x51\xfe\x26\x67\xe9\xf3\xfa\x64\x33\xcb\xc3\xe5\x31\xad\xdc\x5b\xc9\xaf\x5b\x98\x43\x0f\xb2\xe1\x85\x7b\xe3\x7e\x4a\x3b\x9c\x1a\x39\x95\x94\x73\xa7\x1d\x25\xc7\x68\xe4\x98\x76\xea\x74\x82\x4e\x2a\x1b\x96\x1b\x7c\xdd\x07\x1c\xaf\x2c\x0d\x7e\x53\x89\x71\xa7\x9b\x72\xfe\x97\xbd\x57\x49\x16\xcb\x27\xaa\x5b\x49\xd3\xcd\x7c\x87\x5e\xb8\xe2\xb9\x56\x89\xee\x7f\x72\xc3\x91\x62\xfb\xfd\x36\xa8\xa2\xe4\xb3\x84\x61\x05\x06\x74\xb2\x2f\x73\xec\x3f\x03\x00\x54\x83\xe7\x45\x1e\x01\x00\x00"),
 		},
 		"/housekeeper/5deployment.yaml.template": &vfsgen۰CompressedFileInfo{
 			name:             "5deployment.yaml.template",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 188819507, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 985,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x94\x92\x49\x6b\x1c\x41\x0c\x85\xef\xf3\x2b\x44\xdf\x7b\x96\xe4\x56\xb7\x90\x98\x10\x48\xc8\x80\x49\xee\x72\xf5\xcb\xb8\x18\xd5\x12\xa9\xda\x76\x63\xfc\xdf\x43\xdb\xb3\x74\x1b\x82\x3d\x3a\xaa\x9e\x3e\x49\xa5\xc7\x25\xfc\x86\x5a\xc8\xc9\x11\x97\x62\xab\xbb\xcd\x62\x1f\x52\xe7\xe8\x0b\x8a\xe4\x21\x22\xd5\x45\x44\xe5\x8e\x2b\xbb\x05\x51\xe2\x08\x47\xb7\xb9\x37\xec\x81\x02\x6d\x73\x81\x72\xcd\xda\x46\x4e\xbc\x83\x1e\x44\x56\xd8\xbf\x52\xda\x60\x15\x71\x41\x24\x7c\x03\xb1\x11\x47\xe4\x73\xaa\x9a\xa5\x2d\xc2\xe9\x2d\xb2\x15\xf8\xb1\xca\x20\xf0\x35\xeb\x0b\x21\x72\xf5\xb7\xdf\x27\xc8\xcb\xa0\x44\x8a\x22\xc1\xb3\x39\xda\x2c\x88\x2a\x62\x11\xae\x38\xc0\x27\xbb\x8f\x21\xb3\x3e\x97\x76\x22\x3a\xae\x70\xac\xe5\x90\xa0\x27\x5e\x4b\x3e\xc7\xc8\xa9\x3b\x37\x68\x69\xf5\x26\x74\x8c\x10\x79\x07\x47\x8f\x8f\xcb\x9f\x07\xc9\xb7\x31\xf3\x4b\xe5\xe9\x69\x2e\xda\xf6\x22\xdb\x2c\xc1\x0f\x8e\x3e\xc9\x3d\x0f\x76\x7a\x7f\xd7\x75\x5f\xc2\xe0\x7b\x0d\x75\xf8\x9c\x53\xc5\x43\x3d\x0f\x4c\xc4\x22\xf9\x7e\xab\xe1\x2e\x08\x76\xb8\x32\xcf\xc2\xf5\xd9\x62\x7f\x58\x0c\x27\xa5\xc2\x72\xaf\x1e\x36\x2d\x96\x10\x43\x9d\x65\x88\x7c\xe9\x1d\x6d\xd6\xeb\x38\xcb\x46\xc4\xac\x83\xa3\x8f\xeb\x1f\x61\xf2\xa0\xf8\xdb\xc3\x2e\x43\x7c\x38\x23\x2a\x34\x86\xf4\x3c\xef\x57\x65\x8f\x2d\x34\xe4\xee\x1a\x3e\xa7\x6e\x74\xc8\xfa\xa0\x4b\xb9\xc3\xf5\xcc\x87\xc7\x6c\xab\x59\xb0\xdc\xf7\x37\xd0\x84\x0a\x5b\x86\xbc\x7a\x65\x93\xa6\x39\x76\xcb\x32\xfe\x6f\xc8\xc9\xa6\x27\xdf\x63\x70\xd4\xfc\x0f\x16\xd9\x2a\xb4\x99\x6c\x72\xbc\x92\xa3\xe6\xea\x21\x58\xb5\xe6\x5f\x00\x00\x00\xff\xff\x47\x90\x58\x37\xd9\x03\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x94\x92\x4f\x6b\x1c\x31\x0c\xc5\xef\xfb\x29\xc4\xdc\x67\xff\xb4\x37\xdf\x4a\x1b\x4a\xa1\xa5\x0b\xa1\xbd\x2b\x9e\xd7\x8d\x59\xdb\x72\x25\x4f\x92\x21\xe4\xbb\x97\x49\xf6\xcf\x4c\xa0\x24\xeb\xa3\xf4\xf4\x93\x64\x3d\x2e\xe1\x37\xd4\x82\x64\x47\x5c\x8a\xad\xee\x36\x8b\x7d\xc8\x9d\xa3\x2f\x28\x51\x86\x84\x5c\x17\x09\x95\x3b\xae\xec\x16\x44\x99\x13\x1c\xdd\x4a\x6f\xd8\x03\x05\xda\x4a\x81\x72\x15\x6d\x13\x67\xde\x41\x0f\x22\x2b\xec\x5f\x29\x6d\xb0\x8a\xb4\x20\x8a\x7c\x83\x68\x23\x8e\xc8\x4b\xae\x2a\xb1\x2d\x91\xf3\x5b\x64\x2b\xf0\x63\x95\x21\xc2\x57\xd1\x17\x42\xe2\xea\x6f\xbf\x4f\x90\x97\x41\x89\x14\x25\x06\xcf\xe6\x68\xb3\x20\xaa\x48\x25\x72\xc5\x01\x3e\xd9\x9d\x68\x3e\xfa\xe5\x9d\x88\x8e\x2b\x1c\x6b\x39\x64\xe8\x89\xd7\x92\x97\x94\x38\x77\xe7\x06\x2d\xad\xde\x84\x8e\x2f\x24\xde\xc1\xd1\xe3\xe3\xf2\xe7\x41\xf2\x6d\x8c\xfc\xd2\xf8\xf4\x34\x17\x6d\xfb\x18\xb7\x12\x83\x1f\x1c\x7d\x8a\xf7\x3c\xd8\x29\xff\xae\xeb\xbe\x3c\x83\xef\x35\xd4\xe1\xb3\xe4\x8a\x87\x7a\x1e\x98\x88\x63\x94\xfb\xad\x86\xbb\x10\xb1\xc3\x95\x79\x8e\x5c\x9f\x2d\xf6\x87\xa3\xe1\xa4\x54\x98\xf4\xea\x61\xd3\xe2\x18\x52\xa8\xb3\x08\x91\x2f\xbd\xa3\xcd\x7a\x9d\x66\xd1\x84\x24\x3a\x38\xfa\xb8\xfe\x11\x26\x09\xc5\xdf\x1e\x76\x19\xe2\xc3\x19\x51\xa1\x29\xe4\xe7\x79\xbf\x2a\x7b\x6c\xa1\x41\xba\x6b\x78\xc9\xdd\xe8\x90\xf5\x41\x97\xa5\xc3\xf5\xcc\x87\xc7\x68\xab\x12\xb1\xdc\xf7\x37\xd0\x8c\x0a\x5b\x06\x59\xbd\xb2\x49\xd3\x1c\xbb\x49\x1c\xff\x37\x48\xb6\xe9\xc9\xf7\x18\x1c\x35\xff\x83\x25\xb6\x0a\x6d\x26\x9b\x1c\xaf\xe4\xa8\xb9\x7a\x08\x56\xad\xf9\x37\x00\x47\x90\x58\x37\xd9\x03\x00\x00"),
 		},
 		"/housekeeper/6daemonset.yaml.template": &vfsgen۰CompressedFileInfo{
 			name:             "6daemonset.yaml.template",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 188819507, time.UTC),
-			uncompressedSize: 1138,
+			modTime:          time.Date(2026, 10, 16, 11, 28, 47, 0, time.UTC),
+			uncompressedSize: 1442,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xac\x93\x41\x6b\x1b\x31\x10\x85\xef\xfe\x15\x83\xef\xeb\x25\x57\xdd\x42\x93\x42\xa1\x71\x4d\x43\x7b\x2d\x93\xdd\x67\xaf\xd8\x91\x46\x48\xda\x4d\x4d\xc8\x7f\x2f\x8a\x5d\x7b\xd7\x14\xe2\x40\x75\x9c\x79\xf3\xcd\x3c\x31\xc3\xc1\xfe\x44\x4c\x56\xbd\x21\x0e\x21\xd5\xe3\xcd\xa2\xb7\xbe\x35\x74\xc7\x70\xea\x1f\x91\x17\x0e\x99\x5b\xce\x6c\x16\x44\x9e\x1d\x0c\x75\x3a\x24\xf4\x40\x40\xac\x1a\xf5\x39\xaa\x08\x62\xe5\xd8\xf3\x0e\xf1\x28\x4b\x81\x9b\x0b\x6d\xda\xa7\x0c\xb7\x20\x12\x7e\x82\xa4\x02\x24\x3a\x02\xaa\x20\xec\xdf\x67\xa7\x80\xa6\xd4\x25\x08\x9a\xac\xf1\xc0\x70\x9c\x9b\xee\xeb\x04\xfa\x51\x2c\x51\x86\x0b\xc2\x19\x47\xe0\xc4\x73\x79\x32\x63\x7f\x9c\x4e\xf4\x77\xf0\xf2\xb2\x0a\x22\x67\xab\x7e\x82\xac\xa8\xc7\xde\xd0\xd2\x6b\x8b\x2a\xaa\x60\xd5\x0f\x4f\x88\x1e\x19\x69\x65\xb5\x76\x9c\x32\xe2\xf2\xa4\x27\xd2\x50\x28\x1a\x0d\x2d\xef\x7f\xdb\x94\xd3\x34\x89\xed\x16\x4d\x36\xb4\x5c\xeb\x63\xd3\xa1\x1d\x04\xcb\xab\x7b\x3d\x6b\xec\xff\x4f\xaf\xf2\x1b\x6c\x3d\xe2\xd9\x69\x75\xed\x16\x9d\xfe\xda\x39\xf6\xad\x99\x34\xac\xa8\xbe\xb6\xda\x3a\xde\xc1\xd0\xcb\xcb\xea\xd3\x49\xf5\xa5\xc4\x7e\x44\x79\x7d\xbd\xd0\x6d\x06\x91\x8d\x8a\x6d\xf6\x86\x6e\xe5\x99\xf7\xe9\x2c\x18\x55\x06\x87\x07\x1d\x7c\x4e\xf3\x59\x0e\x76\x86\xb0\x8b\xdc\xa2\x6a\xdf\x0e\x67\x22\x20\x72\xa5\x68\xc3\xb9\x33\x54\x8f\x1c\x6b\xdf\xb7\xe7\x3c\xfc\xf8\x2f\xdc\xfa\xdb\xdd\xfd\xaf\xf5\xed\xc3\xfd\x8c\x34\xb2\x0c\xf8\x1c\xd5\x99\x59\x98\xb6\x16\xd2\x7e\xc7\xf6\x22\x4c\xd3\xfb\x1e\x6f\x2e\x92\x6f\x45\x87\xb1\xca\x76\xae\xca\x36\xac\xd9\x61\x31\x35\x3c\xdb\xd0\x77\x9c\x76\x9a\x0e\x36\x67\x8d\xc2\xcc\xf8\x9f\x00\x00\x00\xff\xff\x09\x7d\x30\x1a\x72\x04\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xac\x94\x41\x6f\xdb\x3c\x0c\x86\xef\xfe\x15\x44\xee\x8e\xdb\x5b\xa1\x5b\xd1\xe6\x03\x3e\x60\x4d\x83\x75\xdb\x75\x60\x65\x26\x11\x2c\x89\x02\x45\xbb\x0b\x8a\xfe\xf7\xc1\x4b\x96\xd8\x59\xba\x64\xc3\x72\x24\x5f\x3e\x24\xdf\x88\xc6\xe4\xbe\x90\x64\xc7\xd1\x00\xa6\x94\xab\xee\xba\x68\x5c\xac\x0d\xdc\x23\x05\x8e\x4f\xa4\x45\x20\xc5\x1a\x15\x4d\x01\x10\x31\x90\x81\x35\xb7\x99\x1a\xa2\x44\x52\x5a\x8e\x2a\xec\x3d\x49\x19\x30\xe2\x8a\x64\x27\xcb\x09\xed\x91\x36\x6f\xb2\x52\x28\x00\x3c\x3e\x93\xcf\x3d\x10\x60\x07\x28\x93\xc7\x78\x9e\x9d\x13\xd9\xbe\x2e\x93\x27\xab\x2c\x5b\x46\x40\xb5\xeb\x0f\x03\xe8\x9f\x62\x01\x94\x42\xf2\xa8\xb4\x03\x0e\x76\x06\x18\x0f\xfc\x37\x74\x80\x9f\x83\xf7\x3f\x65\x4f\x82\xea\x38\x0e\x90\x25\x34\xb4\x31\x30\x89\x5c\x53\x29\xec\x69\xda\xb4\xcf\x24\x91\x94\xf2\xd4\x71\x15\x30\x2b\xc9\x64\xaf\x07\xe0\xd4\x53\x58\x0c\x4c\x66\xdf\x5c\xd6\x3c\x4c\xd2\x72\x49\x56\x0d\x4c\xe6\xfc\x64\xd7\x54\xb7\x9e\x26\x17\xf7\x7a\x61\x69\xfe\x4d\xaf\xde\x0d\x74\x91\xe4\xb0\x69\x79\xe9\x2b\xda\x7b\x1d\x02\xc6\xda\x1c\x22\x50\x42\x75\x69\xb5\x0b\xb8\x22\x03\xaf\xaf\xd3\xbb\xbd\xea\xff\x3e\xf6\x59\xfc\xdb\xdb\x91\x6e\xd1\x7a\xbf\x60\xef\xec\xc6\xc0\xad\x7f\xc1\x4d\x3e\x08\x12\x8b\xe6\xf1\x10\xdb\x3d\x02\xa9\x38\x3b\x50\x0e\xd7\x5e\xb0\xa8\x81\x9b\xab\x9b\xab\x51\x3e\x09\x2b\x5b\xf6\x06\x3e\xdd\x2d\x0e\x99\x8e\x7d\x1b\xe8\x81\xdb\x78\xba\x55\x9b\x56\x82\x35\x95\xf5\x8f\xe3\x1c\x11\x43\x5f\xb4\x40\x5d\x1b\xa8\x3a\x94\x2a\x36\xf5\x09\xc0\xd0\xb5\xd4\xb8\x77\x09\xa4\xb6\x27\x0c\x5d\xae\x8e\xf5\x42\x58\x3f\x46\xbf\x31\xa0\xd2\xd2\x21\x45\xb1\x3b\x35\xfb\xfc\xf1\x7e\xf6\x75\x7e\xfb\x30\x1b\x41\x3a\xf4\x2d\xfd\x27\x1c\xcc\x28\x0c\x4b\x47\xbe\xfe\x48\xcb\xa3\x30\x0c\x3f\x58\xdd\x75\x01\xbf\x16\x6d\x37\xe8\xcf\x6d\xda\x3f\xef\x39\x06\x2a\x86\xee\x8e\x4e\xee\x8c\xad\x6b\xce\x5b\x47\xc6\x7f\xde\x69\x97\xcf\x7b\xfc\x5b\xdc\x3b\x96\x7f\x1f\x00\x1c\x65\x64\xc7\xa2\x05\x00\x00"),
 		},
 		"/ignition": &vfsgen۰DirInfo{
 			name:    "ignition",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
+			modTime: time.Date(2026, 10, 16, 13, 7, 35, 0, time.UTC),
+		},
+		"/ignition/bootstrap": &vfsgen۰DirInfo{
+			name:    "bootstrap",
+			modTime: time.Date(2026, 10, 16, 13, 7, 35, 0, time.UTC),
+		},
+		"/ignition/bootstrap/files": &vfsgen۰DirInfo{
+			name:    "files",
+			modTime: time.Date(2026, 10, 16, 13, 7, 35, 0, time.UTC),
+		},
+		"/ignition/bootstrap/files/etc": &vfsgen۰DirInfo{
+			name:    "etc",
+			modTime: time.Date(2026, 10, 16, 13, 7, 35, 0, time.UTC),
+		},
+		"/ignition/bootstrap/files/etc/nkd": &vfsgen۰DirInfo{
+			name:    "nkd",
+			modTime: time.Date(2026, 10, 16, 13, 7, 35, 0, time.UTC),
+		},
+		"/ignition/bootstrap/files/etc/nkd/bootstrap-httpd.sh.template": &vfsgen۰CompressedFileInfo{
+			name:             "bootstrap-httpd.sh.template",
+			modTime:          time.Date(2026, 10, 16, 13, 7, 35, 0, time.UTC),
+			uncompressedSize: 1111,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xbc\x52\x4d\x4f\xdb\x30\x18\xbe\xfb\x57\x3c\xb8\x81\x02\x5b\x92\xc2\xd8\x61\x2d\x45\xaa\x06\x1b\xd3\xbe\x38\xf4\x34\x40\xc8\xc4\x6f\x1a\x8f\xd4\xce\x6c\x43\x81\xb6\xff\x7d\x72\x93\x54\xd5\xc4\x0e\xbb\xac\x52\xa3\xf8\xd1\xeb\xf7\xf9\x4a\x67\x2b\xbd\x55\x3a\x75\x05\x63\x1d\x8c\xb4\x9b\x91\x75\x10\x70\x4a\x4f\x4a\x42\xe1\x7d\x05\x4b\xbf\xee\xc9\x79\x98\x1c\xbe\x20\x64\x46\x6b\xca\xbc\x32\x1a\xee\xc9\x79\x9a\x4a\x54\xc2\x39\x72\x08\x88\x97\x4a\x43\x68\x19\xde\xcc\xbd\x7f\xcd\x3a\xab\x4b\x53\xb2\x13\x82\x9a\x68\xb5\xba\x68\x72\xd0\x03\xd9\x27\x68\x23\x09\x39\xf9\xac\x20\xb7\x62\xeb\xa7\xe9\xf1\xad\x31\xde\x79\x2b\xaa\x93\xfe\x7c\x9e\x5c\x18\xeb\x97\xcb\xf4\x58\x8b\x29\x9d\x24\x6a\xa2\x59\xbb\xe6\x46\x2a\x3b\x9c\xcf\x93\x4f\xcd\xf9\x54\xd9\xe5\x92\x31\x4b\xae\x32\x5a\xee\xee\x61\xce\x00\xa0\xb2\x4a\xfb\x1c\xdd\xf3\xf1\xf8\x22\x3d\x48\x7a\xd8\x76\x57\xf6\x4a\xbf\x37\xda\x93\xf6\xf1\xf8\xa9\xa2\xfe\x1f\xd8\x17\xd2\x13\x5f\x6c\xa0\x8d\xe3\x3e\xb2\xd2\x38\x0a\x60\xf8\x77\xc1\xa3\x03\x0e\x1e\x1d\x86\xc7\x1b\xce\x56\xec\x42\x22\xb6\x98\x92\x2f\x8c\x84\x17\x76\x42\x1e\x0f\x64\x5d\x30\xbe\x58\x80\x1e\x95\x47\x8f\x75\xe0\xee\x54\xb5\x0a\xa7\x0d\xb8\x20\x21\xc9\x3a\x36\x2b\x54\x49\x68\x17\xd5\xe8\x00\xd2\x30\x00\xcd\x71\x18\xed\xb6\xb6\xb6\x5d\x90\x51\xc3\x1c\x0b\x78\x8b\x58\xa2\x7b\x65\xbb\x7b\xab\x0b\x97\x88\x9f\x37\x06\xae\xb1\xb3\x83\x5b\x4b\xe2\x8e\x49\xa3\x89\xb1\x90\xeb\x30\x9a\xd7\x42\x3b\xe9\x92\x65\xc2\x11\x78\x14\x70\x0e\xa5\x57\x4b\x78\xd8\x9c\xec\x63\x81\xfd\xcb\xad\x51\xfc\x43\xc4\xcf\xbd\xf8\x5d\x72\x13\x5f\xef\xd7\x2c\xe1\xd7\x04\x0f\x7e\xd4\x3b\xc2\x37\xe3\xf1\xc1\xdc\x6b\xc9\xe1\xe9\xd1\xa7\x55\x29\x94\x46\x6f\x3d\xdc\xc4\xd0\x1e\x07\x03\x46\x4e\x64\x2c\x57\x25\x0d\x79\xb4\x59\x71\x5a\x4b\x61\x2a\xc7\x25\xb6\x10\xe7\xe0\x51\x18\xe3\xb8\x1e\x84\xfc\x34\xfb\x27\xf2\x86\x38\x57\xac\x75\x5a\x57\xb5\xf6\xfa\xf1\x6c\xfc\x82\xa7\xc3\x5e\x0f\xdf\x3f\x73\x88\xaa\x2a\x55\x26\x82\xb8\xf4\x41\xcb\x24\x33\x96\x8c\x4b\x5a\xc1\xaf\x7e\x3a\xa3\xc1\xa3\xdd\x59\x86\x38\xc3\xf1\x5a\xeb\xba\x18\x74\xf7\xf8\x7a\x7d\x26\x7c\x3b\xb1\x19\x05\x00\x9c\x9f\x8d\x4e\xff\x9b\x8e\x86\xf3\xe5\x32\xdf\xe2\x6b\xfd\x31\x87\x58\x47\x65\x69\x66\xf4\xb7\x56\xdb\x1a\x7f\x0f\x00\x40\x43\x24\xf0\x57\x04\x00\x00"),
+		},
+		"/ignition/bootstrap/systemd": &vfsgen۰DirInfo{
+			name:    "systemd",
+			modTime: time.Date(2026, 10, 16, 13, 7, 35, 0, time.UTC),
+		},
+		"/ignition/bootstrap/systemd/nkd-bootstrap-httpd.socket.template": &vfsgen۰FileInfo{
+			name:    "nkd-bootstrap-httpd.socket.template",
+			modTime: time.Date(2026, 10, 16, 13, 7, 35, 0, time.UTC),
+			content: []byte("\x5b\x55\x6e\x69\x74\x5d\x0a\x44\x65\x73\x63\x72\x69\x70\x74\x69\x6f\x6e\x3d\x53\x65\x72\x76\x65\x20\x74\x68\x65\x20\x69\x67\x6e\x69\x74\x69\x6f\x6e\x20\x6f\x66\x20\x74\x68\x65\x20\x63\x6c\x75\x73\x74\x65\x72\x20\x6e\x6f\x64\x65\x73\x0a\x0a\x5b\x53\x6f\x63\x6b\x65\x74\x5d\x0a\x4c\x69\x73\x74\x65\x6e\x53\x74\x72\x65\x61\x6d\x3d\x7b\x7b\x2e\x50\x6f\x72\x74\x7d\x7d\x0a\x41\x63\x63\x65\x70\x74\x3d\x79\x65\x73\x0a\x0a\x5b\x49\x6e\x73\x74\x61\x6c\x6c\x5d\x0a\x57\x61\x6e\x74\x65\x64\x42\x79\x3d\x73\x6f\x63\x6b\x65\x74\x73\x2e\x74\x61\x72\x67\x65\x74\x0a"),
+		},
+		"/ignition/bootstrap/systemd/nkd-bootstrap-httpd@.service": &vfsgen۰CompressedFileInfo{
+			name:             "nkd-bootstrap-httpd@.service",
+			modTime:          time.Date(2026, 10, 16, 13, 7, 35, 0, time.UTC),
+			uncompressedSize: 177,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6c\xca\x31\x92\xc2\x30\x0c\x05\xd0\xde\xa7\xd0\x05\x76\x73\x02\x77\x9b\x62\x2b\x8a\x0c\x55\x26\x85\xb0\x05\x31\xc9\x48\x46\xfe\x66\x38\x3e\x93\x86\x8a\xf6\xcd\x9b\xcf\x5a\xb0\x84\x3f\x69\xc9\x4b\x45\x31\x8d\x93\xf8\x53\x88\x95\xca\x4d\xcb\x21\xe4\xf2\xe8\xd2\x40\x76\x25\xa6\xb4\xf7\x06\x71\x52\xcb\x12\xc2\x7c\xec\x92\x64\x09\xe3\x4b\xd2\x04\x76\xc4\x41\x90\x06\xdd\xf2\x70\x31\x43\x83\x73\xfd\x59\x81\x9a\x7f\xdb\x1a\x26\xb0\x66\xf6\xfc\xaf\xb5\x23\x36\x4b\x9b\xe0\x83\xa7\x8e\x2f\x3a\xba\x9b\xc7\xbb\x75\x57\xde\xc3\x7b\x00\x3e\x78\xce\xf6\xb1\x00\x00\x00"),
 		},
 		"/ignition/controlplane": &vfsgen۰DirInfo{
 			name:    "controlplane",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime: time.Date(2026, 10, 16, 12, 49, 52, 0, time.UTC),
 		},
 		"/ignition/controlplane/files": &vfsgen۰DirInfo{
 			name:    "files",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime: time.Date(2026, 10, 16, 12, 49, 52, 0, time.UTC),
 		},
 		"/ignition/controlplane/files/etc": &vfsgen۰DirInfo{
 			name:    "etc",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime: time.Date(2026, 10, 16, 12, 49, 52, 0, time.UTC),
 		},
 		"/ignition/controlplane/files/etc/hosts.template": &vfsgen۰CompressedFileInfo{
 			name:             "hosts.template",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 167,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x32\x34\x32\xd7\x33\xd0\x33\xd0\x33\x54\x50\x50\xc8\xc9\x4f\x4e\xcc\xc9\xc8\x2f\x2e\x41\xb0\xf4\xc0\xac\x94\xfc\xdc\xc4\xcc\x3c\x84\xa8\x09\x12\x13\x59\x85\x09\x97\x95\x15\xc8\x20\x08\x20\xda\x38\x33\x24\x26\xb2\x0a\x33\xae\xea\x6a\x3d\x8f\xe2\xcc\x82\xda\x5a\x40\x00\x00\x00\xff\xff\x0b\x57\x23\x96\xa7\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x32\x34\x32\xd7\x33\xd0\x33\xd0\x33\x54\x50\x50\xc8\xc9\x4f\x4e\xcc\xc9\xc8\x2f\x2e\x41\xb0\xf4\xc0\xac\x94\xfc\xdc\xc4\xcc\x3c\x84\xa8\x09\x12\x13\x59\x85\x09\x97\x95\x15\xc8\x20\x08\x20\xda\x38\x33\x24\x26\xb2\x0a\x33\xae\xea\x6a\x3d\x8f\xe2\xcc\x82\xda\x5a\xc0\x00\x0b\x57\x23\x96\xa7\x00\x00\x00"),
 		},
 		"/ignition/controlplane/files/etc/isulad": &vfsgen۰DirInfo{
 			name:    "isulad",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime: time.Date(2026, 10, 16, 11, 8, 40, 0, time.UTC),
 		},
 		"/ignition/controlplane/files/etc/isulad/daemon.json.template": &vfsgen۰CompressedFileInfo{
 			name:             "daemon.json.template",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
-			uncompressedSize: 1174,
+			modTime:          time.Date(2026, 10, 16, 11, 8, 40, 0, time.UTC),
+			uncompressedSize: 1167,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x54\xcb\xae\xd3\x3c\x10\xde\x9f\xa7\x88\xbc\xfe\x9d\xf4\x2f\x12\x8b\x4a\x67\x83\xc4\x02\xb1\x00\x75\x8b\xd0\x91\x6b\x4f\xd2\x21\x8e\x6d\x8d\xed\xd0\x50\xe5\xdd\x91\x5d\x27\x6d\x03\x62\x57\xcd\x77\xe9\x37\x97\xf8\xfa\x52\x55\x55\xc5\xe0\x02\x92\x5b\x17\x3c\x3b\x54\xdf\x98\x11\x01\x47\xa8\x65\x47\x36\x3a\x45\x38\x02\xbd\xfa\xc9\x07\x18\x14\xfb\xfe\xdf\x4d\x91\x31\x76\xa8\x18\xfa\xa8\x05\x2b\x55\x05\xad\x88\x3a\x70\x8a\x26\xe0\x00\x09\xd7\x92\xd8\xaa\x11\xee\x9c\x6a\xcd\x28\xa8\xd1\x78\x6a\xb2\x58\x2d\xb8\x0f\x22\xc0\x8a\x53\x34\x1b\x1c\x4c\x87\x66\x6b\xaa\x6d\xc7\x35\x8c\xa0\x53\xfd\xe3\xf1\xf8\xe5\xb8\x20\x0e\x55\x8b\xfa\x6f\x86\xb5\x43\xf5\xa8\x2f\x9d\xdf\x86\xb1\x56\x93\x98\x0f\x56\x65\x87\xdd\xfb\xdd\xae\x48\x56\x82\x13\xe1\x1f\xed\x64\xda\x20\x2e\x7c\x09\xf1\xff\x16\xf0\xf8\x2b\x03\xef\x76\x9f\x3f\xb0\x0c\xcd\x0f\xa1\x6e\x83\x4f\xb8\x0f\xca\xc6\xb0\x04\x96\xd6\x04\x81\x06\x88\x6b\xdb\x3d\xa7\xbe\x4b\x7e\x78\x6b\x6e\x7f\xfc\xe4\x7b\xb6\xb6\xe7\xde\x81\xcc\xb1\x21\xc8\xa6\x6c\xac\x44\x6f\x12\xc1\x2f\xc5\x3a\xb9\x3c\x2c\x87\x02\x4f\x5b\x4d\x59\x0e\x15\xdb\x0f\x77\xc8\x92\xe8\xe0\x21\xb1\x1d\x81\xb4\x98\xf6\x5b\xc6\x72\x62\xf7\xc8\x0b\xb3\x4e\x3f\x08\x15\xbc\xf5\x40\x06\xf4\x9b\x3c\x83\xec\x5f\x03\xc5\xd2\xc2\x72\x78\x04\x1d\xfa\x40\x13\x1f\x90\xc8\xd2\xc6\x4e\x59\xd9\x03\xd5\x68\x9f\x45\x68\x3c\xc8\x48\xc0\x8b\x1a\x61\xa3\xbb\x5e\xeb\x4f\x83\xe8\xe0\x58\xdc\xe7\xf9\xd9\xc0\x59\xc5\xbd\x30\xea\x64\x2f\x1c\x13\x31\x75\xf9\xa7\xa8\xb9\x5e\xeb\xaf\x22\x7a\xc8\xf5\x79\x5e\xfa\x2f\x5f\x54\x1c\x84\xef\xf3\x46\x73\x9a\x15\x85\xf0\xd3\x52\xcf\x9d\x8e\x1d\x9a\x84\x4b\x83\xeb\xba\x0d\xf2\x13\x1a\xae\x30\x4f\xb6\xb1\x2e\x34\xd2\x60\x73\x42\xf3\x48\x91\xd6\xb4\x2b\x27\x2d\x36\x71\x0c\x84\x7a\x3d\xf4\x1c\x9b\x6b\x31\x01\xf1\x3c\x5c\x76\xa8\x5a\xa1\x3d\x14\x3c\x7a\xe0\x0a\x24\x4d\x2e\x80\xe2\x3d\x4c\xec\x50\xa5\xf1\x6f\x47\xe8\x7b\x74\x7c\x04\xc2\x76\xe2\x60\x5a\x4b\x12\x36\x4e\x92\x70\x79\x01\x36\x9f\x55\x2f\x82\xc8\x8f\x86\xad\xd7\x33\x56\x75\xaa\xd6\xe3\xbe\x9c\xea\xcb\xfc\xf2\x3b\x00\x00\xff\xff\x2e\x9c\xa7\x42\x96\x04\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x54\xcb\xae\x9b\x3c\x10\xde\xe7\x29\x90\xd7\xc7\x90\x3f\xbf\xd4\x05\xd2\xd9\xf4\xb2\xa8\xba\xa8\x94\x2e\xab\xea\xc8\xb1\x07\x32\xc5\xd8\xd6\xd8\xa6\xa1\x11\xef\x5e\xd9\x01\x92\xa0\xaa\x59\x85\xf9\x2e\x7c\x9e\x19\x73\xdd\x15\x45\x51\x30\xb8\x80\xe4\xd6\x05\xcf\xea\xe2\x3b\x33\x22\xe0\x00\xa5\x6c\xc9\x46\xa7\x08\x07\xa0\xd7\xeb\xb5\xfc\x90\x9f\x3f\xe6\xe7\x69\x62\x3f\x5e\x6e\xda\x5c\x65\x75\xc1\xd0\x47\x2d\xd8\x5c\x55\xd0\x88\xa8\x03\xa7\x68\x02\xf6\x90\x70\x2d\x89\xad\x1a\xe1\xce\xa9\x56\x0d\x82\x2a\x8d\xa7\x2a\x8b\xd5\x82\xfb\x20\x02\xac\x38\x45\xb3\xc1\xc1\xb4\x68\xb6\xa6\xda\xb6\x5c\xc3\x00\x3a\xd5\x3f\x1d\x8f\x5f\x8f\x0b\xe2\x50\x35\xa8\xff\x66\x58\x3a\x54\x8f\xfa\xb9\x07\xb7\xb6\xac\xd5\x24\xe6\xbd\x55\xd9\x61\xff\x6e\xbf\x67\x2f\xcf\x04\x27\xc2\x3f\x8e\x93\x7e\xac\x17\x17\xbe\x84\xf8\x6f\x0b\x78\xfc\x9d\x81\xff\xf7\x5f\xde\xb3\x0c\x4d\x0f\xa1\x6e\x23\x48\xb8\x0f\xca\xc6\xb0\x04\x96\xd6\x04\x81\x06\x88\x6b\xdb\x3e\xa7\xbe\x4b\x7e\x7a\x6b\x6e\x2f\x7e\xf2\x3d\x5b\xdb\x71\xef\x40\xe6\xd8\x10\x64\x35\x4f\x6c\x8e\x5e\x25\x82\x5f\x8a\x65\x72\x79\x18\x0e\x05\x9e\xa6\x9a\xb2\xd4\x05\x3b\xf4\x77\xc8\x92\x68\xe1\x21\xb1\x1d\x80\xb4\x18\x0f\x5b\xc6\xb2\x6c\xf7\xc8\x0b\xb3\x4c\x7f\x08\x15\xbc\x75\x40\x06\xf4\x9b\x3c\x83\xec\x5e\x03\xc5\xf9\x08\xcb\xe2\x11\xb4\xe8\x03\x8d\xbc\x47\x22\x4b\x1b\x3b\x65\x65\x07\x54\xa2\x7d\x16\xa1\xf1\x20\x23\x01\x9f\xd5\x08\x1b\xdd\xf5\x5a\x7e\xee\x45\x0b\xc7\xd9\x7d\x9a\x9e\x0d\x9c\x55\xdc\x0b\xa3\x4e\xf6\xc2\x31\x11\x59\x9d\x45\xdf\x6e\xb5\xac\x9d\xa6\xe5\xb8\xf3\x55\x8a\xbd\xf0\x5d\x1e\x60\x7e\xf9\x8a\x42\xf8\x65\xa9\xe3\x4e\xc7\x16\x4d\xc2\xa5\xc1\x75\xba\x06\xf9\x09\x0d\x57\x98\x1b\x59\x59\x17\x2a\x69\xb0\x3a\xa1\x79\xa4\x48\x6b\x9a\x95\x93\xe6\x98\x38\x06\x42\xb9\xee\x75\x4e\xc9\xb5\x18\x81\x78\xee\x25\xab\x8b\x46\x68\x0f\x33\x1e\x3d\x70\x05\x92\x46\x17\x40\xf1\x0e\x46\x56\x17\xa9\xdb\xdb\x8e\xf9\x0e\x1d\x1f\x80\xb0\x19\x39\x98\xc6\x92\x84\x8d\x93\x24\x5c\x2e\xfc\xe6\x16\x75\x22\x88\xfc\x8d\xb0\xe5\xba\xb5\xaa\x4c\xd5\x72\x38\xcc\x9b\xb9\x9b\x76\x7f\x06\x00\xfc\x6f\xf0\xd0\x8f\x04\x00\x00"),
 		},
 		"/ignition/controlplane/files/etc/nkd": &vfsgen۰DirInfo{
 			name:    "nkd",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime: time.Date(2026, 10, 16, 12, 49, 52, 0, time.UTC),
 		},
 		"/ignition/controlplane/files/etc/nkd/init-config.yaml.template": &vfsgen۰CompressedFileInfo{
 			name:             "init-config.yaml.template",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
-			uncompressedSize: 849,
+			modTime:          time.Date(2026, 10, 16, 12, 49, 52, 0, time.UTC),
+			uncompressedSize: 2406,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xac\x51\x41\x8f\x9b\x3c\x10\xbd\xf3\x2b\x2c\xee\xc0\xea\xd3\x77\xa8\x7c\x8b\xd2\x3d\x44\xdb\xae\xa2\xa4\xed\xdd\x81\x89\x77\x84\x99\x41\xe3\x21\xcd\x0a\xf1\xdf\x2b\x03\xc9\x6e\x8e\x95\x7a\x02\x3f\xbf\x37\xf3\xde\xb3\xeb\xf1\x17\x48\x44\x26\x6b\xda\xe1\x04\xae\xe9\xca\xf6\x4b\x2c\x91\xab\x71\x2c\x5f\x16\x64\x73\x27\x4d\x53\x76\x62\xd6\xa8\xe2\xfa\x1f\xdc\x02\x45\x9b\x15\xc6\x0b\x0f\x7d\xb4\x99\x31\x85\x89\xef\x51\xa1\xb3\x77\x56\x0f\x12\xed\x3a\xd9\x36\x70\x76\x43\xd0\x82\xb8\x81\x42\x93\x3e\x33\x66\xfe\x5a\x33\x8e\xe5\x3c\x71\x9a\x12\xa6\xc1\x9a\xff\xfe\x7f\x7b\xea\x9e\x62\x66\xcc\x10\x9d\x87\xdb\x02\xf4\x84\xe4\xe7\x7f\x37\xe8\x1b\x90\x62\xed\x14\x99\xb2\x16\xa9\xb1\x66\x47\xa8\x5b\xa6\x33\xfa\x41\x16\x3c\xad\x3b\x80\xc7\x64\x28\x01\x69\x50\x2d\x78\xe4\xba\x05\x9d\x37\x6f\x6f\xa7\x79\x3b\xb9\x0e\x66\xf8\x95\x1b\x78\x75\x1d\xcc\x68\x0a\x11\x40\x9f\xaf\x2a\x6e\x23\x7e\xb6\x63\xcc\x85\xc3\xd0\x41\xd1\x87\xc1\x23\x15\x0d\x8a\x35\x79\xc5\xbd\x56\x01\x4f\x70\x85\xba\x4a\x32\x21\x50\x88\xd5\x3a\x61\x25\xc7\x6a\xd1\x56\x33\x2d\xcf\x6a\x10\xc5\x73\xca\x02\x2f\xf0\xbe\xd8\x7a\x80\xa6\x29\x2b\x8a\x22\xfb\xfb\x27\x5b\x7a\xd9\x86\x21\x2a\xc8\x63\x35\x35\x93\x0a\x87\x00\xf2\xdd\x91\xf3\x20\x29\x15\x3c\x46\x3c\x07\xb8\x16\xff\x2e\xe7\x07\xf1\x1e\x63\xf5\xfd\xe1\x18\x3b\xe7\xe1\x00\x3d\x47\x54\x96\xa5\x8c\xdd\x82\xcd\xcf\x98\xba\x58\xad\xef\x83\x23\x78\xa6\xa6\x67\x24\xb5\x26\x1f\xc7\x72\xb3\xdf\x1d\x41\x2e\x20\x3f\x0f\xdf\xa6\x29\xcf\x08\xf4\x37\x4b\x8b\xe4\x53\xa0\x08\x72\xc1\x1a\x8e\xc3\x89\x60\x15\x1c\x3f\x43\x49\x61\x4c\xcf\xcd\x67\xc6\xfe\x76\x5c\x6e\x1b\x8a\x5f\xb9\x73\x48\xd6\xe4\xf5\xd2\x6b\x19\xb8\x76\x21\xff\x13\x00\x00\xff\xff\x05\xcc\x21\x0f\x51\x03\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd4\x55\xbb\x6e\xe3\x38\x14\xed\xf5\x15\x17\x42\xb6\x09\x56\x52\xb2\x48\xb1\x10\x90\x22\x70\x02\x6c\x90\x07\x0c\x7b\x77\x7b\x5a\xba\x96\x09\x51\xa4\x42\x52\x4e\x0c\x41\xfd\x7e\x40\x80\xad\xa7\x9b\x76\x8a\xc1\x3c\xbe\x67\x1e\xf9\x8c\x01\x49\xc9\x96\xed\x3c\xe6\x91\x26\x95\xc4\xc3\x7b\xce\x25\xef\x8b\x75\x1d\x40\xb4\x0b\xf3\xfd\x09\x6a\x72\x00\x77\x6f\xdf\x01\xde\x68\x49\x8e\x64\xa6\xe0\xeb\xed\x1b\x28\x48\x09\x5f\x6e\x3f\x7c\x7a\xff\x11\x38\x29\x30\x9a\x13\x56\x21\x7c\xfe\xef\xff\xbb\x57\xaf\x61\x37\x82\xa0\x69\x3c\x23\xb2\x43\x64\x76\x4e\x95\x86\xf8\x10\xf0\x0a\xc2\xb3\x6a\x82\x24\x2d\x8e\x4a\xfa\x2f\x4a\x45\x05\x07\xbf\x75\xe2\x5b\x0e\x59\x6e\xc4\x90\x3b\xdb\x30\xff\x53\x85\x54\x44\x75\xbd\xcd\x6e\x1a\x6f\x22\x84\x56\x5a\x92\xf2\x6f\x91\x23\x57\xb1\x17\x40\x26\x45\x55\xaa\xd8\x03\x08\x40\x2d\x94\xc6\x22\x5e\x5a\x95\x28\x55\xdc\x2a\xc7\x29\x4e\x49\xc5\x74\xc0\x45\x8a\x81\x36\x7c\x0f\xc0\x7e\x63\xa8\xeb\xd0\x2a\x36\x8d\xc1\x34\x8b\xe1\x8f\x83\xd9\x5e\xb1\xa7\x3c\x80\x4a\x91\x0c\x3b\x07\x34\xe3\x94\x67\xf6\x9f\x54\x7a\x86\x5c\xd3\x84\x68\x2a\xb8\x97\x53\x9e\xc6\x70\xca\xa9\x1e\x08\x3e\xa5\x59\x25\x1d\x6e\xdc\x8d\x30\xa3\x4a\x3b\xc0\x08\x25\x92\x8e\x45\x92\xa3\xb6\x9e\x07\xdd\xca\x7a\x37\x11\xb6\xf0\xa5\x48\xf1\x92\x14\x68\x51\x73\x09\x86\xfa\xa4\xcb\x4b\x6c\x03\x4e\xa7\xab\x98\x5b\x33\x73\x2e\x27\x30\x17\xac\x2a\x30\x28\x59\x95\x51\x1e\xa4\x54\xda\x5d\x00\x9b\xbb\x18\xfc\x48\x94\x3a\x62\x74\x82\x37\x98\x44\x46\x5d\x72\xd4\xa8\xa2\xd6\x51\x4b\x54\x91\xd3\x89\xac\x99\x6f\x9d\x22\x53\xd8\x79\xdb\xf2\xf2\x6b\xca\x3c\x35\xc2\x09\x4a\x4d\xa7\x26\xae\x78\x86\x0b\x17\xa2\x35\xa8\x69\xbc\x20\x08\x7e\xa2\x7c\x5c\x8e\x06\xac\x52\x1a\xe5\x7a\x9a\xda\x70\x86\x47\xc3\xd3\x31\xca\x39\xca\x65\xa8\xc1\x55\xaa\x43\x4d\xf6\x70\x3d\x09\x92\xf0\x0c\x61\x27\xc7\xc5\xef\xb0\xe3\x5a\x23\x3e\x7c\x48\xe8\x89\xac\xd5\xb5\x15\xea\xe0\x65\xba\xea\x1a\x4a\x49\xb9\x9e\x82\xff\xdb\x95\xdf\xb9\x69\x9a\xad\x84\xac\x04\x9e\x22\xf1\xf4\xe1\xdf\x44\x70\x2d\x05\x63\x28\x2f\x08\x27\xd9\x8f\x5c\x7b\xb0\x49\x7d\x49\xd7\x37\x05\x30\x4e\x66\x98\x56\x6c\xe3\xe0\xaa\x43\xbf\x3f\x12\xf7\x0b\xbd\x8c\x02\x58\xb5\xed\xb2\xc3\xda\x96\x5a\x35\x13\x2d\x48\x86\x23\x2c\x85\xa2\x5a\x48\xd7\xa7\xa7\x0e\xb3\xd3\x6e\xb1\x2a\xa4\x21\x23\x1c\x4f\x78\x5a\x0a\xca\x75\x0c\x7e\x5d\xaf\xda\xe3\x9f\xd1\x79\xd3\xf8\x1e\x47\x7d\x2d\x64\x4e\x79\x66\x22\xac\x50\xce\x69\x82\xe3\x6a\xc2\xb1\x25\x8c\xfb\x90\x61\x00\x94\x22\xed\x5b\x0c\x45\xda\xdf\x4d\xb9\x3a\x16\x05\xa1\x3c\x06\x3f\x71\x2d\x1f\x32\x91\x10\xe6\xdf\x3b\x3d\x18\xea\x30\xb1\x23\xa1\x1b\x22\xee\xad\xda\x6f\xa7\xc6\x99\xb3\x59\x9f\x1a\x89\x7d\x7d\x8e\x25\x35\xa3\xc1\x0e\xaa\x1e\xd0\x86\xf4\x9a\xea\x19\x84\x6b\xf4\x7e\xbd\x5d\x90\x9b\xa1\x48\x6d\x6d\x14\xee\xd7\x26\xae\x8f\xdf\x57\xa5\xf6\xa5\x1b\xa1\x09\x14\xda\x1d\xb5\x86\x3c\x5e\x99\x5b\xe4\x67\xe9\x1c\x73\xc5\xbe\x68\xde\x5b\x3f\x7a\x9e\x4d\xe2\xb3\x9c\xe6\x64\x4e\x13\x93\xa3\xbf\x88\xb4\x38\xf6\xd6\x8f\x9e\x66\x93\xf8\x4c\x3d\xf5\x6d\x00\x02\x70\x4e\xfc\x66\x09\x00\x00"),
 		},
 		"/ignition/controlplane/files/etc/nkd/node-pivot.sh.template": &vfsgen۰CompressedFileInfo{
 			name:             "node-pivot.sh.template",
-			modTime:          time.Date(2024, 4, 11, 6, 17, 49, 137045203, time.UTC),
-			uncompressedSize: 2798,
+			modTime:          time.Date(2026, 10, 16, 10, 7, 23, 0, time.UTC),
+			uncompressedSize: 3693,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x56\xdb\x6e\xdb\x38\x10\x7d\xd7\x57\x4c\x65\xa3\x97\xa0\x92\xda\x57\x17\xe9\x2e\xb0\x4d\xb1\x05\x8a\xa2\x70\x10\x60\x01\xc7\x35\x68\x6a\x64\x0f\x2c\x91\x0a\x49\xa5\x36\x1c\xff\xfb\x82\xa4\x24\x4b\x8a\xe3\x64\x37\x0f\x49\x4c\x9f\x39\x73\xe6\x4a\x8e\x5e\x25\x4b\x12\x89\x5e\x07\xc1\x08\xbe\x56\x82\x1b\x92\x02\x8c\x84\x82\x09\xb6\x42\xd0\xa8\xee\x89\x23\x68\xc3\x94\xa9\x4a\x60\x22\x05\x14\x6c\x99\x23\x48\x01\x4b\x29\x4d\xe0\x91\x8b\x1a\xf9\xf6\x1d\xec\x03\x00\x68\x2c\x17\x82\x15\x78\x19\x8e\x3f\x86\xee\x94\x32\xd0\x3b\x6d\xb0\xe0\x26\x07\xd2\x11\xe3\x86\xee\x11\xa2\xe8\xae\x22\x34\x10\x8e\xbb\x66\xe1\x27\x30\x6b\x14\xce\xd0\xfe\x20\x5f\xcb\x01\x04\x48\x03\xcb\x15\xb2\x74\x07\xaa\x12\x82\xc4\xca\x3b\xc2\x5c\xe3\x73\x86\x42\x9a\xc6\xe8\xbd\x8f\x90\xc4\x2a\x8e\xe3\xb0\x35\xec\xc9\x75\x88\xa1\x44\x78\xfd\xba\x03\xa9\x53\x73\x3e\x8c\xa7\x14\x35\x0a\x40\x57\x9c\xa3\xd6\x1d\x1d\xbd\x68\x8e\xf6\x37\xde\x9d\x91\xb5\xb6\x1e\x5f\xc7\xdc\x59\x6c\xc9\xc0\xc7\xf6\x28\xa3\xa0\xfe\x73\xb0\xa5\xff\x6b\x8d\x7c\xe3\xc2\xad\x0b\x8e\x5b\xd2\x46\xbb\x7a\xd7\xad\x40\x26\xe0\x16\xb5\x60\x22\x5d\xfc\xff\xa2\xe7\xa4\x4d\x54\x09\x32\x51\x46\x39\x6a\x78\x80\x95\xc2\x12\xa2\xbb\x41\x3a\xe2\xfa\xc3\x30\x7b\x7d\xcf\xc3\x54\xbf\xac\xf6\x8d\x71\x2a\xd1\x77\x81\x8b\xf6\x3d\xe8\x0d\x95\x65\xb7\x07\x7c\x7a\x70\x8b\xbc\x32\xb8\x58\x4b\xb9\x71\xa2\xdb\x78\x73\xc9\x59\x0e\x29\x29\xe4\x46\xaa\x5d\x2f\xe4\x19\xbc\x82\x28\x85\x70\xdc\x7e\x1d\xc2\xfc\x64\x47\x7f\x69\x00\x4e\x4b\x26\x2b\x91\x4e\xa0\x63\xd6\xc2\x15\x9a\x4a\x09\x68\xa4\xf9\x94\xaf\x31\xcf\x17\x4e\xd6\xe5\xdb\xae\xb3\xe4\xe2\xdd\x51\xca\x78\x3f\xea\x00\x67\x7f\xce\x0f\x10\xe1\x1d\x7c\x78\x42\xd1\x0f\x09\xbe\x3a\x4e\x0c\x90\x38\xc6\x78\x4e\x59\xd0\xed\x2c\xa9\x1c\x87\x35\x0e\xc7\xfb\x81\xf7\xf0\x13\xa4\xb2\x3b\x66\x33\x88\x32\x08\xc7\x16\xf0\x38\x4d\x47\x61\x57\xae\x14\x6e\x48\xb8\xa2\xd2\x4c\xc0\x9b\xf4\xa0\x71\x43\x34\xec\xf6\x54\x0a\x3c\x59\x50\x08\xf7\xfb\xf8\x6f\x29\x37\x5f\xed\xa7\x9f\xcc\xac\x0f\x87\xf0\xc9\x76\x77\xe8\x69\x25\x0c\x15\x78\x1e\xb7\x96\x95\xc6\x0d\x62\x89\x2a\x4a\x19\x16\x52\x84\x6e\xda\xa4\xc8\x68\x55\x29\xb4\x61\x02\x57\x24\x81\x4b\x61\x18\x09\x54\x76\x23\x59\xe2\xa0\x4d\x4a\x82\x86\x27\x16\xe4\x7e\xc5\x5c\x8a\xac\x9f\x22\x87\xec\x6b\x82\x4b\x08\x2d\xfa\x71\x2e\x29\x3b\x0e\xdc\xed\xcc\x62\x6e\x63\x2a\xd8\x0a\x6f\xe7\x21\x9c\x70\x75\xa2\x14\x5d\x8a\x5f\xb3\xd9\x44\x97\x8c\xe3\x64\x3e\xbf\x28\x59\xa5\x71\xe1\xd8\xac\x80\x17\xd2\xf9\xc5\x91\x42\x44\xf0\x46\x3f\xfc\xea\x93\xc4\x17\x0f\x03\xd6\xfd\x3e\xfe\x66\xff\x9f\xe2\x8a\xb4\x51\xbb\xc3\x21\xd9\xef\xe3\x9f\x16\xe4\xce\x0f\x87\xf0\xe1\xcd\x29\xd7\xfd\x76\x1a\x6e\xd4\xae\x8a\x64\x90\x98\x84\xc1\x7f\x17\xf1\xbc\x86\xba\x2b\xcf\x2c\x78\x27\xc3\xab\x98\x87\xf0\xf9\xf3\xf3\x61\x39\xb3\xbe\xd8\xdb\x17\xa8\xbd\x0d\x9f\xa5\xef\xa8\x3d\x6e\x73\x85\xfe\xe6\xb1\xf0\x66\xfa\xed\x66\x1a\xc1\x17\xd2\xee\x72\xba\xbe\xfa\x4e\xa2\xda\x06\xcd\xaa\xb3\xa7\x76\x7e\xeb\x73\xb7\x6a\xdb\xea\x8f\xae\xaf\xbe\x7f\xfb\x71\xf3\xcf\x25\x8a\x4c\x2a\x4e\x62\xd5\x9e\xa4\x9e\x2f\x1d\xad\xea\xc4\x6a\xcc\x2d\x41\xc2\xdd\x30\x05\x1a\x8d\x37\x42\xf8\xd0\xbb\xd1\xa6\x98\x23\xab\x03\xbd\x99\xda\x57\x07\x60\x51\x9a\x5d\x3d\x60\xc2\x4f\x4e\x1f\x64\x27\xa8\x3b\x38\x23\xf0\x8b\x07\x41\xe1\x92\xd5\xa5\x52\x65\x11\x49\x6d\x14\x36\xa7\x10\x45\xb8\x2d\x51\x51\x81\xc2\xb0\x1c\xfc\x97\x51\x25\xee\x51\x51\x46\x98\x46\xae\x24\x93\x54\xf2\x0d\xaa\x49\x92\x9c\x72\x0c\x51\xb4\xdc\x95\x4c\xeb\x28\x55\x74\x8f\xaa\xf6\xdf\x86\x63\x37\x46\xed\xee\x37\xd3\xcd\x5b\x21\xab\xf2\xce\xb2\xff\xe3\xec\x72\x9f\x7a\x6b\x59\xa2\x62\xee\xb9\xc7\x65\x51\xe6\x68\x30\xed\xb0\xe5\xbb\x18\xa6\x68\x1f\x78\xb6\x58\xd6\xa9\x2f\x7a\xef\x79\xd4\xed\x03\xf7\x16\x7c\xe2\xf6\x7d\xe4\x31\x63\x94\x63\x1a\xc3\xb5\x23\x80\xdf\x94\xe7\xee\xee\x5b\x62\xcd\x84\xe9\xf1\x06\x0e\x5a\xc6\x86\xed\x74\x41\x8f\xf7\xf7\xe3\xd2\xc4\xa1\x6d\xcb\x7f\x03\x00\x00\xff\xff\x52\xa3\xd7\x9f\xee\x0a\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x57\xdb\x6e\xdb\x38\x10\x7d\xd7\x57\x4c\x65\xa3\x6e\x83\x4a\x6e\x5e\x5d\x24\xbb\xc0\xb6\xc5\x16\x28\x8a\x45\x82\x02\x0b\x58\xae\x41\x53\x23\x99\x30\x45\xaa\x24\xe5\xc6\x70\xfc\xef\x0b\x52\x77\xf9\x12\x6f\xfd\x10\xc7\xd4\x99\x33\x67\x6e\xd2\x68\xf4\x6a\xba\x62\x62\xaa\xd7\x9e\x37\x82\xcf\x85\xa0\x86\x49\x01\x46\x42\x46\x04\x49\x11\x34\xaa\x2d\xa3\x08\xda\x10\x65\x8a\x1c\x88\x88\x01\x05\x59\x71\x04\x29\x60\x25\xa5\xf1\x4a\xe4\xb2\x42\xbe\x79\x0b\x7b\x0f\x00\x6a\xcb\xa5\x20\x19\xde\xf9\xe3\x5b\xdf\x9d\xb2\x04\xf4\x4e\x1b\xcc\xa8\xe1\xc0\x74\x40\xa8\x61\x5b\x84\x20\xf8\x59\x30\x34\xe0\x8f\xbb\x66\xfe\x07\x30\x6b\x14\xce\xd0\x7e\x90\xae\xe5\x00\x02\x4c\x03\xe1\x0a\x49\xbc\x03\x55\x08\xc1\x44\x5a\x3a\x42\xae\xf1\x25\x43\x21\x4d\x6d\xf4\xae\x8c\x90\x89\x34\x0c\x43\xbf\x31\xec\xc9\x75\x88\xa1\x44\x78\xfd\xba\x03\xa9\x52\x73\x39\x8c\x73\x8a\x6a\x05\xa0\x0b\x4a\x51\xeb\x8e\x8e\x5e\x34\xad\xfd\xf7\xd2\x9d\x91\xa5\x2d\xf4\xf8\x42\xbf\x6f\xf1\xc4\x0c\xdc\x36\x47\x09\xf3\xaa\xaf\x83\x2d\xfd\x5f\x6b\xa4\x1b\x17\x6e\xc9\x60\xe1\xda\x68\x57\xef\xaa\x15\x98\xf1\xa8\x45\x2d\x89\x88\x97\xbf\x5f\x74\xce\xb4\x09\x0a\xc1\x4c\x90\x30\x8e\x1a\x9e\x21\x55\x98\x43\xf0\x73\x90\x8e\xb0\xfa\x31\xcc\x5e\xdf\xf3\x30\xd5\xd7\xd5\xbe\xfa\x01\xb1\xc4\xb2\x0b\x5c\xb4\xef\x40\x6f\x58\x9e\x77\x7b\xa0\x4c\x0f\x3e\x21\x2d\x0c\x2e\xd7\x52\x6e\x9c\xe8\x26\x5e\x2e\x29\xe1\x10\x33\x85\xd4\x48\xb5\xeb\x85\x3c\x87\x57\x10\xc4\xe0\x8f\x9b\xcb\x3e\x2c\x4e\x76\xf4\xc7\x1a\xe0\xb4\x24\xb2\x10\xf1\x0c\x3a\x66\x0d\x5c\xa1\x29\x94\x80\x5a\x9a\xfb\xd6\x6b\xe4\x7c\xe9\x64\xdd\xbd\xe9\x3a\x9b\xde\xbc\x6d\xa5\x8c\xf7\xa3\x0e\x70\xfe\xe7\xe2\x00\x01\xfe\x84\xf7\x67\x14\x7d\x93\xe0\x80\xa5\x18\x60\xa2\x8d\xf1\x92\x32\xaf\xdb\x59\x52\x39\x0e\x6b\xec\x8f\xf7\x03\xef\xfe\x07\x88\x65\x77\xcc\xe6\x10\x24\xe0\x8f\x2d\xe0\x38\x4d\xad\xb0\x4f\xae\x14\x6e\x48\xa8\x62\xb9\x99\x41\x69\xd2\x83\x86\xe0\x0f\x4e\x2b\x4d\xb1\x14\x78\xb2\xa0\xe0\xef\xf7\xe1\xdf\x52\x6e\x3e\xdb\x5f\xff\x10\xb3\x3e\x1c\xfc\xb3\xed\xee\xd0\x0f\x85\x30\x2c\xc3\xcb\xb8\xb5\x2c\x34\x6e\x10\x73\x54\x41\x4c\x30\x93\xc2\x77\xd3\x26\x45\xc2\xd2\x42\xa1\x0d\x13\xa8\x62\x12\xa8\x14\x86\x30\x81\x0a\x54\x49\xec\x35\x49\x99\xa2\xa1\x53\x0b\x72\x7f\x42\x2a\x45\xd2\x4f\x91\x43\xf6\x35\xc1\x1d\xf8\x16\x7d\x9c\x4b\x96\xb4\x03\x17\xcd\x2d\x26\x0a\x59\x46\x52\x8c\x16\x3e\x9c\x70\x75\xa2\x14\x5d\x8a\x1f\xf3\xf9\x4c\xe7\x84\xe2\x6c\xb1\xb8\xc9\x49\xa1\x71\xe9\xd8\xac\x80\x2b\xe9\xec\x47\x63\x0c\x01\x83\x89\x7e\xfe\xd1\x27\x09\x6f\x9e\x07\xac\xfb\x7d\xf8\x48\x44\xbc\x92\x4f\x5f\xec\xd1\xe1\xe0\x3f\x4f\x4e\x79\xea\xf9\x38\xba\x81\x76\x9d\x4e\x07\x79\x98\x12\x78\xd1\xe7\xcb\x2e\xab\x9e\xbb\x70\xfb\x76\x5e\x4b\xa7\x0b\x1f\xee\xef\x5f\x8e\xc2\x99\xf5\xb5\x45\xc7\xe2\x22\xff\x45\xb6\x8e\xb8\xf6\xd6\xac\xb0\x7c\x8c\x58\x78\x3d\xca\x09\x3b\xd1\xb0\x75\xaf\xc6\xc7\x6d\xfb\xce\x21\x74\xa9\x07\x4a\x91\x59\xa1\x0d\x64\xc4\xd0\xb5\xbb\xe8\xf4\x97\x97\xbc\x73\xad\xdb\x78\x38\xd1\xe9\xaf\x3a\x53\xd1\xe0\xec\xbf\x09\x4b\x43\x23\x33\x7e\xdc\xf4\xd9\x26\x66\x0a\x82\x1c\x06\x56\x0d\xa0\x1f\x52\xc2\x52\x88\x31\x21\x05\x37\x70\x0f\x17\x3c\x75\x6f\x78\x67\xa7\xa2\x4a\xc6\xd1\x5c\x9c\x64\x1c\x08\xef\xcc\x45\xf4\xa6\x4b\x1a\xbd\x1d\xd2\x86\x37\xcf\xd1\xed\x91\xaf\x0b\xd3\x72\x3e\x22\xe4\x9d\x60\x26\x3f\xa2\x79\xce\x8b\x94\x09\x1d\x85\xbe\x1d\x93\xd6\x32\x0a\x53\x95\xd3\x28\xdc\xde\x46\x21\x55\xcc\x8f\x16\x93\xdf\x08\x6e\xfa\xbf\x3c\x4c\x09\x44\x00\x57\x04\x7a\x4d\x9c\xc3\x45\x61\x52\x0b\xb1\x3a\x3a\x32\x9c\x8a\x70\x7b\xeb\x34\x2c\x26\xed\x78\x9d\x27\x6f\x39\xaf\x54\x7b\x7f\x75\xab\x9d\x18\xd9\xb6\xa5\xcb\x89\xfd\xc8\xb4\xdb\x0d\x1f\x3f\x7d\x65\xa2\x78\xf2\xea\x4d\xc3\x9e\xda\xc7\x67\x75\xee\x36\x9d\xa6\xc9\x46\x8f\x9f\xbe\x7e\xf9\xf6\xfd\xdf\x3b\x14\x89\x54\x94\x89\xb4\x39\x89\x4b\xbe\x78\x94\x56\x69\xd5\xc8\x2d\x41\x25\xd1\xd3\x68\x4a\x23\x84\xf7\xbd\x85\xf2\x01\x39\x12\x8d\x2e\xcc\xef\x0f\x1c\x98\x06\xcc\x72\xb3\xab\x9e\x6f\xa2\x9c\xfe\x3e\xe8\x70\xe8\x8f\xf0\x08\xca\xe7\x3e\x82\xc2\x15\xa9\x6a\xa6\xf2\x2c\x90\xda\x28\xac\x4f\x21\x08\xf0\x29\x47\xc5\x32\x14\x86\x70\x28\x2f\x06\x85\xd8\xa2\x62\x09\xc3\x38\x70\xe9\x9f\xc5\x92\x6e\x50\xcd\xa6\xd3\x53\x8e\x21\x08\x56\xbb\x9c\x68\x1d\xc4\x8a\x6d\x51\x55\xfe\x9b\x70\xcc\xba\x71\xf7\x8b\xe8\x7a\x55\x4f\x0a\xde\xd9\xb5\xfe\xb8\xb8\x5b\x3d\x94\xd6\x32\x47\x45\xdc\xdb\x16\x95\x59\xce\xd1\x60\xdc\x61\xe3\xbb\x10\x1e\xd0\xbe\x5f\xd9\x62\xb9\x5b\xaa\xab\x79\xef\xed\xa4\xdb\x06\x16\x7a\x6e\xf9\x3d\xf2\x98\x10\xc6\x31\x0e\xe1\xd1\x11\xc0\x2f\xc6\xb9\x5b\x3d\x57\x58\x31\x61\xdc\x2e\xc0\x5e\xc3\x58\xb3\x9d\x2e\x68\xbb\x3e\x1f\x97\x26\xf4\x6d\x5b\xfe\x37\x00\xae\xd1\x53\xe7\x6d\x0e\x00\x00"),
+		},
+		"/ignition/controlplane/files/etc/sysconfig": &vfsgen۰DirInfo{
+			name:    "sysconfig",
+			modTime: time.Date(2026, 10, 16, 12, 1, 52, 0, time.UTC),
+		},
+		"/ignition/controlplane/files/etc/sysconfig/kubelet.template": &vfsgen۰CompressedFileInfo{
+			name:             "kubelet.template",
+			modTime:          time.Date(2026, 10, 16, 12, 1, 52, 0, time.UTC),
+			uncompressedSize: 148,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xf2\x0e\x75\x72\xf5\x71\x0d\x89\x77\x8d\x08\x09\x72\x8c\x77\x0c\x72\x0f\xb6\x55\xaa\xae\x56\xc8\x4c\x53\xd0\xf3\xcb\x4f\x49\xf5\x49\x4c\x4a\xcd\x29\x56\xa8\xad\xd5\xd5\xcd\xcb\x4f\x49\xd5\xcd\x01\xf3\x6d\xab\xab\x91\x64\x6b\x6b\xab\xab\x15\x52\xf3\x52\x14\x6a\x6b\x15\x90\xb4\x86\x24\x66\xe6\x95\x40\xb4\x16\xa5\xa6\x67\x16\x97\xa4\x16\xe9\x96\x67\x96\x64\xe8\x96\x80\x25\x60\x66\x40\x94\x21\x99\xa1\xc4\x05\x18\x00\x73\x78\x70\x55\x94\x00\x00\x00"),
 		},
 		"/ignition/controlplane/files/etc/sysctl.d": &vfsgen۰DirInfo{
 			name:    "sysctl.d",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime: time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 		},
 		"/ignition/controlplane/files/etc/sysctl.d/kubernetes.conf": &vfsgen۰CompressedFileInfo{
 			name:             "kubernetes.conf",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 97,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xca\x4b\x2d\xd1\x4b\x2a\xca\x4c\x49\x4f\x85\x52\xba\x79\x69\xba\xc9\x89\x39\x39\xba\x99\x05\x25\x89\x49\x39\xa9\xc5\xb6\x86\x5c\xf8\x14\x99\xa1\xa8\xca\x2c\x28\x33\xd1\xcb\x2c\x88\x4f\xcb\x2f\x2a\x4f\x2c\x4a\xb1\x35\xe4\x02\x04\x00\x00\xff\xff\x6d\xd4\xf2\x72\x61\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xca\x4b\x2d\xd1\x4b\x2a\xca\x4c\x49\x4f\x85\x52\xba\x79\x69\xba\xc9\x89\x39\x39\xba\x99\x05\x25\x89\x49\x39\xa9\xc5\xb6\x86\x5c\xf8\x14\x99\xa1\xa8\xca\x2c\x28\x33\xd1\xcb\x2c\x88\x4f\xcb\x2f\x2a\x4f\x2c\x4a\xb1\x35\xe4\x02\x0c\x00\x6d\xd4\xf2\x72\x61\x00\x00\x00"),
 		},
 		"/ignition/controlplane/files/etc/systemd": &vfsgen۰DirInfo{
 			name:    "systemd",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime: time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 		},
 		"/ignition/controlplane/files/etc/systemd/system": &vfsgen۰DirInfo{
 			name:    "system",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime: time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 		},
 		"/ignition/controlplane/files/etc/systemd/system/kubelet.service.d": &vfsgen۰DirInfo{
 			name:    "kubelet.service.d",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime: time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 		},
 		"/ignition/controlplane/files/etc/systemd/system/kubelet.service.d/10-kubeadm.conf.template": &vfsgen۰CompressedFileInfo{
 			name:             "10-kubeadm.conf.template",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 1086,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x53\xd1\x4e\xdb\x50\x0c\x7d\xef\x57\x58\x01\x89\x87\x91\x54\xbc\x4e\xca\x43\xb7\x15\x34\xb1\x01\x2a\xa0\x4d\x9a\x26\xe4\x24\x4e\x6a\xb8\xbd\x2e\xbe\x4e\xa0\x42\xfc\xfb\x74\x13\x5a\x8a\x56\x78\x8a\xe3\xf8\x9e\x73\xae\xcf\xc9\x1e\x9c\x89\xd1\x67\xb8\x9a\x73\x80\x4a\x65\xc9\x1e\xc4\xbb\x15\x3c\x88\xde\x05\x78\x60\x9b\xc3\x5d\x5b\x10\x56\x0b\x40\x5f\xf5\xb5\x23\x83\xee\x28\x3b\x3a\xfa\x34\xfa\x73\x49\xda\x71\x49\x7f\x47\x53\xdf\xb1\x8a\x5f\x90\xb7\x3c\x39\xbd\xfe\x32\xfd\x31\xbd\xba\x89\xcf\xaf\xe7\x67\xc7\xdf\x4f\x6e\x26\xb3\x93\xcb\x3c\x4d\x0b\x11\x0b\xa6\xb8\x4c\x23\x52\x29\xbe\xe6\x26\x1f\x93\x95\xe3\xf8\xae\x9e\x8c\xc2\xf8\xed\x90\x23\xcb\xe2\x20\xa4\x1f\x9d\xd9\x9e\x4c\x76\xab\x79\xab\x64\x8d\xd3\xa1\x8e\x1d\x17\x6b\x80\xf1\xd0\xcf\x56\xb8\x70\xc9\xe8\xe9\x09\xb8\x06\xba\x87\x6c\xd6\x7a\xe3\x05\x41\x52\x2a\x4b\x02\xcf\xcf\xef\x70\x9c\xcc\xce\xaf\x2f\x7e\xfd\xdc\xb0\x34\x2a\xed\x32\xa4\x4b\xd2\xf4\x5e\x42\x5e\xa3\x0b\x04\x69\x4a\xbe\x16\x2d\x29\xf5\x52\x51\x8a\xce\x49\x89\x86\x85\xa3\xfc\xe0\xa0\x67\x25\x5f\x45\x8e\xbd\xc1\x18\x0e\x80\x50\xb3\x23\xb0\x39\x1a\x24\x6b\x47\xd8\xb3\x25\xbd\x2f\x9b\xd6\xad\xb0\x4f\xa0\x21\x4f\x8a\x46\x01\xd0\x40\x07\xe9\x87\xb0\x94\x65\xeb\xd0\xd8\x37\x60\x73\x82\x6d\x97\x26\xdf\x06\xc9\xd0\xa1\x72\x14\x02\xd5\xca\xe3\x82\x4b\x74\x6e\xb5\x7d\xd5\x63\x76\x94\xa7\xff\x6d\xed\x85\x3e\xad\x1d\x36\x21\x23\xdf\xbd\x23\x3d\xf2\xb6\x81\x14\x4a\xf4\xb1\x80\x5a\x14\xa4\x23\x55\xae\x28\x80\xd4\xfd\xc4\x3a\x65\xa8\x4d\x00\x8c\x08\x0e\x83\x81\x52\x10\xb5\x0c\x2e\x94\x6a\x52\x2c\xdc\xea\xf0\x15\x30\xcc\xa5\x75\x55\xac\x47\x7b\x7d\x37\x3b\x93\x8a\x66\xd4\x70\x8c\x92\xb1\xf8\xec\x74\x80\x9d\x3e\x9a\xe2\x24\x42\x4b\x71\x4b\xa5\x01\xfb\xfe\xc0\xe0\x7c\x3b\x0c\xf7\x9a\x03\xb0\x0f\x46\x58\x65\x9b\x65\x4d\x7f\x5f\xcd\x26\xc3\xaa\x5e\x18\x0b\x82\x20\xad\x96\x54\x41\xad\xb2\x00\x8b\xd7\x8e\xa7\xb3\x1d\x7b\x8b\xa9\x0d\xab\x30\x50\xad\xb7\x37\x9a\x3e\x52\x79\x69\xa8\x96\x6f\x95\xe3\x36\xe8\xb8\x60\xbf\x9e\x82\xfd\x77\x7e\xab\xd7\x0f\x3b\x9b\x6f\xec\xdd\xdf\x71\x8f\x0f\x42\xbe\xbf\x33\xd7\x9b\x7c\xfe\x0b\x00\x00\xff\xff\x21\x0d\x51\xd3\x3e\x04\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x53\xd1\x4e\xdb\x50\x0c\x7d\xef\x57\x58\x01\x89\x87\x91\x54\xbc\x4e\xca\x43\xb7\x15\x34\xb1\x01\x2a\xa0\x4d\x9a\x26\xe4\x24\x4e\x6a\xb8\xbd\x2e\xbe\x4e\xa0\x42\xfc\xfb\x74\x13\x5a\x8a\x56\x78\x8a\xe3\xf8\x9e\x73\xae\xcf\xc9\x1e\x9c\x89\xd1\x67\xb8\x9a\x73\x80\x4a\x65\xc9\x1e\xc4\xbb\x15\x3c\x88\xde\x05\x78\x60\x9b\xc3\x5d\x5b\x10\x56\x0b\x40\x5f\xf5\xb5\x23\x83\xee\x28\x3b\x3a\xfa\x34\xfa\x73\x49\xda\x71\x49\x7f\x47\x53\xdf\xb1\x8a\x5f\x90\xb7\x3c\x39\xbd\xfe\x32\xfd\x31\xbd\xba\x89\xcf\xaf\xe7\x67\xc7\xdf\x4f\x6e\x26\xb3\x93\xcb\x3c\x4d\x0b\x11\x0b\xa6\xb8\x4c\x23\x52\x29\xbe\xe6\x26\x1f\x93\x95\xe3\xf8\xae\x9e\x8c\xc2\xf8\xed\x90\x23\xcb\xe2\x20\xa4\x1f\x9d\xd9\x9e\x4c\x76\xab\x79\xab\x64\x8d\xd3\xa1\x8e\x1d\x17\x6b\x80\xf1\xd0\xcf\x56\xb8\x70\xc9\xe8\xe9\x09\xb8\x06\xba\x87\x6c\xd6\x7a\xe3\x05\x41\x52\x2a\x4b\x02\xcf\xcf\xef\x70\x9c\xcc\xce\xaf\x2f\x7e\xfd\xdc\xb0\x34\x2a\xed\x32\xa4\x4b\xd2\xf4\x5e\x42\x5e\xa3\x0b\x04\x69\x4a\xbe\x16\x2d\x29\xf5\x52\x51\x8a\xce\x49\x89\x86\x85\xa3\xfc\xe0\xa0\x67\x25\x5f\x45\x8e\xbd\xc1\x18\x0e\x80\x50\xb3\x23\xb0\x39\x1a\x24\x6b\x47\xd8\xb3\x25\xbd\x2f\x9b\xd6\xad\xb0\x4f\xa0\x21\x4f\x8a\x46\x01\xd0\x40\x07\xe9\x87\xb0\x94\x65\xeb\xd0\xd8\x37\x60\x73\x82\x6d\x97\x26\xdf\x06\xc9\xd0\xa1\x72\x14\x02\xd5\xca\xe3\x82\x4b\x74\x6e\xb5\x7d\xd5\x63\x76\x94\xa7\xff\x6d\xed\x85\x3e\xad\x1d\x36\x21\x23\xdf\xbd\x23\x3d\xf2\xb6\x81\x14\x4a\xf4\xb1\x80\x5a\x14\xa4\x23\x55\xae\x28\x80\xd4\xfd\xc4\x3a\x65\xa8\x4d\x00\x8c\x08\x0e\x83\x81\x52\x10\xb5\x0c\x2e\x94\x6a\x52\x2c\xdc\xea\xf0\x15\x30\xcc\xa5\x75\x55\xac\x47\x7b\x7d\x37\x3b\x93\x8a\x66\xd4\x70\x8c\x92\xb1\xf8\xec\x74\x80\x9d\x3e\x9a\xe2\x24\x42\x4b\x71\x4b\xa5\x01\xfb\xfe\xc0\xe0\x7c\x3b\x0c\xf7\x9a\x03\xb0\x0f\x46\x58\x65\x9b\x65\x4d\x7f\x5f\xcd\x26\xc3\xaa\x5e\x18\x0b\x82\x20\xad\x96\x54\x41\xad\xb2\x00\x8b\xd7\x8e\xa7\xb3\x1d\x7b\x8b\xa9\x0d\xab\x30\x50\xad\xb7\x37\x9a\x3e\x52\x79\x69\xa8\x96\x6f\x95\xe3\x36\xe8\xb8\x60\xbf\x9e\x82\xfd\x77\x7e\xab\xd7\x0f\x3b\x9b\x6f\xec\xdd\xdf\x71\x8f\x0f\x42\xbe\xbf\x33\xd7\x9b\x7c\xfe\x1b\x00\x21\x0d\x51\xd3\x3e\x04\x00\x00"),
 		},
 		"/ignition/controlplane/systemd": &vfsgen۰DirInfo{
 			name:    "systemd",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime: time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 		},
 		"/ignition/controlplane/systemd/init-cluster.service": &vfsgen۰CompressedFileInfo{
 			name:             "init-cluster.service",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 510,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x90\x3d\x6f\xe3\x30\x0c\x86\x77\xfd\x0a\x26\x43\x36\xd9\x77\xc3\x4d\x07\x0f\xf7\x91\xe1\xb6\x20\xc1\xa1\x83\xe1\x81\x91\x69\x9b\x88\x2c\xb9\x22\x95\x26\xff\xbe\xb0\x9b\xa4\x40\x87\x0e\x1d\x45\x82\xcf\xfb\xe8\xad\xff\x07\xd6\xc6\xfc\x25\x71\x89\x27\xe5\x18\x2a\x0e\xac\x70\xca\x47\x4a\x81\x94\x04\x9c\xcf\xa2\x94\xcc\x9e\x9e\x33\x27\x92\x4a\x48\xed\x69\xde\x7a\x3b\x61\xc2\x42\x28\x9d\xd9\x11\x24\xf2\x84\x42\x96\x47\xec\xc9\x4e\x7c\x8e\x7a\xdf\x99\x5f\x9d\x52\xfa\xd2\xe5\x9f\x18\x5a\x9e\xc5\x76\xa8\xc3\xf6\xc2\xa2\x52\xad\xca\x33\xa6\xd2\xc7\xbe\x9c\x65\xed\xcd\xb0\x10\xc5\x71\x32\xa6\x3e\xbc\x9d\x36\x66\x7b\x21\x77\x50\x4c\xba\x4b\x54\x95\x47\x0e\xe5\x11\x65\x00\xeb\x60\xfd\x32\xb0\x27\xa8\x61\x05\xb6\x83\x07\x2e\xc4\xf6\x91\x3f\xc3\xa0\xf9\x09\x6d\x04\xf1\x44\x13\x7c\xff\x36\x3f\x02\xad\xdf\xb9\x1f\xa0\x73\x6b\xd8\x8e\xb0\x54\x68\xad\x8b\xa1\xe3\xbe\x2a\x49\x5d\x19\x4e\xed\x4d\x76\x19\x16\x57\x1c\x3d\x58\x9b\x27\x1f\xb1\xb5\x8e\x92\x0a\x6c\x36\xb0\xf0\x34\x66\x37\xc0\x27\x7f\x5c\x9b\x3d\xc9\x92\x1f\x83\xed\x90\x7d\x4e\x74\x1f\x1d\xc8\x55\x3f\xc4\x98\xfa\x5f\x10\x45\xef\x1b\xf3\x84\x41\xa9\xfd\x7d\xad\xc6\xec\x95\x6d\x16\x4a\x85\x62\xea\x49\x5f\x03\x00\x00\xff\xff\x6d\x6f\x26\x69\xfe\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x90\x3d\x6f\xe3\x30\x0c\x86\x77\xfd\x0a\x26\x43\x36\xd9\x77\xc3\x4d\x07\x0f\xf7\x91\xe1\xb6\x20\xc1\xa1\x83\xe1\x81\x91\x69\x9b\x88\x2c\xb9\x22\x95\x26\xff\xbe\xb0\x9b\xa4\x40\x87\x0e\x1d\x45\x82\xcf\xfb\xe8\xad\xff\x07\xd6\xc6\xfc\x25\x71\x89\x27\xe5\x18\x2a\x0e\xac\x70\xca\x47\x4a\x81\x94\x04\x9c\xcf\xa2\x94\xcc\x9e\x9e\x33\x27\x92\x4a\x48\xed\x69\xde\x7a\x3b\x61\xc2\x42\x28\x9d\xd9\x11\x24\xf2\x84\x42\x96\x47\xec\xc9\x4e\x7c\x8e\x7a\xdf\x99\x5f\x9d\x52\xfa\xd2\xe5\x9f\x18\x5a\x9e\xc5\x76\xa8\xc3\xf6\xc2\xa2\x52\xad\xca\x33\xa6\xd2\xc7\xbe\x9c\x65\xed\xcd\xb0\x10\xc5\x71\x32\xa6\x3e\xbc\x9d\x36\x66\x7b\x21\x77\x50\x4c\xba\x4b\x54\x95\x47\x0e\xe5\x11\x65\x00\xeb\x60\xfd\x32\xb0\x27\xa8\x61\x05\xb6\x83\x07\x2e\xc4\xf6\x91\x3f\xc3\xa0\xf9\x09\x6d\x04\xf1\x44\x13\x7c\xff\x36\x3f\x02\xad\xdf\xb9\x1f\xa0\x73\x6b\xd8\x8e\xb0\x54\x68\xad\x8b\xa1\xe3\xbe\x2a\x49\x5d\x19\x4e\xed\x4d\x76\x19\x16\x57\x1c\x3d\x58\x9b\x27\x1f\xb1\xb5\x8e\x92\x0a\x6c\x36\xb0\xf0\x34\x66\x37\xc0\x27\x7f\x5c\x9b\x3d\xc9\x92\x1f\x83\xed\x90\x7d\x4e\x74\x1f\x1d\xc8\x55\x3f\xc4\x98\xfa\x5f\x10\x45\xef\x1b\xf3\x84\x41\xa9\xfd\x7d\xad\xc6\xec\x95\x6d\x16\x4a\x85\x62\xea\x49\x5f\x07\x00\x6d\x6f\x26\x69\xfe\x01\x00\x00"),
 		},
 		"/ignition/controlplane/systemd/kubelet.service": &vfsgen۰CompressedFileInfo{
 			name:             "kubelet.service",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 325,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x8f\x31\x6e\xf3\x30\x0c\x46\x77\x9e\xc2\x17\xb0\x95\x7f\x0d\xa0\x21\x7f\x93\x21\x68\x51\x14\x75\x8b\x0e\x86\x07\x59\x66\x6d\xc2\x32\x65\x88\x94\x93\xdc\xbe\xa8\xd1\x74\xeb\xfc\x1e\x89\xef\x35\xef\x4c\xda\xc2\x11\xc5\x27\x5a\x94\x22\xdb\x29\x77\x18\x50\xf7\xc5\xdb\x88\xc5\x63\xee\x30\x31\x2a\x4a\xf1\x1c\x7b\x2c\x0e\x03\xb2\xc2\x31\xfa\x3c\x23\xab\xdb\x0e\x46\xd5\x45\xf6\xc6\x4c\xbf\x6e\x45\xd1\xf4\xd1\x8b\x81\x0f\xc7\x2a\x96\x51\x2f\x31\x4d\x65\xe4\x40\x8c\x95\xba\x34\xa0\xc2\xe1\x53\x31\xfd\xc1\x1e\x22\xf7\xf4\xfd\xfd\xc5\xe9\x78\xba\x92\xa8\x58\xb3\xba\x64\x42\x1c\x0c\xc7\x1e\xcb\x85\xd6\xa8\x95\xa8\x9b\x17\x80\xa6\xc6\xb4\x92\xc7\x16\x4e\x57\xf4\xb5\xba\xa4\xd6\x64\x49\xa6\x23\x36\x3f\x41\xf0\x8a\xb2\x01\x17\x2e\xee\x26\xb0\x59\x4f\x34\x93\x9e\x59\x31\xad\x2e\xd8\xdd\xdd\xa9\xd1\xdb\x7f\x3b\x80\xe6\xcc\xa2\x2e\x84\x76\x0b\xc1\xfe\xff\xcd\xce\x39\x28\x95\x59\x30\xdd\xb7\xc2\x57\x00\x00\x00\xff\xff\xcb\xcf\xb3\x78\x45\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x8f\x31\x6e\xf3\x30\x0c\x46\x77\x9e\xc2\x17\xb0\x95\x7f\x0d\xa0\x21\x7f\x93\x21\x68\x51\x14\x75\x8b\x0e\x86\x07\x59\x66\x6d\xc2\x32\x65\x88\x94\x93\xdc\xbe\xa8\xd1\x74\xeb\xfc\x1e\x89\xef\x35\xef\x4c\xda\xc2\x11\xc5\x27\x5a\x94\x22\xdb\x29\x77\x18\x50\xf7\xc5\xdb\x88\xc5\x63\xee\x30\x31\x2a\x4a\xf1\x1c\x7b\x2c\x0e\x03\xb2\xc2\x31\xfa\x3c\x23\xab\xdb\x0e\x46\xd5\x45\xf6\xc6\x4c\xbf\x6e\x45\xd1\xf4\xd1\x8b\x81\x0f\xc7\x2a\x96\x51\x2f\x31\x4d\x65\xe4\x40\x8c\x95\xba\x34\xa0\xc2\xe1\x53\x31\xfd\xc1\x1e\x22\xf7\xf4\xfd\xfd\xc5\xe9\x78\xba\x92\xa8\x58\xb3\xba\x64\x42\x1c\x0c\xc7\x1e\xcb\x85\xd6\xa8\x95\xa8\x9b\x17\x80\xa6\xc6\xb4\x92\xc7\x16\x4e\x57\xf4\xb5\xba\xa4\xd6\x64\x49\xa6\x23\x36\x3f\x41\xf0\x8a\xb2\x01\x17\x2e\xee\x26\xb0\x59\x4f\x34\x93\x9e\x59\x31\xad\x2e\xd8\xdd\xdd\xa9\xd1\xdb\x7f\x3b\x80\xe6\xcc\xa2\x2e\x84\x76\x0b\xc1\xfe\xff\xcd\xce\x39\x28\x95\x59\x30\xdd\xb7\xc2\xd7\x00\xcb\xcf\xb3\x78\x45\x01\x00\x00"),
 		},
 		"/ignition/controlplane/systemd/release-image-pivot.service": &vfsgen۰CompressedFileInfo{
 			name:             "release-image-pivot.service",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 324,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x8f\xbd\x6e\xc3\x30\x0c\x06\x77\x3d\x05\x9b\x21\x9b\xa3\xa9\xa3\x86\xfe\x64\xe8\x16\xd4\x28\x3a\x18\x1e\x18\x99\xb1\x09\xcb\x94\x21\xd2\x6e\xfa\xf6\x85\x03\x64\xe8\x90\x95\xc4\x77\x87\x6b\xbe\x84\xad\x75\xef\xa4\xb1\xf0\x6c\x9c\x25\x9c\x78\xcd\x06\x92\x3b\x02\xcb\x60\x03\x81\x8c\x1d\x14\x4a\x84\x4a\xc0\x13\xf6\xe4\xbe\x51\x4c\x83\x90\xfd\xe4\x32\x56\x59\x12\x0b\x1d\x0c\x4b\x4f\xe6\x5e\x2e\x46\xe5\xc1\xef\x2d\x4b\xc7\x9b\xe5\x84\x36\x1c\xaf\xac\xa6\xe1\xc9\xaf\x58\x7c\xca\xbd\xdf\x9c\xd5\xbc\xe9\x0f\x6a\x38\xcd\xce\x35\x35\x95\x95\x23\xb5\xee\x78\xa5\x58\x1b\x16\x0b\xfe\xcc\xe2\xcf\xa8\x03\x54\x11\x76\x9e\x2c\x7a\x19\xbb\x7f\xe3\x01\xf6\x7b\xb0\xbc\xc4\x01\x1e\xc2\x77\xce\x7d\x92\xde\x88\x59\xaa\x0b\x72\x5a\x0a\xdd\x4f\x35\xc5\xf0\xac\xce\x35\x1f\xa2\x86\x29\xb5\xb7\x62\xea\x5e\x7f\xc3\xb4\x24\xe3\x6a\x51\x2a\xf7\xa8\xbf\x00\x00\x00\xff\xff\xea\x51\xb4\x1e\x44\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x8f\xbd\x6e\xc3\x30\x0c\x06\x77\x3d\x05\x9b\x21\x9b\xa3\xa9\xa3\x86\xfe\x64\xe8\x16\xd4\x28\x3a\x18\x1e\x18\x99\xb1\x09\xcb\x94\x21\xd2\x6e\xfa\xf6\x85\x03\x64\xe8\x90\x95\xc4\x77\x87\x6b\xbe\x84\xad\x75\xef\xa4\xb1\xf0\x6c\x9c\x25\x9c\x78\xcd\x06\x92\x3b\x02\xcb\x60\x03\x81\x8c\x1d\x14\x4a\x84\x4a\xc0\x13\xf6\xe4\xbe\x51\x4c\x83\x90\xfd\xe4\x32\x56\x59\x12\x0b\x1d\x0c\x4b\x4f\xe6\x5e\x2e\x46\xe5\xc1\xef\x2d\x4b\xc7\x9b\xe5\x84\x36\x1c\xaf\xac\xa6\xe1\xc9\xaf\x58\x7c\xca\xbd\xdf\x9c\xd5\xbc\xe9\x0f\x6a\x38\xcd\xce\x35\x35\x95\x95\x23\xb5\xee\x78\xa5\x58\x1b\x16\x0b\xfe\xcc\xe2\xcf\xa8\x03\x54\x11\x76\x9e\x2c\x7a\x19\xbb\x7f\xe3\x01\xf6\x7b\xb0\xbc\xc4\x01\x1e\xc2\x77\xce\x7d\x92\xde\x88\x59\xaa\x0b\x72\x5a\x0a\xdd\x4f\x35\xc5\xf0\xac\xce\x35\x1f\xa2\x86\x29\xb5\xb7\x62\xea\x5e\x7f\xc3\xb4\x24\xe3\x6a\x51\x2a\xf7\xa8\xbf\x01\x00\xea\x51\xb4\x1e\x44\x01\x00\x00"),
 		},
 		"/ignition/controlplane/systemd/set-kernel-para.service": &vfsgen۰CompressedFileInfo{
 			name:             "set-kernel-para.service",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 286,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x8e\x4d\x4e\xc3\x30\x10\x85\xf7\x73\x0a\x5f\x20\xc9\x09\xbc\x00\xd1\x05\x62\xd7\x82\x58\x44\x11\x72\x9c\x97\x32\xaa\x63\x9b\x99\x49\xd5\xdc\x1e\x41\x24\xc4\xaa\xbb\xf7\x2b\x7d\xfd\x5b\x66\x1b\xe8\x09\x1a\x85\xab\x71\xc9\x5e\x61\xee\x02\xc9\x48\xae\x06\x09\x6e\x2e\xe2\x5e\xd6\xf1\x27\x31\x28\x1d\xf1\xb5\xb2\x40\xbd\x20\x21\x28\x1a\x5e\xc2\x19\x4d\xe5\x6b\xb1\x56\x21\x57\x8e\xa0\x87\xd9\x20\x77\x17\xd4\x9f\x76\x35\xd0\xeb\x56\xe1\x4b\x86\x7e\x16\xa3\x23\x96\xc0\xf9\xf7\x7f\xb8\xb1\xf9\x0d\x4a\x87\x1b\xe2\xc9\x82\x98\x5f\xca\x54\xa5\x8c\x70\xa3\x7c\x64\xd8\xcc\xc9\x20\xff\x7a\xdd\x34\x5a\x72\x4d\x75\x1d\x2c\x76\xbb\x6d\xa7\xee\xf2\xc7\xdf\xc6\x92\x67\xa2\xfe\x39\xab\x85\x94\x06\x7a\x0f\xd9\x30\x3d\x6e\x7e\x59\x93\x71\xb3\x2a\xa4\xb5\x20\x67\x18\xd1\x77\x00\x00\x00\xff\xff\x73\x48\x6e\xcb\x1e\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x8e\x4d\x4e\xc3\x30\x10\x85\xf7\x73\x0a\x5f\x20\xc9\x09\xbc\x00\xd1\x05\x62\xd7\x82\x58\x44\x11\x72\x9c\x97\x32\xaa\x63\x9b\x99\x49\xd5\xdc\x1e\x41\x24\xc4\xaa\xbb\xf7\x2b\x7d\xfd\x5b\x66\x1b\xe8\x09\x1a\x85\xab\x71\xc9\x5e\x61\xee\x02\xc9\x48\xae\x06\x09\x6e\x2e\xe2\x5e\xd6\xf1\x27\x31\x28\x1d\xf1\xb5\xb2\x40\xbd\x20\x21\x28\x1a\x5e\xc2\x19\x4d\xe5\x6b\xb1\x56\x21\x57\x8e\xa0\x87\xd9\x20\x77\x17\xd4\x9f\x76\x35\xd0\xeb\x56\xe1\x4b\x86\x7e\x16\xa3\x23\x96\xc0\xf9\xf7\x7f\xb8\xb1\xf9\x0d\x4a\x87\x1b\xe2\xc9\x82\x98\x5f\xca\x54\xa5\x8c\x70\xa3\x7c\x64\xd8\xcc\xc9\x20\xff\x7a\xdd\x34\x5a\x72\x4d\x75\x1d\x2c\x76\xbb\x6d\xa7\xee\xf2\xc7\xdf\xc6\x92\x67\xa2\xfe\x39\xab\x85\x94\x06\x7a\x0f\xd9\x30\x3d\x6e\x7e\x59\x93\x71\xb3\x2a\xa4\xb5\x20\x67\x18\xd1\xf7\x00\x73\x48\x6e\xcb\x1e\x01\x00\x00"),
 		},
 		"/ignition/master": &vfsgen۰DirInfo{
 			name:    "master",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
+			modTime: time.Date(2026, 10, 16, 12, 1, 52, 0, time.UTC),
 		},
 		"/ignition/master/files": &vfsgen۰DirInfo{
 			name:    "files",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime: time.Date(2026, 10, 16, 12, 1, 52, 0, time.UTC),
 		},
 		"/ignition/master/files/etc": &vfsgen۰DirInfo{
 			name:    "etc",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
+			modTime: time.Date(2026, 10, 16, 12, 1, 52, 0, time.UTC),
 		},
 		"/ignition/master/files/etc/hosts.template": &vfsgen۰CompressedFileInfo{
 			name:             "hosts.template",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 167,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x32\x34\x32\xd7\x33\xd0\x33\xd0\x33\x54\x50\x50\xc8\xc9\x4f\x4e\xcc\xc9\xc8\x2f\x2e\x41\xb0\xf4\xc0\xac\x94\xfc\xdc\xc4\xcc\x3c\x84\xa8\x09\x12\x13\x59\x85\x09\x97\x95\x15\xc8\x20\x08\x20\xda\x38\x33\x24\x26\xb2\x0a\x33\xae\xea\x6a\x3d\x8f\xe2\xcc\x82\xda\x5a\x40\x00\x00\x00\xff\xff\x0b\x57\x23\x96\xa7\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x32\x34\x32\xd7\x33\xd0\x33\xd0\x33\x54\x50\x50\xc8\xc9\x4f\x4e\xcc\xc9\xc8\x2f\x2e\x41\xb0\xf4\xc0\xac\x94\xfc\xdc\xc4\xcc\x3c\x84\xa8\x09\x12\x13\x59\x85\x09\x97\x95\x15\xc8\x20\x08\x20\xda\x38\x33\x24\x26\xb2\x0a\x33\xae\xea\x6a\x3d\x8f\xe2\xcc\x82\xda\x5a\xc0\x00\x0b\x57\x23\x96\xa7\x00\x00\x00"),
 		},
 		"/ignition/master/files/etc/isulad": &vfsgen۰DirInfo{
 			name:    "isulad",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime: time.Date(2026, 10, 16, 11, 8, 40, 0, time.UTC),
 		},
 		"/ignition/master/files/etc/isulad/daemon.json.template": &vfsgen۰CompressedFileInfo{
 			name:             "daemon.json.template",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
-			uncompressedSize: 1174,
+			modTime:          time.Date(2026, 10, 16, 11, 8, 40, 0, time.UTC),
+			uncompressedSize: 1167,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x54\xcb\xae\xd3\x3c\x10\xde\x9f\xa7\x88\xbc\xfe\x9d\xf4\x2f\x12\x8b\x4a\x67\x83\xc4\x02\xb1\x00\x75\x8b\xd0\x91\x6b\x4f\xd2\x21\x8e\x6d\x8d\xed\xd0\x50\xe5\xdd\x91\x5d\x27\x6d\x03\x62\x57\xcd\x77\xe9\x37\x97\xf8\xfa\x52\x55\x55\xc5\xe0\x02\x92\x5b\x17\x3c\x3b\x54\xdf\x98\x11\x01\x47\xa8\x65\x47\x36\x3a\x45\x38\x02\xbd\xfa\xc9\x07\x18\x14\xfb\xfe\xdf\x4d\x91\x31\x76\xa8\x18\xfa\xa8\x05\x2b\x55\x05\xad\x88\x3a\x70\x8a\x26\xe0\x00\x09\xd7\x92\xd8\xaa\x11\xee\x9c\x6a\xcd\x28\xa8\xd1\x78\x6a\xb2\x58\x2d\xb8\x0f\x22\xc0\x8a\x53\x34\x1b\x1c\x4c\x87\x66\x6b\xaa\x6d\xc7\x35\x8c\xa0\x53\xfd\xe3\xf1\xf8\xe5\xb8\x20\x0e\x55\x8b\xfa\x6f\x86\xb5\x43\xf5\xa8\x2f\x9d\xdf\x86\xb1\x56\x93\x98\x0f\x56\x65\x87\xdd\xfb\xdd\xae\x48\x56\x82\x13\xe1\x1f\xed\x64\xda\x20\x2e\x7c\x09\xf1\xff\x16\xf0\xf8\x2b\x03\xef\x76\x9f\x3f\xb0\x0c\xcd\x0f\xa1\x6e\x83\x4f\xb8\x0f\xca\xc6\xb0\x04\x96\xd6\x04\x81\x06\x88\x6b\xdb\x3d\xa7\xbe\x4b\x7e\x78\x6b\x6e\x7f\xfc\xe4\x7b\xb6\xb6\xe7\xde\x81\xcc\xb1\x21\xc8\xa6\x6c\xac\x44\x6f\x12\xc1\x2f\xc5\x3a\xb9\x3c\x2c\x87\x02\x4f\x5b\x4d\x59\x0e\x15\xdb\x0f\x77\xc8\x92\xe8\xe0\x21\xb1\x1d\x81\xb4\x98\xf6\x5b\xc6\x72\x62\xf7\xc8\x0b\xb3\x4e\x3f\x08\x15\xbc\xf5\x40\x06\xf4\x9b\x3c\x83\xec\x5f\x03\xc5\xd2\xc2\x72\x78\x04\x1d\xfa\x40\x13\x1f\x90\xc8\xd2\xc6\x4e\x59\xd9\x03\xd5\x68\x9f\x45\x68\x3c\xc8\x48\xc0\x8b\x1a\x61\xa3\xbb\x5e\xeb\x4f\x83\xe8\xe0\x58\xdc\xe7\xf9\xd9\xc0\x59\xc5\xbd\x30\xea\x64\x2f\x1c\x13\x31\x75\xf9\xa7\xa8\xb9\x5e\xeb\xaf\x22\x7a\xc8\xf5\x79\x5e\xfa\x2f\x5f\x54\x1c\x84\xef\xf3\x46\x73\x9a\x15\x85\xf0\xd3\x52\xcf\x9d\x8e\x1d\x9a\x84\x4b\x83\xeb\xba\x0d\xf2\x13\x1a\xae\x30\x4f\xb6\xb1\x2e\x34\xd2\x60\x73\x42\xf3\x48\x91\xd6\xb4\x2b\x27\x2d\x36\x71\x0c\x84\x7a\x3d\xf4\x1c\x9b\x6b\x31\x01\xf1\x3c\x5c\x76\xa8\x5a\xa1\x3d\x14\x3c\x7a\xe0\x0a\x24\x4d\x2e\x80\xe2\x3d\x4c\xec\x50\xa5\xf1\x6f\x47\xe8\x7b\x74\x7c\x04\xc2\x76\xe2\x60\x5a\x4b\x12\x36\x4e\x92\x70\x79\x01\x36\x9f\x55\x2f\x82\xc8\x8f\x86\xad\xd7\x33\x56\x75\xaa\xd6\xe3\xbe\x9c\xea\xcb\xfc\xf2\x3b\x00\x00\xff\xff\x2e\x9c\xa7\x42\x96\x04\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x54\xcb\xae\x9b\x3c\x10\xde\xe7\x29\x90\xd7\xc7\x90\x3f\xbf\xd4\x05\xd2\xd9\xf4\xb2\xa8\xba\xa8\x94\x2e\xab\xea\xc8\xb1\x07\x32\xc5\xd8\xd6\xd8\xa6\xa1\x11\xef\x5e\xd9\x01\x92\xa0\xaa\x59\x85\xf9\x2e\x7c\x9e\x19\x73\xdd\x15\x45\x51\x30\xb8\x80\xe4\xd6\x05\xcf\xea\xe2\x3b\x33\x22\xe0\x00\xa5\x6c\xc9\x46\xa7\x08\x07\xa0\xd7\xeb\xb5\xfc\x90\x9f\x3f\xe6\xe7\x69\x62\x3f\x5e\x6e\xda\x5c\x65\x75\xc1\xd0\x47\x2d\xd8\x5c\x55\xd0\x88\xa8\x03\xa7\x68\x02\xf6\x90\x70\x2d\x89\xad\x1a\xe1\xce\xa9\x56\x0d\x82\x2a\x8d\xa7\x2a\x8b\xd5\x82\xfb\x20\x02\xac\x38\x45\xb3\xc1\xc1\xb4\x68\xb6\xa6\xda\xb6\x5c\xc3\x00\x3a\xd5\x3f\x1d\x8f\x5f\x8f\x0b\xe2\x50\x35\xa8\xff\x66\x58\x3a\x54\x8f\xfa\xb9\x07\xb7\xb6\xac\xd5\x24\xe6\xbd\x55\xd9\x61\xff\x6e\xbf\x67\x2f\xcf\x04\x27\xc2\x3f\x8e\x93\x7e\xac\x17\x17\xbe\x84\xf8\x6f\x0b\x78\xfc\x9d\x81\xff\xf7\x5f\xde\xb3\x0c\x4d\x0f\xa1\x6e\x23\x48\xb8\x0f\xca\xc6\xb0\x04\x96\xd6\x04\x81\x06\x88\x6b\xdb\x3e\xa7\xbe\x4b\x7e\x7a\x6b\x6e\x2f\x7e\xf2\x3d\x5b\xdb\x71\xef\x40\xe6\xd8\x10\x64\x35\x4f\x6c\x8e\x5e\x25\x82\x5f\x8a\x65\x72\x79\x18\x0e\x05\x9e\xa6\x9a\xb2\xd4\x05\x3b\xf4\x77\xc8\x92\x68\xe1\x21\xb1\x1d\x80\xb4\x18\x0f\x5b\xc6\xb2\x6c\xf7\xc8\x0b\xb3\x4c\x7f\x08\x15\xbc\x75\x40\x06\xf4\x9b\x3c\x83\xec\x5e\x03\xc5\xf9\x08\xcb\xe2\x11\xb4\xe8\x03\x8d\xbc\x47\x22\x4b\x1b\x3b\x65\x65\x07\x54\xa2\x7d\x16\xa1\xf1\x20\x23\x01\x9f\xd5\x08\x1b\xdd\xf5\x5a\x7e\xee\x45\x0b\xc7\xd9\x7d\x9a\x9e\x0d\x9c\x55\xdc\x0b\xa3\x4e\xf6\xc2\x31\x11\x59\x9d\x45\xdf\x6e\xb5\xac\x9d\xa6\xe5\xb8\xf3\x55\x8a\xbd\xf0\x5d\x1e\x60\x7e\xf9\x8a\x42\xf8\x65\xa9\xe3\x4e\xc7\x16\x4d\xc2\xa5\xc1\x75\xba\x06\xf9\x09\x0d\x57\x98\x1b\x59\x59\x17\x2a\x69\xb0\x3a\xa1\x79\xa4\x48\x6b\x9a\x95\x93\xe6\x98\x38\x06\x42\xb9\xee\x75\x4e\xc9\xb5\x18\x81\x78\xee\x25\xab\x8b\x46\x68\x0f\x33\x1e\x3d\x70\x05\x92\x46\x17\x40\xf1\x0e\x46\x56\x17\xa9\xdb\xdb\x8e\xf9\x0e\x1d\x1f\x80\xb0\x19\x39\x98\xc6\x92\x84\x8d\x93\x24\x5c\x2e\xfc\xe6\x16\x75\x22\x88\xfc\x8d\xb0\xe5\xba\xb5\xaa\x4c\xd5\x72\x38\xcc\x9b\xb9\x9b\x76\x7f\x06\x00\xfc\x6f\xf0\xd0\x8f\x04\x00\x00"),
 		},
 		"/ignition/master/files/etc/nkd": &vfsgen۰DirInfo{
 			name:    "nkd",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 189819508, time.UTC),
+			modTime: time.Date(2026, 10, 16, 10, 7, 23, 0, time.UTC),
 		},
 		"/ignition/master/files/etc/nkd/node-pivot.sh.template": &vfsgen۰CompressedFileInfo{
 			name:             "node-pivot.sh.template",
-			modTime:          time.Date(2024, 4, 11, 6, 18, 34, 491108738, time.UTC),
-			uncompressedSize: 2798,
+			modTime:          time.Date(2026, 10, 16, 10, 7, 23, 0, time.UTC),
+			uncompressedSize: 3693,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x57\xdb\x6e\xdb\x38\x10\x7d\xd7\x57\x4c\x65\xa3\x6e\x83\x4a\x6e\x5e\x5d\x24\xbb\xc0\xb6\xc5\x16\x28\x8a\x45\x82\x02\x0b\x58\xae\x41\x53\x23\x99\x30\x45\xaa\x24\xe5\xc6\x70\xfc\xef\x0b\x52\x77\xf9\x12\x6f\xfd\x10\xc7\xd4\x99\x33\x67\x6e\xd2\x68\xf4\x6a\xba\x62\x62\xaa\xd7\x9e\x37\x82\xcf\x85\xa0\x86\x49\x01\x46\x42\x46\x04\x49\x11\x34\xaa\x2d\xa3\x08\xda\x10\x65\x8a\x1c\x88\x88\x01\x05\x59\x71\x04\x29\x60\x25\xa5\xf1\x4a\xe4\xb2\x42\xbe\x79\x0b\x7b\x0f\x00\x6a\xcb\xa5\x20\x19\xde\xf9\xe3\x5b\xdf\x9d\xb2\x04\xf4\x4e\x1b\xcc\xa8\xe1\xc0\x74\x40\xa8\x61\x5b\x84\x20\xf8\x59\x30\x34\xe0\x8f\xbb\x66\xfe\x07\x30\x6b\x14\xce\xd0\x7e\x90\xae\xe5\x00\x02\x4c\x03\xe1\x0a\x49\xbc\x03\x55\x08\xc1\x44\x5a\x3a\x42\xae\xf1\x25\x43\x21\x4d\x6d\xf4\xae\x8c\x90\x89\x34\x0c\x43\xbf\x31\xec\xc9\x75\x88\xa1\x44\x78\xfd\xba\x03\xa9\x52\x73\x39\x8c\x73\x8a\x6a\x05\xa0\x0b\x4a\x51\xeb\x8e\x8e\x5e\x34\xad\xfd\xf7\xd2\x9d\x91\xa5\x2d\xf4\xf8\x42\xbf\x6f\xf1\xc4\x0c\xdc\x36\x47\x09\xf3\xaa\xaf\x83\x2d\xfd\x5f\x6b\xa4\x1b\x17\x6e\xc9\x60\xe1\xda\x68\x57\xef\xaa\x15\x98\xf1\xa8\x45\x2d\x89\x88\x97\xbf\x5f\x74\xce\xb4\x09\x0a\xc1\x4c\x90\x30\x8e\x1a\x9e\x21\x55\x98\x43\xf0\x73\x90\x8e\xb0\xfa\x31\xcc\x5e\xdf\xf3\x30\xd5\xd7\xd5\xbe\xfa\x01\xb1\xc4\xb2\x0b\x5c\xb4\xef\x40\x6f\x58\x9e\x77\x7b\xa0\x4c\x0f\x3e\x21\x2d\x0c\x2e\xd7\x52\x6e\x9c\xe8\x26\x5e\x2e\x29\xe1\x10\x33\x85\xd4\x48\xb5\xeb\x85\x3c\x87\x57\x10\xc4\xe0\x8f\x9b\xcb\x3e\x2c\x4e\x76\xf4\xc7\x1a\xe0\xb4\x24\xb2\x10\xf1\x0c\x3a\x66\x0d\x5c\xa1\x29\x94\x80\x5a\x9a\xfb\xd6\x6b\xe4\x7c\xe9\x64\xdd\xbd\xe9\x3a\x9b\xde\xbc\x6d\xa5\x8c\xf7\xa3\x0e\x70\xfe\xe7\xe2\x00\x01\xfe\x84\xf7\x67\x14\x7d\x93\xe0\x80\xa5\x18\x60\xa2\x8d\xf1\x92\x32\xaf\xdb\x59\x52\x39\x0e\x6b\xec\x8f\xf7\x03\xef\xfe\x07\x88\x65\x77\xcc\xe6\x10\x24\xe0\x8f\x2d\xe0\x38\x4d\xad\xb0\x4f\xae\x14\x6e\x48\xa8\x62\xb9\x99\x41\x69\xd2\x83\x86\xe0\x0f\x4e\x2b\x4d\xb1\x14\x78\xb2\xa0\xe0\xef\xf7\xe1\xdf\x52\x6e\x3e\xdb\x5f\xff\x10\xb3\x3e\x1c\xfc\xb3\xed\xee\xd0\x0f\x85\x30\x2c\xc3\xcb\xb8\xb5\x2c\x34\x6e\x10\x73\x54\x41\x4c\x30\x93\xc2\x77\xd3\x26\x45\xc2\xd2\x42\xa1\x0d\x13\xa8\x62\x12\xa8\x14\x86\x30\x81\x0a\x54\x49\xec\x35\x49\x99\xa2\xa1\x53\x0b\x72\x7f\x42\x2a\x45\xd2\x4f\x91\x43\xf6\x35\xc1\x1d\xf8\x16\x7d\x9c\x4b\x96\xb4\x03\x17\xcd\x2d\x26\x0a\x59\x46\x52\x8c\x16\x3e\x9c\x70\x75\xa2\x14\x5d\x8a\x1f\xf3\xf9\x4c\xe7\x84\xe2\x6c\xb1\xb8\xc9\x49\xa1\x71\xe9\xd8\xac\x80\x2b\xe9\xec\x47\x63\x0c\x01\x83\x89\x7e\xfe\xd1\x27\x09\x6f\x9e\x07\xac\xfb\x7d\xf8\x48\x44\xbc\x92\x4f\x5f\xec\xd1\xe1\xe0\x3f\x4f\x4e\x79\xea\xf9\x38\xba\x81\x76\x9d\x4e\x07\x79\x98\x12\x78\xd1\xe7\xcb\x2e\xab\x9e\xbb\x70\xfb\x76\x5e\x4b\xa7\x0b\x1f\xee\xef\x5f\x8e\xc2\x99\xf5\xb5\x45\xc7\xe2\x22\xff\x45\xb6\x8e\xb8\xf6\xd6\xac\xb0\x7c\x8c\x58\x78\x3d\xca\x09\x3b\xd1\xb0\x75\xaf\xc6\xc7\x6d\xfb\xce\x21\x74\xa9\x07\x4a\x91\x59\xa1\x0d\x64\xc4\xd0\xb5\xbb\xe8\xf4\x97\x97\xbc\x73\xad\xdb\x78\x38\xd1\xe9\xaf\x3a\x53\xd1\xe0\xec\xbf\x09\x4b\x43\x23\x33\x7e\xdc\xf4\xd9\x26\x66\x0a\x82\x1c\x06\x56\x0d\xa0\x1f\x52\xc2\x52\x88\x31\x21\x05\x37\x70\x0f\x17\x3c\x75\x6f\x78\x67\xa7\xa2\x4a\xc6\xd1\x5c\x9c\x64\x1c\x08\xef\xcc\x45\xf4\xa6\x4b\x1a\xbd\x1d\xd2\x86\x37\xcf\xd1\xed\x91\xaf\x0b\xd3\x72\x3e\x22\xe4\x9d\x60\x26\x3f\xa2\x79\xce\x8b\x94\x09\x1d\x85\xbe\x1d\x93\xd6\x32\x0a\x53\x95\xd3\x28\xdc\xde\x46\x21\x55\xcc\x8f\x16\x93\xdf\x08\x6e\xfa\xbf\x3c\x4c\x09\x44\x00\x57\x04\x7a\x4d\x9c\xc3\x45\x61\x52\x0b\xb1\x3a\x3a\x32\x9c\x8a\x70\x7b\xeb\x34\x2c\x26\xed\x78\x9d\x27\x6f\x39\xaf\x54\x7b\x7f\x75\xab\x9d\x18\xd9\xb6\xa5\xcb\x89\xfd\xc8\xb4\xdb\x0d\x1f\x3f\x7d\x65\xa2\x78\xf2\xea\x4d\xc3\x9e\xda\xc7\x67\x75\xee\x36\x9d\xa6\xc9\x46\x8f\x9f\xbe\x7e\xf9\xf6\xfd\xdf\x3b\x14\x89\x54\x94\x89\xb4\x39\x89\x4b\xbe\x78\x94\x56\x69\xd5\xc8\x2d\x41\x25\xd1\xd3\x68\x4a\x23\x84\xf7\xbd\x85\xf2\x01\x39\x12\x8d\x2e\xcc\xef\x0f\x1c\x98\x06\xcc\x72\xb3\xab\x9e\x6f\xa2\x9c\xfe\x3e\xe8\x70\xe8\x8f\xf0\x08\xca\xe7\x3e\x82\xc2\x15\xa9\x6a\xa6\xf2\x2c\x90\xda\x28\xac\x4f\x21\x08\xf0\x29\x47\xc5\x32\x14\x86\x70\x28\x2f\x06\x85\xd8\xa2\x62\x09\xc3\x38\x70\xe9\x9f\xc5\x92\x6e\x50\xcd\xa6\xd3\x53\x8e\x21\x08\x56\xbb\x9c\x68\x1d\xc4\x8a\x6d\x51\x55\xfe\x9b\x70\xcc\xba\x71\xf7\x8b\xe8\x7a\x55\x4f\x0a\xde\xd9\xb5\xfe\xb8\xb8\x5b\x3d\x94\xd6\x32\x47\x45\xdc\xdb\x16\x95\x59\xce\xd1\x60\xdc\x61\xe3\xbb\x10\x1e\xd0\xbe\x5f\xd9\x62\xb9\x5b\xaa\xab\x79\xef\xed\xa4\xdb\x06\x16\x7a\x6e\xf9\x3d\xf2\x98\x10\xc6\x31\x0e\xe1\xd1\x11\xc0\x2f\xc6\xb9\x5b\x3d\x57\x58\x31\x61\xdc\x2e\xc0\x5e\xc3\x58\xb3\x9d\x2e\x68\xbb\x3e\x1f\x97\x26\xf4\x6d\x5b\xfe\x37\x00\xae\xd1\x53\xe7\x6d\x0e\x00\x00"),
+		},
+		"/ignition/master/files/etc/sysconfig": &vfsgen۰DirInfo{
+			name:    "sysconfig",
+			modTime: time.Date(2026, 10, 16, 12, 1, 52, 0, time.UTC),
+		},
+		"/ignition/master/files/etc/sysconfig/kubelet.template": &vfsgen۰CompressedFileInfo{
+			name:             "kubelet.template",
+			modTime:          time.Date(2026, 10, 16, 12, 1, 52, 0, time.UTC),
+			uncompressedSize: 148,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x56\xdb\x6e\xdb\x38\x10\x7d\xd7\x57\x4c\x65\xa3\x97\xa0\x92\xda\x57\x17\xe9\x2e\xb0\x4d\xb1\x05\x8a\xa2\x70\x10\x60\x01\xc7\x35\x68\x6a\x64\x0f\x2c\x91\x0a\x49\xa5\x36\x1c\xff\xfb\x82\xa4\x24\x4b\x8a\xe3\x64\x37\x0f\x49\x4c\x9f\x39\x73\xe6\x4a\x8e\x5e\x25\x4b\x12\x89\x5e\x07\xc1\x08\xbe\x56\x82\x1b\x92\x02\x8c\x84\x82\x09\xb6\x42\xd0\xa8\xee\x89\x23\x68\xc3\x94\xa9\x4a\x60\x22\x05\x14\x6c\x99\x23\x48\x01\x4b\x29\x4d\xe0\x91\x8b\x1a\xf9\xf6\x1d\xec\x03\x00\x68\x2c\x17\x82\x15\x78\x19\x8e\x3f\x86\xee\x94\x32\xd0\x3b\x6d\xb0\xe0\x26\x07\xd2\x11\xe3\x86\xee\x11\xa2\xe8\xae\x22\x34\x10\x8e\xbb\x66\xe1\x27\x30\x6b\x14\xce\xd0\xfe\x20\x5f\xcb\x01\x04\x48\x03\xcb\x15\xb2\x74\x07\xaa\x12\x82\xc4\xca\x3b\xc2\x5c\xe3\x73\x86\x42\x9a\xc6\xe8\xbd\x8f\x90\xc4\x2a\x8e\xe3\xb0\x35\xec\xc9\x75\x88\xa1\x44\x78\xfd\xba\x03\xa9\x53\x73\x3e\x8c\xa7\x14\x35\x0a\x40\x57\x9c\xa3\xd6\x1d\x1d\xbd\x68\x8e\xf6\x37\xde\x9d\x91\xb5\xb6\x1e\x5f\xc7\xdc\x59\x6c\xc9\xc0\xc7\xf6\x28\xa3\xa0\xfe\x73\xb0\xa5\xff\x6b\x8d\x7c\xe3\xc2\xad\x0b\x8e\x5b\xd2\x46\xbb\x7a\xd7\xad\x40\x26\xe0\x16\xb5\x60\x22\x5d\xfc\xff\xa2\xe7\xa4\x4d\x54\x09\x32\x51\x46\x39\x6a\x78\x80\x95\xc2\x12\xa2\xbb\x41\x3a\xe2\xfa\xc3\x30\x7b\x7d\xcf\xc3\x54\xbf\xac\xf6\x8d\x71\x2a\xd1\x77\x81\x8b\xf6\x3d\xe8\x0d\x95\x65\xb7\x07\x7c\x7a\x70\x8b\xbc\x32\xb8\x58\x4b\xb9\x71\xa2\xdb\x78\x73\xc9\x59\x0e\x29\x29\xe4\x46\xaa\x5d\x2f\xe4\x19\xbc\x82\x28\x85\x70\xdc\x7e\x1d\xc2\xfc\x64\x47\x7f\x69\x00\x4e\x4b\x26\x2b\x91\x4e\xa0\x63\xd6\xc2\x15\x9a\x4a\x09\x68\xa4\xf9\x94\xaf\x31\xcf\x17\x4e\xd6\xe5\xdb\xae\xb3\xe4\xe2\xdd\x51\xca\x78\x3f\xea\x00\x67\x7f\xce\x0f\x10\xe1\x1d\x7c\x78\x42\xd1\x0f\x09\xbe\x3a\x4e\x0c\x90\x38\xc6\x78\x4e\x59\xd0\xed\x2c\xa9\x1c\x87\x35\x0e\xc7\xfb\x81\xf7\xf0\x13\xa4\xb2\x3b\x66\x33\x88\x32\x08\xc7\x16\xf0\x38\x4d\x47\x61\x57\xae\x14\x6e\x48\xb8\xa2\xd2\x4c\xc0\x9b\xf4\xa0\x71\x43\x34\xec\xf6\x54\x0a\x3c\x59\x50\x08\xf7\xfb\xf8\x6f\x29\x37\x5f\xed\xa7\x9f\xcc\xac\x0f\x87\xf0\xc9\x76\x77\xe8\x69\x25\x0c\x15\x78\x1e\xb7\x96\x95\xc6\x0d\x62\x89\x2a\x4a\x19\x16\x52\x84\x6e\xda\xa4\xc8\x68\x55\x29\xb4\x61\x02\x57\x24\x81\x4b\x61\x18\x09\x54\x76\x23\x59\xe2\xa0\x4d\x4a\x82\x86\x27\x16\xe4\x7e\xc5\x5c\x8a\xac\x9f\x22\x87\xec\x6b\x82\x4b\x08\x2d\xfa\x71\x2e\x29\x3b\x0e\xdc\xed\xcc\x62\x6e\x63\x2a\xd8\x0a\x6f\xe7\x21\x9c\x70\x75\xa2\x14\x5d\x8a\x5f\xb3\xd9\x44\x97\x8c\xe3\x64\x3e\xbf\x28\x59\xa5\x71\xe1\xd8\xac\x80\x17\xd2\xf9\xc5\x91\x42\x44\xf0\x46\x3f\xfc\xea\x93\xc4\x17\x0f\x03\xd6\xfd\x3e\xfe\x66\xff\x9f\xe2\x8a\xb4\x51\xbb\xc3\x21\xd9\xef\xe3\x9f\x16\xe4\xce\x0f\x87\xf0\xe1\xcd\x29\xd7\xfd\x76\x1a\x6e\xd4\xae\x8a\x64\x90\x98\x84\xc1\x7f\x17\xf1\xbc\x86\xba\x2b\xcf\x2c\x78\x27\xc3\xab\x98\x87\xf0\xf9\xf3\xf3\x61\x39\xb3\xbe\xd8\xdb\x17\xa8\xbd\x0d\x9f\xa5\xef\xa8\x3d\x6e\x73\x85\xfe\xe6\xb1\xf0\x66\xfa\xed\x66\x1a\xc1\x17\xd2\xee\x72\xba\xbe\xfa\x4e\xa2\xda\x06\xcd\xaa\xb3\xa7\x76\x7e\xeb\x73\xb7\x6a\xdb\xea\x8f\xae\xaf\xbe\x7f\xfb\x71\xf3\xcf\x25\x8a\x4c\x2a\x4e\x62\xd5\x9e\xa4\x9e\x2f\x1d\xad\xea\xc4\x6a\xcc\x2d\x41\xc2\xdd\x30\x05\x1a\x8d\x37\x42\xf8\xd0\xbb\xd1\xa6\x98\x23\xab\x03\xbd\x99\xda\x57\x07\x60\x51\x9a\x5d\x3d\x60\xc2\x4f\x4e\x1f\x64\x27\xa8\x3b\x38\x23\xf0\x8b\x07\x41\xe1\x92\xd5\xa5\x52\x65\x11\x49\x6d\x14\x36\xa7\x10\x45\xb8\x2d\x51\x51\x81\xc2\xb0\x1c\xfc\x97\x51\x25\xee\x51\x51\x46\x98\x46\xae\x24\x93\x54\xf2\x0d\xaa\x49\x92\x9c\x72\x0c\x51\xb4\xdc\x95\x4c\xeb\x28\x55\x74\x8f\xaa\xf6\xdf\x86\x63\x37\x46\xed\xee\x37\xd3\xcd\x5b\x21\xab\xf2\xce\xb2\xff\xe3\xec\x72\x9f\x7a\x6b\x59\xa2\x62\xee\xb9\xc7\x65\x51\xe6\x68\x30\xed\xb0\xe5\xbb\x18\xa6\x68\x1f\x78\xb6\x58\xd6\xa9\x2f\x7a\xef\x79\xd4\xed\x03\xf7\x16\x7c\xe2\xf6\x7d\xe4\x31\x63\x94\x63\x1a\xc3\xb5\x23\x80\xdf\x94\xe7\xee\xee\x5b\x62\xcd\x84\xe9\xf1\x06\x0e\x5a\xc6\x86\xed\x74\x41\x8f\xf7\xf7\xe3\xd2\xc4\xa1\x6d\xcb\x7f\x03\x00\x00\xff\xff\x52\xa3\xd7\x9f\xee\x0a\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xf2\x0e\x75\x72\xf5\x71\x0d\x89\x77\x8d\x08\x09\x72\x8c\x77\x0c\x72\x0f\xb6\x55\xaa\xae\x56\xc8\x4c\x53\xd0\xf3\xcb\x4f\x49\xf5\x49\x4c\x4a\xcd\x29\x56\xa8\xad\xd5\xd5\xcd\xcb\x4f\x49\xd5\xcd\x01\xf3\x6d\xab\xab\x91\x64\x6b\x6b\xab\xab\x15\x52\xf3\x52\x14\x6a\x6b\x15\x90\xb4\x86\x24\x66\xe6\x95\x40\xb4\x16\xa5\xa6\x67\x16\x97\xa4\x16\xe9\x96\x67\x96\x64\xe8\x96\x80\x25\x60\x66\x40\x94\x21\x99\xa1\xc4\x05\x18\x00\x73\x78\x70\x55\x94\x00\x00\x00"),
 		},
 		"/ignition/master/files/etc/sysctl.d": &vfsgen۰DirInfo{
 			name:    "sysctl.d",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
+			modTime: time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 		},
 		"/ignition/master/files/etc/sysctl.d/kubernetes.conf": &vfsgen۰CompressedFileInfo{
 			name:             "kubernetes.conf",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 97,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xca\x4b\x2d\xd1\x4b\x2a\xca\x4c\x49\x4f\x85\x52\xba\x79\x69\xba\xc9\x89\x39\x39\xba\x99\x05\x25\x89\x49\x39\xa9\xc5\xb6\x86\x5c\xf8\x14\x99\xa1\xa8\xca\x2c\x28\x33\xd1\xcb\x2c\x88\x4f\xcb\x2f\x2a\x4f\x2c\x4a\xb1\x35\xe4\x02\x04\x00\x00\xff\xff\x6d\xd4\xf2\x72\x61\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xca\x4b\x2d\xd1\x4b\x2a\xca\x4c\x49\x4f\x85\x52\xba\x79\x69\xba\xc9\x89\x39\x39\xba\x99\x05\x25\x89\x49\x39\xa9\xc5\xb6\x86\x5c\xf8\x14\x99\xa1\xa8\xca\x2c\x28\x33\xd1\xcb\x2c\x88\x4f\xcb\x2f\x2a\x4f\x2c\x4a\xb1\x35\xe4\x02\x0c\x00\x6d\xd4\xf2\x72\x61\x00\x00\x00"),
 		},
 		"/ignition/master/files/etc/systemd": &vfsgen۰DirInfo{
 			name:    "systemd",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
+			modTime: time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 		},
 		"/ignition/master/files/etc/systemd/system": &vfsgen۰DirInfo{
 			name:    "system",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
+			modTime: time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 		},
 		"/ignition/master/files/etc/systemd/system/kubelet.service.d": &vfsgen۰DirInfo{
 			name:    "kubelet.service.d",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
+			modTime: time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 		},
 		"/ignition/master/files/etc/systemd/system/kubelet.service.d/10-kubeadm.conf.template": &vfsgen۰CompressedFileInfo{
 			name:             "10-kubeadm.conf.template",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 1086,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x53\xd1\x4e\xdb\x50\x0c\x7d\xef\x57\x58\x01\x89\x87\x91\x54\xbc\x4e\xca\x43\xb7\x15\x34\xb1\x01\x2a\xa0\x4d\x9a\x26\xe4\x24\x4e\x6a\xb8\xbd\x2e\xbe\x4e\xa0\x42\xfc\xfb\x74\x13\x5a\x8a\x56\x78\x8a\xe3\xf8\x9e\x73\xae\xcf\xc9\x1e\x9c\x89\xd1\x67\xb8\x9a\x73\x80\x4a\x65\xc9\x1e\xc4\xbb\x15\x3c\x88\xde\x05\x78\x60\x9b\xc3\x5d\x5b\x10\x56\x0b\x40\x5f\xf5\xb5\x23\x83\xee\x28\x3b\x3a\xfa\x34\xfa\x73\x49\xda\x71\x49\x7f\x47\x53\xdf\xb1\x8a\x5f\x90\xb7\x3c\x39\xbd\xfe\x32\xfd\x31\xbd\xba\x89\xcf\xaf\xe7\x67\xc7\xdf\x4f\x6e\x26\xb3\x93\xcb\x3c\x4d\x0b\x11\x0b\xa6\xb8\x4c\x23\x52\x29\xbe\xe6\x26\x1f\x93\x95\xe3\xf8\xae\x9e\x8c\xc2\xf8\xed\x90\x23\xcb\xe2\x20\xa4\x1f\x9d\xd9\x9e\x4c\x76\xab\x79\xab\x64\x8d\xd3\xa1\x8e\x1d\x17\x6b\x80\xf1\xd0\xcf\x56\xb8\x70\xc9\xe8\xe9\x09\xb8\x06\xba\x87\x6c\xd6\x7a\xe3\x05\x41\x52\x2a\x4b\x02\xcf\xcf\xef\x70\x9c\xcc\xce\xaf\x2f\x7e\xfd\xdc\xb0\x34\x2a\xed\x32\xa4\x4b\xd2\xf4\x5e\x42\x5e\xa3\x0b\x04\x69\x4a\xbe\x16\x2d\x29\xf5\x52\x51\x8a\xce\x49\x89\x86\x85\xa3\xfc\xe0\xa0\x67\x25\x5f\x45\x8e\xbd\xc1\x18\x0e\x80\x50\xb3\x23\xb0\x39\x1a\x24\x6b\x47\xd8\xb3\x25\xbd\x2f\x9b\xd6\xad\xb0\x4f\xa0\x21\x4f\x8a\x46\x01\xd0\x40\x07\xe9\x87\xb0\x94\x65\xeb\xd0\xd8\x37\x60\x73\x82\x6d\x97\x26\xdf\x06\xc9\xd0\xa1\x72\x14\x02\xd5\xca\xe3\x82\x4b\x74\x6e\xb5\x7d\xd5\x63\x76\x94\xa7\xff\x6d\xed\x85\x3e\xad\x1d\x36\x21\x23\xdf\xbd\x23\x3d\xf2\xb6\x81\x14\x4a\xf4\xb1\x80\x5a\x14\xa4\x23\x55\xae\x28\x80\xd4\xfd\xc4\x3a\x65\xa8\x4d\x00\x8c\x08\x0e\x83\x81\x52\x10\xb5\x0c\x2e\x94\x6a\x52\x2c\xdc\xea\xf0\x15\x30\xcc\xa5\x75\x55\xac\x47\x7b\x7d\x37\x3b\x93\x8a\x66\xd4\x70\x8c\x92\xb1\xf8\xec\x74\x80\x9d\x3e\x9a\xe2\x24\x42\x4b\x71\x4b\xa5\x01\xfb\xfe\xc0\xe0\x7c\x3b\x0c\xf7\x9a\x03\xb0\x0f\x46\x58\x65\x9b\x65\x4d\x7f\x5f\xcd\x26\xc3\xaa\x5e\x18\x0b\x82\x20\xad\x96\x54\x41\xad\xb2\x00\x8b\xd7\x8e\xa7\xb3\x1d\x7b\x8b\xa9\x0d\xab\x30\x50\xad\xb7\x37\x9a\x3e\x52\x79\x69\xa8\x96\x6f\x95\xe3\x36\xe8\xb8\x60\xbf\x9e\x82\xfd\x77\x7e\xab\xd7\x0f\x3b\x9b\x6f\xec\xdd\xdf\x71\x8f\x0f\x42\xbe\xbf\x33\xd7\x9b\x7c\xfe\x0b\x00\x00\xff\xff\x21\x0d\x51\xd3\x3e\x04\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x53\xd1\x4e\xdb\x50\x0c\x7d\xef\x57\x58\x01\x89\x87\x91\x54\xbc\x4e\xca\x43\xb7\x15\x34\xb1\x01\x2a\xa0\x4d\x9a\x26\xe4\x24\x4e\x6a\xb8\xbd\x2e\xbe\x4e\xa0\x42\xfc\xfb\x74\x13\x5a\x8a\x56\x78\x8a\xe3\xf8\x9e\x73\xae\xcf\xc9\x1e\x9c\x89\xd1\x67\xb8\x9a\x73\x80\x4a\x65\xc9\x1e\xc4\xbb\x15\x3c\x88\xde\x05\x78\x60\x9b\xc3\x5d\x5b\x10\x56\x0b\x40\x5f\xf5\xb5\x23\x83\xee\x28\x3b\x3a\xfa\x34\xfa\x73\x49\xda\x71\x49\x7f\x47\x53\xdf\xb1\x8a\x5f\x90\xb7\x3c\x39\xbd\xfe\x32\xfd\x31\xbd\xba\x89\xcf\xaf\xe7\x67\xc7\xdf\x4f\x6e\x26\xb3\x93\xcb\x3c\x4d\x0b\x11\x0b\xa6\xb8\x4c\x23\x52\x29\xbe\xe6\x26\x1f\x93\x95\xe3\xf8\xae\x9e\x8c\xc2\xf8\xed\x90\x23\xcb\xe2\x20\xa4\x1f\x9d\xd9\x9e\x4c\x76\xab\x79\xab\x64\x8d\xd3\xa1\x8e\x1d\x17\x6b\x80\xf1\xd0\xcf\x56\xb8\x70\xc9\xe8\xe9\x09\xb8\x06\xba\x87\x6c\xd6\x7a\xe3\x05\x41\x52\x2a\x4b\x02\xcf\xcf\xef\x70\x9c\xcc\xce\xaf\x2f\x7e\xfd\xdc\xb0\x34\x2a\xed\x32\xa4\x4b\xd2\xf4\x5e\x42\x5e\xa3\x0b\x04\x69\x4a\xbe\x16\x2d\x29\xf5\x52\x51\x8a\xce\x49\x89\x86\x85\xa3\xfc\xe0\xa0\x67\x25\x5f\x45\x8e\xbd\xc1\x18\x0e\x80\x50\xb3\x23\xb0\x39\x1a\x24\x6b\x47\xd8\xb3\x25\xbd\x2f\x9b\xd6\xad\xb0\x4f\xa0\x21\x4f\x8a\x46\x01\xd0\x40\x07\xe9\x87\xb0\x94\x65\xeb\xd0\xd8\x37\x60\x73\x82\x6d\x97\x26\xdf\x06\xc9\xd0\xa1\x72\x14\x02\xd5\xca\xe3\x82\x4b\x74\x6e\xb5\x7d\xd5\x63\x76\x94\xa7\xff\x6d\xed\x85\x3e\xad\x1d\x36\x21\x23\xdf\xbd\x23\x3d\xf2\xb6\x81\x14\x4a\xf4\xb1\x80\x5a\x14\xa4\x23\x55\xae\x28\x80\xd4\xfd\xc4\x3a\x65\xa8\x4d\x00\x8c\x08\x0e\x83\x81\x52\x10\xb5\x0c\x2e\x94\x6a\x52\x2c\xdc\xea\xf0\x15\x30\xcc\xa5\x75\x55\xac\x47\x7b\x7d\x37\x3b\x93\x8a\x66\xd4\x70\x8c\x92\xb1\xf8\xec\x74\x80\x9d\x3e\x9a\xe2\x24\x42\x4b\x71\x4b\xa5\x01\xfb\xfe\xc0\xe0\x7c\x3b\x0c\xf7\x9a\x03\xb0\x0f\x46\x58\x65\x9b\x65\x4d\x7f\x5f\xcd\x26\xc3\xaa\x5e\x18\x0b\x82\x20\xad\x96\x54\x41\xad\xb2\x00\x8b\xd7\x8e\xa7\xb3\x1d\x7b\x8b\xa9\x0d\xab\x30\x50\xad\xb7\x37\x9a\x3e\x52\x79\x69\xa8\x96\x6f\x95\xe3\x36\xe8\xb8\x60\xbf\x9e\x82\xfd\x77\x7e\xab\xd7\x0f\x3b\x9b\x6f\xec\xdd\xdf\x71\x8f\x0f\x42\xbe\xbf\x33\xd7\x9b\x7c\xfe\x1b\x00\x21\x0d\x51\xd3\x3e\x04\x00\x00"),
 		},
 		"/ignition/master/systemd": &vfsgen۰DirInfo{
 			name:    "systemd",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
+			modTime: time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 		},
 		"/ignition/master/systemd/join-master.service.template": &vfsgen۰CompressedFileInfo{
 			name:             "join-master.service.template",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 625,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x50\xb1\x8e\x13\x31\x10\xed\xfd\x15\x73\x29\xae\x73\x02\x05\x15\xda\xe2\x08\x27\x71\x82\x22\x4a\x38\x51\x44\x29\x26\xde\x97\xac\x59\xaf\xbd\x8c\x67\xc3\x45\x51\xfe\x1d\x79\x57\xe1\x24\x24\x9a\xeb\xfc\xde\x9b\xf7\xfc\x66\xb6\xcf\xd1\xeb\xce\x7c\x46\x76\xe2\x7b\xf5\x29\x56\x1d\x67\x85\x50\x4c\x35\xe8\x67\xf2\x91\xb4\x01\xb9\x30\x14\xd6\xac\xf1\x6b\xf0\x82\x5c\x65\xa8\x6d\x21\x11\xc1\xf6\x2c\x3c\xcf\x90\x93\x77\x20\x41\x00\x67\x58\xdf\xf1\x11\xb6\xf7\xa7\xa4\x37\xcd\x3c\x1c\x14\xf2\x26\xe7\x32\xc5\xda\x97\x76\x2b\xd6\xe6\xf1\xc5\x67\xcd\xd5\xdd\xe2\xc4\xb2\x08\xe9\xb8\x28\x2d\xed\x54\x7b\x9e\x95\xbb\xde\x98\xed\x66\x72\xee\xcc\xe3\x0b\xdc\x46\x59\x74\x25\xa8\x16\x7b\x1f\x17\x7b\xce\x0d\x59\x47\xb3\xdf\x8d\x0f\xa0\x2d\xdd\x91\x3d\xd0\xdf\xb4\xb2\xf8\xed\xfb\x12\x46\xbb\x8f\x54\x27\xca\x01\xe8\xe9\xfd\xbb\x02\x22\x66\xaf\xb9\xff\x84\xb6\xc3\x1e\x5c\x77\xd3\xe9\x2e\x97\xf9\xc3\xea\xa9\x74\x81\x3c\xaf\xbf\x5d\xaf\x64\xad\xa6\x16\xa3\xf2\xbd\x3c\x46\xaa\xf6\xd9\xa5\x13\xe4\x3c\x89\xd6\xb1\x75\x10\xb5\x4d\x09\xbd\x5c\xe6\x4b\x5e\x42\xf4\x0b\xe7\x66\x1c\x77\x29\xaa\xa4\x60\xfb\xc0\x11\x05\x43\xd4\x1f\xbc\x63\x85\x6d\x71\x1e\x1d\xaf\xd4\x57\x9c\x27\x97\x78\x9b\x93\x6b\xa1\x55\x19\x10\xbf\x19\xc1\xf5\x4a\x74\x7f\x4f\x9a\x06\xd7\xd0\xff\x4f\x3a\x33\x6b\xe4\x71\xdd\x14\xed\x81\x7d\x18\x04\x37\x6a\x03\x57\x7d\xc8\xc6\x6c\x9f\x62\x56\x0e\x61\x67\x7e\x70\x54\xd4\x9f\xce\x55\x37\x04\xf5\x76\xc8\x90\xb9\xb2\x1c\xa1\x7f\x02\x00\x00\xff\xff\xba\x44\x08\x20\x71\x02\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x50\xb1\x8e\x13\x31\x10\xed\xfd\x15\x73\x29\xae\x73\x02\x05\x15\xda\xe2\x08\x27\x71\x82\x22\x4a\x38\x51\x44\x29\x26\xde\x97\xac\x59\xaf\xbd\x8c\x67\xc3\x45\x51\xfe\x1d\x79\x57\xe1\x24\x24\x9a\xeb\xfc\xde\x9b\xf7\xfc\x66\xb6\xcf\xd1\xeb\xce\x7c\x46\x76\xe2\x7b\xf5\x29\x56\x1d\x67\x85\x50\x4c\x35\xe8\x67\xf2\x91\xb4\x01\xb9\x30\x14\xd6\xac\xf1\x6b\xf0\x82\x5c\x65\xa8\x6d\x21\x11\xc1\xf6\x2c\x3c\xcf\x90\x93\x77\x20\x41\x00\x67\x58\xdf\xf1\x11\xb6\xf7\xa7\xa4\x37\xcd\x3c\x1c\x14\xf2\x26\xe7\x32\xc5\xda\x97\x76\x2b\xd6\xe6\xf1\xc5\x67\xcd\xd5\xdd\xe2\xc4\xb2\x08\xe9\xb8\x28\x2d\xed\x54\x7b\x9e\x95\xbb\xde\x98\xed\x66\x72\xee\xcc\xe3\x0b\xdc\x46\x59\x74\x25\xa8\x16\x7b\x1f\x17\x7b\xce\x0d\x59\x47\xb3\xdf\x8d\x0f\xa0\x2d\xdd\x91\x3d\xd0\xdf\xb4\xb2\xf8\xed\xfb\x12\x46\xbb\x8f\x54\x27\xca\x01\xe8\xe9\xfd\xbb\x02\x22\x66\xaf\xb9\xff\x84\xb6\xc3\x1e\x5c\x77\xd3\xe9\x2e\x97\xf9\xc3\xea\xa9\x74\x81\x3c\xaf\xbf\x5d\xaf\x64\xad\xa6\x16\xa3\xf2\xbd\x3c\x46\xaa\xf6\xd9\xa5\x13\xe4\x3c\x89\xd6\xb1\x75\x10\xb5\x4d\x09\xbd\x5c\xe6\x4b\x5e\x42\xf4\x0b\xe7\x66\x1c\x77\x29\xaa\xa4\x60\xfb\xc0\x11\x05\x43\xd4\x1f\xbc\x63\x85\x6d\x71\x1e\x1d\xaf\xd4\x57\x9c\x27\x97\x78\x9b\x93\x6b\xa1\x55\x19\x10\xbf\x19\xc1\xf5\x4a\x74\x7f\x4f\x9a\x06\xd7\xd0\xff\x4f\x3a\x33\x6b\xe4\x71\xdd\x14\xed\x81\x7d\x18\x04\x37\x6a\x03\x57\x7d\xc8\xc6\x6c\x9f\x62\x56\x0e\x61\x67\x7e\x70\x54\xd4\x9f\xce\x55\x37\x04\xf5\x76\xc8\x90\xb9\xb2\x1c\xa1\x7f\x06\x00\xba\x44\x08\x20\x71\x02\x00\x00"),
 		},
 		"/ignition/master/systemd/kubelet.service": &vfsgen۰CompressedFileInfo{
 			name:             "kubelet.service",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 325,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x8f\x31\x6e\xf3\x30\x0c\x46\x77\x9e\xc2\x17\xb0\x95\x7f\x0d\xa0\x21\x7f\x93\x21\x68\x51\x14\x75\x8b\x0e\x86\x07\x59\x66\x6d\xc2\x32\x65\x88\x94\x93\xdc\xbe\xa8\xd1\x74\xeb\xfc\x1e\x89\xef\x35\xef\x4c\xda\xc2\x11\xc5\x27\x5a\x94\x22\xdb\x29\x77\x18\x50\xf7\xc5\xdb\x88\xc5\x63\xee\x30\x31\x2a\x4a\xf1\x1c\x7b\x2c\x0e\x03\xb2\xc2\x31\xfa\x3c\x23\xab\xdb\x0e\x46\xd5\x45\xf6\xc6\x4c\xbf\x6e\x45\xd1\xf4\xd1\x8b\x81\x0f\xc7\x2a\x96\x51\x2f\x31\x4d\x65\xe4\x40\x8c\x95\xba\x34\xa0\xc2\xe1\x53\x31\xfd\xc1\x1e\x22\xf7\xf4\xfd\xfd\xc5\xe9\x78\xba\x92\xa8\x58\xb3\xba\x64\x42\x1c\x0c\xc7\x1e\xcb\x85\xd6\xa8\x95\xa8\x9b\x17\x80\xa6\xc6\xb4\x92\xc7\x16\x4e\x57\xf4\xb5\xba\xa4\xd6\x64\x49\xa6\x23\x36\x3f\x41\xf0\x8a\xb2\x01\x17\x2e\xee\x26\xb0\x59\x4f\x34\x93\x9e\x59\x31\xad\x2e\xd8\xdd\xdd\xa9\xd1\xdb\x7f\x3b\x80\xe6\xcc\xa2\x2e\x84\x76\x0b\xc1\xfe\xff\xcd\xce\x39\x28\x95\x59\x30\xdd\xb7\xc2\x57\x00\x00\x00\xff\xff\xcb\xcf\xb3\x78\x45\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x8f\x31\x6e\xf3\x30\x0c\x46\x77\x9e\xc2\x17\xb0\x95\x7f\x0d\xa0\x21\x7f\x93\x21\x68\x51\x14\x75\x8b\x0e\x86\x07\x59\x66\x6d\xc2\x32\x65\x88\x94\x93\xdc\xbe\xa8\xd1\x74\xeb\xfc\x1e\x89\xef\x35\xef\x4c\xda\xc2\x11\xc5\x27\x5a\x94\x22\xdb\x29\x77\x18\x50\xf7\xc5\xdb\x88\xc5\x63\xee\x30\x31\x2a\x4a\xf1\x1c\x7b\x2c\x0e\x03\xb2\xc2\x31\xfa\x3c\x23\xab\xdb\x0e\x46\xd5\x45\xf6\xc6\x4c\xbf\x6e\x45\xd1\xf4\xd1\x8b\x81\x0f\xc7\x2a\x96\x51\x2f\x31\x4d\x65\xe4\x40\x8c\x95\xba\x34\xa0\xc2\xe1\x53\x31\xfd\xc1\x1e\x22\xf7\xf4\xfd\xfd\xc5\xe9\x78\xba\x92\xa8\x58\xb3\xba\x64\x42\x1c\x0c\xc7\x1e\xcb\x85\xd6\xa8\x95\xa8\x9b\x17\x80\xa6\xc6\xb4\x92\xc7\x16\x4e\x57\xf4\xb5\xba\xa4\xd6\x64\x49\xa6\x23\x36\x3f\x41\xf0\x8a\xb2\x01\x17\x2e\xee\x26\xb0\x59\x4f\x34\x93\x9e\x59\x31\xad\x2e\xd8\xdd\xdd\xa9\xd1\xdb\x7f\x3b\x80\xe6\xcc\xa2\x2e\x84\x76\x0b\xc1\xfe\xff\xcd\xce\x39\x28\x95\x59\x30\xdd\xb7\xc2\xd7\x00\xcb\xcf\xb3\x78\x45\x01\x00\x00"),
 		},
 		"/ignition/master/systemd/release-image-pivot.service": &vfsgen۰CompressedFileInfo{
 			name:             "release-image-pivot.service",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 324,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x8f\xbd\x6e\xc3\x30\x0c\x06\x77\x3d\x05\x9b\x21\x9b\xa3\xa9\xa3\x86\xfe\x64\xe8\x16\xd4\x28\x3a\x18\x1e\x18\x99\xb1\x09\xcb\x94\x21\xd2\x6e\xfa\xf6\x85\x03\x64\xe8\x90\x95\xc4\x77\x87\x6b\xbe\x84\xad\x75\xef\xa4\xb1\xf0\x6c\x9c\x25\x9c\x78\xcd\x06\x92\x3b\x02\xcb\x60\x03\x81\x8c\x1d\x14\x4a\x84\x4a\xc0\x13\xf6\xe4\xbe\x51\x4c\x83\x90\xfd\xe4\x32\x56\x59\x12\x0b\x1d\x0c\x4b\x4f\xe6\x5e\x2e\x46\xe5\xc1\xef\x2d\x4b\xc7\x9b\xe5\x84\x36\x1c\xaf\xac\xa6\xe1\xc9\xaf\x58\x7c\xca\xbd\xdf\x9c\xd5\xbc\xe9\x0f\x6a\x38\xcd\xce\x35\x35\x95\x95\x23\xb5\xee\x78\xa5\x58\x1b\x16\x0b\xfe\xcc\xe2\xcf\xa8\x03\x54\x11\x76\x9e\x2c\x7a\x19\xbb\x7f\xe3\x01\xf6\x7b\xb0\xbc\xc4\x01\x1e\xc2\x77\xce\x7d\x92\xde\x88\x59\xaa\x0b\x72\x5a\x0a\xdd\x4f\x35\xc5\xf0\xac\xce\x35\x1f\xa2\x86\x29\xb5\xb7\x62\xea\x5e\x7f\xc3\xb4\x24\xe3\x6a\x51\x2a\xf7\xa8\xbf\x00\x00\x00\xff\xff\xea\x51\xb4\x1e\x44\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x8f\xbd\x6e\xc3\x30\x0c\x06\x77\x3d\x05\x9b\x21\x9b\xa3\xa9\xa3\x86\xfe\x64\xe8\x16\xd4\x28\x3a\x18\x1e\x18\x99\xb1\x09\xcb\x94\x21\xd2\x6e\xfa\xf6\x85\x03\x64\xe8\x90\x95\xc4\x77\x87\x6b\xbe\x84\xad\x75\xef\xa4\xb1\xf0\x6c\x9c\x25\x9c\x78\xcd\x06\x92\x3b\x02\xcb\x60\x03\x81\x8c\x1d\x14\x4a\x84\x4a\xc0\x13\xf6\xe4\xbe\x51\x4c\x83\x90\xfd\xe4\x32\x56\x59\x12\x0b\x1d\x0c\x4b\x4f\xe6\x5e\x2e\x46\xe5\xc1\xef\x2d\x4b\xc7\x9b\xe5\x84\x36\x1c\xaf\xac\xa6\xe1\xc9\xaf\x58\x7c\xca\xbd\xdf\x9c\xd5\xbc\xe9\x0f\x6a\x38\xcd\xce\x35\x35\x95\x95\x23\xb5\xee\x78\xa5\x58\x1b\x16\x0b\xfe\xcc\xe2\xcf\xa8\x03\x54\x11\x76\x9e\x2c\x7a\x19\xbb\x7f\xe3\x01\xf6\x7b\xb0\xbc\xc4\x01\x1e\xc2\x77\xce\x7d\x92\xde\x88\x59\xaa\x0b\x72\x5a\x0a\xdd\x4f\x35\xc5\xf0\xac\xce\x35\x1f\xa2\x86\x29\xb5\xb7\x62\xea\x5e\x7f\xc3\xb4\x24\xe3\x6a\x51\x2a\xf7\xa8\xbf\x01\x00\xea\x51\xb4\x1e\x44\x01\x00\x00"),
 		},
 		"/ignition/master/systemd/set-kernel-para.service": &vfsgen۰CompressedFileInfo{
 			name:             "set-kernel-para.service",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 286,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x8e\x4d\x4e\xc3\x30\x10\x85\xf7\x73\x0a\x5f\x20\xc9\x09\xbc\x00\xd1\x05\x62\xd7\x82\x58\x44\x11\x72\x9c\x97\x32\xaa\x63\x9b\x99\x49\xd5\xdc\x1e\x41\x24\xc4\xaa\xbb\xf7\x2b\x7d\xfd\x5b\x66\x1b\xe8\x09\x1a\x85\xab\x71\xc9\x5e\x61\xee\x02\xc9\x48\xae\x06\x09\x6e\x2e\xe2\x5e\xd6\xf1\x27\x31\x28\x1d\xf1\xb5\xb2\x40\xbd\x20\x21\x28\x1a\x5e\xc2\x19\x4d\xe5\x6b\xb1\x56\x21\x57\x8e\xa0\x87\xd9\x20\x77\x17\xd4\x9f\x76\x35\xd0\xeb\x56\xe1\x4b\x86\x7e\x16\xa3\x23\x96\xc0\xf9\xf7\x7f\xb8\xb1\xf9\x0d\x4a\x87\x1b\xe2\xc9\x82\x98\x5f\xca\x54\xa5\x8c\x70\xa3\x7c\x64\xd8\xcc\xc9\x20\xff\x7a\xdd\x34\x5a\x72\x4d\x75\x1d\x2c\x76\xbb\x6d\xa7\xee\xf2\xc7\xdf\xc6\x92\x67\xa2\xfe\x39\xab\x85\x94\x06\x7a\x0f\xd9\x30\x3d\x6e\x7e\x59\x93\x71\xb3\x2a\xa4\xb5\x20\x67\x18\xd1\x77\x00\x00\x00\xff\xff\x73\x48\x6e\xcb\x1e\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x8e\x4d\x4e\xc3\x30\x10\x85\xf7\x73\x0a\x5f\x20\xc9\x09\xbc\x00\xd1\x05\x62\xd7\x82\x58\x44\x11\x72\x9c\x97\x32\xaa\x63\x9b\x99\x49\xd5\xdc\x1e\x41\x24\xc4\xaa\xbb\xf7\x2b\x7d\xfd\x5b\x66\x1b\xe8\x09\x1a\x85\xab\x71\xc9\x5e\x61\xee\x02\xc9\x48\xae\x06\x09\x6e\x2e\xe2\x5e\xd6\xf1\x27\x31\x28\x1d\xf1\xb5\xb2\x40\xbd\x20\x21\x28\x1a\x5e\xc2\x19\x4d\xe5\x6b\xb1\x56\x21\x57\x8e\xa0\x87\xd9\x20\x77\x17\xd4\x9f\x76\x35\xd0\xeb\x56\xe1\x4b\x86\x7e\x16\xa3\x23\x96\xc0\xf9\xf7\x7f\xb8\xb1\xf9\x0d\x4a\x87\x1b\xe2\xc9\x82\x98\x5f\xca\x54\xa5\x8c\x70\xa3\x7c\x64\xd8\xcc\xc9\x20\xff\x7a\xdd\x34\x5a\x72\x4d\x75\x1d\x2c\x76\xbb\x6d\xa7\xee\xf2\xc7\xdf\xc6\x92\x67\xa2\xfe\x39\xab\x85\x94\x06\x7a\x0f\xd9\x30\x3d\x6e\x7e\x59\x93\x71\xb3\x2a\xa4\xb5\x20\x67\x18\xd1\xf7\x00\x73\x48\x6e\xcb\x1e\x01\x00\x00"),
 		},
 		"/ignition/worker": &vfsgen۰DirInfo{
 			name:    "worker",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime: time.Date(2026, 10, 16, 11, 8, 40, 0, time.UTC),
 		},
 		"/ignition/worker/files": &vfsgen۰DirInfo{
 			name:    "files",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
+			modTime: time.Date(2026, 10, 16, 11, 8, 40, 0, time.UTC),
 		},
 		"/ignition/worker/files/etc": &vfsgen۰DirInfo{
 			name:    "etc",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime: time.Date(2026, 10, 16, 11, 8, 40, 0, time.UTC),
 		},
 		"/ignition/worker/files/etc/hosts.template": &vfsgen۰CompressedFileInfo{
 			name:             "hosts.template",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 167,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x32\x34\x32\xd7\x33\xd0\x33\xd0\x33\x54\x50\x50\xc8\xc9\x4f\x4e\xcc\xc9\xc8\x2f\x2e\x41\xb0\xf4\xc0\xac\x94\xfc\xdc\xc4\xcc\x3c\x84\xa8\x09\x12\x13\x59\x85\x09\x97\x95\x15\xc8\x20\x08\x20\xda\x38\x33\x24\x26\xb2\x0a\x33\xae\xea\x6a\x3d\x8f\xe2\xcc\x82\xda\x5a\x40\x00\x00\x00\xff\xff\x0b\x57\x23\x96\xa7\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x32\x34\x32\xd7\x33\xd0\x33\xd0\x33\x54\x50\x50\xc8\xc9\x4f\x4e\xcc\xc9\xc8\x2f\x2e\x41\xb0\xf4\xc0\xac\x94\xfc\xdc\xc4\xcc\x3c\x84\xa8\x09\x12\x13\x59\x85\x09\x97\x95\x15\xc8\x20\x08\x20\xda\x38\x33\x24\x26\xb2\x0a\x33\xae\xea\x6a\x3d\x8f\xe2\xcc\x82\xda\x5a\xc0\x00\x0b\x57\x23\x96\xa7\x00\x00\x00"),
 		},
 		"/ignition/worker/files/etc/isulad": &vfsgen۰DirInfo{
 			name:    "isulad",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
+			modTime: time.Date(2026, 10, 16, 11, 8, 40, 0, time.UTC),
 		},
 		"/ignition/worker/files/etc/isulad/daemon.json.template": &vfsgen۰CompressedFileInfo{
 			name:             "daemon.json.template",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
-			uncompressedSize: 1174,
+			modTime:          time.Date(2026, 10, 16, 11, 8, 40, 0, time.UTC),
+			uncompressedSize: 1167,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x54\xcb\xae\xd3\x3c\x10\xde\x9f\xa7\x88\xbc\xfe\x9d\xf4\x2f\x12\x8b\x4a\x67\x83\xc4\x02\xb1\x00\x75\x8b\xd0\x91\x6b\x4f\xd2\x21\x8e\x6d\x8d\xed\xd0\x50\xe5\xdd\x91\x5d\x27\x6d\x03\x62\x57\xcd\x77\xe9\x37\x97\xf8\xfa\x52\x55\x55\xc5\xe0\x02\x92\x5b\x17\x3c\x3b\x54\xdf\x98\x11\x01\x47\xa8\x65\x47\x36\x3a\x45\x38\x02\xbd\xfa\xc9\x07\x18\x14\xfb\xfe\xdf\x4d\x91\x31\x76\xa8\x18\xfa\xa8\x05\x2b\x55\x05\xad\x88\x3a\x70\x8a\x26\xe0\x00\x09\xd7\x92\xd8\xaa\x11\xee\x9c\x6a\xcd\x28\xa8\xd1\x78\x6a\xb2\x58\x2d\xb8\x0f\x22\xc0\x8a\x53\x34\x1b\x1c\x4c\x87\x66\x6b\xaa\x6d\xc7\x35\x8c\xa0\x53\xfd\xe3\xf1\xf8\xe5\xb8\x20\x0e\x55\x8b\xfa\x6f\x86\xb5\x43\xf5\xa8\x2f\x9d\xdf\x86\xb1\x56\x93\x98\x0f\x56\x65\x87\xdd\xfb\xdd\xae\x48\x56\x82\x13\xe1\x1f\xed\x64\xda\x20\x2e\x7c\x09\xf1\xff\x16\xf0\xf8\x2b\x03\xef\x76\x9f\x3f\xb0\x0c\xcd\x0f\xa1\x6e\x83\x4f\xb8\x0f\xca\xc6\xb0\x04\x96\xd6\x04\x81\x06\x88\x6b\xdb\x3d\xa7\xbe\x4b\x7e\x78\x6b\x6e\x7f\xfc\xe4\x7b\xb6\xb6\xe7\xde\x81\xcc\xb1\x21\xc8\xa6\x6c\xac\x44\x6f\x12\xc1\x2f\xc5\x3a\xb9\x3c\x2c\x87\x02\x4f\x5b\x4d\x59\x0e\x15\xdb\x0f\x77\xc8\x92\xe8\xe0\x21\xb1\x1d\x81\xb4\x98\xf6\x5b\xc6\x72\x62\xf7\xc8\x0b\xb3\x4e\x3f\x08\x15\xbc\xf5\x40\x06\xf4\x9b\x3c\x83\xec\x5f\x03\xc5\xd2\xc2\x72\x78\x04\x1d\xfa\x40\x13\x1f\x90\xc8\xd2\xc6\x4e\x59\xd9\x03\xd5\x68\x9f\x45\x68\x3c\xc8\x48\xc0\x8b\x1a\x61\xa3\xbb\x5e\xeb\x4f\x83\xe8\xe0\x58\xdc\xe7\xf9\xd9\xc0\x59\xc5\xbd\x30\xea\x64\x2f\x1c\x13\x31\x75\xf9\xa7\xa8\xb9\x5e\xeb\xaf\x22\x7a\xc8\xf5\x79\x5e\xfa\x2f\x5f\x54\x1c\x84\xef\xf3\x46\x73\x9a\x15\x85\xf0\xd3\x52\xcf\x9d\x8e\x1d\x9a\x84\x4b\x83\xeb\xba\x0d\xf2\x13\x1a\xae\x30\x4f\xb6\xb1\x2e\x34\xd2\x60\x73\x42\xf3\x48\x91\xd6\xb4\x2b\x27\x2d\x36\x71\x0c\x84\x7a\x3d\xf4\x1c\x9b\x6b\x31\x01\xf1\x3c\x5c\x76\xa8\x5a\xa1\x3d\x14\x3c\x7a\xe0\x0a\x24\x4d\x2e\x80\xe2\x3d\x4c\xec\x50\xa5\xf1\x6f\x47\xe8\x7b\x74\x7c\x04\xc2\x76\xe2\x60\x5a\x4b\x12\x36\x4e\x92\x70\x79\x01\x36\x9f\x55\x2f\x82\xc8\x8f\x86\xad\xd7\x33\x56\x75\xaa\xd6\xe3\xbe\x9c\xea\xcb\xfc\xf2\x3b\x00\x00\xff\xff\x2e\x9c\xa7\x42\x96\x04\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x54\xcb\xae\x9b\x3c\x10\xde\xe7\x29\x90\xd7\xc7\x90\x3f\xbf\xd4\x05\xd2\xd9\xf4\xb2\xa8\xba\xa8\x94\x2e\xab\xea\xc8\xb1\x07\x32\xc5\xd8\xd6\xd8\xa6\xa1\x11\xef\x5e\xd9\x01\x92\xa0\xaa\x59\x85\xf9\x2e\x7c\x9e\x19\x73\xdd\x15\x45\x51\x30\xb8\x80\xe4\xd6\x05\xcf\xea\xe2\x3b\x33\x22\xe0\x00\xa5\x6c\xc9\x46\xa7\x08\x07\xa0\xd7\xeb\xb5\xfc\x90\x9f\x3f\xe6\xe7\x69\x62\x3f\x5e\x6e\xda\x5c\x65\x75\xc1\xd0\x47\x2d\xd8\x5c\x55\xd0\x88\xa8\x03\xa7\x68\x02\xf6\x90\x70\x2d\x89\xad\x1a\xe1\xce\xa9\x56\x0d\x82\x2a\x8d\xa7\x2a\x8b\xd5\x82\xfb\x20\x02\xac\x38\x45\xb3\xc1\xc1\xb4\x68\xb6\xa6\xda\xb6\x5c\xc3\x00\x3a\xd5\x3f\x1d\x8f\x5f\x8f\x0b\xe2\x50\x35\xa8\xff\x66\x58\x3a\x54\x8f\xfa\xb9\x07\xb7\xb6\xac\xd5\x24\xe6\xbd\x55\xd9\x61\xff\x6e\xbf\x67\x2f\xcf\x04\x27\xc2\x3f\x8e\x93\x7e\xac\x17\x17\xbe\x84\xf8\x6f\x0b\x78\xfc\x9d\x81\xff\xf7\x5f\xde\xb3\x0c\x4d\x0f\xa1\x6e\x23\x48\xb8\x0f\xca\xc6\xb0\x04\x96\xd6\x04\x81\x06\x88\x6b\xdb\x3e\xa7\xbe\x4b\x7e\x7a\x6b\x6e\x2f\x7e\xf2\x3d\x5b\xdb\x71\xef\x40\xe6\xd8\x10\x64\x35\x4f\x6c\x8e\x5e\x25\x82\x5f\x8a\x65\x72\x79\x18\x0e\x05\x9e\xa6\x9a\xb2\xd4\x05\x3b\xf4\x77\xc8\x92\x68\xe1\x21\xb1\x1d\x80\xb4\x18\x0f\x5b\xc6\xb2\x6c\xf7\xc8\x0b\xb3\x4c\x7f\x08\x15\xbc\x75\x40\x06\xf4\x9b\x3c\x83\xec\x5e\x03\xc5\xf9\x08\xcb\xe2\x11\xb4\xe8\x03\x8d\xbc\x47\x22\x4b\x1b\x3b\x65\x65\x07\x54\xa2\x7d\x16\xa1\xf1\x20\x23\x01\x9f\xd5\x08\x1b\xdd\xf5\x5a\x7e\xee\x45\x0b\xc7\xd9\x7d\x9a\x9e\x0d\x9c\x55\xdc\x0b\xa3\x4e\xf6\xc2\x31\x11\x59\x9d\x45\xdf\x6e\xb5\xac\x9d\xa6\xe5\xb8\xf3\x55\x8a\xbd\xf0\x5d\x1e\x60\x7e\xf9\x8a\x42\xf8\x65\xa9\xe3\x4e\xc7\x16\x4d\xc2\xa5\xc1\x75\xba\x06\xf9\x09\x0d\x57\x98\x1b\x59\x59\x17\x2a\x69\xb0\x3a\xa1\x79\xa4\x48\x6b\x9a\x95\x93\xe6\x98\x38\x06\x42\xb9\xee\x75\x4e\xc9\xb5\x18\x81\x78\xee\x25\xab\x8b\x46\x68\x0f\x33\x1e\x3d\x70\x05\x92\x46\x17\x40\xf1\x0e\x46\x56\x17\xa9\xdb\xdb\x8e\xf9\x0e\x1d\x1f\x80\xb0\x19\x39\x98\xc6\x92\x84\x8d\x93\x24\x5c\x2e\xfc\xe6\x16\x75\x22\x88\xfc\x8d\xb0\xe5\xba\xb5\xaa\x4c\xd5\x72\x38\xcc\x9b\xb9\x9b\x76\x7f\x06\x00\xfc\x6f\xf0\xd0\x8f\x04\x00\x00"),
 		},
 		"/ignition/worker/files/etc/nkd": &vfsgen۰DirInfo{
 			name:    "nkd",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 190819509, time.UTC),
+			modTime: time.Date(2026, 10, 16, 10, 7, 23, 0, time.UTC),
 		},
 		"/ignition/worker/files/etc/nkd/node-pivot.sh.template": &vfsgen۰CompressedFileInfo{
 			name:             "node-pivot.sh.template",
-			modTime:          time.Date(2024, 4, 11, 6, 18, 46, 112125019, time.UTC),
-			uncompressedSize: 2798,
+			modTime:          time.Date(2026, 10, 16, 10, 7, 23, 0, time.UTC),
+			uncompressedSize: 3693,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x56\xdb\x6e\xdb\x38\x10\x7d\xd7\x57\x4c\x65\xa3\x97\xa0\x92\xda\x57\x17\xe9\x2e\xb0\x4d\xb1\x05\x8a\xa2\x70\x10\x60\x01\xc7\x35\x68\x6a\x64\x0f\x2c\x91\x0a\x49\xa5\x36\x1c\xff\xfb\x82\xa4\x24\x4b\x8a\xe3\x64\x37\x0f\x49\x4c\x9f\x39\x73\xe6\x4a\x8e\x5e\x25\x4b\x12\x89\x5e\x07\xc1\x08\xbe\x56\x82\x1b\x92\x02\x8c\x84\x82\x09\xb6\x42\xd0\xa8\xee\x89\x23\x68\xc3\x94\xa9\x4a\x60\x22\x05\x14\x6c\x99\x23\x48\x01\x4b\x29\x4d\xe0\x91\x8b\x1a\xf9\xf6\x1d\xec\x03\x00\x68\x2c\x17\x82\x15\x78\x19\x8e\x3f\x86\xee\x94\x32\xd0\x3b\x6d\xb0\xe0\x26\x07\xd2\x11\xe3\x86\xee\x11\xa2\xe8\xae\x22\x34\x10\x8e\xbb\x66\xe1\x27\x30\x6b\x14\xce\xd0\xfe\x20\x5f\xcb\x01\x04\x48\x03\xcb\x15\xb2\x74\x07\xaa\x12\x82\xc4\xca\x3b\xc2\x5c\xe3\x73\x86\x42\x9a\xc6\xe8\xbd\x8f\x90\xc4\x2a\x8e\xe3\xb0\x35\xec\xc9\x75\x88\xa1\x44\x78\xfd\xba\x03\xa9\x53\x73\x3e\x8c\xa7\x14\x35\x0a\x40\x57\x9c\xa3\xd6\x1d\x1d\xbd\x68\x8e\xf6\x37\xde\x9d\x91\xb5\xb6\x1e\x5f\xc7\xdc\x59\x6c\xc9\xc0\xc7\xf6\x28\xa3\xa0\xfe\x73\xb0\xa5\xff\x6b\x8d\x7c\xe3\xc2\xad\x0b\x8e\x5b\xd2\x46\xbb\x7a\xd7\xad\x40\x26\xe0\x16\xb5\x60\x22\x5d\xfc\xff\xa2\xe7\xa4\x4d\x54\x09\x32\x51\x46\x39\x6a\x78\x80\x95\xc2\x12\xa2\xbb\x41\x3a\xe2\xfa\xc3\x30\x7b\x7d\xcf\xc3\x54\xbf\xac\xf6\x8d\x71\x2a\xd1\x77\x81\x8b\xf6\x3d\xe8\x0d\x95\x65\xb7\x07\x7c\x7a\x70\x8b\xbc\x32\xb8\x58\x4b\xb9\x71\xa2\xdb\x78\x73\xc9\x59\x0e\x29\x29\xe4\x46\xaa\x5d\x2f\xe4\x19\xbc\x82\x28\x85\x70\xdc\x7e\x1d\xc2\xfc\x64\x47\x7f\x69\x00\x4e\x4b\x26\x2b\x91\x4e\xa0\x63\xd6\xc2\x15\x9a\x4a\x09\x68\xa4\xf9\x94\xaf\x31\xcf\x17\x4e\xd6\xe5\xdb\xae\xb3\xe4\xe2\xdd\x51\xca\x78\x3f\xea\x00\x67\x7f\xce\x0f\x10\xe1\x1d\x7c\x78\x42\xd1\x0f\x09\xbe\x3a\x4e\x0c\x90\x38\xc6\x78\x4e\x59\xd0\xed\x2c\xa9\x1c\x87\x35\x0e\xc7\xfb\x81\xf7\xf0\x13\xa4\xb2\x3b\x66\x33\x88\x32\x08\xc7\x16\xf0\x38\x4d\x47\x61\x57\xae\x14\x6e\x48\xb8\xa2\xd2\x4c\xc0\x9b\xf4\xa0\x71\x43\x34\xec\xf6\x54\x0a\x3c\x59\x50\x08\xf7\xfb\xf8\x6f\x29\x37\x5f\xed\xa7\x9f\xcc\xac\x0f\x87\xf0\xc9\x76\x77\xe8\x69\x25\x0c\x15\x78\x1e\xb7\x96\x95\xc6\x0d\x62\x89\x2a\x4a\x19\x16\x52\x84\x6e\xda\xa4\xc8\x68\x55\x29\xb4\x61\x02\x57\x24\x81\x4b\x61\x18\x09\x54\x76\x23\x59\xe2\xa0\x4d\x4a\x82\x86\x27\x16\xe4\x7e\xc5\x5c\x8a\xac\x9f\x22\x87\xec\x6b\x82\x4b\x08\x2d\xfa\x71\x2e\x29\x3b\x0e\xdc\xed\xcc\x62\x6e\x63\x2a\xd8\x0a\x6f\xe7\x21\x9c\x70\x75\xa2\x14\x5d\x8a\x5f\xb3\xd9\x44\x97\x8c\xe3\x64\x3e\xbf\x28\x59\xa5\x71\xe1\xd8\xac\x80\x17\xd2\xf9\xc5\x91\x42\x44\xf0\x46\x3f\xfc\xea\x93\xc4\x17\x0f\x03\xd6\xfd\x3e\xfe\x66\xff\x9f\xe2\x8a\xb4\x51\xbb\xc3\x21\xd9\xef\xe3\x9f\x16\xe4\xce\x0f\x87\xf0\xe1\xcd\x29\xd7\xfd\x76\x1a\x6e\xd4\xae\x8a\x64\x90\x98\x84\xc1\x7f\x17\xf1\xbc\x86\xba\x2b\xcf\x2c\x78\x27\xc3\xab\x98\x87\xf0\xf9\xf3\xf3\x61\x39\xb3\xbe\xd8\xdb\x17\xa8\xbd\x0d\x9f\xa5\xef\xa8\x3d\x6e\x73\x85\xfe\xe6\xb1\xf0\x66\xfa\xed\x66\x1a\xc1\x17\xd2\xee\x72\xba\xbe\xfa\x4e\xa2\xda\x06\xcd\xaa\xb3\xa7\x76\x7e\xeb\x73\xb7\x6a\xdb\xea\x8f\xae\xaf\xbe\x7f\xfb\x71\xf3\xcf\x25\x8a\x4c\x2a\x4e\x62\xd5\x9e\xa4\x9e\x2f\x1d\xad\xea\xc4\x6a\xcc\x2d\x41\xc2\xdd\x30\x05\x1a\x8d\x37\x42\xf8\xd0\xbb\xd1\xa6\x98\x23\xab\x03\xbd\x99\xda\x57\x07\x60\x51\x9a\x5d\x3d\x60\xc2\x4f\x4e\x1f\x64\x27\xa8\x3b\x38\x23\xf0\x8b\x07\x41\xe1\x92\xd5\xa5\x52\x65\x11\x49\x6d\x14\x36\xa7\x10\x45\xb8\x2d\x51\x51\x81\xc2\xb0\x1c\xfc\x97\x51\x25\xee\x51\x51\x46\x98\x46\xae\x24\x93\x54\xf2\x0d\xaa\x49\x92\x9c\x72\x0c\x51\xb4\xdc\x95\x4c\xeb\x28\x55\x74\x8f\xaa\xf6\xdf\x86\x63\x37\x46\xed\xee\x37\xd3\xcd\x5b\x21\xab\xf2\xce\xb2\xff\xe3\xec\x72\x9f\x7a\x6b\x59\xa2\x62\xee\xb9\xc7\x65\x51\xe6\x68\x30\xed\xb0\xe5\xbb\x18\xa6\x68\x1f\x78\xb6\x58\xd6\xa9\x2f\x7a\xef\x79\xd4\xed\x03\xf7\x16\x7c\xe2\xf6\x7d\xe4\x31\x63\x94\x63\x1a\xc3\xb5\x23\x80\xdf\x94\xe7\xee\xee\x5b\x62\xcd\x84\xe9\xf1\x06\x0e\x5a\xc6\x86\xed\x74\x41\x8f\xf7\xf7\xe3\xd2\xc4\xa1\x6d\xcb\x7f\x03\x00\x00\xff\xff\x52\xa3\xd7\x9f\xee\x0a\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x57\xdb\x6e\xdb\x38\x10\x7d\xd7\x57\x4c\x65\xa3\x6e\x83\x4a\x6e\x5e\x5d\x24\xbb\xc0\xb6\xc5\x16\x28\x8a\x45\x82\x02\x0b\x58\xae\x41\x53\x23\x99\x30\x45\xaa\x24\xe5\xc6\x70\xfc\xef\x0b\x52\x77\xf9\x12\x6f\xfd\x10\xc7\xd4\x99\x33\x67\x6e\xd2\x68\xf4\x6a\xba\x62\x62\xaa\xd7\x9e\x37\x82\xcf\x85\xa0\x86\x49\x01\x46\x42\x46\x04\x49\x11\x34\xaa\x2d\xa3\x08\xda\x10\x65\x8a\x1c\x88\x88\x01\x05\x59\x71\x04\x29\x60\x25\xa5\xf1\x4a\xe4\xb2\x42\xbe\x79\x0b\x7b\x0f\x00\x6a\xcb\xa5\x20\x19\xde\xf9\xe3\x5b\xdf\x9d\xb2\x04\xf4\x4e\x1b\xcc\xa8\xe1\xc0\x74\x40\xa8\x61\x5b\x84\x20\xf8\x59\x30\x34\xe0\x8f\xbb\x66\xfe\x07\x30\x6b\x14\xce\xd0\x7e\x90\xae\xe5\x00\x02\x4c\x03\xe1\x0a\x49\xbc\x03\x55\x08\xc1\x44\x5a\x3a\x42\xae\xf1\x25\x43\x21\x4d\x6d\xf4\xae\x8c\x90\x89\x34\x0c\x43\xbf\x31\xec\xc9\x75\x88\xa1\x44\x78\xfd\xba\x03\xa9\x52\x73\x39\x8c\x73\x8a\x6a\x05\xa0\x0b\x4a\x51\xeb\x8e\x8e\x5e\x34\xad\xfd\xf7\xd2\x9d\x91\xa5\x2d\xf4\xf8\x42\xbf\x6f\xf1\xc4\x0c\xdc\x36\x47\x09\xf3\xaa\xaf\x83\x2d\xfd\x5f\x6b\xa4\x1b\x17\x6e\xc9\x60\xe1\xda\x68\x57\xef\xaa\x15\x98\xf1\xa8\x45\x2d\x89\x88\x97\xbf\x5f\x74\xce\xb4\x09\x0a\xc1\x4c\x90\x30\x8e\x1a\x9e\x21\x55\x98\x43\xf0\x73\x90\x8e\xb0\xfa\x31\xcc\x5e\xdf\xf3\x30\xd5\xd7\xd5\xbe\xfa\x01\xb1\xc4\xb2\x0b\x5c\xb4\xef\x40\x6f\x58\x9e\x77\x7b\xa0\x4c\x0f\x3e\x21\x2d\x0c\x2e\xd7\x52\x6e\x9c\xe8\x26\x5e\x2e\x29\xe1\x10\x33\x85\xd4\x48\xb5\xeb\x85\x3c\x87\x57\x10\xc4\xe0\x8f\x9b\xcb\x3e\x2c\x4e\x76\xf4\xc7\x1a\xe0\xb4\x24\xb2\x10\xf1\x0c\x3a\x66\x0d\x5c\xa1\x29\x94\x80\x5a\x9a\xfb\xd6\x6b\xe4\x7c\xe9\x64\xdd\xbd\xe9\x3a\x9b\xde\xbc\x6d\xa5\x8c\xf7\xa3\x0e\x70\xfe\xe7\xe2\x00\x01\xfe\x84\xf7\x67\x14\x7d\x93\xe0\x80\xa5\x18\x60\xa2\x8d\xf1\x92\x32\xaf\xdb\x59\x52\x39\x0e\x6b\xec\x8f\xf7\x03\xef\xfe\x07\x88\x65\x77\xcc\xe6\x10\x24\xe0\x8f\x2d\xe0\x38\x4d\xad\xb0\x4f\xae\x14\x6e\x48\xa8\x62\xb9\x99\x41\x69\xd2\x83\x86\xe0\x0f\x4e\x2b\x4d\xb1\x14\x78\xb2\xa0\xe0\xef\xf7\xe1\xdf\x52\x6e\x3e\xdb\x5f\xff\x10\xb3\x3e\x1c\xfc\xb3\xed\xee\xd0\x0f\x85\x30\x2c\xc3\xcb\xb8\xb5\x2c\x34\x6e\x10\x73\x54\x41\x4c\x30\x93\xc2\x77\xd3\x26\x45\xc2\xd2\x42\xa1\x0d\x13\xa8\x62\x12\xa8\x14\x86\x30\x81\x0a\x54\x49\xec\x35\x49\x99\xa2\xa1\x53\x0b\x72\x7f\x42\x2a\x45\xd2\x4f\x91\x43\xf6\x35\xc1\x1d\xf8\x16\x7d\x9c\x4b\x96\xb4\x03\x17\xcd\x2d\x26\x0a\x59\x46\x52\x8c\x16\x3e\x9c\x70\x75\xa2\x14\x5d\x8a\x1f\xf3\xf9\x4c\xe7\x84\xe2\x6c\xb1\xb8\xc9\x49\xa1\x71\xe9\xd8\xac\x80\x2b\xe9\xec\x47\x63\x0c\x01\x83\x89\x7e\xfe\xd1\x27\x09\x6f\x9e\x07\xac\xfb\x7d\xf8\x48\x44\xbc\x92\x4f\x5f\xec\xd1\xe1\xe0\x3f\x4f\x4e\x79\xea\xf9\x38\xba\x81\x76\x9d\x4e\x07\x79\x98\x12\x78\xd1\xe7\xcb\x2e\xab\x9e\xbb\x70\xfb\x76\x5e\x4b\xa7\x0b\x1f\xee\xef\x5f\x8e\xc2\x99\xf5\xb5\x45\xc7\xe2\x22\xff\x45\xb6\x8e\xb8\xf6\xd6\xac\xb0\x7c\x8c\x58\x78\x3d\xca\x09\x3b\xd1\xb0\x75\xaf\xc6\xc7\x6d\xfb\xce\x21\x74\xa9\x07\x4a\x91\x59\xa1\x0d\x64\xc4\xd0\xb5\xbb\xe8\xf4\x97\x97\xbc\x73\xad\xdb\x78\x38\xd1\xe9\xaf\x3a\x53\xd1\xe0\xec\xbf\x09\x4b\x43\x23\x33\x7e\xdc\xf4\xd9\x26\x66\x0a\x82\x1c\x06\x56\x0d\xa0\x1f\x52\xc2\x52\x88\x31\x21\x05\x37\x70\x0f\x17\x3c\x75\x6f\x78\x67\xa7\xa2\x4a\xc6\xd1\x5c\x9c\x64\x1c\x08\xef\xcc\x45\xf4\xa6\x4b\x1a\xbd\x1d\xd2\x86\x37\xcf\xd1\xed\x91\xaf\x0b\xd3\x72\x3e\x22\xe4\x9d\x60\x26\x3f\xa2\x79\xce\x8b\x94\x09\x1d\x85\xbe\x1d\x93\xd6\x32\x0a\x53\x95\xd3\x28\xdc\xde\x46\x21\x55\xcc\x8f\x16\x93\xdf\x08\x6e\xfa\xbf\x3c\x4c\x09\x44\x00\x57\x04\x7a\x4d\x9c\xc3\x45\x61\x52\x0b\xb1\x3a\x3a\x32\x9c\x8a\x70\x7b\xeb\x34\x2c\x26\xed\x78\x9d\x27\x6f\x39\xaf\x54\x7b\x7f\x75\xab\x9d\x18\xd9\xb6\xa5\xcb\x89\xfd\xc8\xb4\xdb\x0d\x1f\x3f\x7d\x65\xa2\x78\xf2\xea\x4d\xc3\x9e\xda\xc7\x67\x75\xee\x36\x9d\xa6\xc9\x46\x8f\x9f\xbe\x7e\xf9\xf6\xfd\xdf\x3b\x14\x89\x54\x94\x89\xb4\x39\x89\x4b\xbe\x78\x94\x56\x69\xd5\xc8\x2d\x41\x25\xd1\xd3\x68\x4a\x23\x84\xf7\xbd\x85\xf2\x01\x39\x12\x8d\x2e\xcc\xef\x0f\x1c\x98\x06\xcc\x72\xb3\xab\x9e\x6f\xa2\x9c\xfe\x3e\xe8\x70\xe8\x8f\xf0\x08\xca\xe7\x3e\x82\xc2\x15\xa9\x6a\xa6\xf2\x2c\x90\xda\x28\xac\x4f\x21\x08\xf0\x29\x47\xc5\x32\x14\x86\x70\x28\x2f\x06\x85\xd8\xa2\x62\x09\xc3\x38\x70\xe9\x9f\xc5\x92\x6e\x50\xcd\xa6\xd3\x53\x8e\x21\x08\x56\xbb\x9c\x68\x1d\xc4\x8a\x6d\x51\x55\xfe\x9b\x70\xcc\xba\x71\xf7\x8b\xe8\x7a\x55\x4f\x0a\xde\xd9\xb5\xfe\xb8\xb8\x5b\x3d\x94\xd6\x32\x47\x45\xdc\xdb\x16\x95\x59\xce\xd1\x60\xdc\x61\xe3\xbb\x10\x1e\xd0\xbe\x5f\xd9\x62\xb9\x5b\xaa\xab\x79\xef\xed\xa4\xdb\x06\x16\x7a\x6e\xf9\x3d\xf2\x98\x10\xc6\x31\x0e\xe1\xd1\x11\xc0\x2f\xc6\xb9\x5b\x3d\x57\x58\x31\x61\xdc\x2e\xc0\x5e\xc3\x58\xb3\x9d\x2e\x68\xbb\x3e\x1f\x97\x26\xf4\x6d\x5b\xfe\x37\x00\xae\xd1\x53\xe7\x6d\x0e\x00\x00"),
+		},
+		"/ignition/worker/files/etc/sysconfig": &vfsgen۰DirInfo{
+			name:    "sysconfig",
+			modTime: time.Date(2026, 10, 16, 10, 2, 33, 0, time.UTC),
+		},
+		"/ignition/worker/files/etc/sysconfig/kubelet.template": &vfsgen۰CompressedFileInfo{
+			name:             "kubelet.template",
+			modTime:          time.Date(2026, 10, 16, 10, 2, 33, 0, time.UTC),
+			uncompressedSize: 148,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xf2\x0e\x75\x72\xf5\x71\x0d\x89\x77\x8d\x08\x09\x72\x8c\x77\x0c\x72\x0f\xb6\x55\xaa\xae\x56\xc8\x4c\x53\xd0\xf3\xcb\x4f\x49\xf5\x49\x4c\x4a\xcd\x29\x56\xa8\xad\xd5\xd5\xcd\xcb\x4f\x49\xd5\xcd\x01\xf3\x6d\xab\xab\x91\x64\x6b\x6b\xab\xab\x15\x52\xf3\x52\x14\x6a\x6b\x15\x90\xb4\x86\x24\x66\xe6\x95\x40\xb4\x16\xa5\xa6\x67\x16\x97\xa4\x16\xe9\x96\x67\x96\x64\xe8\x96\x80\x25\x60\x66\x40\x94\x21\x99\xa1\xc4\x05\x18\x00\x73\x78\x70\x55\x94\x00\x00\x00"),
 		},
 		"/ignition/worker/files/etc/sysctl.d": &vfsgen۰DirInfo{
 			name:    "sysctl.d",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime: time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 		},
 		"/ignition/worker/files/etc/sysctl.d/kubernetes.conf": &vfsgen۰CompressedFileInfo{
 			name:             "kubernetes.conf",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 97,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xca\x4b\x2d\xd1\x4b\x2a\xca\x4c\x49\x4f\x85\x52\xba\x79\x69\xba\xc9\x89\x39\x39\xba\x99\x05\x25\x89\x49\x39\xa9\xc5\xb6\x86\x5c\xf8\x14\x99\xa1\xa8\xca\x2c\x28\x33\xd1\xcb\x2c\x88\x4f\xcb\x2f\x2a\x4f\x2c\x4a\xb1\x35\xe4\x02\x04\x00\x00\xff\xff\x6d\xd4\xf2\x72\x61\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xca\x4b\x2d\xd1\x4b\x2a\xca\x4c\x49\x4f\x85\x52\xba\x79\x69\xba\xc9\x89\x39\x39\xba\x99\x05\x25\x89\x49\x39\xa9\xc5\xb6\x86\x5c\xf8\x14\x99\xa1\xa8\xca\x2c\x28\x33\xd1\xcb\x2c\x88\x4f\xcb\x2f\x2a\x4f\x2c\x4a\xb1\x35\xe4\x02\x0c\x00\x6d\xd4\xf2\x72\x61\x00\x00\x00"),
 		},
 		"/ignition/worker/files/etc/systemd": &vfsgen۰DirInfo{
 			name:    "systemd",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime: time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 		},
 		"/ignition/worker/files/etc/systemd/system": &vfsgen۰DirInfo{
 			name:    "system",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime: time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 		},
 		"/ignition/worker/files/etc/systemd/system/kubelet.service.d": &vfsgen۰DirInfo{
 			name:    "kubelet.service.d",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime: time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 		},
 		"/ignition/worker/files/etc/systemd/system/kubelet.service.d/10-kubeadm.conf.template": &vfsgen۰CompressedFileInfo{
 			name:             "10-kubeadm.conf.template",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 1086,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x53\xd1\x4e\xdb\x50\x0c\x7d\xef\x57\x58\x01\x89\x87\x91\x54\xbc\x4e\xca\x43\xb7\x15\x34\xb1\x01\x2a\xa0\x4d\x9a\x26\xe4\x24\x4e\x6a\xb8\xbd\x2e\xbe\x4e\xa0\x42\xfc\xfb\x74\x13\x5a\x8a\x56\x78\x8a\xe3\xf8\x9e\x73\xae\xcf\xc9\x1e\x9c\x89\xd1\x67\xb8\x9a\x73\x80\x4a\x65\xc9\x1e\xc4\xbb\x15\x3c\x88\xde\x05\x78\x60\x9b\xc3\x5d\x5b\x10\x56\x0b\x40\x5f\xf5\xb5\x23\x83\xee\x28\x3b\x3a\xfa\x34\xfa\x73\x49\xda\x71\x49\x7f\x47\x53\xdf\xb1\x8a\x5f\x90\xb7\x3c\x39\xbd\xfe\x32\xfd\x31\xbd\xba\x89\xcf\xaf\xe7\x67\xc7\xdf\x4f\x6e\x26\xb3\x93\xcb\x3c\x4d\x0b\x11\x0b\xa6\xb8\x4c\x23\x52\x29\xbe\xe6\x26\x1f\x93\x95\xe3\xf8\xae\x9e\x8c\xc2\xf8\xed\x90\x23\xcb\xe2\x20\xa4\x1f\x9d\xd9\x9e\x4c\x76\xab\x79\xab\x64\x8d\xd3\xa1\x8e\x1d\x17\x6b\x80\xf1\xd0\xcf\x56\xb8\x70\xc9\xe8\xe9\x09\xb8\x06\xba\x87\x6c\xd6\x7a\xe3\x05\x41\x52\x2a\x4b\x02\xcf\xcf\xef\x70\x9c\xcc\xce\xaf\x2f\x7e\xfd\xdc\xb0\x34\x2a\xed\x32\xa4\x4b\xd2\xf4\x5e\x42\x5e\xa3\x0b\x04\x69\x4a\xbe\x16\x2d\x29\xf5\x52\x51\x8a\xce\x49\x89\x86\x85\xa3\xfc\xe0\xa0\x67\x25\x5f\x45\x8e\xbd\xc1\x18\x0e\x80\x50\xb3\x23\xb0\x39\x1a\x24\x6b\x47\xd8\xb3\x25\xbd\x2f\x9b\xd6\xad\xb0\x4f\xa0\x21\x4f\x8a\x46\x01\xd0\x40\x07\xe9\x87\xb0\x94\x65\xeb\xd0\xd8\x37\x60\x73\x82\x6d\x97\x26\xdf\x06\xc9\xd0\xa1\x72\x14\x02\xd5\xca\xe3\x82\x4b\x74\x6e\xb5\x7d\xd5\x63\x76\x94\xa7\xff\x6d\xed\x85\x3e\xad\x1d\x36\x21\x23\xdf\xbd\x23\x3d\xf2\xb6\x81\x14\x4a\xf4\xb1\x80\x5a\x14\xa4\x23\x55\xae\x28\x80\xd4\xfd\xc4\x3a\x65\xa8\x4d\x00\x8c\x08\x0e\x83\x81\x52\x10\xb5\x0c\x2e\x94\x6a\x52\x2c\xdc\xea\xf0\x15\x30\xcc\xa5\x75\x55\xac\x47\x7b\x7d\x37\x3b\x93\x8a\x66\xd4\x70\x8c\x92\xb1\xf8\xec\x74\x80\x9d\x3e\x9a\xe2\x24\x42\x4b\x71\x4b\xa5\x01\xfb\xfe\xc0\xe0\x7c\x3b\x0c\xf7\x9a\x03\xb0\x0f\x46\x58\x65\x9b\x65\x4d\x7f\x5f\xcd\x26\xc3\xaa\x5e\x18\x0b\x82\x20\xad\x96\x54\x41\xad\xb2\x00\x8b\xd7\x8e\xa7\xb3\x1d\x7b\x8b\xa9\x0d\xab\x30\x50\xad\xb7\x37\x9a\x3e\x52\x79\x69\xa8\x96\x6f\x95\xe3\x36\xe8\xb8\x60\xbf\x9e\x82\xfd\x77\x7e\xab\xd7\x0f\x3b\x9b\x6f\xec\xdd\xdf\x71\x8f\x0f\x42\xbe\xbf\x33\xd7\x9b\x7c\xfe\x0b\x00\x00\xff\xff\x21\x0d\x51\xd3\x3e\x04\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x53\xd1\x4e\xdb\x50\x0c\x7d\xef\x57\x58\x01\x89\x87\x91\x54\xbc\x4e\xca\x43\xb7\x15\x34\xb1\x01\x2a\xa0\x4d\x9a\x26\xe4\x24\x4e\x6a\xb8\xbd\x2e\xbe\x4e\xa0\x42\xfc\xfb\x74\x13\x5a\x8a\x56\x78\x8a\xe3\xf8\x9e\x73\xae\xcf\xc9\x1e\x9c\x89\xd1\x67\xb8\x9a\x73\x80\x4a\x65\xc9\x1e\xc4\xbb\x15\x3c\x88\xde\x05\x78\x60\x9b\xc3\x5d\x5b\x10\x56\x0b\x40\x5f\xf5\xb5\x23\x83\xee\x28\x3b\x3a\xfa\x34\xfa\x73\x49\xda\x71\x49\x7f\x47\x53\xdf\xb1\x8a\x5f\x90\xb7\x3c\x39\xbd\xfe\x32\xfd\x31\xbd\xba\x89\xcf\xaf\xe7\x67\xc7\xdf\x4f\x6e\x26\xb3\x93\xcb\x3c\x4d\x0b\x11\x0b\xa6\xb8\x4c\x23\x52\x29\xbe\xe6\x26\x1f\x93\x95\xe3\xf8\xae\x9e\x8c\xc2\xf8\xed\x90\x23\xcb\xe2\x20\xa4\x1f\x9d\xd9\x9e\x4c\x76\xab\x79\xab\x64\x8d\xd3\xa1\x8e\x1d\x17\x6b\x80\xf1\xd0\xcf\x56\xb8\x70\xc9\xe8\xe9\x09\xb8\x06\xba\x87\x6c\xd6\x7a\xe3\x05\x41\x52\x2a\x4b\x02\xcf\xcf\xef\x70\x9c\xcc\xce\xaf\x2f\x7e\xfd\xdc\xb0\x34\x2a\xed\x32\xa4\x4b\xd2\xf4\x5e\x42\x5e\xa3\x0b\x04\x69\x4a\xbe\x16\x2d\x29\xf5\x52\x51\x8a\xce\x49\x89\x86\x85\xa3\xfc\xe0\xa0\x67\x25\x5f\x45\x8e\xbd\xc1\x18\x0e\x80\x50\xb3\x23\xb0\x39\x1a\x24\x6b\x47\xd8\xb3\x25\xbd\x2f\x9b\xd6\xad\xb0\x4f\xa0\x21\x4f\x8a\x46\x01\xd0\x40\x07\xe9\x87\xb0\x94\x65\xeb\xd0\xd8\x37\x60\x73\x82\x6d\x97\x26\xdf\x06\xc9\xd0\xa1\x72\x14\x02\xd5\xca\xe3\x82\x4b\x74\x6e\xb5\x7d\xd5\x63\x76\x94\xa7\xff\x6d\xed\x85\x3e\xad\x1d\x36\x21\x23\xdf\xbd\x23\x3d\xf2\xb6\x81\x14\x4a\xf4\xb1\x80\x5a\x14\xa4\x23\x55\xae\x28\x80\xd4\xfd\xc4\x3a\x65\xa8\x4d\x00\x8c\x08\x0e\x83\x81\x52\x10\xb5\x0c\x2e\x94\x6a\x52\x2c\xdc\xea\xf0\x15\x30\xcc\xa5\x75\x55\xac\x47\x7b\x7d\x37\x3b\x93\x8a\x66\xd4\x70\x8c\x92\xb1\xf8\xec\x74\x80\x9d\x3e\x9a\xe2\x24\x42\x4b\x71\x4b\xa5\x01\xfb\xfe\xc0\xe0\x7c\x3b\x0c\xf7\x9a\x03\xb0\x0f\x46\x58\x65\x9b\x65\x4d\x7f\x5f\xcd\x26\xc3\xaa\x5e\x18\x0b\x82\x20\xad\x96\x54\x41\xad\xb2\x00\x8b\xd7\x8e\xa7\xb3\x1d\x7b\x8b\xa9\x0d\xab\x30\x50\xad\xb7\x37\x9a\x3e\x52\x79\x69\xa8\x96\x6f\x95\xe3\x36\xe8\xb8\x60\xbf\x9e\x82\xfd\x77\x7e\xab\xd7\x0f\x3b\x9b\x6f\xec\xdd\xdf\x71\x8f\x0f\x42\xbe\xbf\x33\xd7\x9b\x7c\xfe\x1b\x00\x21\x0d\x51\xd3\x3e\x04\x00\x00"),
 		},
 		"/ignition/worker/systemd": &vfsgen۰DirInfo{
 			name:    "systemd",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime: time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 		},
 		"/ignition/worker/systemd/join-worker.service.template": &vfsgen۰CompressedFileInfo{
 			name:             "join-worker.service.template",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 572,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x90\xb1\x6e\xdb\x30\x10\x86\x77\x3e\xc5\xc5\x43\x36\x4a\xed\xd0\xa9\xd0\x90\xba\x01\x1a\xa0\x83\x61\x37\xe8\x60\x78\xa0\xa9\xdf\x16\x2b\x9a\x54\xef\x4e\x4e\x02\xc3\xef\x5e\x50\x82\x5b\xa0\x40\x97\x6e\xbc\xfb\x79\x1f\x3f\xde\xf6\x39\x05\xdd\x99\xcf\x10\xcf\x61\xd0\x90\x53\xf3\x92\xb9\x07\x53\xca\x2d\xe8\x47\x0e\x89\xb4\x03\xf9\x38\x8a\x82\xcd\x1a\x3f\xc7\xc0\x90\x46\xa0\xb6\x07\x27\x44\x3b\x38\x76\x95\x80\xcf\xc1\x83\x18\x11\x4e\x60\xc3\xc9\x1d\x61\x87\x70\xce\x7a\xcb\xcc\xc3\x41\xc1\xff\x35\xb9\xcc\xa9\x0d\xc5\x6e\xe5\xb4\x7b\x7c\x0d\xa2\xd2\xdc\xd5\x67\xc7\x75\xcc\xc7\xba\x58\xda\x59\xbb\x12\x75\xa7\xc1\x98\xed\x66\x9e\xdc\x99\xc7\x57\xf8\x8d\x3a\xd6\x15\xa3\xa9\xf7\x21\xd5\x7b\x27\x1d\x59\x4f\x8b\x97\x2e\x44\xd0\x96\xee\xc8\x1e\xe8\x37\xad\x7c\xfc\xf6\x7c\x81\xd1\xee\x23\xb5\x99\x24\x02\x03\xbd\x7f\x57\x8a\x84\xc5\x1f\xee\x5f\xd0\x7e\xdc\xc3\xb5\xa7\x79\x75\x97\x4b\xf5\xb0\x7a\x2a\x2e\xe0\xe7\xf5\xd7\xeb\x95\xac\xd5\xdc\x63\x4a\xbe\x95\xc3\xd4\x6a\x83\xf8\x7c\x06\xbf\xcd\xa1\xf5\xce\x7a\xb0\xda\xae\x40\x2f\x97\x6a\xe9\x96\x60\xfd\xe2\xa4\x9b\xae\x7b\x0e\x56\xb2\xef\xa1\x4d\x09\x39\x6c\xa6\xe2\x7a\x25\xba\xbf\x27\xcd\xa3\xef\xe8\xdf\xcb\x59\x98\x35\x64\x12\xcf\xc9\x1e\x5c\x88\x23\xe3\xd6\xda\xc0\x37\x1f\xc4\x98\xed\x53\x12\x75\x31\xee\xcc\x77\x97\x14\xed\xa7\xb7\xe6\x34\x46\x0d\x76\x14\x70\xa5\x8e\x8f\x50\xf3\x2b\x00\x00\xff\xff\xee\x62\x97\xde\x3c\x02\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x90\xb1\x6e\xdb\x30\x10\x86\x77\x3e\xc5\xc5\x43\x36\x4a\xed\xd0\xa9\xd0\x90\xba\x01\x1a\xa0\x83\x61\x37\xe8\x60\x78\xa0\xa9\xdf\x16\x2b\x9a\x54\xef\x4e\x4e\x02\xc3\xef\x5e\x50\x82\x5b\xa0\x40\x97\x6e\xbc\xfb\x79\x1f\x3f\xde\xf6\x39\x05\xdd\x99\xcf\x10\xcf\x61\xd0\x90\x53\xf3\x92\xb9\x07\x53\xca\x2d\xe8\x47\x0e\x89\xb4\x03\xf9\x38\x8a\x82\xcd\x1a\x3f\xc7\xc0\x90\x46\xa0\xb6\x07\x27\x44\x3b\x38\x76\x95\x80\xcf\xc1\x83\x18\x11\x4e\x60\xc3\xc9\x1d\x61\x87\x70\xce\x7a\xcb\xcc\xc3\x41\xc1\xff\x35\xb9\xcc\xa9\x0d\xc5\x6e\xe5\xb4\x7b\x7c\x0d\xa2\xd2\xdc\xd5\x67\xc7\x75\xcc\xc7\xba\x58\xda\x59\xbb\x12\x75\xa7\xc1\x98\xed\x66\x9e\xdc\x99\xc7\x57\xf8\x8d\x3a\xd6\x15\xa3\xa9\xf7\x21\xd5\x7b\x27\x1d\x59\x4f\x8b\x97\x2e\x44\xd0\x96\xee\xc8\x1e\xe8\x37\xad\x7c\xfc\xf6\x7c\x81\xd1\xee\x23\xb5\x99\x24\x02\x03\xbd\x7f\x57\x8a\x84\xc5\x1f\xee\x5f\xd0\x7e\xdc\xc3\xb5\xa7\x79\x75\x97\x4b\xf5\xb0\x7a\x2a\x2e\xe0\xe7\xf5\xd7\xeb\x95\xac\xd5\xdc\x63\x4a\xbe\x95\xc3\xd4\x6a\x83\xf8\x7c\x06\xbf\xcd\xa1\xf5\xce\x7a\xb0\xda\xae\x40\x2f\x97\x6a\xe9\x96\x60\xfd\xe2\xa4\x9b\xae\x7b\x0e\x56\xb2\xef\xa1\x4d\x09\x39\x6c\xa6\xe2\x7a\x25\xba\xbf\x27\xcd\xa3\xef\xe8\xdf\xcb\x59\x98\x35\x64\x12\xcf\xc9\x1e\x5c\x88\x23\xe3\xd6\xda\xc0\x37\x1f\xc4\x98\xed\x53\x12\x75\x31\xee\xcc\x77\x97\x14\xed\xa7\xb7\xe6\x34\x46\x0d\x76\x14\x70\xa5\x8e\x8f\x50\xf3\x6b\x00\xee\x62\x97\xde\x3c\x02\x00\x00"),
 		},
 		"/ignition/worker/systemd/kubelet.service": &vfsgen۰CompressedFileInfo{
 			name:             "kubelet.service",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 325,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x8f\x31\x6e\xf3\x30\x0c\x46\x77\x9e\xc2\x17\xb0\x95\x7f\x0d\xa0\x21\x7f\x93\x21\x68\x51\x14\x75\x8b\x0e\x86\x07\x59\x66\x6d\xc2\x32\x65\x88\x94\x93\xdc\xbe\xa8\xd1\x74\xeb\xfc\x1e\x89\xef\x35\xef\x4c\xda\xc2\x11\xc5\x27\x5a\x94\x22\xdb\x29\x77\x18\x50\xf7\xc5\xdb\x88\xc5\x63\xee\x30\x31\x2a\x4a\xf1\x1c\x7b\x2c\x0e\x03\xb2\xc2\x31\xfa\x3c\x23\xab\xdb\x0e\x46\xd5\x45\xf6\xc6\x4c\xbf\x6e\x45\xd1\xf4\xd1\x8b\x81\x0f\xc7\x2a\x96\x51\x2f\x31\x4d\x65\xe4\x40\x8c\x95\xba\x34\xa0\xc2\xe1\x53\x31\xfd\xc1\x1e\x22\xf7\xf4\xfd\xfd\xc5\xe9\x78\xba\x92\xa8\x58\xb3\xba\x64\x42\x1c\x0c\xc7\x1e\xcb\x85\xd6\xa8\x95\xa8\x9b\x17\x80\xa6\xc6\xb4\x92\xc7\x16\x4e\x57\xf4\xb5\xba\xa4\xd6\x64\x49\xa6\x23\x36\x3f\x41\xf0\x8a\xb2\x01\x17\x2e\xee\x26\xb0\x59\x4f\x34\x93\x9e\x59\x31\xad\x2e\xd8\xdd\xdd\xa9\xd1\xdb\x7f\x3b\x80\xe6\xcc\xa2\x2e\x84\x76\x0b\xc1\xfe\xff\xcd\xce\x39\x28\x95\x59\x30\xdd\xb7\xc2\x57\x00\x00\x00\xff\xff\xcb\xcf\xb3\x78\x45\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x8f\x31\x6e\xf3\x30\x0c\x46\x77\x9e\xc2\x17\xb0\x95\x7f\x0d\xa0\x21\x7f\x93\x21\x68\x51\x14\x75\x8b\x0e\x86\x07\x59\x66\x6d\xc2\x32\x65\x88\x94\x93\xdc\xbe\xa8\xd1\x74\xeb\xfc\x1e\x89\xef\x35\xef\x4c\xda\xc2\x11\xc5\x27\x5a\x94\x22\xdb\x29\x77\x18\x50\xf7\xc5\xdb\x88\xc5\x63\xee\x30\x31\x2a\x4a\xf1\x1c\x7b\x2c\x0e\x03\xb2\xc2\x31\xfa\x3c\x23\xab\xdb\x0e\x46\xd5\x45\xf6\xc6\x4c\xbf\x6e\x45\xd1\xf4\xd1\x8b\x81\x0f\xc7\x2a\x96\x51\x2f\x31\x4d\x65\xe4\x40\x8c\x95\xba\x34\xa0\xc2\xe1\x53\x31\xfd\xc1\x1e\x22\xf7\xf4\xfd\xfd\xc5\xe9\x78\xba\x92\xa8\x58\xb3\xba\x64\x42\x1c\x0c\xc7\x1e\xcb\x85\xd6\xa8\x95\xa8\x9b\x17\x80\xa6\xc6\xb4\x92\xc7\x16\x4e\x57\xf4\xb5\xba\xa4\xd6\x64\x49\xa6\x23\x36\x3f\x41\xf0\x8a\xb2\x01\x17\x2e\xee\x26\xb0\x59\x4f\x34\x93\x9e\x59\x31\xad\x2e\xd8\xdd\xdd\xa9\xd1\xdb\x7f\x3b\x80\xe6\xcc\xa2\x2e\x84\x76\x0b\xc1\xfe\xff\xcd\xce\x39\x28\x95\x59\x30\xdd\xb7\xc2\xd7\x00\xcb\xcf\xb3\x78\x45\x01\x00\x00"),
 		},
 		"/ignition/worker/systemd/release-image-pivot.service": &vfsgen۰CompressedFileInfo{
 			name:             "release-image-pivot.service",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 325,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x8f\xbd\x6e\xc3\x30\x0c\x06\x77\x3d\x05\x9b\x21\x9b\xa3\xa9\xa3\x86\xfe\x64\xe8\x16\xd4\x28\x3a\x18\x1e\x18\x99\xb1\x09\xcb\x94\x21\xd2\x6e\xfa\xf6\x85\x03\x64\xe8\x90\x95\xc4\x77\x87\x6b\xbe\x84\xad\x75\xef\xa4\xb1\xf0\x6c\x9c\x25\x9c\x78\xcd\x06\x92\x3b\x02\xcb\x60\x03\x81\x8c\x1d\x14\x4a\x84\x4a\xc0\x13\xf6\xe4\xbe\x51\x4c\x83\x90\xfd\xe4\x32\x56\x59\x12\x0b\x1d\x0c\x4b\x4f\xe6\x5e\x2e\x46\xe5\xc1\xef\x2d\x4b\xc7\x9b\xe5\x84\x36\x1c\xaf\xac\xa6\xe1\xc9\xaf\x58\x7c\xca\xbd\xdf\x9c\xd5\xbc\xe9\x0f\x6a\x38\xcd\xce\x35\x35\x95\x95\x23\xb5\xee\x78\xa5\x58\x1b\x16\x0b\xfe\xcc\xe2\xcf\xa8\x03\x54\x11\x76\x9e\x2c\x7a\x19\xbb\x7f\xe3\x01\xf6\x7b\xb0\xbc\xc4\x01\x1e\xc2\x77\xce\x7d\x92\xde\x88\x59\xaa\x0b\x72\x5a\x0a\xdd\x4f\x35\xc5\xf0\xac\xce\x35\x1f\xa2\x86\x29\xb5\xb7\x62\xea\x5e\x7f\xc3\xb4\x24\xe3\x6a\x51\x2a\xf7\x28\xf7\x17\x00\x00\xff\xff\xa4\xb9\x16\x72\x45\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x8f\xbd\x6e\xc3\x30\x0c\x06\x77\x3d\x05\x9b\x21\x9b\xa3\xa9\xa3\x86\xfe\x64\xe8\x16\xd4\x28\x3a\x18\x1e\x18\x99\xb1\x09\xcb\x94\x21\xd2\x6e\xfa\xf6\x85\x03\x64\xe8\x90\x95\xc4\x77\x87\x6b\xbe\x84\xad\x75\xef\xa4\xb1\xf0\x6c\x9c\x25\x9c\x78\xcd\x06\x92\x3b\x02\xcb\x60\x03\x81\x8c\x1d\x14\x4a\x84\x4a\xc0\x13\xf6\xe4\xbe\x51\x4c\x83\x90\xfd\xe4\x32\x56\x59\x12\x0b\x1d\x0c\x4b\x4f\xe6\x5e\x2e\x46\xe5\xc1\xef\x2d\x4b\xc7\x9b\xe5\x84\x36\x1c\xaf\xac\xa6\xe1\xc9\xaf\x58\x7c\xca\xbd\xdf\x9c\xd5\xbc\xe9\x0f\x6a\x38\xcd\xce\x35\x35\x95\x95\x23\xb5\xee\x78\xa5\x58\x1b\x16\x0b\xfe\xcc\xe2\xcf\xa8\x03\x54\x11\x76\x9e\x2c\x7a\x19\xbb\x7f\xe3\x01\xf6\x7b\xb0\xbc\xc4\x01\x1e\xc2\x77\xce\x7d\x92\xde\x88\x59\xaa\x0b\x72\x5a\x0a\xdd\x4f\x35\xc5\xf0\xac\xce\x35\x1f\xa2\x86\x29\xb5\xb7\x62\xea\x5e\x7f\xc3\xb4\x24\xe3\x6a\x51\x2a\xf7\x28\xf7\x37\x00\xa4\xb9\x16\x72\x45\x01\x00\x00"),
 		},
 		"/ignition/worker/systemd/set-kernel-para.service": &vfsgen۰CompressedFileInfo{
 			name:             "set-kernel-para.service",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 286,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x8e\x4d\x4e\xc3\x30\x10\x85\xf7\x73\x0a\x5f\x20\xc9\x09\xbc\x00\xd1\x05\x62\xd7\x82\x58\x44\x11\x72\x9c\x97\x32\xaa\x63\x9b\x99\x49\xd5\xdc\x1e\x41\x24\xc4\xaa\xbb\xf7\x2b\x7d\xfd\x5b\x66\x1b\xe8\x09\x1a\x85\xab\x71\xc9\x5e\x61\xee\x02\xc9\x48\xae\x06\x09\x6e\x2e\xe2\x5e\xd6\xf1\x27\x31\x28\x1d\xf1\xb5\xb2\x40\xbd\x20\x21\x28\x1a\x5e\xc2\x19\x4d\xe5\x6b\xb1\x56\x21\x57\x8e\xa0\x87\xd9\x20\x77\x17\xd4\x9f\x76\x35\xd0\xeb\x56\xe1\x4b\x86\x7e\x16\xa3\x23\x96\xc0\xf9\xf7\x7f\xb8\xb1\xf9\x0d\x4a\x87\x1b\xe2\xc9\x82\x98\x5f\xca\x54\xa5\x8c\x70\xa3\x7c\x64\xd8\xcc\xc9\x20\xff\x7a\xdd\x34\x5a\x72\x4d\x75\x1d\x2c\x76\xbb\x6d\xa7\xee\xf2\xc7\xdf\xc6\x92\x67\xa2\xfe\x39\xab\x85\x94\x06\x7a\x0f\xd9\x30\x3d\x6e\x7e\x59\x93\x71\xb3\x2a\xa4\xb5\x20\x67\x18\xd1\x77\x00\x00\x00\xff\xff\x73\x48\x6e\xcb\x1e\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x8e\x4d\x4e\xc3\x30\x10\x85\xf7\x73\x0a\x5f\x20\xc9\x09\xbc\x00\xd1\x05\x62\xd7\x82\x58\x44\x11\x72\x9c\x97\x32\xaa\x63\x9b\x99\x49\xd5\xdc\x1e\x41\x24\xc4\xaa\xbb\xf7\x2b\x7d\xfd\x5b\x66\x1b\xe8\x09\x1a\x85\xab\x71\xc9\x5e\x61\xee\x02\xc9\x48\xae\x06\x09\x6e\x2e\xe2\x5e\xd6\xf1\x27\x31\x28\x1d\xf1\xb5\xb2\x40\xbd\x20\x21\x28\x1a\x5e\xc2\x19\x4d\xe5\x6b\xb1\x56\x21\x57\x8e\xa0\x87\xd9\x20\x77\x17\xd4\x9f\x76\x35\xd0\xeb\x56\xe1\x4b\x86\x7e\x16\xa3\x23\x96\xc0\xf9\xf7\x7f\xb8\xb1\xf9\x0d\x4a\x87\x1b\xe2\xc9\x82\x98\x5f\xca\x54\xa5\x8c\x70\xa3\x7c\x64\xd8\xcc\xc9\x20\xff\x7a\xdd\x34\x5a\x72\x4d\x75\x1d\x2c\x76\xbb\x6d\xa7\xee\xf2\xc7\xdf\xc6\x92\x67\xa2\xfe\x39\xab\x85\x94\x06\x7a\x0f\xd9\x30\x3d\x6e\x7e\x59\x93\x71\xb3\x2a\xa4\xb5\x20\x67\x18\xd1\xf7\x00\x73\x48\x6e\xcb\x1e\x01\x00\x00"),
 		},
 		"/terraform": &vfsgen۰DirInfo{
 			name:    "terraform",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime: time.Date(2026, 10, 16, 13, 22, 56, 0, time.UTC),
 		},
 		"/terraform/libvirt": &vfsgen۰DirInfo{
 			name:    "libvirt",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime: time.Date(2026, 10, 16, 13, 22, 56, 0, time.UTC),
 		},
 		"/terraform/libvirt/master.tf.template": &vfsgen۰CompressedFileInfo{
 			name:             "master.tf.template",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 3232,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x56\xdf\x6b\xe4\x36\x10\x7e\xf7\x5f\x31\x15\x7d\xb8\x0b\x8d\x93\x96\xa3\x85\xc2\x52\x4a\x02\xed\x3e\x1c\x0d\x69\xef\xe9\x08\x46\xb1\x67\xbd\x62\x6d\xc9\x95\x64\xa7\xdb\xc5\xff\xfb\x31\xb2\xe4\x1f\xbb\xf6\xde\xde\x06\xb2\xb6\xe5\x99\x6f\x46\xdf\xcc\x37\xb2\x45\xad\xf9\x46\xe9\x12\x0e\x11\x80\xc6\x7f\x6b\xa1\x31\x4b\x2a\xad\x1a\x91\xa1\x36\x6e\x19\xa0\x10\xaf\x8d\xd0\x16\x56\xfe\x19\xc0\xa8\x5a\xa7\x08\x2b\x60\x59\xc9\xd3\x46\xa4\x5c\xdf\x79\x2b\xe6\x4d\x1a\xd4\x46\x28\x49\x36\xf7\xf1\x2f\xf1\xcf\xdd\x7a\x1b\xd1\x7f\x1b\x45\x21\x06\xb0\xe0\xe7\xc0\x6b\x2d\xc8\xe3\x70\x88\x9f\x0a\x6e\x29\xb5\xf8\xd3\xf3\xba\x6d\x19\xf9\x34\x5c\x0b\xfe\x5a\x20\xb0\xb4\xa8\x8d\x45\x9d\x88\xac\x73\xb3\xfb\x0a\x09\x7e\x05\xc6\x6a\x21\xf3\x08\x20\xc3\x0d\xaf\x0b\xeb\xd1\x1e\x3a\x87\xf5\xe3\x09\x94\x90\xc6\x72\x99\x62\x92\xaa\x5a\xda\x0b\xe1\x3e\x72\x42\x8b\x1f\xc8\x65\x19\x71\xab\x8c\x95\xbc\xc4\x63\xd0\x42\x18\xfb\xae\x43\x7e\x3f\x81\x1e\x90\xff\xf4\xae\x6d\xbb\x80\x2d\xaa\x2b\x50\xd7\x4f\xc7\x78\x3d\x91\x55\xf3\x21\x49\x45\xa6\x2f\x24\xa0\xaf\xce\xc3\xfa\xf1\x79\xb9\x3c\x84\x9a\x73\x8b\x6f\x7c\xff\xad\xc0\x7f\x74\x6e\x67\xea\x55\xd5\x57\x50\xf0\xf0\xf4\x69\x91\x53\xcd\xcb\x2b\x10\x9f\x7f\xff\xb8\x88\x98\x09\xb3\xbb\x02\xf2\x51\x98\xdd\x72\xe5\x73\x79\x4d\xe9\x73\x99\x3c\x71\xbb\xed\x60\x35\x7a\x05\x07\xf5\x25\x95\x52\x05\x03\xd6\x5d\x08\x9d\xba\x8f\x6a\xf2\xfd\xa1\xe1\x3a\x1e\x04\xd7\xde\x3a\x9b\x10\x9f\x46\x80\xd0\xf4\x58\x71\xbb\xa5\xc7\xbb\xa6\x1b\x06\x61\x20\xdc\x89\x92\xe7\x68\xee\x4e\x80\xd8\x7c\x26\x8d\x2a\x6a\xd2\x0c\x0b\x37\x7d\x36\x30\x9f\x8f\xb7\xa3\x14\x94\x2a\x3c\x21\xc3\xae\x62\xf7\x43\x00\xd1\x78\x70\x8d\x7b\xed\xaf\xbf\xd7\x94\xa3\x27\xe8\x6b\x79\x0d\x45\x75\x23\x03\xfa\xbf\x15\x50\x6a\xd3\x81\x32\xe4\x3e\x58\xf9\x3d\x9c\xcc\x89\xcf\xce\x23\x16\x32\xc3\xff\x5e\xda\x5b\x17\x27\x02\x78\xe5\x06\x7d\xf4\x44\x64\xa3\xdd\x75\x6b\xb1\xbf\x88\x6c\x60\x60\x88\xb5\xcc\x84\xf8\x7f\x9a\xd5\x24\x25\x8a\x3d\x49\x07\x6e\xe0\xc7\xfb\x9f\x3e\x4c\x2f\xf3\x44\x89\x5c\x0a\x2b\x94\x64\xc0\x86\xdb\x31\x5d\x5f\xe1\xe9\x62\x82\x7a\xf4\xd1\xc6\xcf\xec\x38\x55\xd2\xa2\x24\x59\x58\x2c\xab\x82\x5b\xdc\x88\x02\xdf\x4d\x22\x89\x5c\x4e\x82\xfc\x00\x07\x08\xd1\x8f\xf3\x9e\xcd\x0a\xda\xf7\xf3\xac\x48\xb4\x6f\x4a\xef\x18\xb0\xfe\xee\x30\x69\x8e\xd9\xde\x96\xe8\x0e\xd4\x52\x65\x83\x95\xe4\x6e\x2d\x53\x25\x17\x72\xc9\x33\x2e\x54\xca\x9d\x4c\x79\x96\x69\x34\x06\x0d\xac\xe0\xf3\xc4\x2a\xcc\xfc\x97\x88\xe0\xb6\x69\xe5\xcf\x77\x94\x34\x72\xa8\xd3\xac\xae\xd1\x9d\xd8\x64\x20\xcd\xd1\x7b\x17\xda\x9b\x00\xb8\x80\x89\x92\xc5\xfe\xc8\x51\xab\xda\x62\xf0\xa5\x78\x43\xbf\x9d\xa4\xe2\x6c\xfc\x89\x31\x67\xe3\x5f\x05\x68\x5e\x5b\x65\x2c\x77\xdf\x26\x2e\xe4\x2c\xf5\x1d\x53\x8e\x79\x63\x95\x99\xd5\xee\x85\xe2\xbd\xa8\x07\x08\xbd\xaa\xfd\x86\x5d\xe9\x56\xc0\xc8\xf2\xb6\xe2\xc6\xd8\xad\x56\x75\xbe\x65\x51\xf7\x35\xd4\x90\xe9\x99\x3c\xaa\xfa\x18\xbb\xc4\x52\xe9\xfd\xa2\x87\xe6\xe5\x49\x36\x4a\xa3\x32\xbd\x30\x47\x22\x09\x4b\x71\x7f\x73\x13\x8b\xec\x24\x22\x4f\xb7\x42\xe2\x78\x84\xb9\x93\xc5\xad\xfe\xb3\xaf\x90\x86\xe6\xb8\x1a\xc1\xcc\xb7\x41\x38\xa9\x46\xfe\xbb\xa6\x64\xae\xab\x84\xd9\x79\xa6\xce\x8c\x38\xb2\x9a\xcd\xcc\x75\x81\x17\x54\x22\xa4\x45\xbd\xe1\x29\x7a\xc0\xb0\xde\x1f\x1e\x47\x5a\x8c\xfb\x6b\x37\x20\x60\x90\xfa\x37\x15\x7b\xac\xb1\x39\x3f\x51\x4d\x47\xc4\x77\x24\xe2\xba\x28\x18\xfc\xd6\x29\x72\xc9\xf2\x05\x7e\x05\x32\x74\x31\xde\xb8\xb0\xc9\x46\xe9\xa4\x40\x6e\xf0\x48\x63\xb9\xe6\xd5\x56\xa4\x41\x65\x63\xc2\x57\xc0\x1a\x99\x32\xff\x25\x6f\x2c\xca\x24\x9c\xdc\x3e\x6d\x16\x50\x52\x25\x8d\x2a\x70\x1e\xa4\xb2\xfb\x0e\xc4\x72\x9d\x23\xcd\x57\x27\x3b\x76\xcf\xc2\x37\xbd\xaa\x6d\x55\x5b\x60\xa4\xd4\x4e\x64\x0d\x2f\x6a\x1c\x11\xdf\x29\x31\xee\x74\x18\xdf\xc4\x27\x95\x8b\xef\xe3\x9e\xcb\xa8\xfd\x12\x00\x00\xff\xff\xdd\xf0\xef\x6a\xa0\x0c\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x56\xdd\x6e\xe3\x36\x13\xbd\xd7\x53\xcc\x47\x7c\x17\xbb\x41\xa3\xa4\xc5\xa2\x05\x0a\x18\x45\x91\x00\xad\x2f\x16\x0d\xd2\xee\x55\x10\x08\x8c\x34\x96\x09\x53\xa4\xca\x1f\xa5\xae\xa1\x77\x2f\x48\x91\xfa\xb1\x25\xaf\xd7\x01\x62\x51\xf4\xcc\x99\xe1\x99\x39\x43\x1b\x54\x8a\x6e\xa4\xaa\xe0\x90\x00\x28\xfc\xdb\x32\x85\x45\x56\x2b\xd9\xb0\x02\x95\xf6\xdb\x00\x9c\xbd\x35\x4c\x19\x58\x85\x77\x00\x2d\xad\xca\x11\x56\x40\x8a\x8a\xe6\x0d\xcb\xa9\xba\x0b\x56\x24\x98\x34\xa8\x34\x93\xc2\xd9\xdc\xa7\x3f\xa5\x3f\x76\xfb\x6d\xe2\xfe\xdb\x24\x89\x31\x80\x44\x3f\x0f\x6e\x15\x73\x1e\x87\x43\xfa\xc4\xa9\x71\xa9\xa5\x5f\x9e\xd7\x6d\x4b\x9c\x4f\x43\x15\xa3\x6f\x1c\x81\xe4\xdc\x6a\x83\x2a\x63\x45\xe7\x66\xf6\x35\x02\x00\xac\x40\x1b\xc5\x44\x99\x00\x14\xb8\xa1\x96\x9b\x80\xf6\xd0\x39\xac\x1f\x4f\xa0\x98\xd0\x86\x8a\x1c\xb3\x5c\x5a\x61\x2e\x84\xfb\x4c\x1d\x5a\xfa\xe0\x5c\x96\x11\xb7\x52\x1b\x41\x2b\x3c\x06\xe5\x4c\x9b\x0f\x1d\xf2\xc7\x09\xf4\x80\xfc\x7b\x70\x6d\xdb\x05\x6c\x56\x5f\x81\xba\x7e\x6a\xdb\x05\x22\xeb\xe6\x53\x96\xb3\x42\x5d\x48\x40\x5f\x9d\x87\xf5\xe3\xf3\x72\x79\x1c\x6a\x49\x0d\xbe\xd3\xfd\xb7\x02\xff\xd6\xb9\x9d\xa9\x57\x6d\xaf\xa0\xe0\xe1\xe9\xcb\x22\xa7\x8a\x56\x57\x20\x3e\xff\xfa\x79\x11\xb1\x60\x7a\x77\x05\xe4\x23\xd3\xbb\xe5\xca\x97\xe2\x9a\xd2\x97\x22\x7b\xa2\x66\xdb\xc1\x2a\x0c\x0a\x8e\xea\xcb\x6a\x29\x39\x01\xd2\x3d\x1c\xba\xeb\x3e\x57\x93\xff\x1f\x1a\xaa\xd2\x41\x70\xed\xad\xb7\x89\xf1\xdd\x08\x60\xca\xbd\xd6\xd4\x6c\xdd\xeb\x5d\xd3\x0d\x83\x38\x10\xee\x58\x45\x4b\xd4\x77\x27\x40\x64\x3e\x93\x46\x72\xeb\x34\x43\xe2\xa2\xcf\x06\xe6\xf3\x09\x76\x2e\x05\x29\x79\x20\x64\x38\x55\xea\x3f\x1c\x40\x32\x1e\x5c\xe3\x5e\xfb\xe3\xcf\xb5\xcb\x31\x10\xf4\xb5\xbc\x86\xa2\xfa\x91\x01\xfd\xdf\x0a\x5c\x6a\xd3\x81\x32\xe4\x3e\x58\x85\x33\x9c\xcc\x89\x17\xef\x91\x32\x51\xe0\x3f\xaf\xed\xad\x8f\x93\x00\xbc\x51\x8d\x21\x7a\xc6\x8a\xd1\xe9\xba\xbd\x34\x3c\x58\x31\x30\x30\xc4\x5a\x66\x82\xfd\x3b\xcd\x6a\x92\x92\x8b\x3d\x49\x07\x6e\xe0\xfb\xfb\x1f\x3e\x4d\x1f\xf3\x44\xb1\x52\x30\xc3\xa4\x20\x40\x86\xe5\x98\xae\xaf\xf0\x74\x31\x41\x3d\xfa\xe8\xe0\x67\x4e\x9c\x4b\x61\x50\x18\x58\x81\xc1\xaa\xe6\xd4\xe0\x86\x71\xfc\x30\x89\xc4\x4a\x31\x09\xf2\x1d\x1c\x20\x46\x3f\xce\x7b\x36\x2b\x68\x3f\xce\xb3\x22\xd0\xbc\x4b\xb5\x23\x40\xfa\xd5\x61\xd2\x1c\xb3\xbd\x2d\xd0\x5f\xa8\x95\x2c\x06\x2b\x41\xfd\x5e\x21\x2b\xca\xc4\x92\x67\xca\x65\x4e\xbd\x4c\x69\x51\x28\xd4\x1a\x35\xac\xe0\x65\x62\x15\x67\xfe\x6b\xe2\xe0\xb6\x79\x1d\xee\x77\x14\x6e\xe4\xb8\x4e\x33\xca\xa2\xbf\xb1\x9d\x81\xd0\x47\xdf\x03\x0c\x26\x00\x3e\x60\x26\x05\xdf\x1f\x39\x2a\x69\x0d\x46\x5f\x17\x6f\xe8\xb7\x93\x54\xbc\x4d\xb8\x31\xe6\x6c\xc2\x57\x11\x9a\x5a\x23\xb5\xa1\xca\xc4\x90\xb3\xd4\x77\x4c\x79\xe6\xb5\x91\x7a\x56\xbb\x17\x8a\xf7\xa2\x1e\x70\xe8\xb5\x0d\x07\xf6\xa5\x5b\x01\x71\x96\xb7\x35\xd5\xda\x6c\x95\xb4\xe5\x96\xf8\x33\x00\x34\xce\xf4\x4c\x1e\xb5\x3d\xc6\xae\xb0\x92\x6a\xbf\xe8\xa1\x68\x75\x92\x8d\x54\x28\x75\x2f\xcc\x91\x48\xe2\x56\xda\x2f\x6e\x52\x56\x9c\x44\xa4\xf9\x96\x09\x1c\x8f\x30\x7f\xb3\xf8\xdd\xbf\xf6\x35\xba\xa1\x39\xae\x46\x34\x0b\x6d\x10\x6f\xaa\x91\xff\xae\xa9\x88\xef\x2a\xa6\x77\x81\xa9\x33\x23\xce\x59\xcd\x66\xe6\xbb\x20\x08\x2a\x63\xc2\xa0\xda\xd0\x1c\x03\x60\xdc\xef\x2f\x8f\x23\x2d\xa6\xfd\xb3\x1b\x10\x30\x48\xfd\x9b\x8a\x3d\xd6\xd8\x9c\x1f\xab\x27\x1e\xf0\x3f\x27\x62\xcb\x39\x81\x5f\xe0\xe5\x9c\xe5\x2b\xfc\x0c\xce\xd0\xc7\x78\xa7\xcc\x64\x1b\xa9\x32\x8e\x54\xe3\x91\xc6\x4a\x45\xeb\x2d\xcb\xa3\xca\xc6\x84\xaf\x80\x34\x22\x27\xe1\x97\xbc\x36\x28\xb2\x78\x73\x87\xb4\x49\x44\xc9\xa5\xd0\x92\xe3\x3c\x48\x6d\xf6\x1d\x88\xa1\xaa\x44\x37\x5f\xbd\xec\xc8\x3d\x89\xbf\xe9\xa5\x35\xb5\x35\x40\x9c\x52\x3b\x91\x35\x94\x5b\x1c\x11\xdf\x29\x31\xed\x74\x98\xde\xa4\x27\x95\x4b\xef\xd3\x9e\xcb\xa4\xfd\x6f\x00\xdd\xf0\xef\x6a\xa0\x0c\x00\x00"),
 		},
 		"/terraform/libvirt/worker.tf.template": &vfsgen۰CompressedFileInfo{
 			name:             "worker.tf.template",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
-			uncompressedSize: 2531,
+			modTime:          time.Date(2026, 10, 16, 13, 22, 56, 0, time.UTC),
+			uncompressedSize: 3150,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x56\x51\x8b\xe3\x36\x10\x7e\xf7\xaf\x98\x8a\x3e\xdc\x2d\x5d\x75\x5b\x8e\x16\x0a\xa1\x94\xbd\x87\xee\xc3\xd1\xe5\xda\xa3\x0f\xc7\x61\xb4\xf6\xc4\x19\x62\x4b\xea\x48\xf2\x75\x1b\xfc\xdf\x8b\x6c\x39\xb1\x37\xf6\xde\x5e\x02\x21\x8e\x3c\xf3\xcd\xa7\x4f\xdf\x48\xf2\xc8\xac\xb6\x86\x1b\x38\x64\x00\x8c\xff\x04\x62\x2c\x73\xcb\xa6\xa5\x12\xd9\xf5\xc3\x00\x35\x3d\xb4\xc4\x1e\x36\xe9\x3f\x80\x33\x81\x0b\x84\x0d\x88\xb2\x51\x45\x4b\x85\xe2\xef\x53\x94\x48\x21\x2d\xb2\x23\xa3\x63\xcc\x8d\xfc\x59\xfe\x34\x8c\x77\x59\xfc\x76\x59\x36\xd6\x00\x31\xe6\xf5\xe0\x81\x29\x66\x1c\x0e\xf2\xbe\x56\x3e\x52\x93\x1f\xde\xdf\x75\x9d\x88\x39\xad\x62\x52\x0f\x35\x82\x28\xea\xe0\x3c\x72\x4e\xe5\x90\xe6\x1f\x2d\x46\xf8\x0d\x38\xcf\xa4\xab\x0c\xa0\xc4\xad\x0a\xb5\x4f\x68\xb7\x43\xc2\xdd\xdb\x33\x28\xd2\xce\x2b\x5d\x60\x5e\x98\xa0\x13\x8b\x79\xee\xdf\x86\xf7\xc8\xf2\x36\xbe\x5f\x4f\xdf\x19\xe7\xb5\x6a\xf0\x29\xa1\x9a\x9c\x7f\x35\xb0\x7a\x3d\x83\x3e\x21\xff\x9e\x52\xbb\x6e\x05\x9b\xec\x05\xa8\x77\xf7\xab\x78\x85\x0d\x17\x00\xde\xde\x7f\x58\x45\x64\xd5\x5c\x80\xf8\xfe\xb7\x77\xab\x88\x25\xb9\xfd\x05\x90\x6f\xc9\xed\xd7\x75\xac\xf4\x25\x42\x56\x3a\xbf\x57\x7e\x37\xc0\x32\x26\xf3\x8f\xc6\xcd\x5b\x53\x87\xb8\xee\x62\x7c\x88\x15\xe2\x7a\xf6\x05\xc4\xb7\x87\x56\xb1\x3c\x39\xb6\xbb\x4e\x71\x19\x80\x35\xa6\x5e\x8b\x8a\xef\x62\xcc\xa9\xd7\xa6\x5d\xf1\xc7\x9f\x77\x8d\xaa\x30\x11\x13\x5f\x60\x76\x12\xb3\x77\x39\x4c\x3e\x1b\x88\x85\xe7\x5d\x70\xe2\x3f\x8d\x4b\x1c\xcf\x1c\xff\xb1\xcf\x91\xa4\x4b\xfc\xf7\x53\x77\xdd\xd7\xca\x00\x1e\x94\xc3\xc4\x20\xef\xd1\x5e\xa6\xc5\x42\xc5\x45\x55\xe8\xbf\xa7\xfc\x66\xe4\x22\x8b\x19\x31\xb8\x82\x1f\x6e\x7e\x7c\x33\xff\x59\x96\x8d\x2a\x4d\x9e\x8c\x16\x20\x4e\x8f\x53\xf1\xbe\xa0\xd9\x8b\xa5\x3a\xa2\x4f\xa6\xff\xec\xac\x0b\xa3\x3d\xea\x68\x4f\x8f\x8d\xad\x95\xc7\x2d\xd5\xf8\x6a\x56\x8b\x2a\x3d\x2b\xf3\x1d\x1c\x60\xac\xff\x94\xf9\x22\x2f\xe8\x5e\x2f\xeb\x52\x9a\x46\x51\x54\x45\xa3\xf3\xc6\x2d\x1b\xea\x85\x7e\x7a\x09\x91\x88\x6e\x43\x3a\x74\x1a\x53\xf6\x16\x8a\x91\xd7\x56\x39\xe7\x77\x6c\x42\xb5\x13\xd9\x70\xaa\xb4\x31\xf4\x19\x1e\x36\x3c\xc5\x6e\xb0\x31\xfc\xb8\x9a\xc1\xaa\x39\x63\x63\x18\x8d\x3b\xfa\xa3\xdf\x3e\xe6\x96\x91\xc7\x87\x2b\x49\xe5\x59\x45\x55\xec\x48\xe3\xd4\xe1\x87\x83\x7c\x37\x8c\xfe\xf5\x68\x31\x76\x32\x80\x0a\xde\x38\xaf\xd8\x1f\xc3\x3c\x07\x9c\x6c\x5b\x93\xfc\x7d\xdb\x88\x2c\x6e\x5c\xe4\xf6\x49\xa9\xd4\x73\x54\x4e\xf8\x0d\x63\x32\x46\x2d\x32\xeb\x22\x86\x46\xff\xd9\xf0\x3e\x27\xed\x91\xb7\xaa\xc0\x04\x38\x8e\x3f\xb7\xa7\x69\x4c\x27\xff\xd1\x6a\x5f\xb5\xce\x00\xaa\x2c\x19\x9d\x43\xb7\x98\x47\x76\x6e\xd1\x6f\x36\x20\x74\xa8\x6b\x01\xbf\xc2\xc7\xe7\x22\x3f\xc1\x2f\x10\x03\xfb\x1a\x9f\x15\xf9\x7c\x6b\x38\xaf\x51\x39\x3c\xe9\xda\xcf\xbe\x62\x65\x77\x54\x8c\x97\x9e\xa9\xd6\x1b\x10\xad\x2e\x44\xba\x0c\x39\x8f\x3a\xef\x5f\x6f\x40\x24\xda\x62\x44\x29\x8c\x76\xa6\xc6\x65\x10\xeb\x1f\x07\x10\xaf\xb8\x42\x9f\x5b\xd3\xdf\xaa\xc4\x8d\x18\xaf\x45\x26\x78\x1b\x3c\x08\xb2\xed\x9b\xa1\xbf\x5a\x55\x07\x9c\xac\xe4\xd0\x84\x72\x68\x41\x79\x25\xcf\x16\x4d\xde\xc8\xa3\x96\x59\xf7\x7f\x00\x00\x00\xff\xff\x1e\x1d\x37\x93\xe3\x09\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x56\x51\x6f\xe3\x36\x0c\x7e\xf7\xaf\xe0\xb4\x7b\x48\xba\xc6\x6b\x87\xc3\x06\x0c\x30\x86\xa1\xf7\xb0\x3e\x1c\x56\xdc\x76\xd8\x43\x11\x18\xaa\xcd\x38\x42\x6c\xc9\x93\x68\x77\x59\xe0\xff\x7e\x90\x2c\xc7\x76\x6a\xa7\x6d\x0a\x14\xb1\x65\xf2\xe3\xc7\x4f\x24\x25\x42\xad\xf9\x46\xe9\x02\x0e\x01\x80\xc6\x7f\x2b\xa1\x31\x8d\x4b\xad\x6a\x91\xa2\x36\x6e\x19\x20\x17\x4f\xb5\xd0\x04\x91\x7f\x07\x30\xaa\xd2\x09\x42\x04\x2c\x2d\x78\x52\x8b\x84\xeb\x1f\xbd\x15\xf3\x26\x35\x6a\x23\x94\xb4\x36\x37\xe1\x2f\xe1\xcf\xed\x7a\x13\xd8\xff\x26\x08\xba\x18\xc0\x3a\x3f\x07\x5e\x69\x61\x3d\x0e\x87\xf0\x21\xe7\x64\xa9\x85\x5f\xbf\xdc\x37\x0d\xb3\x3e\x35\xd7\x82\x3f\xe5\x08\x2c\xc9\x2b\x43\xa8\x63\x91\xb6\x6e\xb4\x2f\x11\x00\x20\x02\x43\x5a\xc8\x2c\x00\x48\x71\xc3\xab\x9c\x3c\xda\x5d\xeb\x70\xff\xe9\x05\x94\x90\x86\xb8\x4c\x30\x4e\x54\x25\x3d\x8b\xb1\xef\x3f\x4a\xef\x50\x87\x77\xf6\xfb\xbc\xfb\x56\x19\x92\xbc\xc0\x53\x42\xb9\x30\xb4\x68\x59\x2d\x47\xd0\x3d\xf2\x1f\xde\xb5\x69\x66\xb0\x45\x79\x01\xea\xfd\xc3\x2c\x5e\x52\x56\x17\x00\xde\x3d\x7c\x9d\x45\xd4\xbc\xb8\x00\xf1\xcb\xef\x9f\x67\x11\x53\x61\x76\x17\x40\x7e\x12\x66\x37\xaf\x63\x26\x2f\x11\x32\x93\xf1\x03\xa7\xed\x2c\xac\x32\xa2\xe0\xd9\x25\x3b\xff\xe7\x5f\xf7\xd6\xb3\x45\xce\x55\xc2\xf3\xb6\xe7\xbe\x07\xda\x22\x38\x54\x50\x1b\xf7\x52\xfa\x7e\x80\x44\x15\x68\x60\x23\xb4\xa1\x6b\xd8\xa8\x3c\x57\xcf\x98\xc2\xd3\xbe\x77\x31\x9d\x8f\x54\x29\x42\xa9\x54\x6e\x02\x00\xcf\xd2\x40\x04\xa9\x30\x24\x64\x42\x8b\x44\xc9\x84\xd3\xe2\x71\xd4\x70\x9e\x94\xcf\x99\xad\xaf\xa1\xe6\x3a\x3c\xcd\x76\x69\x73\xaa\x55\x5e\x59\x36\x10\xc1\x11\xea\xc3\xc1\x9a\xf7\x1d\xda\xac\x5a\x2b\x0b\xe4\x7b\x3d\x6e\x57\x42\x4b\xad\x7b\xbe\x0a\x6d\x0b\x2c\xad\x10\x1a\xfd\x7c\x61\x63\x7b\x06\xac\x7b\xb0\x22\x59\x7b\x27\xf4\x7c\xc8\x00\x5c\xfa\x73\x56\xf6\x9b\xb5\xe9\xc7\xd9\x19\x1d\x5e\x61\x36\xc8\xa5\xa5\xe7\xe6\x89\xab\x03\x94\x19\x6d\x17\x6e\x7b\xc3\x6e\x17\x96\xb0\x82\xdb\x37\x25\xb1\xfa\x70\x70\x50\xa1\x90\x29\xfe\x07\x3f\xc0\x6d\xf3\xee\xbc\xc6\xb1\x1f\x4f\xf0\xd6\xaf\xa4\xd6\xb7\xa2\xcf\xa9\xff\x8b\xc6\xb5\xe1\xbe\xf7\x59\x0d\xed\x3c\xcd\x17\xf3\x72\x48\x66\xdd\xac\x5c\xac\x00\xe0\x89\x1b\xf4\x0c\x62\x87\xd6\x25\xd1\xae\x99\x47\xe7\x70\x22\xea\x74\xa5\x8e\x22\x2c\xd7\xbd\x76\x13\xf4\x26\x55\x14\xff\x9f\x26\x33\x0a\x63\x29\x8f\x62\xc0\x15\xdc\xde\xfc\xf4\x71\xfc\x33\xad\xb1\xc8\xa4\x20\xa1\x24\x03\xd6\x3f\x0e\x95\x7e\x45\xe0\x37\xeb\x7a\x44\x1f\xa4\x7f\x36\xeb\x44\x49\x42\x49\x10\x01\x61\x61\x87\x0f\x6e\x44\x8e\x8b\x51\x2c\x91\xc9\x51\x98\x6b\x38\x40\x17\xff\x94\xf9\x24\x2f\x68\x66\x1a\x3e\x55\x05\x17\x56\x15\x89\x86\x94\x99\xae\xbe\x37\x16\xdf\x5b\x88\x58\xf4\xb2\xf2\xf7\x9b\xc2\x8e\xcd\x08\x98\xb5\x5c\x95\xdc\x18\xda\x6a\x55\x65\x5b\x16\xb4\x17\x98\xda\x9a\x9e\xe1\x51\x56\xa7\xd8\x05\x16\x4a\xef\x67\x3d\x34\x2f\x5e\xb0\x51\x1a\x95\x39\xd6\x87\x3b\x4e\xc6\x25\x13\x1e\x1f\xae\x42\x91\xbe\x88\xc8\x93\xad\x90\x38\xac\xf0\xc3\x21\xfc\xdc\xae\xfe\xbd\x2f\xb1\x71\x53\x84\x57\xa4\x0c\x71\x4d\x47\x33\xd2\x15\x0e\x8e\xb1\x81\xff\xae\x2e\x58\x60\x0f\x32\x61\x76\x5e\x29\xdf\xa0\x22\x1d\xf0\x6b\xd7\x42\x6b\x35\xc9\xac\xb1\x18\x12\xe9\x59\xe9\x5d\x2c\x24\xa1\xde\xf0\x04\x3d\x60\xb7\x7e\x6e\x2c\x4a\xf4\x97\xcc\x63\xa9\xbd\x6b\x9f\x01\x78\x9a\x6a\x34\x06\xcd\xa4\x9f\x28\x47\x1e\xf0\x5d\x04\x4c\x56\x79\xce\xe0\x37\x78\x3c\x67\xb9\x86\x5f\xc1\x1a\xba\x18\xcf\x5c\x50\xbc\x51\x3a\xce\x91\x1b\xec\x75\x75\xd9\x67\x9a\x97\x5b\x91\x74\xf7\xeb\xa1\xd6\x11\xb0\x5a\x26\xcc\xdf\xbb\x0d\xa1\x8c\xdd\xe7\x08\x98\xa7\xcd\x3a\x94\x44\x49\xa3\x72\x9c\x06\x29\x69\xdf\x82\x10\xd7\x19\x52\x5c\x2a\x77\x81\x67\x37\xac\xbb\x81\xab\x8a\xca\x8a\x80\x89\xb2\xfe\xd8\xf6\x57\xcd\xf3\x0a\x07\x3b\xd9\x36\x61\xd8\xb6\xa0\x3d\xa0\x4f\x37\x2d\xbc\x09\x8f\x5a\x06\xcd\xb7\x01\x00\xc8\xae\xa1\x34\x4e\x0c\x00\x00"),
 		},
 		"/terraform/openstack": &vfsgen۰DirInfo{
 			name:    "openstack",
-			modTime: time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime: time.Date(2026, 10, 16, 13, 22, 56, 0, time.UTC),
 		},
 		"/terraform/openstack/master.tf.template": &vfsgen۰CompressedFileInfo{
 			name:             "master.tf.template",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
+			modTime:          time.Date(2026, 10, 16, 9, 32, 34, 0, time.UTC),
 			uncompressedSize: 4743,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xcc\x58\x6d\x6f\xdb\x36\x10\xfe\xae\x5f\x71\x13\xf6\xa1\x2d\x16\xd5\xb1\xf3\xb6\x01\xc1\x10\xa4\xc3\x16\x60\x2d\x82\x60\xf9\xb2\x62\x20\x18\x89\x76\x08\x53\x22\xc7\x17\x35\xad\xe1\xff\x3e\x50\x24\x65\x4b\xa6\x6c\xc7\x4d\x80\xa1\x28\x40\x1d\xef\x9e\xe7\xee\xc8\xe3\x9d\xa3\x89\x94\x78\xca\x65\x09\x8b\x04\x40\x92\x7f\x0d\x95\xa4\x40\x42\xf2\x9a\x16\x44\xaa\x46\x0c\xc0\x05\xa9\x94\xc6\xf9\x1c\x2e\xbd\x04\x40\x71\x23\x73\x02\x97\x90\xb6\x20\x47\xc1\xee\xa8\x35\x78\xdf\xae\x52\x6f\x57\x13\xa9\x28\xaf\xac\xe1\x71\x76\x3a\xce\x8e\xdd\xc6\x32\xb1\xff\x97\x49\x12\x30\x20\x5d\x99\x36\xa4\x46\x11\x89\x2a\x5c\x12\x00\x6b\xbc\x58\x64\xb7\x0c\x6b\xcb\x9b\xdd\x2b\x22\xed\xce\x72\x69\xc1\x04\x56\xea\x0b\x97\x05\x6c\x2a\xde\xfa\x2d\xa7\xa8\x49\x85\x2b\xed\x30\x7b\x8a\x7f\xb9\xad\x4f\x2d\x28\x36\xfa\x11\x19\xc9\x22\xa0\x57\x76\xeb\xfe\xee\x4f\xa7\x28\xc9\xcc\x86\x07\x11\xc5\xbb\x66\xcb\xaa\x2d\x93\xa4\xc6\x92\xe2\x07\x46\x20\xcd\x99\x51\x9a\x48\x44\x0b\x17\xa8\xfe\x2a\x88\xb3\x56\x5a\xd2\x6a\x96\x00\x14\x64\x8a\x0d\xd3\x1e\xf0\xda\x19\xdc\x7c\xd8\x80\xa2\x36\x61\x55\x4e\x50\xce\x4d\xa5\xf7\x84\xfb\x88\x2d\x5a\x76\x6d\x4d\x86\x11\x1f\xb9\xd2\x36\x53\x7d\x50\x46\x95\x7e\xe3\x90\xdf\x76\xa0\x57\xc8\x7f\x78\xd3\xe5\x72\xc8\x5b\x61\x0e\x80\xbd\xbe\xbd\x1f\x44\x94\xb8\x3c\x00\xf1\xee\xea\xe3\x20\x62\x41\xd5\xfc\x00\xc8\x0f\x54\xcd\x07\x31\xb9\xa2\x25\x9e\x91\x3d\xcf\xa9\xbd\x47\xbf\xb3\xc6\x3a\x5c\xce\x0e\x36\xae\x31\x65\xf8\x81\x32\xaa\xbf\xa2\x6f\xbc\x7a\x36\xf8\xd5\x3a\xc0\xdf\xbc\x22\xc3\x57\xc2\x56\x64\x81\x35\x3e\x20\x2d\x37\xb3\x0a\xdd\x62\xfd\xb8\x99\x1a\x6d\x4b\x99\xa1\x8a\xe8\xe7\x7a\x7e\x13\x6c\x3f\x11\xfd\x85\xcb\xf9\xb0\xe3\x54\x1c\xe2\xf2\x6d\xdf\x59\xf2\x74\xb8\xb3\xbf\x3d\x45\x9d\x95\xc4\xbf\xab\xab\xd7\x0f\xe5\xbc\x14\x46\x13\x34\x65\xb8\xe6\x12\xd5\xe3\x14\x52\xb7\x76\xa4\x4d\xad\xfb\xf7\xa6\xc6\x32\xeb\x3e\x02\x09\x80\x7f\x34\x37\x15\x42\x4d\x7f\x6e\x34\x33\x5a\x15\xe4\xe9\x9f\x04\xa0\xce\x85\x51\x51\x44\x61\xfa\xba\x12\x97\x10\x05\x97\xb8\xec\xeb\xda\x1a\x8a\xea\xda\x8d\xbe\x32\x55\x48\x98\x07\x46\xf3\xa6\xc7\x48\x43\x06\x13\xf4\xc0\x78\x3e\x57\x9a\x4b\x3c\x23\xa8\xe6\xcc\x94\x04\xd5\x93\x14\x52\xb7\x5e\xcf\xd2\xd6\x0c\xed\x99\x1d\x45\xbf\x91\x3d\x22\xd8\x71\x9a\x8a\xe4\x33\xc9\x8d\x70\xe7\x19\xbe\x9c\xaf\xab\x03\x6b\x7a\xc8\x8f\x0b\x4b\xb5\x6a\x13\xcb\xa3\xb2\xb9\x92\x69\x73\xbd\x54\x2e\xa9\xd0\xbe\xa5\x06\x1c\x98\x72\x09\xf3\x0b\x05\x41\xd3\x9e\x94\x61\xc4\xb7\xef\xa9\xe4\x25\x12\x5c\xea\x86\x60\x3c\x6e\x84\x9a\x07\xd1\x9a\x90\x0a\x3b\x0d\x68\x9e\x73\xd6\x9c\x43\x2e\x5c\xbf\xce\x69\x21\xd7\x5c\x1c\x65\xcd\xbf\xf7\xa3\xb4\xe9\xe3\xdb\xd8\x8e\x8e\x23\x6c\x5e\xd8\x63\xa3\x79\xf9\xdd\x74\x17\xa3\x08\x9d\x17\xbe\x7c\x70\x27\x27\x93\x08\x5d\x90\xbe\x3c\xdf\x78\x72\xfe\x73\xec\xf0\x26\xaf\x16\xe1\x71\x94\x30\x48\x5f\x9e\xef\x2c\x9e\xd2\xb3\xd7\xcb\xe9\xf1\x68\x7c\x72\x11\x8b\xb1\x95\xbf\x0a\xe7\xe9\x28\xce\x79\xfa\x5a\x27\x39\x19\x8d\x46\x31\xce\xc9\xf8\xfc\xec\xfc\xff\xc2\x69\x8a\xbd\x38\x77\x3c\xb6\xed\x33\xdd\x3c\xb6\xe1\xab\xdf\x3e\x01\xe2\x9d\x6c\xb3\x8f\x0e\x69\x0e\xb5\x8c\x66\xcc\x43\x1d\xfb\x9e\xa5\x1f\x05\x13\x00\xdf\xe6\xbb\x3d\x60\xbf\xc6\x44\x72\x23\xed\xd8\xd6\xb4\x00\xe5\x2c\x3f\x6f\x6d\x3d\x59\x58\x67\x16\xd0\x82\x6c\x0c\x90\x81\x7e\x63\x23\xfc\x26\xb3\x23\xe0\x7a\x46\x34\x29\x05\xc3\x9a\x4c\x29\x23\x6f\x3a\x8e\x87\x81\xb1\xe3\xf8\x4f\xb0\x80\x10\xd1\x5e\x91\xc2\xf2\xad\xbd\x63\x95\x1b\x9c\xfc\x35\x8b\xa5\x6b\x35\x9d\xb9\x9b\x48\x9f\x48\x81\xa8\x40\xf5\x49\x9f\x87\x8a\x2e\xc3\x0f\x97\x90\x56\x86\xb1\x14\x7e\xdd\xae\xf8\x0b\x58\xb5\x6d\x57\xd0\xbb\x49\xab\x19\x9a\x32\x8e\x35\xad\x66\x54\x84\x21\xce\x7e\x8b\x3d\xe6\x13\xc1\x39\x0b\x71\xad\x4f\x9d\xbb\x47\xc6\x96\x11\x2b\xc5\x73\x8a\xb5\xaf\x81\xe9\xba\x68\xb3\x10\x06\xfc\x08\x78\x88\x0a\xb8\x84\xdd\x41\x66\x3e\xc4\xec\x5d\x86\x8b\x42\x12\xa5\x36\x2a\xa3\xcd\x6c\xd1\x41\x8c\x54\x6e\xeb\x4e\xf6\x2e\xa3\xc5\xb3\x86\x2d\x3f\x14\x62\xad\x71\xfe\xe8\x12\xd0\x11\xed\x9d\x80\x17\x72\x17\xc0\xd3\xd3\xa2\xe1\xda\x35\xcb\x66\x6e\x15\x0f\x9c\x1b\x2d\x8c\x5e\xff\x79\x53\x4d\xb9\x8b\xa8\xc6\xcc\x90\xf6\x8f\x36\x6d\x45\xf4\x4e\x6f\x87\xf3\xfe\x70\xb3\x51\xb6\x56\x41\xae\xa2\xbe\xfb\x3a\xb8\xca\xf9\x2f\x00\x00\xff\xff\x2c\x2f\x41\x1e\x87\x12\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xcc\x58\x6d\x6f\xdb\x36\x10\xfe\xae\x5f\x71\x13\xf6\xa1\x2d\x16\x55\xb6\xf3\xb6\x01\xc1\x10\xa4\xc3\x16\x60\x2d\x82\x60\xf9\xb2\x62\x20\x18\x89\x76\x08\x53\x22\xc7\x17\x35\xad\xe1\xff\x3e\x90\x14\x65\xcb\xa6\x1c\xc7\x4d\x80\xa1\x28\x40\x1d\xef\x9e\xe7\xee\xc8\xe3\x9d\xa3\x89\x94\x78\xca\x65\x05\x8b\x04\x40\x92\x7f\x0d\x95\xa4\x44\x42\xf2\x86\x96\x44\x2a\x27\x06\xe0\x82\xd4\x4a\xe3\x62\x0e\x17\xad\x04\x40\x71\x23\x0b\x02\x17\x90\x76\x20\x47\xc1\xee\xa8\x33\x78\xdf\xad\xd2\xd6\xae\x21\x52\x51\x5e\x5b\xc3\x51\x76\x32\xce\x46\x7e\x63\x99\xd8\xff\xcb\x24\x09\x18\x90\xae\x4c\x1d\xa9\x51\x44\xa2\x1a\x57\x04\xc0\x1a\x2f\x16\xd9\x0d\xc3\xda\xf2\x66\x77\x8a\x48\xbb\xb3\x5c\x5a\x30\x81\x95\xfa\xc2\x65\x09\xdb\x8a\x37\xed\x96\x57\xd4\xa4\xc6\xb5\xf6\x98\x1b\x8a\x7f\xf9\xad\x4f\x1d\x28\x36\xfa\x01\x19\xc9\x22\xa0\x97\x76\xeb\xee\xf6\x4f\xaf\x28\xc9\xcc\x86\x07\x11\xc5\x5b\xb7\x65\xd5\x96\x49\xd2\x60\x49\xf1\x3d\x23\x90\x16\xcc\x28\x4d\x24\xa2\xa5\x0f\x54\x7f\x15\xc4\x5b\x2b\x2d\x69\x3d\x4b\x00\x4a\x32\xc5\x86\xe9\x16\xf0\xca\x1b\x5c\x7f\xd8\x82\xa2\x36\x61\x75\x41\x50\xc1\x4d\xad\xf7\x84\xfb\x88\x2d\x5a\x76\x65\x4d\x86\x11\x1f\xb8\xd2\x36\x53\x9b\xa0\x8c\x2a\xfd\xc6\x23\xbf\xed\x41\xaf\x90\xff\x68\x4d\x97\xcb\x21\x6f\x85\x39\x00\xf6\xea\xe6\x6e\x10\x51\xe2\xea\x00\xc4\xdb\xcb\x8f\x83\x88\x25\x55\xf3\x03\x20\x3f\x50\x35\x1f\xc4\xe4\x8a\x56\x78\x46\xf6\x3c\xa7\xee\x1e\xfd\xce\x9c\x75\xb8\x9c\x3d\x6c\xdc\x60\xca\xf0\x3d\x65\x54\x7f\x45\xdf\x78\xfd\x6c\xf0\xcb\x75\x80\xbf\x79\x4d\x86\xaf\x84\xad\xc8\x12\x6b\x7c\x40\x5a\xae\x67\x35\xba\xc1\xfa\x61\x3b\x35\xda\x96\x32\x43\x35\xd1\xcf\xf5\xfc\x3a\xd8\x7e\x22\xfa\x0b\x97\xf3\x61\xc7\xa9\x38\xc4\xe5\x9b\x4d\x67\xc9\xe3\xe1\xce\xfe\xf6\x18\x75\x56\x92\xf6\x5d\x5d\xbd\x7e\xa8\xe0\x95\x30\x9a\xa0\x29\xc3\x0d\x97\xa8\x19\xa7\x90\xfa\xb5\x27\x75\xb5\xde\xbe\x37\x0d\x96\x59\xff\x11\x48\x00\xda\x47\x73\x5b\x21\xd4\xf4\x67\xa7\x99\xd1\xba\x24\x8f\xff\x24\x00\x4d\x21\x8c\x8a\x22\x0a\xb3\xa9\x2b\x71\x05\x51\x70\x89\xab\x4d\x5d\x5b\x43\x51\x5d\xbb\xb1\xa9\x4c\x15\x12\xe6\x9e\xd1\xc2\xf5\x18\x69\xc8\x60\x82\xee\x19\x2f\xe6\x4a\x73\x89\x67\x04\x35\x9c\x99\x8a\xa0\x66\x92\x42\xea\xd7\xeb\x59\xda\x99\xa1\x3d\xb3\xa3\xe8\x37\xb2\x47\x04\x4f\x9c\xa6\x22\xc5\x4c\x72\x23\xfc\x79\x86\x2f\xef\xeb\xea\xc0\xc0\x06\xff\xe3\xc2\x52\xad\xda\xc4\xf2\xa8\x72\x57\x32\x75\xd7\x4b\x15\x92\x0a\xdd\xb6\xd4\x80\x03\x53\x2e\x61\x7e\xae\x20\x68\x26\x00\xd2\x30\xe2\xe0\x01\xa6\x92\x57\x48\x70\xa9\x1d\xc1\x78\xec\x84\x9a\x07\xd1\x9a\x90\x0a\x3b\x0d\x68\x5e\x70\xe6\xce\xa1\x10\xa9\x93\x17\xb4\x94\x6b\x2e\xe6\x99\xfb\xf7\x3e\x4f\x5d\x1f\xdf\xc5\x76\x34\x8a\xb0\x1d\x8d\x62\x6c\xb4\xa8\xbe\x9b\xee\x3c\x8f\xd0\x9d\xe7\x31\xba\x17\x08\xee\xf8\x78\x12\xa1\x0b\xd2\x97\xe7\x1b\x4f\xce\x7e\x8e\x1d\xde\xe4\xd5\x22\x1c\x45\x09\x83\xf4\xe5\xf9\x4e\xe3\x29\x3d\x7d\xbd\x9c\x8e\xf2\xf1\xf1\x79\x2c\xc6\x4e\xfe\x2a\x9c\x27\x79\x9c\xf3\xe4\xb5\x4e\x72\x92\xe7\x79\x8c\x73\x32\x3e\x3b\x3d\xfb\xbf\x70\x9a\x72\x2f\xce\x27\x1e\xdb\xee\x99\x76\x8f\x6d\xf8\xda\x6c\x9f\x00\xf1\x4e\xb6\xdd\x47\x87\x34\x87\x5a\x86\x1b\xf3\x50\xcf\x7e\xc3\xb2\x1d\x05\x13\x80\xb6\xcd\xf7\x7b\xc0\x7e\x8d\x89\x14\x46\xda\xb1\xcd\xb5\x00\xe5\x2d\x3f\xef\x6c\x3d\x59\x58\x67\x16\xd0\x82\x6c\x0d\x90\x81\x7e\x6b\x23\xfc\x26\xb3\x23\xe0\x7a\x46\x34\xa9\x04\xc3\x9a\x4c\x29\x23\x6f\x7a\x8e\x87\x81\xb1\xe7\xf8\x4f\xb0\x80\x10\xd1\x5e\x91\xc2\xf2\x6d\x92\x00\xd4\x7e\x70\x6a\xaf\x59\x2c\x5d\xab\xe9\xcc\xdf\x44\xfa\x48\x4a\x44\x05\x6a\x8e\x37\x79\xa8\xe8\x33\xfc\x70\x01\x69\x6d\x18\x4b\xe1\xd7\xdd\x8a\xbf\x80\x55\xdb\x75\x05\x5b\x37\x69\x3d\x43\x53\xc6\xb1\xa6\xf5\x8c\x8a\x30\xc4\xd9\x6f\xb1\xc7\x7c\x22\x38\x67\x21\xae\xf5\xa9\xf3\xe9\x91\xb1\x63\xc4\x4a\xf1\x82\x62\xdd\xd6\xc0\x74\x5d\xb4\x5d\x08\x03\x7e\x04\x3c\x44\x05\x5c\xc0\xd3\x41\x66\x6d\x88\xd9\xbb\x0c\x97\xa5\x24\x4a\x6d\x55\x46\x97\xd9\xb2\x87\x18\xa9\xdc\xce\x9d\xec\x5d\x46\xcb\x67\x0d\x5b\xed\x50\x88\xb5\xc6\xc5\x83\x4f\x40\x4f\xb4\x77\x02\x5e\xc8\x5d\x80\x96\x9e\x96\x00\x3d\xa0\xf8\x2c\x9b\xf9\x55\x3c\x70\x6e\xb4\x30\x7a\xfd\xe7\x4d\x3d\xe5\x3e\xa2\x06\x33\x43\xba\x3f\xda\x74\x15\x41\xc5\x73\x9c\x6f\x0f\x37\xcb\xb3\xb5\x0a\xf2\x15\xf5\xdd\xd7\xc1\x57\xce\x7f\x03\x00\x2c\x2f\x41\x1e\x87\x12\x00\x00"),
 		},
 		"/terraform/openstack/worker.tf.template": &vfsgen۰CompressedFileInfo{
 			name:             "worker.tf.template",
-			modTime:          time.Date(2024, 4, 11, 6, 15, 1, 191819510, time.UTC),
-			uncompressedSize: 4724,
+			modTime:          time.Date(2026, 10, 16, 13, 22, 56, 0, time.UTC),
+			uncompressedSize: 4735,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xcc\x58\x6d\x6b\x23\x37\x10\xfe\xee\x5f\x31\x5d\xfa\xe1\xee\x68\xf6\x1c\x3b\x97\xa4\x85\x50\x42\xae\xb4\x81\xf6\x08\xa1\xa1\xd0\xa3\x08\x65\x77\xec\x08\x6b\x57\xaa\x5e\x36\xb9\x0b\xfe\xef\x45\x2b\x69\xed\x5d\xef\xc6\x8e\x2f\x81\x72\x1c\x68\x47\x33\xcf\x33\x33\xd2\x68\xc6\x31\xa8\x14\x9d\x09\x55\xc0\xe3\x08\x40\xe1\xbf\x96\x29\xcc\x89\x54\xa2\x62\x39\x2a\x5d\x8b\x01\x84\xc4\x52\x1b\x9a\x2d\xe0\x2c\x48\x00\xb4\xb0\x2a\x43\x38\x83\xa4\x01\x39\x88\x76\x07\x8d\xc1\xfb\x66\x95\x04\xbb\x0a\x95\x66\xa2\x74\x86\x87\xe9\x87\x49\x7a\xe8\x37\x96\x23\xf7\x7f\x39\x1a\x45\x0c\x48\x56\xa6\x35\xa9\xd5\xa8\x48\x49\x0b\x04\x70\xc6\x8f\x8f\xe9\x15\xa7\xc6\xf1\xa6\x37\x1a\x95\xdb\x59\x2e\x1d\x98\xa4\x5a\xdf\x0b\x95\xc3\xa6\xe2\x55\xd8\xf2\x8a\x06\x4b\x5a\x1a\x8f\xd9\x51\xfc\xd3\x6f\x7d\x6a\x40\xa9\x35\x77\xc4\x2a\xde\x03\x7a\xee\xb6\x6e\xae\x7f\xf7\x8a\x0a\xe7\x2e\x3c\xe8\x51\xbc\xae\xb7\x9c\xda\x72\x34\xaa\xa8\x62\xf4\x96\x23\x24\x19\xb7\xda\xa0\x22\x2c\xf7\x81\x9a\x2f\x12\xbd\xb5\x36\x8a\x95\xf3\x11\x40\x8e\x33\x6a\xb9\x09\x80\x17\xde\xe0\xf2\xe3\x06\x14\x73\x09\x2b\x33\x24\x99\xb0\xa5\xf1\x70\x6d\xdb\xbf\x84\x5a\xa0\x4a\x2f\xdc\xfe\xb0\xf9\x9d\xd0\xc6\xa5\xa5\xeb\x10\x67\xda\xbc\xf1\x5e\xbd\x6d\x41\xaf\x90\x7f\x0b\xa6\xcb\xe5\x90\x6b\xd2\xee\x01\x7b\x71\x75\x33\x88\xa8\x68\xb1\x07\xe2\xf5\xf9\x1f\x83\x88\x39\xd3\x8b\x3d\x20\x3f\x32\xbd\x18\xc4\x14\x9a\x15\x74\x8e\x3b\x9e\x71\x73\x69\x7e\xe5\xb5\x75\xbc\x89\x2d\x6c\x5a\x51\xc6\xe9\x2d\xe3\xcc\x7c\x21\x5f\x45\xf9\x6c\xf0\xf3\x75\x80\xbf\x45\x89\xc3\x57\xc2\x95\x5f\x4e\x0d\xdd\x23\x2d\x97\xf3\x92\x5c\x51\x73\xb7\x99\x1a\xe3\xea\x96\x93\x12\xcd\x73\x3d\xbf\x8c\xb6\x9f\xd0\xdc\x0b\xb5\x18\x76\x9c\xc9\x7d\x5c\xbe\xea\x3a\x8b\x0f\xfb\x3b\xfb\xcb\x43\xaf\xb3\x0a\xc3\x23\xba\x7a\xea\x48\x26\x0a\x69\x0d\x92\x19\xa7\x95\x50\xa4\x9a\x24\x90\xf8\xb5\x27\xad\x0b\x3b\x3c\x2e\x15\x55\x69\xbb\xe2\x47\x00\xe1\x85\xdc\x54\x88\x35\xfd\xb9\xd6\x4c\x59\x99\xe3\xc3\x3f\x23\x80\x2a\x93\x56\xf7\x22\x4a\xdb\xd5\x55\xb4\x80\x5e\x70\x45\x8b\xae\xae\xab\xa1\x5e\x5d\xb7\xd1\x55\x66\x9a\x48\x7b\xcb\x59\x56\x37\x14\x65\x71\x30\x41\xb7\x5c\x64\x0b\x6d\x84\xa2\x73\x24\x95\xe0\xb6\x40\x52\x4d\x13\x48\xfc\x7a\x3d\x4b\x4f\x66\x68\xc7\xec\x68\xf6\x15\x77\x88\x60\xcb\x69\x6a\xcc\xe6\x4a\x58\xe9\xcf\x33\x7e\x79\x5f\x57\x07\x56\x37\x8c\xef\x1f\x1d\xd5\xaa\x27\x2c\x0f\xee\xeb\x2b\x99\xd4\xd7\x4b\x67\x8a\x49\x13\xfa\x67\xc4\x81\x99\x50\xb0\x38\xd5\x10\x35\xdd\x49\x59\x8e\xa1\x57\xcf\x94\x28\x88\x14\xca\xd4\x04\x93\x49\x2d\x34\x22\x8a\xd6\x84\x4c\xba\xd6\x6f\x44\x26\x78\x7d\x0e\x99\xf4\xcd\x39\x63\xb9\x5a\x73\x71\x9c\xd6\xff\xde\x8f\x93\xba\x69\x3f\xc5\x76\x70\xd8\xc3\x16\x84\x1d\x36\x96\x15\xdf\x4c\x77\x3a\xee\xa1\x0b\xc2\x97\x0f\xee\xe8\x68\xda\x43\x17\xa5\x2f\xcf\x37\x99\x9e\xfc\xd8\x77\x78\xd3\x57\x8b\xf0\xb0\x97\x30\x4a\x5f\x9e\xef\xb8\x3f\xa5\xc7\xaf\x97\xd3\xc3\xf1\xe4\xe8\xb4\x2f\xc6\x46\xfe\x2a\x9c\x1f\xc6\xfd\x9c\x1f\x5e\xeb\x24\xa7\xe3\xf1\xb8\x8f\x73\x3a\x39\x39\x3e\xf9\xbf\x70\xda\x7c\x27\xce\x2d\x8f\x6d\xf3\x4c\xd7\x8f\x6d\xfc\xea\xb6\x4f\x80\xfe\x4e\xb6\xd9\x47\x87\x34\x87\x5a\x46\x3d\xe6\x91\x96\x7d\xc7\x32\x8c\x82\x23\x80\xd0\xe6\xdb\x3d\x60\xb7\xc6\x84\x99\x55\x6e\x6c\xab\x5b\x80\xf6\x96\x9f\x9f\x6c\x3d\x69\x5c\xa7\x0e\xd0\x81\x6c\x0c\x90\x91\x7e\x63\x23\xfe\x00\x73\x23\xe0\x7a\x46\x0c\x16\x92\x53\x83\x33\xc6\xf1\x4d\xcb\xf1\x38\x30\xb6\x1c\xff\x01\x1e\x21\x46\xb4\x53\xa4\xb0\x7c\xeb\xee\x58\xe9\x07\xa7\x70\xcd\xfa\xd2\xb5\x9a\xce\xfc\x4d\x64\x0f\x98\x13\x26\x49\x75\xd4\xe5\x61\xb2\xcd\xf0\xdd\x19\x24\xa5\xe5\x3c\x81\x9f\x9f\x56\xfc\x09\x9c\xda\x53\x57\x30\xb8\xc9\xca\x39\x99\x71\x41\x0d\x2b\xe7\x4c\xc6\x21\xce\x7d\xcb\x1d\xe6\x13\x29\x04\x8f\x71\xad\x4f\x9d\xdb\x47\xc6\x86\x91\x6a\x2d\x32\x46\x4d\xa8\x81\xd9\xba\x68\xb3\x10\x06\xfc\x88\x78\x84\x49\x38\x83\xed\x41\xa6\x21\xc4\xf4\x5d\x4a\xf3\x5c\xa1\xd6\x1b\x95\xd1\x64\x36\x6f\x21\xf6\x54\x6e\xe3\x4e\xfa\x2e\x65\xf9\xb3\x86\xad\x30\x14\x52\x63\x68\x76\xe7\x13\xd0\x12\xed\x9c\x80\x17\x72\x17\x20\xd0\xb3\xbc\xe6\xda\x36\xcb\xa6\x7e\xd5\x1f\xb8\xb0\x46\x5a\xb3\xfe\xf3\xa6\x9c\x09\x1f\x51\x45\xb9\xc5\xe6\x2f\x34\x4d\x45\x74\x4e\x6f\x8b\xf3\xe1\x70\xd3\x71\xba\x56\x41\xbe\xa2\xbe\xf9\x3a\xf8\xca\xf9\x2f\x00\x00\xff\xff\x03\xd8\xc9\xd7\x74\x12\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xcc\x58\x6d\x6b\xe4\xb6\x13\x7f\xef\x4f\x31\x7f\xf3\x7f\x71\x77\x34\x3e\xef\x6e\x9e\x5a\x08\xe5\xc8\x15\x1a\x68\xaf\x21\x6d\x28\xf4\x28\x42\xb1\x67\x37\x62\x65\x4b\xd5\x83\x93\xbb\xb0\xdf\xbd\xc8\xb2\xbd\x6b\xaf\x9c\x6c\xf6\x12\x28\xc7\x81\x3c\x9a\xf9\xfd\x66\x46\x1a\xcd\x6c\x0c\x2a\x45\xe7\x42\x15\xf0\x10\x01\x28\xfc\xc7\x32\x85\x39\x91\x4a\x54\x2c\x47\xa5\x6b\x31\x80\x90\x58\x6a\x43\xb3\x25\x9c\x35\x12\x00\x2d\xac\xca\x10\xce\x20\xee\x40\x0e\x5a\xbb\x83\xce\xe0\x7d\xb7\x8a\x1b\xbb\x0a\x95\x66\xa2\x74\x86\x93\xe4\x68\x9a\x4c\xfc\xc6\x2a\x72\xff\x57\x51\xd4\x62\x40\xbc\x36\xad\x49\xad\x46\x45\x4a\x5a\x20\x80\x33\x7e\x78\x48\x2e\x39\x35\x8e\x37\xb9\xd6\xa8\xdc\xce\x6a\xe5\xc0\x24\xd5\xfa\x4e\xa8\x1c\xb6\x15\x2f\x9b\x2d\xaf\x68\xb0\xa4\xa5\xf1\x98\x03\xc5\x3f\xfc\xd6\xa7\x0e\x94\x5a\x73\x4b\xac\xe2\x01\xd0\x0f\x6e\xeb\xfa\xea\x17\xaf\xa8\x70\xe1\xc2\x83\x80\xe2\x55\xbd\xe5\xd4\x56\x51\x54\x51\xc5\xe8\x0d\x47\x88\x33\x6e\xb5\x41\x45\x58\xee\x03\x35\x5f\x24\x7a\x6b\x6d\x14\x2b\x17\x11\x40\x8e\x73\x6a\xb9\x69\x00\xcf\xbd\xc1\xc5\xc7\x2d\x28\xe6\x12\x56\x66\x48\x32\x61\x4b\xe3\xe1\xfa\xb6\x7f\x0a\xb5\x44\x95\x9c\xbb\xfd\x71\xf3\x5b\xa1\x8d\x4b\xcb\xd0\x21\xce\xb4\x79\xe3\xbd\x7a\xdb\x83\x5e\x23\xff\xdc\x98\xae\x56\x63\xae\x49\xbb\x07\xec\xf9\xe5\xf5\x28\xa2\xa2\xc5\x1e\x88\x57\x1f\x7e\x1d\x45\xcc\x99\x5e\xee\x01\xf9\x91\xe9\xe5\x28\xa6\xd0\xac\xa0\x8b\x7d\x52\xfa\xdb\xef\x17\xce\x72\x88\x4c\x2b\xca\x38\xbd\x61\x9c\x99\x2f\xe4\xab\x28\x71\xc7\xeb\xb3\xbe\xb8\x9b\x00\x7f\x89\x12\xc7\x2f\x84\x2b\xbe\x9c\x1a\xba\x87\xf7\x17\x8b\x92\x5c\x52\x73\xbb\x9d\x18\xe3\xaa\x96\x93\x12\xcd\x73\x3d\xbf\x68\x6d\x3f\xa1\xb9\x13\x6a\x39\xee\x38\x93\xfb\xb8\x7c\x39\x74\x16\xef\xf7\x77\xf6\xa7\xfb\xa0\xb3\x0a\x9b\x27\x74\xfd\xd0\x91\x4c\x14\xd2\x1a\x24\x73\x4e\x2b\xa1\x48\x35\x8d\x21\xf6\x6b\x4f\x5a\x97\x75\xf3\xb4\x54\x54\x25\xfd\x7a\x8f\x00\x9a\xf7\x71\x5b\xa1\xad\xe8\xcf\xb5\x66\xc2\xca\x1c\xef\xff\x8e\x00\xaa\x4c\x5a\x1d\x44\x94\x76\xa8\xab\x68\x01\x41\x70\x45\x8b\xa1\xae\xab\xa0\xa0\xae\xdb\x18\x2a\x33\x4d\xa4\xbd\xe1\x2c\xab\xdb\x89\xb2\x38\x9a\xa0\x1b\x2e\xb2\xa5\x36\x42\xd1\x05\x92\x4a\x70\x5b\x20\xa9\x66\x31\xc4\x7e\xbd\x99\xa5\x47\x33\xb4\x63\x76\x34\xfb\x8a\x3b\x44\xf0\xc4\x69\x6a\xcc\x16\x4a\x58\xe9\xcf\xb3\xfd\xf2\xbe\xae\x0f\xac\x6e\x17\xff\x7f\x70\x54\xeb\x8e\xb0\x3a\xb8\xab\xaf\x64\x5c\x5f\x2f\x9d\x29\x26\x4d\xd3\x3d\x5b\x1c\x98\x0b\x05\xcb\x53\x0d\xad\x66\x04\xa0\x2c\xc7\x1a\x1e\x60\xae\x44\x41\xa4\x50\xa6\x26\x98\x4e\x6b\xa1\x11\xad\x68\x43\xc8\xa4\x6b\xfc\x46\x64\x82\xd7\xe7\x90\xc9\xb8\x96\x67\x2c\x57\x1b\x2e\xa6\x49\xfd\xef\x7d\x1a\xd7\x2d\xfb\x31\xb6\x83\x49\x80\xed\x60\x12\x62\x63\x59\xf1\xcd\x74\xa7\x69\x80\xee\x34\x0d\xd1\xbd\x40\x70\x87\x87\xb3\x00\x5d\x2b\x7d\x79\xbe\xe9\xec\xe4\xfb\xd0\xe1\xcd\x5e\x2d\xc2\x49\x90\xb0\x95\xbe\x3c\xdf\x71\x38\xa5\xc7\xaf\x97\xd3\x49\x3a\x3d\x3c\x0d\xc5\xd8\xc9\x5f\x85\xf3\x28\x0d\x73\x1e\xbd\xd6\x49\xce\xd2\x34\x0d\x71\xce\xa6\x27\xc7\x27\xff\x15\x4e\x9b\xef\xc4\xf9\xc4\x63\xdb\x3d\xd3\xf5\x63\xdb\x7e\x0d\xdb\x27\x40\xb8\x93\x6d\xf7\xd1\x31\xcd\xb1\x96\x51\x0f\x79\xa4\x67\x3f\xb0\x6c\x06\xc1\xa1\x61\xd3\xf3\xfb\x0d\x61\xb7\x2e\x85\x99\x55\x6e\x86\xab\xfb\x81\xf6\x96\x9f\x1f\xed\x43\x49\xbb\x4e\x1c\xa0\x03\xd9\x9a\x26\x5b\xfa\xad\x8d\xf6\xb7\x98\x9b\x07\x37\xd3\x63\xb0\x90\x9c\x1a\x9c\x33\x8e\x6f\x7a\x8e\xb7\xd3\x63\xcf\xf1\xef\xe0\x01\xda\x88\x76\x8a\x14\x56\x6f\xa3\x08\xa0\xf4\x53\x54\x73\xe7\x42\xe9\x5a\x8f\x6a\xfe\x5a\xb2\x7b\xcc\x09\x93\xa4\x3a\x1c\xf2\x30\xd9\x67\xf8\xdf\x19\xc4\xa5\xe5\x3c\x86\x1f\x1f\x57\xfc\x01\x9c\xda\x63\xf7\xb1\x71\x93\x95\x0b\x32\xe7\x82\x1a\x56\x2e\x98\x6c\x27\x3a\xf7\x2d\x77\x18\x56\xa4\x10\xbc\x8d\x6b\x73\x04\x7d\x7a\x7e\xec\x18\xa9\xd6\x22\x63\xd4\x34\x05\x31\xdf\x14\x6d\x57\xc5\x88\x1f\x2d\x1e\x61\x12\xce\xe0\xe9\x20\x93\x26\xc4\xe4\x5d\x42\xf3\x5c\xa1\xd6\x5b\x65\xd2\x65\x36\xef\x21\x06\xca\xb8\x73\x27\x79\x97\xb0\xfc\x59\x93\x57\x33\x21\x52\x63\x68\x76\xeb\x13\xd0\x13\xed\x9c\x80\x17\x72\x17\xa0\xa1\x67\x39\x40\x0f\x28\x3c\xd8\x26\x7e\x15\x0e\x5c\x58\x23\xad\xd9\xfc\xad\x53\xce\x85\x8f\xa8\xa2\xdc\x62\xf7\xc7\x9a\xae\x22\x98\x7c\x8e\xf3\xcd\xe1\x26\x69\xb2\x51\x41\xbe\xa2\xbe\xf9\x3a\xf8\xca\xf9\x77\x00\x5a\x54\xb4\xec\x7f\x12\x00\x00"),
 		},
 	}
 	fs["/"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
@@ -441,10 +513,28 @@ var Assets = func() http.FileSystem {
 		fs["/housekeeper/6daemonset.yaml.template"].(os.FileInfo),
 	}
 	fs["/ignition"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
+		fs["/ignition/bootstrap"].(os.FileInfo),
 		fs["/ignition/controlplane"].(os.FileInfo),
 		fs["/ignition/master"].(os.FileInfo),
 		fs["/ignition/worker"].(os.FileInfo),
 	}
+	fs["/ignition/bootstrap"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
+		fs["/ignition/bootstrap/files"].(os.FileInfo),
+		fs["/ignition/bootstrap/systemd"].(os.FileInfo),
+	}
+	fs["/ignition/bootstrap/files"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
+		fs["/ignition/bootstrap/files/etc"].(os.FileInfo),
+	}
+	fs["/ignition/bootstrap/files/etc"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
+		fs["/ignition/bootstrap/files/etc/nkd"].(os.FileInfo),
+	}
+	fs["/ignition/bootstrap/files/etc/nkd"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
+		fs["/ignition/bootstrap/files/etc/nkd/bootstrap-httpd.sh.template"].(os.FileInfo),
+	}
+	fs["/ignition/bootstrap/systemd"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
+		fs["/ignition/bootstrap/systemd/nkd-bootstrap-httpd.socket.template"].(os.FileInfo),
+		fs["/ignition/bootstrap/systemd/nkd-bootstrap-httpd@.service"].(os.FileInfo),
+	}
 	fs["/ignition/controlplane"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
 		fs["/ignition/controlplane/files"].(os.FileInfo),
 		fs["/ignition/controlplane/systemd"].(os.FileInfo),
@@ -456,6 +546,7 @@ var Assets = func() http.FileSystem {
 		fs["/ignition/controlplane/files/etc/hosts.template"].(os.FileInfo),
 		fs["/ignition/controlplane/files/etc/isulad"].(os.FileInfo),
 		fs["/ignition/controlplane/files/etc/nkd"].(os.FileInfo),
+		fs["/ignition/controlplane/files/etc/sysconfig"].(os.FileInfo),
 		fs["/ignition/controlplane/files/etc/sysctl.d"].(os.FileInfo),
 		fs["/ignition/controlplane/files/etc/systemd"].(os.FileInfo),
 	}
@@ -466,6 +557,9 @@ var Assets = func() http.FileSystem {
 		fs["/ignition/controlplane/files/etc/nkd/init-config.yaml.template"].(os.FileInfo),
 		fs["/ignition/controlplane/files/etc/nkd/node-pivot.sh.template"].(os.FileInfo),
 	}
+	fs["/ignition/controlplane/files/etc/sysconfig"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
+		fs["/ignition/controlplane/files/etc/sysconfig/kubelet.template"].(os.FileInfo),
+	}
 	fs["/ignition/controlplane/files/etc/sysctl.d"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
 		fs["/ignition/controlplane/files/etc/sysctl.d/kubernetes.conf"].(os.FileInfo),
 	}
@@ -495,6 +589,7 @@ var Assets = func() http.FileSystem {
 		fs["/ignition/master/files/etc/hosts.template"].(os.FileInfo),
 		fs["/ignition/master/files/etc/isulad"].(os.FileInfo),
 		fs["/ignition/master/files/etc/nkd"].(os.FileInfo),
+		fs["/ignition/master/files/etc/sysconfig"].(os.FileInfo),
 		fs["/ignition/master/files/etc/sysctl.d"].(os.FileInfo),
 		fs["/ignition/master/files/etc/systemd"].(os.FileInfo),
 	}
@@ -504,6 +599,9 @@ var Assets = func() http.FileSystem {
 	fs["/ignition/master/files/etc/nkd"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
 		fs["/ignition/master/files/etc/nkd/node-pivot.sh.template"].(os.FileInfo),
 	}
+	fs["/ignition/master/files/etc/sysconfig"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
+		fs["/ignition/master/files/etc/sysconfig/kubelet.template"].(os.FileInfo),
+	}
 	fs["/ignition/master/files/etc/sysctl.d"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
 		fs["/ignition/master/files/etc/sysctl.d/kubernetes.conf"].(os.FileInfo),
 	}
@@ -533,6 +631,7 @@ var Assets = func() http.FileSystem {
 		fs["/ignition/worker/files/etc/hosts.template"].(os.FileInfo),
 		fs["/ignition/worker/files/etc/isulad"].(os.FileInfo),
 		fs["/ignition/worker/files/etc/nkd"].(os.FileInfo),
+		fs["/ignition/worker/files/etc/sysconfig"].(os.FileInfo),
 		fs["/ignition/worker/files/etc/sysctl.d"].(os.FileInfo),
 		fs["/ignition/worker/files/etc/systemd"].(os.FileInfo),
 	}
@@ -542,6 +641,9 @@ var Assets = func() http.FileSystem {
 	fs["/ignition/worker/files/etc/nkd"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
 		fs["/ignition/worker/files/etc/nkd/node-pivot.sh.template"].(os.FileInfo),
 	}
+	fs["/ignition/worker/files/etc/sysconfig"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
+		fs["/ignition/worker/files/etc/sysconfig/kubelet.template"].(os.FileInfo),
+	}
 	fs["/ignition/worker/files/etc/sysctl.d"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
 		fs["/ignition/worker/files/etc/sysctl.d/kubernetes.conf"].(os.FileInfo),
 	}
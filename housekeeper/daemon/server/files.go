@@ -0,0 +1,150 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	pb "housekeeper.io/pkg/connection/proto"
+)
+
+const defaultFileMode = 0644
+
+// fileBackup keeps the content of a file before it is overwritten
+type fileBackup struct {
+	path    string
+	existed bool
+	content []byte
+	mode    os.FileMode
+}
+
+// Implements the ApplyFiles
+func (s *Server) ApplyFiles(_ context.Context, req *pb.FileBatchRequest) (*pb.FileBatchResponse, error) {
+	if err := s.beginRequest(); err != nil {
+		return &pb.FileBatchResponse{}, err
+	}
+	defer s.inflight.Done()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &pb.FileBatchResponse{}
+	var backups []fileBackup
+	for _, file := range req.Files {
+		backup, err := backupFile(file.Path)
+		if err != nil {
+			logrus.Errorf("failed to back up %s: %v", file.Path, err)
+			rollbackFiles(backups)
+			return &pb.FileBatchResponse{}, err
+		}
+		backups = append(backups, backup)
+
+		mode := os.FileMode(file.Mode)
+		if mode == 0 {
+			mode = defaultFileMode
+		}
+		if err := writeFileAtomic(file.Path, file.Content, mode); err != nil {
+			logrus.Errorf("failed to write %s: %v", file.Path, err)
+			rollbackFiles(backups)
+			return &pb.FileBatchResponse{}, err
+		}
+		resp.Written = append(resp.Written, file.Path)
+	}
+
+	for _, service := range req.RestartServices {
+		if err := s.restartService(service); err != nil {
+			logrus.Errorf("failed to restart %s: %v", service, err)
+			rollbackFiles(backups)
+			// 恢复文件后重启已重启过的服务，使其重新加载原有配置
+			for _, restarted := range resp.Restarted {
+				if err := s.restartService(restarted); err != nil {
+					logrus.Errorf("failed to restart %s after rollback: %v", restarted, err)
+				}
+			}
+			return &pb.FileBatchResponse{}, err
+		}
+		resp.Restarted = append(resp.Restarted, service)
+	}
+	return resp, nil
+}
+
+func backupFile(path string) (fileBackup, error) {
+	if !filepath.IsAbs(path) {
+		return fileBackup{}, fmt.Errorf("file path %s is not absolute", path)
+	}
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return fileBackup{path: path}, nil
+	}
+	if err != nil {
+		return fileBackup{}, err
+	}
+	if info.IsDir() {
+		return fileBackup{}, fmt.Errorf("file path %s is a directory", path)
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fileBackup{}, err
+	}
+	return fileBackup{path: path, existed: true, content: content, mode: info.Mode().Perm()}, nil
+}
+
+// rollbackFiles restores the backed up files in reverse order
+func rollbackFiles(backups []fileBackup) {
+	for i := len(backups) - 1; i >= 0; i-- {
+		backup := backups[i]
+		if !backup.existed {
+			if err := os.Remove(backup.path); err != nil && !os.IsNotExist(err) {
+				logrus.Errorf("failed to remove %s during rollback: %v", backup.path, err)
+			}
+			continue
+		}
+		if err := writeFileAtomic(backup.path, backup.content, backup.mode); err != nil {
+			logrus.Errorf("failed to restore %s during rollback: %v", backup.path, err)
+		}
+	}
+}
+
+// writeFileAtomic writes to a temporary file in the same directory and renames it into place
+func writeFileAtomic(path string, content []byte, mode os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmpFile, err := ioutil.TempFile(dir, "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmpFile.Write(content); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, mode); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
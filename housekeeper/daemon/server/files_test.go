@@ -0,0 +1,107 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	pb "housekeeper.io/pkg/connection/proto"
+)
+
+func TestApplyFilesRollsBack(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.conf")
+	created := filepath.Join(dir, "created.conf")
+	if err := ioutil.WriteFile(existing, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	runner := newFakeRunner().
+		on("systemctl restart crio", "", errors.New("exit status 1"))
+	s := newTestServer(t, runner)
+
+	_, err := s.ApplyFiles(context.Background(), &pb.FileBatchRequest{
+		Files: []*pb.FileWrite{
+			{Path: existing, Content: []byte("new")},
+			{Path: created, Content: []byte("new")},
+		},
+		RestartServices: []string{"kubelet", "crio"},
+	})
+	if err == nil {
+		t.Fatal("ApplyFiles() succeeded with a failed restart")
+	}
+	content, err := ioutil.ReadFile(existing)
+	if err != nil || string(content) != "old" {
+		t.Errorf("existing file not restored: %q, %v", content, err)
+	}
+	if info, err := os.Stat(existing); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("mode of existing file not restored: %v, %v", info, err)
+	}
+	if _, err := os.Stat(created); !os.IsNotExist(err) {
+		t.Errorf("created file not removed: %v", err)
+	}
+	// 回滚后重新启动已重启的服务以加载原有配置
+	want := []string{"systemctl restart kubelet", "systemctl restart crio", "systemctl restart kubelet"}
+	if got := runner.ran(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ran %q, want %q", got, want)
+	}
+}
+
+func TestApplyFilesRejectsRelativePath(t *testing.T) {
+	s := newTestServer(t, newFakeRunner())
+	if _, err := s.ApplyFiles(context.Background(), &pb.FileBatchRequest{
+		Files: []*pb.FileWrite{{Path: "relative.conf", Content: []byte("new")}},
+	}); err == nil {
+		t.Error("ApplyFiles() accepted a relative path")
+	}
+}
+
+func TestResetNode(t *testing.T) {
+	dir := t.TempDir()
+	adminConf := filepath.Join(dir, "admin.conf")
+	cniDir := filepath.Join(dir, "net.d")
+	runner := newFakeRunner()
+	s := newTestServer(t, runner)
+
+	resp, err := s.resetNode(adminConf, filepath.Join(dir, "config.yaml"), cniDir)
+	if err != nil || resp.GetJoined() || !reflect.DeepEqual(resp.GetActions(), []string{ActionNotJoined}) {
+		t.Fatalf("resetNode() of a node never joined = %v, %v", resp, err)
+	}
+
+	if err := ioutil.WriteFile(adminConf, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(cniDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	resp, err = s.resetNode(adminConf, filepath.Join(dir, "config.yaml"), cniDir)
+	if err != nil {
+		t.Fatalf("resetNode() error = %v", err)
+	}
+	wantActions := []string{ActionKubeadmReset, ActionCNIRemoved, ActionKubeletRestarted}
+	if !resp.GetJoined() || !reflect.DeepEqual(resp.GetActions(), wantActions) {
+		t.Errorf("resetNode() = %v", resp)
+	}
+	if _, err := os.Stat(cniDir); !os.IsNotExist(err) {
+		t.Errorf("cni config not removed: %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	pb "housekeeper.io/pkg/connection/proto"
+)
+
+// os-release file the os version is read from, replaced in tests
+var osReleaseFile = "/etc/os-release"
+
+// 节点的OS和kubeadm版本只在重启后变化，健康检查在该时间内复用检测结果
+const healthCacheTTL = 30 * time.Second
+
+// healthCache caches the versions reported by HealthCheck for healthCacheTTL,
+// concurrent health checks of an expired result share a single detection
+type healthCache struct {
+	mu      sync.Mutex
+	resp    *pb.HealthCheckResponse
+	expires time.Time
+	call    *healthCall
+}
+
+// healthCall is a detection in progress, done is closed once it returns
+type healthCall struct {
+	done chan struct{}
+	resp *pb.HealthCheckResponse
+	err  error
+}
+
+// get returns the cached result, or runs detect once for all concurrent callers.
+// Failed detections are not cached.
+func (c *healthCache) get(detect func() (*pb.HealthCheckResponse, error)) (*pb.HealthCheckResponse, error) {
+	c.mu.Lock()
+	if c.resp != nil && time.Now().Before(c.expires) {
+		resp := c.resp
+		c.mu.Unlock()
+		return resp, nil
+	}
+	if call := c.call; call != nil {
+		c.mu.Unlock()
+		<-call.done
+		return call.resp, call.err
+	}
+	call := &healthCall{done: make(chan struct{})}
+	c.call = call
+	c.mu.Unlock()
+
+	call.resp, call.err = detect()
+
+	c.mu.Lock()
+	c.call = nil
+	if call.err == nil {
+		c.resp = call.resp
+		c.expires = time.Now().Add(healthCacheTTL)
+	}
+	c.mu.Unlock()
+	close(call.done)
+	return call.resp, call.err
+}
+
+// Implements the HealthCheck
+func (s *Server) HealthCheck(_ context.Context, _ *pb.HealthCheckRequest) (*pb.HealthCheckResponse, error) {
+	resp, err := s.health.get(s.detectHealth)
+	if err != nil {
+		return &pb.HealthCheckResponse{}, err
+	}
+	return resp, nil
+}
+
+// detectHealth reads the os version and runs kubeadm for its version
+func (s *Server) detectHealth() (*pb.HealthCheckResponse, error) {
+	osVersion, err := getOSVersion(osReleaseFile)
+	if err != nil {
+		logrus.Errorf("failed to get os version: %v", err)
+		return nil, err
+	}
+	kubeadmVersion, err := s.getKubeadmVersion()
+	if err != nil {
+		return nil, err
+	}
+	return &pb.HealthCheckResponse{
+		OsVersion:   osVersion,
+		KubeVersion: kubeadmVersion,
+		IsMaster:    isMasterNode(),
+	}, nil
+}
+
+// getOSVersion returns the VERSION field of the os-release file
+func getOSVersion(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.SplitN(strings.TrimSpace(scanner.Text()), "=", 2)
+		if len(fields) != 2 || fields[0] != "VERSION" {
+			continue
+		}
+		return strings.Trim(fields[1], `"'`), nil
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no VERSION in %s", path)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	pb "housekeeper.io/pkg/connection/proto"
+)
+
+// withOSRelease points the health check at an os-release file with the content for the test
+func withOSRelease(t *testing.T, content string) {
+	path := filepath.Join(t.TempDir(), "os-release")
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	original := osReleaseFile
+	osReleaseFile = path
+	t.Cleanup(func() { osReleaseFile = original })
+}
+
+// blockingRunner blocks kubeadm until release is closed, so the health checks overlap
+type blockingRunner struct {
+	*fakeRunner
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (b *blockingRunner) Run(name string, args ...string) ([]byte, error) {
+	b.once.Do(func() { close(b.started) })
+	<-b.release
+	return b.fakeRunner.Run(name, args...)
+}
+
+func TestHealthCheckCoalescesDetection(t *testing.T) {
+	withOSRelease(t, "NAME=\"NestOS\"\nVERSION=\"22.03.20231228.0\"\n")
+	runner := &blockingRunner{
+		fakeRunner: newFakeRunner().on(kubeadmCmd+" version -o short", "v1.23.10\n", nil),
+		started:    make(chan struct{}),
+		release:    make(chan struct{}),
+	}
+	s := &Server{Runner: runner}
+
+	const callers = 8
+	var wg sync.WaitGroup
+	responses := make([]*pb.HealthCheckResponse, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			responses[i], errs[i] = s.HealthCheck(context.Background(), &pb.HealthCheckRequest{})
+		}(i)
+	}
+	<-runner.started
+	close(runner.release)
+	wg.Wait()
+
+	// 缓存期内的健康检查复用检测结果
+	if _, err := s.HealthCheck(context.Background(), &pb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("HealthCheck() error = %v", err)
+	}
+	if got := runner.count(kubeadmCmd + " version -o short"); got != 1 {
+		t.Errorf("kubeadm version ran %d times, want 1", got)
+	}
+	for i := 0; i < callers; i++ {
+		if errs[i] != nil {
+			t.Fatalf("HealthCheck() error = %v", errs[i])
+		}
+		if responses[i].GetOsVersion() != "22.03.20231228.0" || responses[i].GetKubeVersion() != "v1.23.10" {
+			t.Errorf("HealthCheck() = %v", responses[i])
+		}
+	}
+}
+
+func TestHealthCheckDoesNotCacheFailures(t *testing.T) {
+	withOSRelease(t, "VERSION=22.03\n")
+	runner := newFakeRunner().
+		on(kubeadmCmd+" version -o short", "", errors.New("exit status 1")).
+		on(kubeadmCmd+" version -o short", "v1.23.10", nil)
+	s := &Server{Runner: runner}
+	if _, err := s.HealthCheck(context.Background(), &pb.HealthCheckRequest{}); err == nil {
+		t.Fatal("HealthCheck() succeeded with a failed kubeadm")
+	}
+	resp, err := s.HealthCheck(context.Background(), &pb.HealthCheckRequest{})
+	if err != nil || resp.GetKubeVersion() != "v1.23.10" {
+		t.Errorf("HealthCheck() after a failure = %v, %v", resp, err)
+	}
+}
+
+func TestGetOSVersion(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "os-release")
+	if err := ioutil.WriteFile(path, []byte("NAME=NestOS\nVERSION_ID=22.03\nVERSION='22.03-LTS-SP2'\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if version, err := getOSVersion(path); err != nil || version != "22.03-LTS-SP2" {
+		t.Errorf("getOSVersion() = %q, %v", version, err)
+	}
+	if err := ioutil.WriteFile(path, []byte("NAME=NestOS\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getOSVersion(path); err == nil {
+		t.Error("getOSVersion() succeeded without VERSION")
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"housekeeper.io/pkg/constants"
+)
+
+// subdirectories of the hook directory, named after the phase their scripts run in
+const (
+	preUpgradeHooks  = "pre-upgrade"
+	postUpgradeHooks = "post-upgrade"
+)
+
+func (s *Server) hookDir() string {
+	if s.HookDir == "" {
+		return constants.HookDir
+	}
+	return s.HookDir
+}
+
+// runHooks runs the executable files of the phase in the order of their names,
+// it stops at the first hook exiting non-zero. A missing phase directory has no hooks.
+func (s *Server) runHooks(phase string) error {
+	dir := filepath.Join(s.hookDir(), phase)
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		logrus.Errorf("failed to read %s hooks: %v", phase, err)
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.Mode().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		hook := filepath.Join(dir, entry.Name())
+		if entry.Mode().Perm()&0111 == 0 {
+			logrus.Warnf("skipping %s hook %s, it is not executable", phase, hook)
+			continue
+		}
+		logrus.Infof("running %s hook %s", phase, hook)
+		output, err := s.cmdRunner().Run(hook)
+		if err != nil {
+			return fmt.Errorf("%s hook %s failed: %w: %s", phase, hook, err, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
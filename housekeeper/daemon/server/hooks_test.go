@@ -0,0 +1,62 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRunHooks(t *testing.T) {
+	runner := newFakeRunner()
+	s := newTestServer(t, runner)
+	if err := s.runHooks(preUpgradeHooks); err != nil {
+		t.Fatalf("runHooks() without a hook directory = %v", err)
+	}
+
+	dir := filepath.Join(s.HookDir, preUpgradeHooks)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	hooks := map[string]os.FileMode{"20-drain-lb": 0755, "10-backup": 0755, ".hidden": 0755, "30-disabled": 0644}
+	for name, mode := range hooks {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), nil, mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.runHooks(preUpgradeHooks); err != nil {
+		t.Fatalf("runHooks() error = %v", err)
+	}
+	want := []string{filepath.Join(dir, "10-backup"), filepath.Join(dir, "20-drain-lb")}
+	if got := runner.ran(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ran %q, want %q", got, want)
+	}
+
+	// 钩子失败时不再执行后续钩子
+	runner = newFakeRunner().on(filepath.Join(dir, "10-backup"), "", errors.New("exit status 1"))
+	s.Runner = runner
+	if err := s.runHooks(preUpgradeHooks); err == nil {
+		t.Fatal("runHooks() succeeded with a failed hook")
+	}
+	if got := runner.ran(); !reflect.DeepEqual(got, want[:1]) {
+		t.Errorf("ran %q after a failed hook", got)
+	}
+}
@@ -16,14 +16,20 @@ limitations under the License.
 package server
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strconv"
 	"syscall"
 
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"housekeeper.io/pkg/connection"
 	pb "housekeeper.io/pkg/connection/proto"
 	"housekeeper.io/pkg/constants"
 )
@@ -64,12 +70,54 @@ func Run() error {
 		return err
 	}
 	//get grpc server
-	s := grpc.NewServer()
-	pb.RegisterUpgradeClusterServer(s, &Server{})
+	// 允许控制器在长时间的升级请求中发送心跳，否则连接会被服务端以 too_many_pings 关闭
+	serverOpts := []grpc.ServerOption{grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+		MinTime:             constants.KeepaliveMinTime,
+		PermitWithoutStream: true,
+	})}
+	if connection.Insecure() {
+		logrus.Warnf("%s is set, serving without TLS", constants.EnvInsecure)
+	} else {
+		tlsConfig, err := connection.ServerTLSConfig(constants.PKIDir)
+		if err != nil {
+			logrus.Errorf("failed to load TLS config: %v", err)
+			return err
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	s := grpc.NewServer(serverOpts...)
+	server := &Server{RebaseMaxAttempts: constants.RebaseMaxAttempts, Runner: execRunner{}}
+	if value := os.Getenv(constants.EnvRebaseMaxAttempts); value != "" {
+		attempts, err := strconv.Atoi(value)
+		if err != nil || attempts <= 0 {
+			logrus.Warnf("ignoring invalid %s %q", constants.EnvRebaseMaxAttempts, value)
+		} else {
+			server.RebaseMaxAttempts = attempts
+		}
+	}
+	pb.RegisterUpgradeClusterServer(s, server)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	defer signal.Stop(signals)
+	go func() {
+		sig := <-signals
+		logrus.Infof("received %v, waiting for the running upgrade before stopping", sig)
+		ctx, cancel := context.WithTimeout(context.Background(), constants.ShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			logrus.Errorf("stopping housekeeper-daemon before the running upgrade finished: %v", err)
+			s.Stop()
+			return
+		}
+		s.GracefulStop()
+	}()
+
 	logrus.Info("housekeeper-daemon start serving")
 	if err := s.Serve(lis); err != nil {
 		logrus.Errorf("housekeeper-daemon server error: %v", err)
 		return err
 	}
+	logrus.Info("housekeeper-daemon stopped")
 	return nil
 }
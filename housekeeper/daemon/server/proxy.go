@@ -0,0 +1,233 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// 单次读取的上限，也是令牌桶的容量
+	proxyChunkSize   = 32 * 1024
+	proxyDialTimeout = 30 * time.Second
+)
+
+// hop-by-hop headers are not forwarded by the proxy
+var hopHeaders = []string{
+	"Connection",
+	"Proxy-Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// rateUnits are the suffixes of a rate, powers of 1024 like systemd uses
+var rateUnits = map[byte]float64{'K': 1 << 10, 'M': 1 << 20, 'G': 1 << 30, 'T': 1 << 40}
+
+// parseRateLimit returns the bytes per second of a rate such as 500K or 10M
+func parseRateLimit(limit string) (rate.Limit, error) {
+	if !rateLimitPattern.MatchString(limit) {
+		return 0, fmt.Errorf("invalid rate %q, expected bytes per second such as 10M", limit)
+	}
+	multiplier := float64(1)
+	if unit, ok := rateUnits[limit[len(limit)-1]]; ok {
+		multiplier = unit
+		limit = limit[:len(limit)-1]
+	}
+	value, err := strconv.ParseFloat(limit, 64)
+	if err != nil {
+		return 0, err
+	}
+	return rate.Limit(value * multiplier), nil
+}
+
+// pullProxy is an http proxy on the loopback interface rpm-ostreed pulls the os image through,
+// the downloads of all connections share one rate limit. https is tunneled with CONNECT,
+// so the limit applies without terminating the tls connection to the registry.
+type pullProxy struct {
+	limiter   *rate.Limiter
+	listener  net.Listener
+	server    *http.Server
+	transport *http.Transport
+	// the server does not close hijacked connections, tunnels are closed by Close
+	mu      sync.Mutex
+	tunnels map[net.Conn]struct{}
+}
+
+// startPullProxy listens on a free loopback port and serves the proxy until Close is called
+func startPullProxy(limit rate.Limit) (*pullProxy, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	p := &pullProxy{
+		limiter:  rate.NewLimiter(limit, proxyChunkSize),
+		listener: listener,
+		tunnels:  make(map[net.Conn]struct{}),
+		transport: &http.Transport{
+			DialContext:           (&net.Dialer{Timeout: proxyDialTimeout}).DialContext,
+			TLSHandshakeTimeout:   proxyDialTimeout,
+			ResponseHeaderTimeout: proxyDialTimeout,
+		},
+	}
+	p.server = &http.Server{Handler: p}
+	go func() {
+		if err := p.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logrus.Errorf("pull proxy stopped: %v", err)
+		}
+	}()
+	return p, nil
+}
+
+// URL returns the proxy url set as http_proxy and https_proxy of rpm-ostreed
+func (p *pullProxy) URL() string {
+	return "http://" + p.listener.Addr().String()
+}
+
+// Close stops the proxy and closes the connections it serves
+func (p *pullProxy) Close() error {
+	err := p.server.Close()
+	p.transport.CloseIdleConnections()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for conn := range p.tunnels {
+		conn.Close()
+	}
+	return err
+}
+
+// track registers a tunneled connection closed by Close, it returns a function removing it again
+func (p *pullProxy) track(conn net.Conn) func() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tunnels[conn] = struct{}{}
+	return func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		delete(p.tunnels, conn)
+	}
+}
+
+func (p *pullProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodConnect {
+		p.tunnel(w, r)
+		return
+	}
+	p.forward(w, r)
+}
+
+// tunnel relays a CONNECT connection, only the direction from the registry to rpm-ostreed is limited
+func (p *pullProxy) tunnel(w http.ResponseWriter, r *http.Request) {
+	upstream, err := net.DialTimeout("tcp", r.Host, proxyDialTimeout)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer upstream.Close()
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "hijacking is not supported", http.StatusInternalServerError)
+		return
+	}
+	client, buffered, err := hijacker.Hijack()
+	if err != nil {
+		logrus.Errorf("failed to hijack the connection to %s: %v", r.Host, err)
+		return
+	}
+	defer client.Close()
+	defer p.track(client)()
+	defer p.track(upstream)()
+	if _, err := client.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n")); err != nil {
+		return
+	}
+
+	go func() {
+		// 客户端已缓冲的数据先发给上游
+		_, _ = io.Copy(upstream, buffered)
+		if tcp, ok := upstream.(*net.TCPConn); ok {
+			_ = tcp.CloseWrite()
+		}
+	}()
+	_, _ = io.Copy(client, p.limitedReader(r.Context(), upstream))
+}
+
+// forward sends a plain http request to the registry and limits the response body
+func (p *pullProxy) forward(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Host == "" {
+		http.Error(w, "expected an absolute url", http.StatusBadRequest)
+		return
+	}
+	outReq := r.Clone(r.Context())
+	outReq.RequestURI = ""
+	for _, header := range hopHeaders {
+		outReq.Header.Del(header)
+	}
+	resp, err := p.transport.RoundTrip(outReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	for _, header := range hopHeaders {
+		resp.Header.Del(header)
+	}
+	for key, values := range resp.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, p.limitedReader(r.Context(), resp.Body))
+}
+
+func (p *pullProxy) limitedReader(ctx context.Context, r io.Reader) io.Reader {
+	return &limitedReader{ctx: ctx, reader: r, limiter: p.limiter}
+}
+
+// limitedReader waits for the limiter before returning the bytes it read
+type limitedReader struct {
+	ctx     context.Context
+	reader  io.Reader
+	limiter *rate.Limiter
+}
+
+func (l *limitedReader) Read(b []byte) (int, error) {
+	if len(b) > l.limiter.Burst() {
+		b = b[:l.limiter.Burst()]
+	}
+	n, err := l.reader.Read(b)
+	if n > 0 {
+		if waitErr := l.limiter.WaitN(l.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
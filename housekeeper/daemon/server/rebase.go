@@ -0,0 +1,219 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	pb "housekeeper.io/pkg/connection/proto"
+	"housekeeper.io/pkg/constants"
+)
+
+const (
+	rebaseInitialBackoff = 10 * time.Second
+	rebaseMaxBackoff     = 2 * time.Minute
+
+	// rpm-ostree客户端只是通过D-Bus调用rpm-ostreed，镜像由该服务拉取并写入/sysroot下的ostree仓库
+	rpmOstreedService = "rpm-ostreed.service"
+	ostreeRepoPath    = "/sysroot"
+	// runtime drop-in of rpm-ostreed pointing it at the pull proxy, removed on reboot
+	rpmOstreedDropInDir = "/run/systemd/system/rpm-ostreed.service.d"
+	pullProxyDropIn     = "50-housekeeper-pull-proxy.conf"
+)
+
+// rateLimitPattern matches a rate in bytes per second such as 500K or 10M, the format of systemd IOWriteBandwidthMax
+var rateLimitPattern = regexp.MustCompile(`^[1-9][0-9]*[KMGT]?$`)
+
+// rpm-ostree输出中表示可重试的网络错误
+var retriableRebaseErrors = []string{
+	"timeout",
+	"timed out",
+	"connection refused",
+	"connection reset",
+	"no such host",
+	"temporary failure",
+	"tls handshake",
+	"unexpected eof",
+	"too many requests",
+	"service unavailable",
+	"bad gateway",
+}
+
+// sleep waits between rebase attempts, replaced to skip the backoff
+var sleep = time.Sleep
+
+// checkDiskWriteLimit rejects a pull disk write limit systemd does not accept, an empty limit is valid
+func checkDiskWriteLimit(limit string) error {
+	if limit != "" && !rateLimitPattern.MatchString(limit) {
+		return rejected(codes.InvalidArgument,
+			fmt.Errorf("invalid pull disk write limit %q, expected bytes per second such as 10M", limit))
+	}
+	return nil
+}
+
+// checkBandwidthLimit rejects an invalid pull bandwidth limit, an empty limit is valid
+func checkBandwidthLimit(limit string) error {
+	if limit != "" && !rateLimitPattern.MatchString(limit) {
+		return rejected(codes.InvalidArgument,
+			fmt.Errorf("invalid pull bandwidth limit %q, expected bytes per second such as 10M", limit))
+	}
+	return nil
+}
+
+// throttledRebase rebases with the limits of the request applied to rpm-ostreed, it rebases
+// directly when no limit is set
+func (s *Server) throttledRebase(req *pb.UpgradeRequest) error {
+	if req.PullDiskWriteLimit != "" {
+		restore, err := s.limitDiskWrites(req.PullDiskWriteLimit)
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
+	if req.PullBandwidthLimit != "" {
+		restore, err := s.limitPullBandwidth(req.PullBandwidthLimit)
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
+	return s.rebaseWithRetry(rebaseArgs(req))
+}
+
+// limitDiskWrites limits the disk writes of rpm-ostreed to the ostree repo by IOWriteBandwidthMax,
+// so writing the pulled os image and the new deployment does not starve the disk of the workloads.
+// It is not a network limit: the cgroup only throttles block device writes. The limit is only set
+// for the runtime of rpm-ostreed, the returned function removes it.
+func (s *Server) limitDiskWrites(limit string) (func(), error) {
+	if err := checkDiskWriteLimit(limit); err != nil {
+		return nil, err
+	}
+	if output, err := s.cmdRunner().Run("systemctl", "set-property", "--runtime", rpmOstreedService,
+		fmt.Sprintf("IOWriteBandwidthMax=%s %s", ostreeRepoPath, limit)); err != nil {
+		return nil, fmt.Errorf("failed to limit the disk writes of %s: %w: %s", rpmOstreedService, err,
+			strings.TrimSpace(string(output)))
+	}
+	logrus.Infof("pulling the os image with disk writes limited to %s/s", limit)
+	return func() {
+		if output, err := s.cmdRunner().Run("systemctl", "set-property", "--runtime", rpmOstreedService,
+			"IOWriteBandwidthMax="); err != nil {
+			logrus.Errorf("failed to remove the disk write limit of %s: %v: %s", rpmOstreedService, err,
+				strings.TrimSpace(string(output)))
+		}
+	}, nil
+}
+
+// limitPullBandwidth starts a rate limited proxy and points rpm-ostreed at it with a runtime drop-in.
+// rpm-ostreed is stopped so D-Bus activation starts it again with the proxy for the rebase.
+// The returned function removes the drop-in, stops rpm-ostreed again and stops the proxy.
+func (s *Server) limitPullBandwidth(limit string) (func(), error) {
+	if err := checkBandwidthLimit(limit); err != nil {
+		return nil, err
+	}
+	bytesPerSecond, err := parseRateLimit(limit)
+	if err != nil {
+		return nil, rejected(codes.InvalidArgument, err)
+	}
+	proxy, err := startPullProxy(bytesPerSecond)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start the pull proxy: %w", err)
+	}
+	dropIn := filepath.Join(s.dropInDir(), pullProxyDropIn)
+	restore := func() {
+		if err := os.Remove(dropIn); err != nil && !os.IsNotExist(err) {
+			logrus.Errorf("failed to remove %s: %v", dropIn, err)
+		}
+		if err := s.reloadRpmOstreed(); err != nil {
+			logrus.Errorf("failed to restore %s without the pull proxy: %v", rpmOstreedService, err)
+		}
+		if err := proxy.Close(); err != nil {
+			logrus.Errorf("failed to stop the pull proxy: %v", err)
+		}
+	}
+	if err := os.MkdirAll(s.dropInDir(), 0755); err != nil {
+		restore()
+		return nil, err
+	}
+	content := fmt.Sprintf("[Service]\nEnvironment=\"http_proxy=%[1]s\" \"https_proxy=%[1]s\" "+
+		"\"HTTP_PROXY=%[1]s\" \"HTTPS_PROXY=%[1]s\"\n", proxy.URL())
+	if err := ioutil.WriteFile(dropIn, []byte(content), 0644); err != nil {
+		restore()
+		return nil, err
+	}
+	if err := s.reloadRpmOstreed(); err != nil {
+		restore()
+		return nil, fmt.Errorf("failed to point %s at the pull proxy: %w", rpmOstreedService, err)
+	}
+	logrus.Infof("pulling the os image with the bandwidth limited to %s/s through %s", limit, proxy.URL())
+	return restore, nil
+}
+
+// reloadRpmOstreed loads the drop-ins of rpm-ostreed, which take effect the next time it is started
+func (s *Server) reloadRpmOstreed() error {
+	for _, args := range [][]string{{"daemon-reload"}, {"stop", rpmOstreedService}} {
+		if output, err := s.cmdRunner().Run("systemctl", args...); err != nil {
+			return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
+
+// rebaseWithRetry runs rpm-ostree with the rebase arguments, retrying network errors with exponential backoff
+func (s *Server) rebaseWithRetry(args []string) error {
+	maxAttempts := s.RebaseMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = constants.RebaseMaxAttempts
+	}
+	backoff := rebaseInitialBackoff
+	for attempt := 1; ; attempt++ {
+		output, err := s.cmdRunner().Run("rpm-ostree", args...)
+		if err == nil {
+			return nil
+		}
+		if !isRetriableRebaseError(string(output)) {
+			return fmt.Errorf("rpm-ostree rebase failed: %w: %s", err, strings.TrimSpace(string(output)))
+		}
+		if attempt >= maxAttempts {
+			return fmt.Errorf("rpm-ostree rebase failed after %d attempts: %w: %s", attempt, err,
+				strings.TrimSpace(string(output)))
+		}
+		logrus.Warnf("rpm-ostree rebase attempt %d/%d failed, retrying in %v: %v", attempt, maxAttempts, backoff, err)
+		sleep(backoff)
+		backoff *= 2
+		if backoff > rebaseMaxBackoff {
+			backoff = rebaseMaxBackoff
+		}
+	}
+}
+
+func isRetriableRebaseError(output string) bool {
+	output = strings.ToLower(output)
+	for _, msg := range retriableRebaseErrors {
+		if strings.Contains(output, msg) {
+			return true
+		}
+	}
+	return false
+}
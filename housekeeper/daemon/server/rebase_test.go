@@ -0,0 +1,224 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+	pb "housekeeper.io/pkg/connection/proto"
+)
+
+// skipBackoff replaces the backoff between rebase attempts for the test, it returns the delays waited for
+func skipBackoff(t *testing.T) *[]time.Duration {
+	var delays []time.Duration
+	sleep = func(delay time.Duration) { delays = append(delays, delay) }
+	t.Cleanup(func() { sleep = time.Sleep })
+	return &delays
+}
+
+func TestRebaseWithRetry(t *testing.T) {
+	rebase := "rpm-ostree rebase nestos:stable --bypass-driver"
+	exitErr := errors.New("exit status 1")
+	networkErr := fakeResult{output: "connection reset by peer", err: exitErr}
+	tests := []struct {
+		name      string
+		results   []fakeResult
+		wantRuns  int
+		wantError bool
+	}{
+		{name: "success", results: []fakeResult{{}}, wantRuns: 1},
+		{name: "network error retried", results: []fakeResult{networkErr, {}}, wantRuns: 2},
+		{name: "fails twice then succeeds", results: []fakeResult{networkErr, {output: "i/o timeout", err: exitErr}, {}}, wantRuns: 3},
+		{name: "other error not retried", results: []fakeResult{{output: "no space left on device", err: exitErr}}, wantRuns: 1, wantError: true},
+		{name: "other error after retry not retried",
+			results: []fakeResult{networkErr, {output: "no space left on device", err: exitErr}, {}}, wantRuns: 2, wantError: true},
+		{name: "attempts exhausted", results: []fakeResult{{output: "i/o timeout", err: exitErr}}, wantRuns: 3, wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delays := skipBackoff(t)
+			runner := newFakeRunner()
+			for _, result := range tt.results {
+				runner.on(rebase, result.output, result.err)
+			}
+			s := newTestServer(t, runner)
+			err := s.rebaseWithRetry([]string{"rebase", "nestos:stable", "--bypass-driver"})
+			if (err != nil) != tt.wantError {
+				t.Fatalf("rebaseWithRetry() error = %v, wantError %v", err, tt.wantError)
+			}
+			if got := runner.count(rebase); got != tt.wantRuns {
+				t.Errorf("rebase ran %d times, want %d", got, tt.wantRuns)
+			}
+			// 每次重试前等待，等待时间逐次加倍
+			var wantDelays []time.Duration
+			for i, delay := 0, rebaseInitialBackoff; i < tt.wantRuns-1; i, delay = i+1, delay*2 {
+				wantDelays = append(wantDelays, delay)
+			}
+			if !reflect.DeepEqual(*delays, wantDelays) {
+				t.Errorf("waited %v between attempts, want %v", *delays, wantDelays)
+			}
+		})
+	}
+}
+
+func TestCheckRateLimits(t *testing.T) {
+	for _, check := range []func(string) error{checkDiskWriteLimit, checkBandwidthLimit} {
+		for _, limit := range []string{"", "500K", "10M", "1G", "1024"} {
+			if err := check(limit); err != nil {
+				t.Errorf("check(%q) = %v", limit, err)
+			}
+		}
+		for _, limit := range []string{"0", "10MB", "-1M", "10 M", "fast"} {
+			if err := check(limit); err == nil {
+				t.Errorf("check(%q) accepted an invalid limit", limit)
+			}
+		}
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	tests := map[string]rate.Limit{"1024": 1024, "500K": 500 << 10, "10M": 10 << 20, "2G": 2 << 30}
+	for limit, want := range tests {
+		got, err := parseRateLimit(limit)
+		if err != nil || got != want {
+			t.Errorf("parseRateLimit(%q) = %v, %v, want %v", limit, got, err, want)
+		}
+	}
+}
+
+func TestThrottledRebase(t *testing.T) {
+	const (
+		rebase     = "rpm-ostree rebase nestos:stable --bypass-driver"
+		reload     = "systemctl daemon-reload"
+		stop       = "systemctl stop " + rpmOstreedService
+		setLimit   = "systemctl set-property --runtime " + rpmOstreedService + " IOWriteBandwidthMax=" + ostreeRepoPath + " 10M"
+		clearLimit = "systemctl set-property --runtime " + rpmOstreedService + " IOWriteBandwidthMax="
+	)
+	tests := []struct {
+		name string
+		req  *pb.UpgradeRequest
+		want []string
+	}{
+		{name: "no limit", req: &pb.UpgradeRequest{}, want: []string{rebase}},
+		{name: "disk write limit", req: &pb.UpgradeRequest{PullDiskWriteLimit: "10M"},
+			want: []string{setLimit, rebase, clearLimit}},
+		{name: "bandwidth limit", req: &pb.UpgradeRequest{PullBandwidthLimit: "10M"},
+			want: []string{reload, stop, rebase, reload, stop}},
+		{name: "both limits", req: &pb.UpgradeRequest{PullDiskWriteLimit: "10M", PullBandwidthLimit: "10M"},
+			want: []string{setLimit, reload, stop, rebase, reload, stop, clearLimit}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := newFakeRunner()
+			s := newTestServer(t, runner)
+			tt.req.OsRef = "nestos:stable"
+			if err := s.throttledRebase(tt.req); err != nil {
+				t.Fatalf("throttledRebase() error = %v", err)
+			}
+			if got := runner.ran(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ran %q, want %q", got, tt.want)
+			}
+			if _, err := os.Stat(filepath.Join(s.DropInDir, pullProxyDropIn)); !os.IsNotExist(err) {
+				t.Errorf("expected the pull proxy drop-in to be removed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLimitPullBandwidth(t *testing.T) {
+	runner := newFakeRunner()
+	s := newTestServer(t, runner)
+	restore, err := s.limitPullBandwidth("10M")
+	if err != nil {
+		t.Fatalf("limitPullBandwidth() error = %v", err)
+	}
+	content, err := ioutil.ReadFile(filepath.Join(s.DropInDir, pullProxyDropIn))
+	if err != nil {
+		t.Fatalf("failed to read the drop-in: %v", err)
+	}
+	proxyURL := regexp.MustCompile(`"https_proxy=(http://127\.0\.0\.1:[0-9]+)"`).FindSubmatch(content)
+	if !strings.HasPrefix(string(content), "[Service]\n") || proxyURL == nil {
+		t.Fatalf("unexpected drop-in:\n%s", content)
+	}
+	conn, err := net.Dial("tcp", strings.TrimPrefix(string(proxyURL[1]), "http://"))
+	if err != nil {
+		t.Fatalf("the pull proxy is not listening: %v", err)
+	}
+	conn.Close()
+
+	restore()
+	if _, err := net.Dial("tcp", strings.TrimPrefix(string(proxyURL[1]), "http://")); err == nil {
+		t.Error("the pull proxy is still listening after restore")
+	}
+}
+
+func TestPullProxyLimitsDownloads(t *testing.T) {
+	body := bytes.Repeat([]byte("nestos"), 16*1024)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(body)
+	})
+	for _, tt := range []struct {
+		name   string
+		server *httptest.Server
+	}{
+		{name: "http", server: httptest.NewServer(handler)},
+		{name: "https", server: httptest.NewTLSServer(handler)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer tt.server.Close()
+			// 令牌桶装满时先放行一块，其余按 192K/s 下载
+			limit := rate.Limit(192 << 10)
+			proxy, err := startPullProxy(limit)
+			if err != nil {
+				t.Fatalf("startPullProxy() error = %v", err)
+			}
+			defer proxy.Close()
+			proxyURL, _ := url.Parse(proxy.URL())
+			client := tt.server.Client()
+			client.Transport.(*http.Transport).Proxy = http.ProxyURL(proxyURL)
+
+			start := time.Now()
+			resp, err := client.Get(tt.server.URL)
+			if err != nil {
+				t.Fatalf("get through the proxy: %v", err)
+			}
+			got, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil || !bytes.Equal(got, body) {
+				t.Fatalf("read %d bytes through the proxy: %v", len(got), err)
+			}
+			minimum := time.Duration(float64(len(body)-proxyChunkSize) / float64(limit) * float64(time.Second))
+			if elapsed := time.Since(start); elapsed < minimum*9/10 {
+				t.Errorf("downloaded %d bytes in %v, expected at least %v", len(body), elapsed, minimum)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"housekeeper.io/pkg/common"
+	pb "housekeeper.io/pkg/connection/proto"
+)
+
+const (
+	kubeletConfigFile = "/var/lib/kubelet/config.yaml"
+	cniConfigDir      = "/etc/cni/net.d"
+)
+
+// Actions reported in the reset response
+const (
+	ActionNotJoined        = "not-joined"
+	ActionKubeadmReset     = "kubeadm-reset"
+	ActionCNIRemoved       = "cni-config-removed"
+	ActionKubeletRestarted = "kubelet-restarted"
+)
+
+// Implements the Reset
+func (s *Server) Reset(_ context.Context, _ *pb.ResetRequest) (*pb.ResetResponse, error) {
+	if err := s.beginRequest(); err != nil {
+		return &pb.ResetResponse{}, err
+	}
+	defer s.inflight.Done()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.resetNode(adminFile, kubeletConfigFile, cniConfigDir)
+}
+
+// resetNode tears down the kubernetes state of the node, a node that was never joined
+// has neither the admin kubeconfig nor the kubelet config and is left unchanged
+func (s *Server) resetNode(adminConf string, kubeletConf string, cniDir string) (*pb.ResetResponse, error) {
+	if !common.IsFileExist(adminConf) && !common.IsFileExist(kubeletConf) {
+		logrus.Info("the node was never joined to a cluster, skip reset")
+		return &pb.ResetResponse{Actions: []string{ActionNotJoined}}, nil
+	}
+
+	resp := &pb.ResetResponse{Joined: true}
+	if _, err := s.cmdRunner().Run(kubeadmCmd, "reset", "-f"); err != nil {
+		logrus.Errorf("failed to reset node: %v", err)
+		return resp, err
+	}
+	resp.Actions = append(resp.Actions, ActionKubeadmReset)
+
+	// kubeadm reset 不会清理 CNI 配置，残留的配置会影响节点重新加入集群
+	if err := os.RemoveAll(cniDir); err != nil {
+		logrus.Errorf("failed to remove %s: %v", cniDir, err)
+		return resp, err
+	}
+	resp.Actions = append(resp.Actions, ActionCNIRemoved)
+
+	if err := s.restartKubelet(); err != nil {
+		return resp, err
+	}
+	resp.Actions = append(resp.Actions, ActionKubeletRestarted)
+	logrus.Info("the node has been reset")
+	return resp, nil
+}
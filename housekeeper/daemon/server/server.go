@@ -22,148 +22,525 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
 
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"housekeeper.io/pkg/common"
 	pb "housekeeper.io/pkg/connection/proto"
 	"housekeeper.io/pkg/constants"
 )
 
 const (
-	ostreeImage      = "ostree-unverified-image:docker://"
-	kubeadmCmd       = "/usr/bin/kubeadm"
-	upgradeMasterCmd = "/usr/bin/kubeadm upgrade apply -y"
-	upgradeWorkerCmd = "/usr/bin/kubeadm upgrade node"
-	kubeletUpdateCmd = "systemctl daemon-reload && systemctl restart kubelet"
-	adminFile        = "/etc/kubernetes/admin.conf"
+	ostreeImage = "ostree-unverified-image:docker://"
+	kubeadmCmd  = "/usr/bin/kubeadm"
+	skopeoCmd   = "/usr/bin/skopeo"
+	adminFile   = "/etc/kubernetes/admin.conf"
+	// static pod manifest of the stacked etcd member of a master node
+	etcdManifest = "/etc/kubernetes/manifests/etcd.yaml"
 )
 
+// image digests such as sha256:<hex>
+var digestPattern = regexp.MustCompile(`^[a-z0-9]+:[a-f0-9]{32,}$`)
+
+// image of the etcd container in its static pod manifest, the tag is not part of the match
+var etcdImagePattern = regexp.MustCompile(`(?m)^(\s*image:\s*"?(?:\S*/)?etcd):[^\s"]+`)
+
+// Actions reported in the upgrade response
+const (
+	ActionOSUpgraded      = "os-upgraded"
+	ActionOSSkipped       = "os-skipped"
+	ActionKubeUpgraded    = "kube-upgraded"
+	ActionKubeSkipped     = "kube-skipped"
+	ActionKubeUpToDate    = "kube-up-to-date"
+	ActionRebootScheduled = "reboot-scheduled"
+	ActionEtcdUpgraded    = "etcd-upgraded"
+	ActionEtcdSkipped     = "etcd-skipped"
+)
+
+// Phases reported by UpgradeStream
+const (
+	PhasePullingImage     = "pulling-image"
+	PhaseRebasing         = "rebasing"
+	PhaseRebooting        = "rebooting"
+	PhaseKubeadmUpgrading = "kubeadm-upgrading"
+	PhasePreUpgradeHooks  = "pre-upgrade-hooks"
+	PhasePostUpgradeHooks = "post-upgrade-hooks"
+	PhaseDone             = "done"
+)
+
+// progressFunc reports the phase an upgrade enters
+type progressFunc func(phase string, message string)
+
+func noProgress(string, string) {}
+
+// errShuttingDown is returned for the requests received once Shutdown is called
+var errShuttingDown = status.Error(codes.Unavailable, "housekeeper daemon is shutting down")
+
+// rejected returns the error of a request the node will never run, so the controller does not retry it.
+// codes.InvalidArgument is used for a malformed request, codes.FailedPrecondition for a request
+// the node refuses as it is. Other errors reach the controller as codes.Unknown and are retried.
+func rejected(code codes.Code, err error) error {
+	return status.Error(code, err.Error())
+}
+
 type Server struct {
 	pb.UnimplementedUpgradeClusterServer
 	mu sync.Mutex
+	// shutdownMu guards shuttingDown, inflight counts the requests changing the node
+	shutdownMu   sync.Mutex
+	shuttingDown bool
+	inflight     sync.WaitGroup
+	// attempts of rpm-ostree rebase, constants.RebaseMaxAttempts when unset
+	RebaseMaxAttempts int
+	// runs the commands on the host, commands are executed directly when unset
+	Runner CommandRunner
+	// directory of the upgrade stamp files, constants.SockDir when unset
+	StampDir string
+	// directory of the upgrade hook scripts, constants.HookDir when unset
+	HookDir string
+	// directory of the runtime drop-ins of rpm-ostreed, /run/systemd/system/rpm-ostreed.service.d when unset
+	DropInDir string
+	// versions reported by HealthCheck
+	health healthCache
+}
+
+// CommandRunner runs a command on the host and returns its output,
+// the output of a failed command also contains its stderr
+type CommandRunner interface {
+	Run(name string, args ...string) ([]byte, error)
+}
+
+type execRunner struct{}
+
+func (execRunner) Run(name string, args ...string) ([]byte, error) {
+	output, err := exec.Command(name, args...).Output()
+	if err != nil {
+		logrus.Errorf("error running  %s: %s: %v", name, strings.Join(args, " "), err)
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			output = append(output, exitErr.Stderr...)
+		}
+		return output, err
+	}
+	return output, nil
+}
+
+func (s *Server) cmdRunner() CommandRunner {
+	if s.Runner == nil {
+		return execRunner{}
+	}
+	return s.Runner
+}
+
+// beginRequest registers a request changing the node, it fails once the server is shutting down
+func (s *Server) beginRequest() error {
+	s.shutdownMu.Lock()
+	defer s.shutdownMu.Unlock()
+	if s.shuttingDown {
+		return errShuttingDown
+	}
+	s.inflight.Add(1)
+	return nil
+}
+
+// Shutdown rejects new requests changing the node and waits for the running ones to finish,
+// it returns the error of the context when the context is done first
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.shutdownMu.Lock()
+	s.shuttingDown = true
+	s.shutdownMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.inflight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Server) stampDir() string {
+	if s.StampDir == "" {
+		return constants.SockDir
+	}
+	return s.StampDir
+}
+
+func (s *Server) dropInDir() string {
+	if s.DropInDir == "" {
+		return rpmOstreedDropInDir
+	}
+	return s.DropInDir
 }
 
 // Implements the Upgrade
 func (s *Server) Upgrade(_ context.Context, req *pb.UpgradeRequest) (*pb.UpgradeResponse, error) {
+	if err := s.beginRequest(); err != nil {
+		return &pb.UpgradeResponse{}, err
+	}
+	defer s.inflight.Done()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.upgrade(req, noProgress)
+}
+
+// Implements the UpgradeStream
+func (s *Server) UpgradeStream(req *pb.UpgradeRequest, stream pb.UpgradeCluster_UpgradeStreamServer) error {
+	if err := s.beginRequest(); err != nil {
+		return err
+	}
+	defer s.inflight.Done()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	// 客户端断开时继续完成升级，不能让节点停在中间状态
+	report := func(phase string, message string) {
+		if err := stream.Send(&pb.UpgradeProgress{Phase: phase, Message: message}); err != nil {
+			logrus.Warnf("failed to send upgrade progress %s: %v", phase, err)
+		}
+	}
+	resp, err := s.upgrade(req, report)
+	if err != nil {
+		return err
+	}
+	return stream.Send(&pb.UpgradeProgress{Phase: PhaseDone, Result: resp})
+}
+
+// upgrade runs the pre-upgrade hooks before the node is changed and the post-upgrade hooks once it is upgraded
+func (s *Server) upgrade(req *pb.UpgradeRequest, report progressFunc) (*pb.UpgradeResponse, error) {
+	// pre-upgrade 钩子在标记节点前执行一次，失败时不标记节点以便重试
+	hooksRun := false
+	runPreUpgradeHooks := func() error {
+		if hooksRun {
+			return nil
+		}
+		hooksRun = true
+		report(PhasePreUpgradeHooks, "running pre-upgrade hooks")
+		return s.runHooks(preUpgradeHooks)
+	}
+	resp, err := s.upgradeNode(req, report, runPreUpgradeHooks)
+	if err != nil || !hooksRun {
+		return resp, err
+	}
+	report(PhasePostUpgradeHooks, "running post-upgrade hooks")
+	if err := s.runHooks(postUpgradeHooks); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+func (s *Server) upgradeNode(req *pb.UpgradeRequest, report progressFunc,
+	runPreUpgradeHooks func() error) (*pb.UpgradeResponse, error) {
+	resp := &pb.UpgradeResponse{}
+
+	if len(req.OsImageUrl) > 0 && len(req.OsRef) > 0 {
+		return resp, rejected(codes.InvalidArgument,
+			fmt.Errorf("os image url %s and os ref %s are mutually exclusive", req.OsImageUrl, req.OsRef))
+	}
 	// upgrade os
-	if len(req.OsImageUrl) > 0 {
-		osImageTag, err := common.ExtractImageTag(req.OsImageUrl)
+	if len(req.OsImageUrl) > 0 || len(req.OsRef) > 0 {
+		osVersion, err := common.GetOSVersion(req.OsImageUrl, req.OsRef)
 		if err != nil {
 			logrus.Info("the mirror address url parameter is invalid")
-			return &pb.UpgradeResponse{}, nil
+			return resp, nil
 		}
-		markOsPath := fmt.Sprintf("%s/%s/", constants.SockDir, "os")
-		markOsStamp := fmt.Sprintf("%s%s%s", markOsPath, osImageTag, ".stamp")
+		resp.OsVersion = osVersion
+		markOsPath := filepath.Join(s.stampDir(), "os")
+		markOsStamp := filepath.Join(markOsPath, common.OSStampName(osVersion)+".stamp")
 		if common.IsFileExist(markOsStamp) {
-			return &pb.UpgradeResponse{}, nil
+			resp.Actions = append(resp.Actions, ActionOSSkipped)
+			return resp, nil
+		}
+		report(PhasePullingImage, "fetching os image "+req.OsImageUrl+req.OsRef)
+		// 校验失败时不标记节点，修正镜像后可以重试
+		if err := s.verifyImageDigest(req); err != nil {
+			logrus.Errorf("refusing to upgrade os: %v", err)
+			return resp, err
+		}
+		if err := checkDiskWriteLimit(req.PullDiskWriteLimit); err != nil {
+			logrus.Errorf("refusing to upgrade os: %v", err)
+			return resp, err
+		}
+		if err := checkBandwidthLimit(req.PullBandwidthLimit); err != nil {
+			logrus.Errorf("refusing to upgrade os: %v", err)
+			return resp, err
+		}
+		if err := runPreUpgradeHooks(); err != nil {
+			return resp, err
 		}
 		if err := markNode(markOsPath, markOsStamp); err != nil {
 			logrus.Errorf("failed to mark node: %v", err)
-			return &pb.UpgradeResponse{}, err
+			return resp, err
 		}
-		if err := upgradeOSVersion(req); err != nil {
+		if err := s.upgradeOSVersion(req, report); err != nil {
 			logrus.Errorf("upgrade os version error: %v", err)
-			return &pb.UpgradeResponse{}, err
+			return resp, err
 		}
+		resp.RebootPending = true
+		resp.Actions = append(resp.Actions, ActionOSUpgraded, ActionRebootScheduled)
 	}
 	// upgrade kubernetes
 	if len(req.KubeVersion) > 0 {
-		markKubePath := fmt.Sprintf("%s/%s/", constants.SockDir, "kube")
-		markKubeStamp := fmt.Sprintf("%s%s%s", markKubePath, req.KubeVersion, ".stamp")
+		if err := common.ValidateKubeVersion(req.KubeVersion); err != nil {
+			logrus.Errorf("rejecting kubernetes upgrade: %v", err)
+			return resp, rejected(codes.InvalidArgument, err)
+		}
+		component, err := common.ParseUpgradeComponent(req.UpgradeComponent)
+		if err != nil {
+			logrus.Errorf("rejecting kubernetes upgrade: %v", err)
+			return resp, rejected(codes.InvalidArgument, err)
+		}
+		resp.KubeVersion = req.KubeVersion
+		markKubePath := filepath.Join(s.stampDir(), "kube")
+		markKubeStamp := filepath.Join(markKubePath, common.KubeStampName(req.KubeVersion, component)+".stamp")
 		if common.IsFileExist(markKubeStamp) {
-			return &pb.UpgradeResponse{}, nil
+			resp.Actions = append(resp.Actions, ActionKubeSkipped)
+			return resp, nil
+		}
+		kubeadmVersion, err := s.getKubeadmVersion()
+		if err != nil {
+			return resp, err
+		}
+		// kubeadm随OS镜像发布，超出其支持范围的版本需要先升级OS，检查失败时不标记节点以便升级OS后重试
+		if err := common.CheckKubeadmUpgradeTarget(kubeadmVersion, strings.TrimSpace(req.KubeVersion)); err != nil {
+			logrus.Errorf("rejecting kubernetes upgrade: %v", err)
+			return resp, rejected(codes.FailedPrecondition, err)
+		}
+		if err := checkControlPlaneSkew(req, component); err != nil {
+			logrus.Errorf("rejecting kubernetes upgrade: %v", err)
+			return resp, err
+		}
+		if err := runPreUpgradeHooks(); err != nil {
+			return resp, err
 		}
 		if err := markNode(markKubePath, markKubeStamp); err != nil {
 			logrus.Errorf("failed to mark node: %v", err)
-			return &pb.UpgradeResponse{}, err
+			return resp, err
 		}
-		if err := checkKubeVersion(req); err != nil {
-			return &pb.UpgradeResponse{}, err
+		action, err := s.checkKubeVersion(req, component, kubeadmVersion, report)
+		if err != nil {
+			return resp, err
 		}
+		resp.Actions = append(resp.Actions, action)
 	}
-	return &pb.UpgradeResponse{}, nil
+	return resp, nil
 }
 
-func checkKubeVersion(req *pb.UpgradeRequest) error {
+func (s *Server) getKubeadmVersion() (string, error) {
 	args := []string{"version", "-o", "short"}
-	kubeadmVersionBytes, err := runCmd(kubeadmCmd, args...)
+	kubeadmVersionBytes, err := s.cmdRunner().Run(kubeadmCmd, args...)
 	if err != nil {
 		logrus.Errorf("kubeadm get version failed: %v", err)
-		return err
+		return "", err
 	}
-	kubeadmVersion := strings.TrimSpace(string(kubeadmVersionBytes))
+	return strings.TrimSpace(string(kubeadmVersionBytes)), nil
+}
+
+func (s *Server) checkKubeVersion(req *pb.UpgradeRequest, component string, kubeadmVersion string,
+	report progressFunc) (string, error) {
 	KubeVersion := strings.TrimSpace(req.KubeVersion)
 	if kubeadmVersion == KubeVersion {
 		logrus.Infof("The current k8s version %s and the desired upgrade version %s are the same", string(kubeadmVersion), req.KubeVersion)
-		return nil
+		return ActionKubeUpToDate, nil
 	}
-	if err := upgradeKubeVersion(req); err != nil {
+	report(PhaseKubeadmUpgrading, "upgrading kubernetes "+component+" from "+kubeadmVersion+" to "+KubeVersion)
+	action, err := s.upgradeKubeVersion(KubeVersion, component)
+	if err != nil {
 		logrus.Errorf("upgrade kubernetes version error: %v", err)
-		return err
+		return "", err
+	}
+	return action, nil
+}
+
+// verifyImageDigest checks the digest of the os image in the registry before rebasing onto it
+func (s *Server) verifyImageDigest(req *pb.UpgradeRequest) error {
+	expected := strings.TrimSpace(req.OsImageDigest)
+	if expected == "" {
+		return nil
+	}
+	if len(req.OsRef) > 0 {
+		return rejected(codes.InvalidArgument,
+			fmt.Errorf("os image digest %s can not be verified for ostree ref %s", expected, req.OsRef))
+	}
+	if !digestPattern.MatchString(expected) {
+		return rejected(codes.InvalidArgument, fmt.Errorf("invalid os image digest %q", expected))
+	}
+	output, err := s.cmdRunner().Run(skopeoCmd, "inspect", "--format", "{{.Digest}}", "docker://"+req.OsImageUrl)
+	if err != nil {
+		return fmt.Errorf("failed to inspect os image %s: %w: %s", req.OsImageUrl, err, strings.TrimSpace(string(output)))
+	}
+	if actual := strings.TrimSpace(string(output)); actual != expected {
+		return rejected(codes.FailedPrecondition,
+			fmt.Errorf("digest of os image %s is %s, expected %s", req.OsImageUrl, actual, expected))
 	}
 	return nil
 }
 
-func upgradeOSVersion(req *pb.UpgradeRequest) error {
-	//upgrade os
+// rebaseArgs returns the rpm-ostree arguments rebasing onto the ostree ref or the container image
+func rebaseArgs(req *pb.UpgradeRequest) []string {
+	if len(req.OsRef) > 0 {
+		return []string{"rebase", req.OsRef, "--bypass-driver"}
+	}
 	customImageURL := fmt.Sprintf("%s%s", ostreeImage, req.OsImageUrl)
-	args := []string{"rebase", "--experimental", customImageURL, "--bypass-driver"}
-	if _, err := runCmd("rpm-ostree", args...); err != nil {
+	return []string{"rebase", "--experimental", customImageURL, "--bypass-driver"}
+}
+
+func (s *Server) upgradeOSVersion(req *pb.UpgradeRequest, report progressFunc) error {
+	//upgrade os
+	report(PhaseRebasing, "rebasing onto "+req.OsImageUrl+req.OsRef)
+	if err := s.throttledRebase(req); err != nil {
 		logrus.Errorf("failed to upgrade os: %v", err)
 		return err
 	}
-	if err := exec.Command("/bin/sh", "-c", "systemctl reboot").Run(); err != nil {
+	report(PhaseRebooting, "rebooting into the new os image")
+	if _, err := s.cmdRunner().Run("systemctl", "reboot"); err != nil {
 		logrus.Errorf("failed to run reboot: %v", err)
 		return err
 	}
 	return nil
 }
 
-func upgradeKubeVersion(req *pb.UpgradeRequest) error {
+// upgradeKubeVersion upgrades the component of the node, only master nodes run etcd
+func (s *Server) upgradeKubeVersion(version string, component string) (string, error) {
+	if component == common.UpgradeComponentEtcd {
+		if !isMasterNode() {
+			return ActionEtcdSkipped, nil
+		}
+		return s.upgradeEtcd(version)
+	}
 	if isMasterNode() {
-		if err := upgradeMasterNodes(req.KubeVersion); err != nil {
+		if err := s.upgradeMasterNodes(version, component); err != nil {
 			logrus.Errorf("failed to upgrade master nodes: %v", err)
-			return err
+			return "", err
 		}
 	} else {
-		if err := upgradeWorkerNodes(); err != nil {
+		if err := s.upgradeWorkerNodes(); err != nil {
 			logrus.Errorf("failed to upgrade worker nodes: %v", err)
-			return err
+			return "", err
 		}
 	}
-	return nil
+	return ActionKubeUpgraded, nil
 }
 
-func upgradeMasterNodes(version string) error {
-	if err := exec.Command("/bin/sh", "-c", kubeletUpdateCmd).Run(); err != nil {
-		logrus.Errorf("failed to restart kubelet: %v", err)
+// upgradeApplyArgs returns the kubeadm arguments upgrading the control plane,
+// etcd is left alone when only the control plane is upgraded
+func upgradeApplyArgs(version string, component string) []string {
+	args := []string{"upgrade", "apply", "-y"}
+	if component == common.UpgradeComponentControlPlane {
+		args = append(args, "--etcd-upgrade=false")
+	}
+	return append(args, version)
+}
+
+func (s *Server) upgradeMasterNodes(version string, component string) error {
+	if err := s.restartKubelet(); err != nil {
 		return err
 	}
-	args := []string{"-c", upgradeMasterCmd, version}
-	if err := exec.Command("/bin/sh", args...).Run(); err != nil {
+	if _, err := s.cmdRunner().Run(kubeadmCmd, upgradeApplyArgs(version, component)...); err != nil {
 		logrus.Errorf("failed to upgrade nodes: %v", err)
 		return err
 	}
 	return nil
 }
 
-func upgradeWorkerNodes() error {
-	if err := exec.Command("/bin/sh", "-c", kubeletUpdateCmd).Run(); err != nil {
-		logrus.Errorf("failed to restart kubelet: %v", err)
+// upgradeEtcd moves the local etcd member to the etcd version kubeadm uses for the kubernetes version.
+// kubeadm upgrade apply always upgrades the control plane, so the image of the static pod manifest
+// is updated instead and the kubelet restarts the etcd pod.
+func (s *Server) upgradeEtcd(version string) (string, error) {
+	if !common.IsFileExist(etcdManifest) {
+		logrus.Infof("no local etcd member, skipping etcd upgrade")
+		return ActionEtcdSkipped, nil
+	}
+	output, err := s.cmdRunner().Run(kubeadmCmd, "config", "images", "list", "--kubernetes-version", version)
+	if err != nil {
+		logrus.Errorf("failed to list images of kubernetes %s: %v", version, err)
+		return "", err
+	}
+	tag, err := etcdImageTag(string(output))
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(etcdManifest)
+	if err != nil {
+		return "", err
+	}
+	content, err := ioutil.ReadFile(etcdManifest)
+	if err != nil {
+		return "", err
+	}
+	if !etcdImagePattern.Match(content) {
+		return "", fmt.Errorf("no etcd image in %s", etcdManifest)
+	}
+	content = etcdImagePattern.ReplaceAll(content, []byte("${1}:"+tag))
+	if err := writeFileAtomic(etcdManifest, content, info.Mode().Perm()); err != nil {
+		logrus.Errorf("failed to update %s: %v", etcdManifest, err)
+		return "", err
+	}
+	return ActionEtcdUpgraded, nil
+}
+
+// etcdImageTag returns the tag of the etcd image in the output of kubeadm config images list
+func etcdImageTag(images string) (string, error) {
+	for _, image := range strings.Fields(images) {
+		name := image[strings.LastIndex(image, "/")+1:]
+		if strings.HasPrefix(name, "etcd:") {
+			return strings.TrimPrefix(name, "etcd:"), nil
+		}
+	}
+	return "", fmt.Errorf("no etcd image in kubeadm images: %s", strings.TrimSpace(images))
+}
+
+// checkControlPlaneSkew refuses to upgrade a worker beyond the control plane version pushed by the controller,
+// kubeadm upgrade node does not check it and the kubelet breaks when it is newer than the apiserver
+func checkControlPlaneSkew(req *pb.UpgradeRequest, component string) error {
+	if isMasterNode() || component == common.UpgradeComponentEtcd {
+		return nil
+	}
+	controlPlaneVersion := strings.TrimSpace(req.ControlPlaneVersion)
+	if len(controlPlaneVersion) == 0 {
+		logrus.Info("no control plane version pushed, skipping the version skew check")
+		return nil
+	}
+	return common.CheckKubeletVersionSkew(controlPlaneVersion, strings.TrimSpace(req.KubeVersion))
+}
+
+func (s *Server) upgradeWorkerNodes() error {
+	if err := s.restartKubelet(); err != nil {
 		return err
 	}
-	if err := exec.Command("/bin/sh", "-c", upgradeWorkerCmd).Run(); err != nil {
+	if _, err := s.cmdRunner().Run(kubeadmCmd, "upgrade", "node"); err != nil {
 		logrus.Errorf("failed to upgrade nodes: %v", err)
 		return err
 	}
 	return nil
 }
 
+func (s *Server) restartKubelet() error {
+	if _, err := s.cmdRunner().Run("systemctl", "daemon-reload"); err != nil {
+		logrus.Errorf("failed to reload systemd: %v", err)
+		return err
+	}
+	if err := s.restartService("kubelet"); err != nil {
+		logrus.Errorf("failed to restart kubelet: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (s *Server) restartService(service string) error {
+	_, err := s.cmdRunner().Run("systemctl", "restart", service)
+	return err
+}
+
 func isMasterNode() bool {
 	return common.IsFileExist(adminFile)
 }
@@ -179,13 +556,3 @@ func markNode(dir string, file string) error {
 	}
 	return nil
 }
-
-func runCmd(name string, args ...string) ([]byte, error) {
-	cmd := exec.Command(name, args...)
-	output, err := cmd.Output()
-	if err != nil {
-		logrus.Errorf("error running  %s: %s: %v", name, strings.Join(args, " "), err)
-		return nil, err
-	}
-	return output, nil
-}
@@ -0,0 +1,394 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"housekeeper.io/pkg/common"
+	"housekeeper.io/pkg/connection"
+	pb "housekeeper.io/pkg/connection/proto"
+)
+
+// fakeResult is the output and error of a command run by fakeRunner
+type fakeResult struct {
+	output string
+	err    error
+}
+
+// fakeRunner records the commands it is asked to run and answers them without running anything,
+// results are looked up by the command line and each result is returned once before the next one
+type fakeRunner struct {
+	mu       sync.Mutex
+	commands []string
+	results  map[string][]fakeResult
+}
+
+func newFakeRunner() *fakeRunner {
+	return &fakeRunner{results: map[string][]fakeResult{}}
+}
+
+// on queues the result of the command line, the last result is returned for all later runs
+func (f *fakeRunner) on(command string, output string, err error) *fakeRunner {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results[command] = append(f.results[command], fakeResult{output: output, err: err})
+	return f
+}
+
+func (f *fakeRunner) Run(name string, args ...string) ([]byte, error) {
+	command := strings.Join(append([]string{name}, args...), " ")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.commands = append(f.commands, command)
+	results := f.results[command]
+	if len(results) == 0 {
+		return nil, nil
+	}
+	result := results[0]
+	if len(results) > 1 {
+		f.results[command] = results[1:]
+	}
+	return []byte(result.output), result.err
+}
+
+// ran returns the command lines run so far
+func (f *fakeRunner) ran() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.commands...)
+}
+
+// count returns how many times the command line was run
+func (f *fakeRunner) count(command string) int {
+	n := 0
+	for _, c := range f.ran() {
+		if c == command {
+			n++
+		}
+	}
+	return n
+}
+
+func newTestServer(t *testing.T, runner *fakeRunner) *Server {
+	t.Helper()
+	dir := t.TempDir()
+	return &Server{
+		RebaseMaxAttempts: 3,
+		Runner:            runner,
+		StampDir:          filepath.Join(dir, "stamps"),
+		HookDir:           filepath.Join(dir, "hooks"),
+		DropInDir:         filepath.Join(dir, "rpm-ostreed.service.d"),
+	}
+}
+
+func TestUpgradeKubeVersionOnWorker(t *testing.T) {
+	runner := newFakeRunner().on(kubeadmCmd+" version -o short", "v1.23.12\n", nil)
+	s := newTestServer(t, runner)
+	resp, err := s.Upgrade(context.Background(), &pb.UpgradeRequest{KubeVersion: "v1.23.10"})
+	if err != nil {
+		t.Fatalf("Upgrade() error = %v", err)
+	}
+	if !reflect.DeepEqual(resp.GetActions(), []string{ActionKubeUpgraded}) {
+		t.Errorf("Upgrade() actions = %v", resp.GetActions())
+	}
+	want := []string{
+		kubeadmCmd + " version -o short",
+		"systemctl daemon-reload",
+		"systemctl restart kubelet",
+		kubeadmCmd + " upgrade node",
+	}
+	if got := runner.ran(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ran %q, want %q", got, want)
+	}
+	if !common.IsFileExist(filepath.Join(s.StampDir, "kube", "v1.23.10.stamp")) {
+		t.Error("node not stamped")
+	}
+
+	// 已标记的版本不再升级
+	resp, err = s.Upgrade(context.Background(), &pb.UpgradeRequest{KubeVersion: "v1.23.10"})
+	if err != nil {
+		t.Fatalf("Upgrade() error = %v", err)
+	}
+	if !reflect.DeepEqual(resp.GetActions(), []string{ActionKubeSkipped}) {
+		t.Errorf("Upgrade() actions = %v", resp.GetActions())
+	}
+	if got := len(runner.ran()); got != len(want) {
+		t.Errorf("ran %d commands for a stamped upgrade", got-len(want))
+	}
+}
+
+func TestUpgradeStagedComponentsOnWorker(t *testing.T) {
+	tests := []struct {
+		component   string
+		wantAction  string
+		wantCommand string
+		wantStamp   string
+	}{
+		{component: "etcd", wantAction: ActionEtcdSkipped, wantStamp: "v1.23.10-etcd.stamp"},
+		{component: "controlplane", wantAction: ActionKubeUpgraded, wantCommand: kubeadmCmd + " upgrade node",
+			wantStamp: "v1.23.10-controlplane.stamp"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.component, func(t *testing.T) {
+			runner := newFakeRunner().on(kubeadmCmd+" version -o short", "v1.23.12", nil)
+			s := newTestServer(t, runner)
+			resp, err := s.Upgrade(context.Background(), &pb.UpgradeRequest{KubeVersion: "v1.23.10", UpgradeComponent: tt.component})
+			if err != nil {
+				t.Fatalf("Upgrade() error = %v", err)
+			}
+			if !reflect.DeepEqual(resp.GetActions(), []string{tt.wantAction}) {
+				t.Errorf("Upgrade() actions = %v", resp.GetActions())
+			}
+			if tt.wantCommand != "" && runner.count(tt.wantCommand) != 1 {
+				t.Errorf("ran %q, want %q", runner.ran(), tt.wantCommand)
+			}
+			if !common.IsFileExist(filepath.Join(s.StampDir, "kube", tt.wantStamp)) {
+				t.Errorf("node not stamped with %s", tt.wantStamp)
+			}
+		})
+	}
+}
+
+func TestUpgradeApplyArgs(t *testing.T) {
+	tests := []struct {
+		component string
+		want      []string
+	}{
+		{component: common.UpgradeComponentAll, want: []string{"upgrade", "apply", "-y", "v1.23.10"}},
+		{component: common.UpgradeComponentControlPlane, want: []string{"upgrade", "apply", "-y", "--etcd-upgrade=false", "v1.23.10"}},
+	}
+	for _, tt := range tests {
+		if got := upgradeApplyArgs("v1.23.10", tt.component); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("upgradeApplyArgs(%q) = %q, want %q", tt.component, got, tt.want)
+		}
+	}
+}
+
+func TestEtcdImageTag(t *testing.T) {
+	images := "registry.k8s.io/kube-apiserver:v1.23.10\nregistry.k8s.io/etcd:3.5.1-0\nregistry.k8s.io/coredns/coredns:v1.8.6\n"
+	tag, err := etcdImageTag(images)
+	if err != nil || tag != "3.5.1-0" {
+		t.Errorf("etcdImageTag() = %q, %v", tag, err)
+	}
+	if _, err := etcdImageTag("registry.k8s.io/kube-apiserver:v1.23.10"); err == nil {
+		t.Error("etcdImageTag() found no etcd image but succeeded")
+	}
+	manifest := "    image: registry.k8s.io/etcd:3.5.0-0\n"
+	if got := etcdImagePattern.ReplaceAllString(manifest, "${1}:"+tag); got != "    image: registry.k8s.io/etcd:3.5.1-0\n" {
+		t.Errorf("etcd manifest image = %q", got)
+	}
+}
+
+func TestUpgradeRejectsInvalidRequests(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *pb.UpgradeRequest
+	}{
+		{name: "image url and ref", req: &pb.UpgradeRequest{OsImageUrl: "nestos:v2", OsRef: "nestos:stable"}},
+		{name: "kube version", req: &pb.UpgradeRequest{KubeVersion: "latest"}},
+		{name: "component", req: &pb.UpgradeRequest{KubeVersion: "v1.23.10", UpgradeComponent: "kubelet"}},
+		{name: "digest of ref", req: &pb.UpgradeRequest{OsRef: "nestos:stable", OsImageDigest: "sha256:" + strings.Repeat("a", 64)}},
+		{name: "disk write limit", req: &pb.UpgradeRequest{OsImageUrl: "nestos:v2", PullDiskWriteLimit: "10MB"}},
+		{name: "bandwidth limit", req: &pb.UpgradeRequest{OsImageUrl: "nestos:v2", PullBandwidthLimit: "fast"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := newFakeRunner()
+			s := newTestServer(t, runner)
+			_, err := s.Upgrade(context.Background(), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Upgrade() error = %v, want %v", err, codes.InvalidArgument)
+			}
+			if got := runner.ran(); len(got) != 0 {
+				t.Errorf("ran %q for a rejected request", got)
+			}
+		})
+	}
+}
+
+func TestUpgradeOSVersion(t *testing.T) {
+	runner := newFakeRunner()
+	s := newTestServer(t, runner)
+	var phases []string
+	resp, err := s.upgrade(&pb.UpgradeRequest{OsImageUrl: "hub.oepkgs.net/nestos/nestos:v2"}, func(phase string, _ string) {
+		phases = append(phases, phase)
+	})
+	if err != nil {
+		t.Fatalf("upgrade() error = %v", err)
+	}
+	if !resp.GetRebootPending() || resp.GetOsVersion() != "v2" {
+		t.Errorf("upgrade() = %v", resp)
+	}
+	want := []string{
+		"rpm-ostree rebase --experimental " + ostreeImage + "hub.oepkgs.net/nestos/nestos:v2 --bypass-driver",
+		"systemctl reboot",
+	}
+	if got := runner.ran(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ran %q, want %q", got, want)
+	}
+	wantPhases := []string{PhasePullingImage, PhasePreUpgradeHooks, PhaseRebasing, PhaseRebooting, PhasePostUpgradeHooks}
+	if !reflect.DeepEqual(phases, wantPhases) {
+		t.Errorf("reported phases %v, want %v", phases, wantPhases)
+	}
+}
+
+func TestUpgradeRejectsKubeadmTarget(t *testing.T) {
+	runner := newFakeRunner().on(kubeadmCmd+" version -o short", "v1.23.10", nil)
+	s := newTestServer(t, runner)
+	_, err := s.Upgrade(context.Background(), &pb.UpgradeRequest{KubeVersion: "v1.24.0"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Upgrade() error = %v, want %v", err, codes.FailedPrecondition)
+	}
+	// 升级OS后可以重试，不标记节点
+	if common.IsFileExist(filepath.Join(s.StampDir, "kube", "v1.24.0.stamp")) {
+		t.Error("node stamped for a rejected upgrade")
+	}
+}
+
+// TestUpgradeErrorClassification pushes upgrades through the client to check how the controller sees their errors
+func TestUpgradeErrorClassification(t *testing.T) {
+	tests := []struct {
+		name string
+		info *connection.PushInfo
+		want error
+	}{
+		{name: "kubeadm too old", info: &connection.PushInfo{KubeVersion: "v1.24.0"}, want: connection.ErrPushRejected},
+		{name: "invalid component", info: &connection.PushInfo{KubeVersion: "v1.23.10", UpgradeComponent: "kubelet"},
+			want: connection.ErrPushRejected},
+		{name: "kubeadm failed", info: &connection.PushInfo{KubeVersion: "v1.23.10"}, want: connection.ErrPushTransient},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := newFakeRunner().
+				on(kubeadmCmd+" version -o short", "v1.23.12", nil).
+				on(kubeadmCmd+" upgrade node", "", errors.New("exit status 1"))
+			c := newTestClient(t, newTestServer(t, runner))
+			_, err := c.UpgradeKubeSpecStream(tt.info, func(string, string) {})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("UpgradeKubeSpecStream() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// newTestClient serves the daemon on an in-memory listener and connects a client to it
+func newTestClient(t *testing.T, s *Server) *connection.Client {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	pb.RegisterUpgradeClusterServer(grpcServer, s)
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+	c, err := connection.New("bufnet", connection.WithInsecure(),
+		connection.WithDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}))
+	if err != nil {
+		t.Fatalf("connection.New() error = %v", err)
+	}
+	return c
+}
+
+func TestVerifyImageDigest(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	inspect := skopeoCmd + " inspect --format {{.Digest}} docker://nestos:v2"
+	runner := newFakeRunner().on(inspect, "sha256:"+strings.Repeat("b", 64), nil)
+	s := newTestServer(t, runner)
+	_, err := s.Upgrade(context.Background(), &pb.UpgradeRequest{OsImageUrl: "nestos:v2", OsImageDigest: digest})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Upgrade() error = %v, want %v", err, codes.FailedPrecondition)
+	}
+	// 校验失败时不标记节点
+	if common.IsFileExist(filepath.Join(s.StampDir, "os", "v2.stamp")) {
+		t.Error("node stamped for a rejected image")
+	}
+}
+
+func TestShutdownRejectsRequests(t *testing.T) {
+	s := newTestServer(t, newFakeRunner())
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if _, err := s.Upgrade(context.Background(), &pb.UpgradeRequest{KubeVersion: "v1.23.10"}); !errors.Is(err, errShuttingDown) {
+		t.Errorf("Upgrade() error = %v, want %v", err, errShuttingDown)
+	}
+}
+
+func TestShutdownWaitsForUpgrade(t *testing.T) {
+	runner := &blockingRunner{
+		fakeRunner: newFakeRunner().on(kubeadmCmd+" version -o short", "v1.23.12\n", nil),
+		started:    make(chan struct{}),
+		release:    make(chan struct{}),
+	}
+	s := newTestServer(t, runner.fakeRunner)
+	s.Runner = runner
+
+	upgraded := make(chan error, 1)
+	go func() {
+		_, err := s.Upgrade(context.Background(), &pb.UpgradeRequest{KubeVersion: "v1.23.10"})
+		upgraded <- err
+	}()
+	select {
+	case <-runner.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("upgrade did not start")
+	}
+
+	// 升级未完成时Shutdown一直等待，直到上下文结束
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := s.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown() during the upgrade error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- s.Shutdown(context.Background())
+	}()
+	select {
+	case err := <-shutdown:
+		t.Fatalf("Shutdown() returned %v before the upgrade finished", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(runner.release)
+	select {
+	case err := <-shutdown:
+		if err != nil {
+			t.Fatalf("Shutdown() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Shutdown() did not return after the upgrade finished")
+	}
+	if err := <-upgraded; err != nil {
+		t.Errorf("Upgrade() error = %v", err)
+	}
+}
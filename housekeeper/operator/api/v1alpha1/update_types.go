@@ -27,16 +27,98 @@ import (
 type UpdateSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
-	OSImageURL     string `json:"osImageURL"`
+	// Image url used to upgrade OS, exactly one of OSImageURL and OSRef must be set
+	OSImageURL     string `json:"osImageURL,omitempty"`
 	KubeVersion    string `json:"kubeVersion"`
 	EvictPodForce  bool   `json:"evictPodForce"`
 	MaxUnavailable int    `json:"maxUnavailable"`
+	// ostree ref as <remote>:<branch> used to upgrade OS instead of OSImageURL
+	OSRef string `json:"osRef,omitempty"`
+	// Digest such as sha256:<hex> the image of OSImageURL must have, not verified when unset
+	OSImageDigest string `json:"osImageDigest,omitempty"`
+	// Disk write rate of rpm-ostreed to /sysroot while the os image is pulled and deployed on each node,
+	// in bytes per second with an optional K, M, G or T suffix such as 10M, so the pull does not starve
+	// the disk of the workloads. It does not limit the network bandwidth. Not limited when unset.
+	OSPullDiskWriteLimit string `json:"osPullDiskWriteLimit,omitempty"`
+	// Network rate the os image is downloaded with on each node, in bytes per second with an optional
+	// K, M, G or T suffix such as 10M, so the pull does not saturate the link of the workloads.
+	// The daemon applies it with a local proxy rpm-ostreed pulls through. Not limited when unset.
+	OSPullBandwidthLimit string `json:"osPullBandwidthLimit,omitempty"`
+	// Kubernetes components upgraded on master nodes: etcd, controlplane or all, defaults to all.
+	// Upgrade etcd first and then the control plane to stage the etcd upgrade.
+	UpgradeComponent string `json:"upgradeComponent,omitempty"`
+	// Timeout in seconds for draining a node, defaults to 15 minutes when unset
+	DrainTimeoutSeconds int `json:"drainTimeoutSeconds,omitempty"`
+	// Grace period in seconds for evicted pods, the pod's own value is used when unset
+	DrainGracePeriodSeconds int `json:"drainGracePeriodSeconds,omitempty"`
+	// Do not drain a node hosting critical singleton pods until they are rescheduled,
+	// only a warning event is recorded when unset
+	BlockOnCriticalPods bool `json:"blockOnCriticalPods,omitempty"`
+	// Restore the labels, annotations and taints of a node re-registered after the os upgrade reboot
+	PreserveNodeMetadata bool `json:"preserveNodeMetadata,omitempty"`
+	// Upgrade nodes again even though their upgrade stamp files exist, once per generation of the Update.
+	// Use it only after inspecting the nodes whose previous upgrade partially failed.
+	ForceUpgrade bool `json:"forceUpgrade,omitempty"`
+	// Only cordon the nodes labeled for upgrade ahead of a maintenance window,
+	// the nodes are drained and upgraded once it is cleared
+	CordonOnly bool `json:"cordonOnly,omitempty"`
+	// Maintenance window nodes are drained and upgraded in, an upgrade already pushed to a node completes
+	// after the window closes. Nodes are upgraded at any time when unset.
+	UpgradeWindow *UpgradeWindow `json:"upgradeWindow,omitempty"`
+	// Rollout policies scoped to node pools, worker nodes outside these pools use MaxUnavailable
+	PoolPolicies []PoolPolicy `json:"poolPolicies,omitempty"`
 }
 
+// UpgradeWindow is a daily maintenance window, the window ends on the next day when End is before Start
+// and lasts the whole day when End equals Start
+type UpgradeWindow struct {
+	// Time of day the window opens as HH:MM
+	Start string `json:"start"`
+	// Time of day the window closes as HH:MM
+	End string `json:"end"`
+	// Days of the week the window opens on, such as Mon or Sat, every day when empty
+	Days []string `json:"days,omitempty"`
+	// IANA time zone of Start and End, such as Asia/Shanghai, defaults to UTC
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// PoolPolicy defines the rollout policy of the worker nodes in a node pool
+type PoolPolicy struct {
+	// Name of the node pool, matched against the node pool label of nodes
+	Pool string `json:"pool"`
+	// Number of nodes in the pool that are being upgraded at the same time
+	MaxUnavailable int `json:"maxUnavailable"`
+	// Number of nodes in the pool upgraded one at a time before the rest of the pool
+	Canary int `json:"canary,omitempty"`
+	// Maintenance window nodes of the pool start upgrading in, on top of UpgradeWindow.
+	// Nodes of the pool start upgrading at any time when unset.
+	Window *UpgradeWindow `json:"window,omitempty"`
+}
+
+// Condition types of the upgrade, set for each node in Nodes and summarized for the cluster in Conditions
+const (
+	UpdateConditionDraining  = "Draining"
+	UpdateConditionUpgrading = "Upgrading"
+	UpdateConditionCompleted = "Completed"
+	UpdateConditionFailed    = "Failed"
+)
+
 // UpdateStatus defines the observed state of Update
 type UpdateStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
+	// Conditions of the upgrade of the cluster, summarized by the operator from the conditions of the nodes
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+	// Status of each node as it moves through draining and upgrading, set by the controller of the node
+	Nodes []NodeStatus `json:"nodes,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+}
+
+// NodeStatus defines the observed upgrade state of a node
+type NodeStatus struct {
+	// Name of the node
+	Name string `json:"name"`
+	// Conditions of the upgrade of the node
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 //+kubebuilder:object:root=true
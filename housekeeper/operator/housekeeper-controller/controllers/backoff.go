@@ -0,0 +1,62 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"sync"
+	"time"
+)
+
+// requeueBackoff tracks the consecutive failed reconciles of each node,
+// so a node that keeps failing is retried less and less often
+type requeueBackoff struct {
+	mu       sync.Mutex
+	base     time.Duration
+	max      time.Duration
+	failures map[string]int
+}
+
+func newRequeueBackoff(base time.Duration, max time.Duration) *requeueBackoff {
+	return &requeueBackoff{
+		base:     base,
+		max:      max,
+		failures: make(map[string]int),
+	}
+}
+
+// failure records a failed reconcile of the node and returns the delay before the next one,
+// the base delay doubled for every earlier consecutive failure and capped at the max delay
+func (b *requeueBackoff) failure(node string) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures[node]++
+	delay := b.base
+	for i := 1; i < b.failures[node] && delay < b.max; i++ {
+		delay *= 2
+	}
+	if delay > b.max {
+		delay = b.max
+	}
+	return delay
+}
+
+// reset forgets the failures of the node after a successful reconcile
+func (b *requeueBackoff) reset(node string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.failures, node)
+}
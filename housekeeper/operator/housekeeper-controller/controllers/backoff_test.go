@@ -0,0 +1,39 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRequeueBackoff(t *testing.T) {
+	b := newRequeueBackoff(10*time.Second, time.Minute)
+	want := []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute, time.Minute}
+	for i, delay := range want {
+		if got := b.failure("node1"); got != delay {
+			t.Errorf("failure %d delay = %v, want %v", i+1, got, delay)
+		}
+	}
+	if got := b.failure("node2"); got != 10*time.Second {
+		t.Errorf("failures of another node counted, delay = %v", got)
+	}
+	b.reset("node1")
+	if got := b.failure("node1"); got != 10*time.Second {
+		t.Errorf("delay after reset = %v", got)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	housekeeperiov1alpha1 "housekeeper.io/operator/api/v1alpha1"
+	"housekeeper.io/pkg/common"
+	"housekeeper.io/pkg/connection"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// setConditions sets the conditions of the node on the latest version of the Update instance, each node
+// only writes its own entry of the node statuses and the operator summarizes them for the cluster.
+// The controllers of all nodes update the status of the same instance so it is fetched again on every conflict.
+// Failing to record the status does not fail the upgrade.
+func setConditions(ctx context.Context, r common.ReadWriterClient, upInstance *housekeeperiov1alpha1.Update,
+	nodeName string, conditions ...metav1.Condition) {
+	if upInstance.Name == "" {
+		return
+	}
+	key := client.ObjectKeyFromObject(upInstance)
+	var latest housekeeperiov1alpha1.Update
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, key, &latest); err != nil {
+			return err
+		}
+		nodeStatus := findNodeStatus(&latest.Status, nodeName)
+		for _, condition := range conditions {
+			condition.ObservedGeneration = latest.Generation
+			meta.SetStatusCondition(&nodeStatus.Conditions, condition)
+		}
+		return r.Status().Update(ctx, &latest)
+	})
+	if err != nil {
+		logrus.Errorf("unable to update status of update instance %s: %v", key.Name, err)
+		return
+	}
+	upInstance.Status = latest.Status
+}
+
+// findNodeStatus returns the status entry of the node, adding it when the node has none
+func findNodeStatus(status *housekeeperiov1alpha1.UpdateStatus, nodeName string) *housekeeperiov1alpha1.NodeStatus {
+	for i := range status.Nodes {
+		if status.Nodes[i].Name == nodeName {
+			return &status.Nodes[i]
+		}
+	}
+	status.Nodes = append(status.Nodes, housekeeperiov1alpha1.NodeStatus{Name: nodeName})
+	return &status.Nodes[len(status.Nodes)-1]
+}
+
+func newCondition(conditionType string, status metav1.ConditionStatus, reason string, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// failureReason returns the reason of the Failed condition for an upgrade error
+func failureReason(err error) string {
+	switch {
+	case errors.Is(err, errDrainTimeout):
+		return "DrainTimeout"
+	case errors.Is(err, errVersionSkew):
+		return "VersionSkew"
+	case errors.Is(err, errCriticalPods):
+		return "CriticalPods"
+	case errors.Is(err, connection.ErrNodeUnreachable):
+		return "NodeUnreachable"
+	case errors.Is(err, connection.ErrPushRejected):
+		return "UpgradeRejected"
+	default:
+		return "UpgradeFailed"
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	housekeeperiov1alpha1 "housekeeper.io/operator/api/v1alpha1"
+	"housekeeper.io/pkg/connection"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestFailureReason(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: errDrainTimeout, want: "DrainTimeout"},
+		{err: errVersionSkew, want: "VersionSkew"},
+		{err: errCriticalPods, want: "CriticalPods"},
+		{err: fmt.Errorf("%w: connection refused", connection.ErrNodeUnreachable), want: "NodeUnreachable"},
+		{err: fmt.Errorf("%w: invalid kube version", connection.ErrPushRejected), want: "UpgradeRejected"},
+		{err: errors.New("rpm-ostree rebase failed"), want: "UpgradeFailed"},
+	}
+	for _, tt := range tests {
+		if got := failureReason(tt.err); got != tt.want {
+			t.Errorf("failureReason(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+// nodeCondition returns the condition of the node recorded on the Update
+func nodeCondition(t *testing.T, r client.Client, nodeName string, conditionType string) *metav1.Condition {
+	t.Helper()
+	var update housekeeperiov1alpha1.Update
+	if err := r.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "update"}, &update); err != nil {
+		t.Fatal(err)
+	}
+	for _, nodeStatus := range update.Status.Nodes {
+		if nodeStatus.Name == nodeName {
+			return meta.FindStatusCondition(nodeStatus.Conditions, conditionType)
+		}
+	}
+	return nil
+}
+
+func TestSetConditionsPerNode(t *testing.T) {
+	ctx := context.Background()
+	upInstance := newUpdate(housekeeperiov1alpha1.UpdateSpec{OSImageURL: "nestos:v2"})
+	r := newFakeClient(t, upInstance)
+
+	setConditions(ctx, r, upInstance, "worker1",
+		newCondition(housekeeperiov1alpha1.UpdateConditionDraining, metav1.ConditionTrue, "DrainStarted", "draining node worker1"))
+	draining := nodeCondition(t, r, "worker1", housekeeperiov1alpha1.UpdateConditionDraining)
+	if draining == nil || draining.Status != metav1.ConditionTrue || draining.ObservedGeneration != 1 {
+		t.Fatalf("Draining condition of worker1 = %v", draining)
+	}
+
+	// 其他节点的状态不覆盖worker1的状态
+	setConditions(ctx, r, upInstance, "worker2",
+		newCondition(housekeeperiov1alpha1.UpdateConditionDraining, metav1.ConditionFalse, "Drained", "node worker2 drained"))
+	if got := nodeCondition(t, r, "worker1", housekeeperiov1alpha1.UpdateConditionDraining); got == nil ||
+		got.Status != metav1.ConditionTrue {
+		t.Errorf("Draining condition of worker1 = %v after worker2 drained", got)
+	}
+
+	// 状态变化时更新迁移时间，状态不变时保留
+	transitioned := metav1.NewTime(draining.LastTransitionTime.Add(-time.Hour))
+	var update housekeeperiov1alpha1.Update
+	if err := r.Get(ctx, client.ObjectKeyFromObject(upInstance), &update); err != nil {
+		t.Fatal(err)
+	}
+	update.Status.Nodes[0].Conditions[0].LastTransitionTime = transitioned
+	if err := r.Status().Update(ctx, &update); err != nil {
+		t.Fatal(err)
+	}
+	setConditions(ctx, r, upInstance, "worker1",
+		newCondition(housekeeperiov1alpha1.UpdateConditionDraining, metav1.ConditionTrue, "DrainStarted", "draining node worker1 again"))
+	if got := nodeCondition(t, r, "worker1", housekeeperiov1alpha1.UpdateConditionDraining); !got.LastTransitionTime.Equal(&transitioned) {
+		t.Errorf("transition time changed to %v without a status change", got.LastTransitionTime)
+	}
+	setConditions(ctx, r, upInstance, "worker1",
+		newCondition(housekeeperiov1alpha1.UpdateConditionDraining, metav1.ConditionFalse, "Drained", "node worker1 drained"))
+	got := nodeCondition(t, r, "worker1", housekeeperiov1alpha1.UpdateConditionDraining)
+	if got.Status != metav1.ConditionFalse || got.Reason != "Drained" || !got.LastTransitionTime.After(transitioned.Time) {
+		t.Errorf("Draining condition of worker1 = %v after drained", got)
+	}
+	if len(upInstance.Status.Nodes) != 2 {
+		t.Errorf("status of the update instance not refreshed: %v", upInstance.Status)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"housekeeper.io/pkg/constants"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// every node runs its own controller, the metrics of all controllers add up to the cluster upgrade progress
+var (
+	upgradesStarted = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "housekeeper_upgrades_started_total",
+		Help: "Number of node upgrades started by draining the node",
+	})
+	upgradesSucceeded = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "housekeeper_upgrades_succeeded_total",
+		Help: "Number of node upgrades completed",
+	})
+	upgradesFailed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "housekeeper_upgrades_failed_total",
+		Help: "Number of failed node upgrade attempts by reason",
+	}, []string{"reason"})
+	nodesUpgrading = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "housekeeper_nodes_upgrading",
+		Help: "Whether the node of the controller is being upgraded",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(upgradesStarted, upgradesSucceeded, upgradesFailed, nodesUpgrading)
+}
+
+// recordNodeUpgrading sets the upgrading gauge from the upgrading label of the node
+func recordNodeUpgrading(node *corev1.Node) {
+	if _, ok := node.Labels[constants.LabelUpgrading]; ok {
+		nodesUpgrading.Set(1)
+		return
+	}
+	nodesUpgrading.Set(0)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	housekeeperiov1alpha1 "housekeeper.io/operator/api/v1alpha1"
+	"housekeeper.io/pkg/constants"
+)
+
+// upgradeMetrics are the values of the upgrade counters
+type upgradeMetrics struct {
+	started   float64
+	succeeded float64
+	failed    float64
+}
+
+func readUpgradeMetrics() upgradeMetrics {
+	return upgradeMetrics{
+		started:   testutil.ToFloat64(upgradesStarted),
+		succeeded: testutil.ToFloat64(upgradesSucceeded),
+		failed:    testutil.ToFloat64(upgradesFailed.WithLabelValues("UpgradeFailed")),
+	}
+}
+
+func TestReconcileMetrics(t *testing.T) {
+	ctx := context.Background()
+	node := newNode("node1", map[string]string{constants.LabelUpgrading: ""})
+	upInstance := newUpdate(housekeeperiov1alpha1.UpdateSpec{OSImageURL: "nestos:v2"})
+	daemon := &fakeDaemon{err: status.Error(codes.Internal, "rpm-ostree rebase failed")}
+	r, _ := newTestReconciler(t, daemon, upInstance, node)
+
+	// 计数器是全局的，只比较调和前后的差值
+	want := readUpgradeMetrics()
+	check := func(step string) {
+		t.Helper()
+		if got := readUpgradeMetrics(); got != want {
+			t.Errorf("%s: metrics = %+v, want %+v", step, got, want)
+		}
+	}
+
+	if _, err := r.Reconcile(ctx, updateRequest); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	want.started++
+	want.failed++
+	check("failed upgrade")
+	if got := testutil.ToFloat64(nodesUpgrading); got != 1 {
+		t.Errorf("failed upgrade: nodes upgrading = %v, want 1", got)
+	}
+
+	// 重试时节点已被驱逐，不重复计入开始的升级
+	daemon.err = nil
+	if _, err := r.Reconcile(ctx, updateRequest); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	check("pushed upgrade")
+
+	// 守护进程完成升级后写入标记文件
+	stamp := osStampFile(r.StampDir, "v2")
+	if err := os.MkdirAll(filepath.Dir(stamp), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(stamp, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, updateRequest); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	want.succeeded++
+	check("completed upgrade")
+	if got := testutil.ToFloat64(nodesUpgrading); got != 0 {
+		t.Errorf("completed upgrade: nodes upgrading = %v, want 0", got)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"housekeeper.io/pkg/common"
+	"housekeeper.io/pkg/constants"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// taints with this prefix are managed by the node lifecycle controller and are never restored
+const managedTaintPrefix = "node.kubernetes.io/"
+
+// nodeMetadata is the node metadata kept on the host while the os upgrade reboots the node
+type nodeMetadata struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Taints      []corev1.Taint    `json:"taints,omitempty"`
+	// boot id of the node when the snapshot was taken, it changes once the node rebooted
+	BootID string `json:"bootID,omitempty"`
+}
+
+func nodeMetadataFile() string {
+	return filepath.Join(constants.SockDir, constants.NodeMetadataFile)
+}
+
+// snapshotNodeMetadata saves the labels, annotations and taints of the node before the os upgrade,
+// an existing snapshot is kept since the node may already have lost its metadata
+func snapshotNodeMetadata(node *corev1.Node, path string) error {
+	if common.IsFileExist(path) {
+		return nil
+	}
+	snapshot := nodeMetadata{
+		Labels:      node.Labels,
+		Annotations: node.Annotations,
+		BootID:      node.Status.NodeInfo.BootID,
+	}
+	for _, taint := range node.Spec.Taints {
+		if !strings.HasPrefix(taint.Key, managedTaintPrefix) {
+			snapshot.Taints = append(snapshot.Taints, taint)
+		}
+	}
+	content, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, content, 0600)
+}
+
+func loadNodeMetadata(path string) (*nodeMetadata, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &nodeMetadata{}
+	if err := json.Unmarshal(content, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// mergeNodeMetadata adds the labels, annotations and taints of the snapshot missing on the node,
+// values set on the re-registered node win. It reports whether the node was changed.
+func mergeNodeMetadata(node *corev1.Node, snapshot *nodeMetadata) bool {
+	changed := false
+	for key, value := range snapshot.Labels {
+		if _, ok := node.Labels[key]; !ok {
+			if node.Labels == nil {
+				node.Labels = map[string]string{}
+			}
+			node.Labels[key] = value
+			changed = true
+		}
+	}
+	for key, value := range snapshot.Annotations {
+		if _, ok := node.Annotations[key]; !ok {
+			if node.Annotations == nil {
+				node.Annotations = map[string]string{}
+			}
+			node.Annotations[key] = value
+			changed = true
+		}
+	}
+	for _, taint := range snapshot.Taints {
+		found := false
+		for _, existing := range node.Spec.Taints {
+			if existing.Key == taint.Key && existing.Effect == taint.Effect {
+				found = true
+				break
+			}
+		}
+		if !found {
+			node.Spec.Taints = append(node.Spec.Taints, taint)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// isRebootedSince reports whether the node rebooted since the snapshot was taken and is Ready again,
+// a snapshot without a boot id only waits for the node to be Ready
+func isRebootedSince(node *corev1.Node, snapshot *nodeMetadata) bool {
+	bootID := node.Status.NodeInfo.BootID
+	if snapshot.BootID != "" && (bootID == "" || bootID == snapshot.BootID) {
+		return false
+	}
+	return isNodeReady(node)
+}
+
+// restoreNodeMetadata re-applies the metadata saved before the os upgrade once the node rebooted
+// into the new os and removes the snapshot, it is kept until then
+func (r *UpdateReconciler) restoreNodeMetadata(ctx context.Context, node *corev1.Node, path string) error {
+	if !common.IsFileExist(path) {
+		return nil
+	}
+	snapshot, err := loadNodeMetadata(path)
+	if err != nil {
+		logrus.Errorf("failed to load node metadata snapshot %s: %v", path, err)
+		return err
+	}
+	// 升级标记在重启前就已存在，重启前恢复后删除快照会导致重新注册的节点丢失元数据
+	if !isRebootedSince(node, snapshot) {
+		logrus.Infof("node %s has not rebooted into the new os yet, keeping its metadata snapshot", node.Name)
+		return nil
+	}
+	if mergeNodeMetadata(node.DeepCopy(), snapshot) {
+		if err := common.UpdateNode(ctx, r, node, func(node *corev1.Node) {
+			mergeNodeMetadata(node, snapshot)
+		}); err != nil {
+			logrus.Errorf("unable to restore %s node metadata: %v", node.Name, err)
+			return err
+		}
+		logrus.Infof("restored metadata of node %s lost during the os upgrade", node.Name)
+	}
+	return os.Remove(path)
+}
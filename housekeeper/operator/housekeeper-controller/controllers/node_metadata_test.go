@@ -0,0 +1,125 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"housekeeper.io/pkg/common"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSnapshotNodeMetadata(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "node-metadata.json")
+	node := newNode("node1", map[string]string{"pool": "a"})
+	node.Annotations = map[string]string{"owner": "team-a"}
+	node.Status.NodeInfo.BootID = "boot-1"
+	node.Spec.Taints = []corev1.Taint{
+		{Key: "dedicated", Value: "db", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "node.kubernetes.io/unschedulable", Effect: corev1.TaintEffectNoSchedule},
+	}
+	if err := snapshotNodeMetadata(node, path); err != nil {
+		t.Fatalf("snapshotNodeMetadata() error = %v", err)
+	}
+	// 已有的快照不会被覆盖
+	if err := snapshotNodeMetadata(newNode("node1", nil), path); err != nil {
+		t.Fatalf("snapshotNodeMetadata() error = %v", err)
+	}
+	snapshot, err := loadNodeMetadata(path)
+	if err != nil {
+		t.Fatalf("loadNodeMetadata() error = %v", err)
+	}
+	want := &nodeMetadata{
+		Labels:      node.Labels,
+		Annotations: node.Annotations,
+		Taints:      node.Spec.Taints[:1],
+		BootID:      "boot-1",
+	}
+	if !reflect.DeepEqual(snapshot, want) {
+		t.Errorf("snapshot = %+v, want %+v", snapshot, want)
+	}
+}
+
+func TestMergeNodeMetadata(t *testing.T) {
+	snapshot := &nodeMetadata{
+		Labels:      map[string]string{"pool": "a", "zone": "z1"},
+		Annotations: map[string]string{"owner": "team-a"},
+		Taints:      []corev1.Taint{{Key: "dedicated", Value: "db", Effect: corev1.TaintEffectNoSchedule}},
+	}
+	node := newNode("node1", map[string]string{"zone": "z2"})
+	if !mergeNodeMetadata(node, snapshot) {
+		t.Fatal("mergeNodeMetadata() reported no change")
+	}
+	// 重新注册的节点上已有的值优先
+	wantLabels := map[string]string{"pool": "a", "zone": "z2"}
+	if !reflect.DeepEqual(node.Labels, wantLabels) {
+		t.Errorf("labels = %v, want %v", node.Labels, wantLabels)
+	}
+	if node.Annotations["owner"] != "team-a" || len(node.Spec.Taints) != 1 {
+		t.Errorf("metadata not merged: %v, %v", node.Annotations, node.Spec.Taints)
+	}
+	if mergeNodeMetadata(node, snapshot) {
+		t.Error("merging the snapshot again changed the node")
+	}
+}
+
+func TestRestoreNodeMetadataAfterReboot(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "node-metadata.json")
+	before := newNode("node1", map[string]string{"pool": "a"})
+	before.Status.NodeInfo.BootID = "boot-1"
+	if err := snapshotNodeMetadata(before, path); err != nil {
+		t.Fatal(err)
+	}
+
+	// 节点重新注册后丢失了标签
+	node := newNode("node1", nil)
+	node.Status.NodeInfo.BootID = "boot-1"
+	r := &UpdateReconciler{Client: newFakeClient(t, node)}
+	restore := func(bootID string, ready corev1.ConditionStatus) {
+		t.Helper()
+		if err := r.Get(ctx, client.ObjectKey{Name: "node1"}, node); err != nil {
+			t.Fatal(err)
+		}
+		node.Status.NodeInfo.BootID = bootID
+		node.Status.Conditions[0].Status = ready
+		if err := r.restoreNodeMetadata(ctx, node, path); err != nil {
+			t.Fatalf("restoreNodeMetadata() error = %v", err)
+		}
+	}
+
+	restore("boot-1", corev1.ConditionTrue)
+	if _, ok := node.Labels["pool"]; ok || !common.IsFileExist(path) {
+		t.Fatal("metadata restored before the node rebooted")
+	}
+	restore("boot-2", corev1.ConditionFalse)
+	if _, ok := node.Labels["pool"]; ok || !common.IsFileExist(path) {
+		t.Fatal("metadata restored before the rebooted node is Ready")
+	}
+	restore("boot-2", corev1.ConditionTrue)
+	if node.Labels["pool"] != "a" {
+		t.Errorf("labels = %v, want the pool label restored", node.Labels)
+	}
+	if common.IsFileExist(path) {
+		t.Error("snapshot not removed after the restore")
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+	housekeeperiov1alpha1 "housekeeper.io/operator/api/v1alpha1"
+	"housekeeper.io/pkg/common"
+	"housekeeper.io/pkg/connection"
+	pb "housekeeper.io/pkg/connection/proto"
+	"housekeeper.io/pkg/constants"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubectl/pkg/drain"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// updateRequest is the reconcile request of the Update returned by newUpdate
+var updateRequest = ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "update"}}
+
+// newFakeClient returns a client serving the objects from memory
+func newFakeClient(t *testing.T, objects ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := housekeeperiov1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+// newNode returns a Ready node with the labels
+func newNode(name string, labels map[string]string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func newUpdate(spec housekeeperiov1alpha1.UpdateSpec) *housekeeperiov1alpha1.Update {
+	return &housekeeperiov1alpha1.Update{
+		ObjectMeta: metav1.ObjectMeta{Name: "update", Namespace: "default", Generation: 1},
+		Spec:       spec,
+	}
+}
+
+// fakeDaemon answers the health check and records the upgrades pushed to it, failing them with err
+type fakeDaemon struct {
+	pb.UnimplementedUpgradeClusterServer
+	mu     sync.Mutex
+	pushed []*pb.UpgradeRequest
+	err    error
+}
+
+func (d *fakeDaemon) HealthCheck(context.Context, *pb.HealthCheckRequest) (*pb.HealthCheckResponse, error) {
+	return &pb.HealthCheckResponse{OsVersion: "22.03", KubeVersion: "v1.23.1"}, nil
+}
+
+func (d *fakeDaemon) Upgrade(_ context.Context, req *pb.UpgradeRequest) (*pb.UpgradeResponse, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pushed = append(d.pushed, req)
+	if d.err != nil {
+		return nil, d.err
+	}
+	return &pb.UpgradeResponse{Actions: []string{"os-upgraded"}, RebootPending: true}, nil
+}
+
+func (d *fakeDaemon) pushes() []*pb.UpgradeRequest {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]*pb.UpgradeRequest{}, d.pushed...)
+}
+
+// fakeDrainer sets the nodes schedulable or not with the client instead of the clientset,
+// it cordons the nodes it drains like the drain would and fails the drain with err
+type fakeDrainer struct {
+	client  client.Client
+	drained []string
+	err     error
+}
+
+func (d *fakeDrainer) Cordon(drainer *drain.Helper, node *corev1.Node, desired bool) error {
+	return common.UpdateNode(drainer.Ctx, d.client, node, func(node *corev1.Node) {
+		node.Spec.Unschedulable = desired
+	})
+}
+
+func (d *fakeDrainer) Drain(drainer *drain.Helper, node *corev1.Node) error {
+	d.drained = append(d.drained, node.Name)
+	if err := d.Cordon(drainer, node, true); err != nil {
+		return err
+	}
+	return d.err
+}
+
+// newTestReconciler returns the reconciler of the controller on node1 draining with a fakeDrainer, the objects are
+// served by a fake client and the nodes and pods among them also by a fake clientset.
+// The daemon is served on an in-memory listener.
+func newTestReconciler(t *testing.T, daemon *fakeDaemon, objects ...client.Object) (*UpdateReconciler, *fakeDrainer) {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	pb.RegisterUpgradeClusterServer(s, daemon)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	conn, err := connection.New("bufnet", connection.WithInsecure(),
+		connection.WithDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var kubeObjects []runtime.Object
+	for _, object := range objects {
+		switch object.(type) {
+		case *corev1.Node, *corev1.Pod:
+			kubeObjects = append(kubeObjects, object.DeepCopyObject())
+		}
+	}
+	c := newFakeClient(t, objects...)
+	drainer := &fakeDrainer{client: c}
+	return &UpdateReconciler{
+		Client:        c,
+		KubeClientSet: kubefake.NewSimpleClientset(kubeObjects...),
+		Connection:    conn,
+		HostName:      "node1",
+		Recorder:      record.NewFakeRecorder(32),
+		Clock:         clock.RealClock{},
+		Drainer:       drainer,
+		StampDir:      t.TempDir(),
+		backoff:       newRequeueBackoff(constants.RequeueBackoffBase, constants.RequeueBackoffMax),
+	}, drainer
+}
+
+// recordedEvents returns the events recorded by the reconciler since the last call
+func recordedEvents(r *UpdateReconciler) []string {
+	var events []string
+	recorder := r.Recorder.(*record.FakeRecorder)
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
@@ -18,8 +18,14 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	housekeeperiov1alpha1 "housekeeper.io/operator/api/v1alpha1"
@@ -28,10 +34,14 @@ import (
 	"housekeeper.io/pkg/constants"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/kubectl/pkg/drain"
+	"k8s.io/utils/clock"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -46,11 +56,41 @@ type UpdateReconciler struct {
 	KubeClientSet kubernetes.Interface
 	Connection    *connection.Client
 	HostName      string
+	Recorder      record.EventRecorder
+	Clock         clock.PassiveClock
+	// cordons, uncordons and drains the node with KubeClientSet when unset
+	Drainer NodeDrainer
+	// directory of the upgrade stamp files of the daemon, constants.SockDir when unset
+	StampDir string
+	backoff  *requeueBackoff
 }
 
+// NodeDrainer sets the node schedulable or not and evicts its pods with the settings of the drain helper
+type NodeDrainer interface {
+	Cordon(drainer *drain.Helper, node *corev1.Node, desired bool) error
+	Drain(drainer *drain.Helper, node *corev1.Node) error
+}
+
+type kubeDrainer struct{}
+
+func (kubeDrainer) Cordon(drainer *drain.Helper, node *corev1.Node, desired bool) error {
+	return cordonOrUncordonNode(desired, drainer, node)
+}
+
+func (kubeDrainer) Drain(drainer *drain.Helper, node *corev1.Node) error {
+	return drainNode(drainer, node)
+}
+
+var (
+	errDrainTimeout = errors.New("timed out draining node")
+	errVersionSkew  = errors.New("kubelet version skew not allowed")
+	errCriticalPods = errors.New("node hosts critical singleton pods")
+)
+
 //+kubebuilder:rbac:groups=housekeeper.io,resources=updates,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=housekeeper.io,resources=updates/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=housekeeper.io,resources=updates/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -61,7 +101,11 @@ type UpdateReconciler struct {
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.13.0/pkg/reconcile
-func NewUpdateReconciler(mgr manager.Manager) *UpdateReconciler {
+func NewUpdateReconciler(mgr manager.Manager, nodeNameFromHostname bool) (*UpdateReconciler, error) {
+	hostName, err := getNodeName(nodeNameFromHostname)
+	if err != nil {
+		return nil, err
+	}
 	kubeClientSet, err := kubernetes.NewForConfig(mgr.GetConfig())
 	if err != nil {
 		logrus.Errorf("failed to build the kubernetes clientset: %v", err)
@@ -70,63 +114,364 @@ func NewUpdateReconciler(mgr manager.Manager) *UpdateReconciler {
 		Client:        mgr.GetClient(),
 		Scheme:        mgr.GetScheme(),
 		KubeClientSet: kubeClientSet,
-		HostName:      os.Getenv("NODE_NAME"),
+		HostName:      hostName,
+		Recorder:      mgr.GetEventRecorderFor("housekeeper-controller"),
+		Clock:         clock.RealClock{},
+		backoff:       newRequeueBackoff(constants.RequeueBackoffBase, constants.RequeueBackoffMax),
 	}
-	return reconciler
+	return reconciler, nil
+}
+
+// getNodeName returns the name of the node the controller runs on from NODE_NAME,
+// falling back to the hostname registered by kubelet when allowed
+func getNodeName(fromHostname bool) (string, error) {
+	if nodeName := strings.TrimSpace(os.Getenv(constants.EnvNodeName)); nodeName != "" {
+		return nodeName, nil
+	}
+	if !fromHostname {
+		return "", fmt.Errorf("environment variable %s is not set, set it from spec.nodeName in the DaemonSet", constants.EnvNodeName)
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("environment variable %s is not set and failed to get hostname: %w", constants.EnvNodeName, err)
+	}
+	// kubelet registers the node with the lowercased hostname by default
+	return strings.ToLower(hostname), nil
+}
+
+func (r *UpdateReconciler) nodeDrainer() NodeDrainer {
+	if r.Drainer == nil {
+		return kubeDrainer{}
+	}
+	return r.Drainer
+}
+
+func (r *UpdateReconciler) stampDir() string {
+	if r.StampDir == "" {
+		return constants.SockDir
+	}
+	return r.StampDir
 }
 
 func (r *UpdateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = log.FromContext(ctx)
 	ctx = context.Background()
 	upInstance, nodeInstance := reqInstance(ctx, r, req.NamespacedName, r.HostName)
+	defer func() {
+		if nodeInstance.Name != "" {
+			recordNodeUpgrading(&nodeInstance)
+		}
+	}()
 	kubeVersionSpec := upInstance.Spec.KubeVersion
-	osImageUrlSpec := upInstance.Spec.OSImageURL
-	osImageTag, err := common.ExtractImageTag(osImageUrlSpec)
+	osVersion, err := common.GetOSVersion(upInstance.Spec.OSImageURL, upInstance.Spec.OSRef)
 	if err != nil {
 		logrus.Info("the mirror address url parameter is invalid")
-		return common.RequeueNow, err
+		return r.requeueOnFailure(err), nil
+	}
+	force := upInstance.Spec.ForceUpgrade && !isForceApplied(&nodeInstance, upInstance.Generation)
+	nodeOSImage := nodeInstance.Status.NodeInfo.OSImage
+	if len(upInstance.Spec.OSRef) > 0 {
+		// ostree ref 指向的是分支而不是版本，只能依据标记文件判断
+		nodeOSImage = ""
 	}
-	upgradeCluster := checkUpgrade(osImageTag, kubeVersionSpec)
+	upgradeCluster := checkUpgrade(r.stampDir(), osVersion, nodeOSImage, kubeVersionSpec,
+		upInstance.Spec.UpgradeComponent, force)
 	if upgradeCluster {
-		if err := r.upgradeNodes(ctx, &upInstance, &nodeInstance); err != nil {
-			return common.RequeueNow, err
+		if wait, err := r.waitForUpgradeWindow(&upInstance, &nodeInstance); err != nil {
+			return r.requeueOnFailure(err), nil
+		} else if wait > 0 {
+			r.backoff.reset(r.HostName)
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+		if err := r.upgradeNodes(ctx, &upInstance, &nodeInstance, force); err != nil {
+			setConditions(ctx, r, &upInstance, nodeInstance.Name,
+				newCondition(housekeeperiov1alpha1.UpdateConditionFailed, metav1.ConditionTrue, failureReason(err),
+					fmt.Sprintf("node %s: %v", nodeInstance.Name, err)))
+			upgradesFailed.WithLabelValues(failureReason(err)).Inc()
+			// 守护进程拒绝的升级重试也不会成功，等待升级配置修改后再调和
+			if errors.Is(err, connection.ErrPushRejected) {
+				logrus.Errorf("upgrade of node %s rejected, not retrying: %v", nodeInstance.Name, err)
+				r.Recorder.Eventf(&nodeInstance, corev1.EventTypeWarning, "UpgradeRejected",
+					"upgrade rejected, fix the update spec to retry: %v", err)
+				r.backoff.reset(r.HostName)
+				return ctrl.Result{}, nil
+			}
+			return r.requeueOnFailure(err), nil
 		}
 	} else {
-		r.refreshNodes(ctx, &nodeInstance)
+		if nodeInstance.Name != "" {
+			if err := r.restoreNodeMetadata(ctx, &nodeInstance, nodeMetadataFile()); err != nil {
+				return r.requeueOnFailure(err), nil
+			}
+		}
+		// 升级的节点重启后须恢复Ready并运行目标版本，才能释放下一个节点
+		if ready, reason := isNodeUpgradeHealthy(&upInstance, &nodeInstance, osVersion); !ready {
+			logrus.Infof("waiting for node %s to come back after the upgrade: %s", nodeInstance.Name, reason)
+			r.backoff.reset(r.HostName)
+			return common.RequeueAfter, nil
+		}
+		r.refreshNodes(ctx, &upInstance, &nodeInstance)
 	}
+	r.backoff.reset(r.HostName)
 	return common.RequeueAfter, nil
 }
 
+// waitForUpgradeWindow returns how long the node waits for the upgrade window to open,
+// zero when it may be drained and upgraded now. Cordoning only and completing a pushed upgrade need no window.
+func (r *UpdateReconciler) waitForUpgradeWindow(upInstance *housekeeperiov1alpha1.Update, node *corev1.Node) (time.Duration, error) {
+	if _, ok := node.Labels[constants.LabelUpgrading]; !ok || upInstance.Spec.CordonOnly || isUpgradePushed(node) {
+		return 0, nil
+	}
+	open, wait, err := common.CheckUpgradeWindow(upInstance.Spec.UpgradeWindow, r.Clock.Now())
+	if err != nil || open {
+		return 0, err
+	}
+	if wait <= 0 {
+		wait = common.RequeueAfter.RequeueAfter
+	}
+	logrus.Infof("node %s waits %v for the upgrade window to open", node.Name, wait)
+	return wait, nil
+}
+
+// requeueOnFailure requeues a failed reconcile after the backoff delay of the node.
+// The error is logged here since controller-runtime drops the requeue delay returned with an error,
+// expected failures such as a drain timeout are retried no sooner than the regular interval.
+func (r *UpdateReconciler) requeueOnFailure(err error) ctrl.Result {
+	delay := r.backoff.failure(r.HostName)
+	if errors.Is(err, errDrainTimeout) || errors.Is(err, errVersionSkew) ||
+		errors.Is(err, errCriticalPods) || errors.Is(err, connection.ErrNodeUnreachable) {
+		if delay < common.RequeueAfter.RequeueAfter {
+			delay = common.RequeueAfter.RequeueAfter
+		}
+	}
+	logrus.Errorf("failed to reconcile node %s, retrying in %v: %v", r.HostName, delay, err)
+	return ctrl.Result{RequeueAfter: delay}
+}
+
 func (r *UpdateReconciler) upgradeNodes(ctx context.Context, upInstance *housekeeperiov1alpha1.Update,
-	node *corev1.Node) error {
+	node *corev1.Node, force bool) error {
 	if _, ok := node.Labels[constants.LabelUpgrading]; ok {
+		if upInstance.Spec.CordonOnly {
+			return r.cordonForUpgrade(ctx, upInstance, node)
+		}
+		if isTargetPushed(node, upInstance) {
+			// 守护进程已确认升级，控制器重启后等待节点完成升级，不再重复驱逐和下发
+			logrus.Infof("upgrade of node %s is already pushed, waiting for it to complete", node.Name)
+			return nil
+		}
+		controlPlaneVersion, err := r.checkVersionSkew(upInstance, node)
+		if err != nil {
+			return err
+		}
+		if err := r.checkCriticalPods(ctx, upInstance, node); err != nil {
+			return err
+		}
+		// 驱逐节点前确认节点上的守护进程可用
+		health, err := r.Connection.HealthCheck()
+		if err != nil {
+			logrus.Errorf("housekeeper daemon on node %s is not healthy: %v", node.Name, err)
+			return err
+		}
+		logrus.Infof("node %s runs os version %q, kubeadm version %q, master %v",
+			node.Name, health.GetOsVersion(), health.GetKubeVersion(), health.GetIsMaster())
+		drainTimeout := constants.DrainTimeout
+		if upInstance.Spec.DrainTimeoutSeconds > 0 {
+			drainTimeout = time.Duration(upInstance.Spec.DrainTimeoutSeconds) * time.Second
+		}
+		gracePeriod := -1
+		if upInstance.Spec.DrainGracePeriodSeconds > 0 {
+			gracePeriod = upInstance.Spec.DrainGracePeriodSeconds
+		}
+		drainCtx, cancel := context.WithTimeout(ctx, drainTimeout)
+		defer cancel()
 		drainer := &drain.Helper{
-			Ctx:                 ctx,
+			Ctx:                 drainCtx,
 			Client:              r.KubeClientSet,
 			IgnoreAllDaemonSets: true,
 			DeleteEmptyDirData:  true,
-			GracePeriodSeconds:  -1,
+			GracePeriodSeconds:  gracePeriod,
+			Timeout:             drainTimeout,
 			Out:                 os.Stdout,
 			ErrOut:              os.Stderr,
 		}
 		if upInstance.Spec.EvictPodForce {
 			drainer.Force = true
 		}
-		if err := drainNode(drainer, node); err != nil {
+		if isUpgradePushed(node) {
+			// 升级已下发且节点已驱逐，控制器重启后不再重复驱逐
+			logrus.Infof("node %s is already drained for the pushed upgrade, skipping drain", node.Name)
+		} else {
+			if !node.Spec.Unschedulable {
+				upgradesStarted.Inc()
+			}
+			setConditions(ctx, r, upInstance, node.Name,
+				newCondition(housekeeperiov1alpha1.UpdateConditionDraining, metav1.ConditionTrue, "DrainStarted",
+					fmt.Sprintf("draining node %s", node.Name)),
+				newCondition(housekeeperiov1alpha1.UpdateConditionCompleted, metav1.ConditionFalse, "UpgradeInProgress",
+					fmt.Sprintf("upgrading node %s", node.Name)))
+			if err := r.nodeDrainer().Drain(drainer, node); err != nil {
+				if errors.Is(err, context.DeadlineExceeded) || drainCtx.Err() == context.DeadlineExceeded {
+					logrus.Errorf("timed out draining node %s after %v: %v", node.Name, drainTimeout, err)
+					r.Recorder.Eventf(node, corev1.EventTypeWarning, "DrainTimeout",
+						"timed out draining node after %v, will retry", drainTimeout)
+					return errDrainTimeout
+				}
+				return err
+			}
+		}
+		setConditions(ctx, r, upInstance, node.Name,
+			newCondition(housekeeperiov1alpha1.UpdateConditionDraining, metav1.ConditionFalse, "Drained",
+				fmt.Sprintf("node %s drained", node.Name)),
+			newCondition(housekeeperiov1alpha1.UpdateConditionUpgrading, metav1.ConditionTrue, "UpgradePushed",
+				fmt.Sprintf("upgrading node %s", node.Name)))
+		// OS升级重启后节点可能重新注册，丢失标签、注解和污点
+		if upInstance.Spec.PreserveNodeMetadata &&
+			(len(upInstance.Spec.OSImageURL) > 0 || len(upInstance.Spec.OSRef) > 0) {
+			if err := snapshotNodeMetadata(node, nodeMetadataFile()); err != nil {
+				logrus.Errorf("failed to save metadata of node %s: %v", node.Name, err)
+				return err
+			}
+		}
+		if err := markTargetVersions(ctx, r, node, upInstance); err != nil {
 			return err
 		}
+		if force {
+			// 删除上次升级的标记文件，否则守护进程会跳过升级
+			if err := removeUpgradeStamps(r.stampDir(), upInstance); err != nil {
+				logrus.Errorf("failed to remove upgrade stamps of node %s: %v", node.Name, err)
+				return err
+			}
+		}
 		pushInfo := &connection.PushInfo{
-			KubeVersion: upInstance.Spec.KubeVersion,
-			OSImageURL:  upInstance.Spec.OSImageURL,
+			KubeVersion:         upInstance.Spec.KubeVersion,
+			OSImageURL:          upInstance.Spec.OSImageURL,
+			OSRef:               upInstance.Spec.OSRef,
+			OSImageDigest:       upInstance.Spec.OSImageDigest,
+			UpgradeComponent:    upInstance.Spec.UpgradeComponent,
+			ControlPlaneVersion: controlPlaneVersion,
+			PullDiskWriteLimit:  upInstance.Spec.OSPullDiskWriteLimit,
+			PullBandwidthLimit:  upInstance.Spec.OSPullBandwidthLimit,
 		}
-		if err := r.Connection.UpgradeKubeSpec(pushInfo); err != nil {
+		result, err := r.Connection.UpgradeKubeSpecStream(pushInfo, func(phase string, message string) {
+			logrus.Infof("upgrading node %s: %s: %s", node.Name, phase, message)
+			r.Recorder.Eventf(node, corev1.EventTypeNormal, "UpgradeProgress", "%s: %s", phase, message)
+		})
+		if err != nil {
 			return err
 		}
+		if err := markUpgradePushed(ctx, r, node); err != nil {
+			return err
+		}
+		if force {
+			if err := markForceApplied(ctx, r, node, upInstance.Generation); err != nil {
+				return err
+			}
+		}
+		logrus.Infof("node %s upgraded: os version %q, kube version %q, reboot pending %v, actions %v",
+			node.Name, result.GetOsVersion(), result.GetKubeVersion(), result.GetRebootPending(), result.GetActions())
+		r.Recorder.Eventf(node, corev1.EventTypeNormal, "Upgraded", "actions: %s",
+			strings.Join(result.GetActions(), ", "))
 	}
 	return nil
 }
 
-func (r *UpdateReconciler) refreshNodes(ctx context.Context, node *corev1.Node) error {
+// cordonForUpgrade only cordons the node, the drain and upgrade are deferred until CordonOnly is cleared
+func (r *UpdateReconciler) cordonForUpgrade(ctx context.Context, upInstance *housekeeperiov1alpha1.Update,
+	node *corev1.Node) error {
+	if node.Spec.Unschedulable {
+		return nil
+	}
+	drainer := &drain.Helper{
+		Ctx:                ctx,
+		Client:             r.KubeClientSet,
+		GracePeriodSeconds: -1,
+		Out:                os.Stdout,
+		ErrOut:             os.Stderr,
+	}
+	if err := r.nodeDrainer().Cordon(drainer, node, true); err != nil {
+		logrus.Errorf("failed to cordon node %s: %v", node.Name, err)
+		return err
+	}
+	// 节点已不可调度，清除CordonOnly后驱逐时不再重复计数
+	upgradesStarted.Inc()
+	logrus.Infof("node %s cordoned, waiting for cordonOnly to be cleared before upgrading", node.Name)
+	r.Recorder.Event(node, corev1.EventTypeNormal, "Cordoned", "node cordoned for the upgrade, drain deferred")
+	setConditions(ctx, r, upInstance, node.Name,
+		newCondition(housekeeperiov1alpha1.UpdateConditionCompleted, metav1.ConditionFalse, "CordonOnly",
+			fmt.Sprintf("node %s cordoned, upgrade deferred", node.Name)))
+	return nil
+}
+
+// Worker kubelets must wait until the control plane has been upgraded,
+// the apiserver version is returned so the daemon checks it again before upgrading
+func (r *UpdateReconciler) checkVersionSkew(upInstance *housekeeperiov1alpha1.Update, node *corev1.Node) (string, error) {
+	if len(upInstance.Spec.KubeVersion) == 0 {
+		return "", nil
+	}
+	if _, ok := node.Labels[constants.LabelMaster]; ok {
+		return "", nil
+	}
+	serverVersion, err := r.KubeClientSet.Discovery().ServerVersion()
+	if err != nil {
+		logrus.Errorf("failed to get apiserver version: %v", err)
+		return "", err
+	}
+	if err := common.CheckKubeletVersionSkew(serverVersion.GitVersion, upInstance.Spec.KubeVersion); err != nil {
+		logrus.Errorf("refusing to upgrade node %s: %v", node.Name, err)
+		r.Recorder.Eventf(node, corev1.EventTypeWarning, "VersionSkew",
+			"refusing to upgrade kubelet: %v, will retry", err)
+		return "", errVersionSkew
+	}
+	return serverVersion.GitVersion, nil
+}
+
+// Warns about, or blocks on, critical singleton pods that draining would evict
+func (r *UpdateReconciler) checkCriticalPods(ctx context.Context, upInstance *housekeeperiov1alpha1.Update,
+	node *corev1.Node) error {
+	pods, err := r.KubeClientSet.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node.Name).String(),
+	})
+	if err != nil {
+		logrus.Errorf("failed to list pods on node %s: %v", node.Name, err)
+		return err
+	}
+	criticalPods := getCriticalPods(pods.Items)
+	if len(criticalPods) == 0 {
+		return nil
+	}
+
+	if upInstance.Spec.BlockOnCriticalPods {
+		logrus.Errorf("refusing to drain node %s hosting critical pods %v", node.Name, criticalPods)
+		r.Recorder.Eventf(node, corev1.EventTypeWarning, "CriticalPods",
+			"refusing to drain node hosting critical pods %s, reschedule them first", strings.Join(criticalPods, ", "))
+		return errCriticalPods
+	}
+	logrus.Warnf("draining node %s evicts critical pods %v", node.Name, criticalPods)
+	r.Recorder.Eventf(node, corev1.EventTypeWarning, "CriticalPods",
+		"draining node evicts critical pods %s", strings.Join(criticalPods, ", "))
+	return nil
+}
+
+// getCriticalPods returns the running pods annotated as critical that are not managed by a DaemonSet
+func getCriticalPods(pods []corev1.Pod) []string {
+	var criticalPods []string
+	for _, pod := range pods {
+		if pod.Annotations[constants.AnnotationCriticalPod] != "true" {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if controllerRef := metav1.GetControllerOf(&pod); controllerRef != nil && controllerRef.Kind == "DaemonSet" {
+			continue
+		}
+		criticalPods = append(criticalPods, pod.Namespace+"/"+pod.Name)
+	}
+	return criticalPods
+}
+
+func (r *UpdateReconciler) refreshNodes(ctx context.Context, upInstance *housekeeperiov1alpha1.Update,
+	node *corev1.Node) error {
 	if node.Spec.Unschedulable {
 		drainer := &drain.Helper{
 			Ctx:                ctx,
@@ -135,7 +480,7 @@ func (r *UpdateReconciler) refreshNodes(ctx context.Context, node *corev1.Node)
 			Out:                os.Stdout,
 			ErrOut:             os.Stderr,
 		}
-		if err := cordonOrUncordonNode(false, drainer, node); err != nil {
+		if err := r.nodeDrainer().Cordon(drainer, node, false); err != nil {
 			logrus.Errorf("failed to uncordon node %s: %v", node.Name, err)
 			return err
 		}
@@ -145,18 +490,185 @@ func (r *UpdateReconciler) refreshNodes(ctx context.Context, node *corev1.Node)
 		if err := addUpgradeCompletedLabel(ctx, r, node); err != nil {
 			return err
 		}
-		delete(node.Labels, constants.LabelUpgrading)
-		if err := r.Update(ctx, node); err != nil {
-			logrus.Errorf("unable to delete %s node label: %v", node.Name, err)
+		upgradesSucceeded.Inc()
+		message := fmt.Sprintf("node %s upgraded", node.Name)
+		setConditions(ctx, r, upInstance, node.Name,
+			newCondition(housekeeperiov1alpha1.UpdateConditionUpgrading, metav1.ConditionFalse, "NodeUpgraded", message),
+			newCondition(housekeeperiov1alpha1.UpdateConditionCompleted, metav1.ConditionTrue, "NodeUpgraded", message),
+			newCondition(housekeeperiov1alpha1.UpdateConditionFailed, metav1.ConditionFalse, "NodeUpgraded", message))
+	}
+	if _, ok := node.Annotations[constants.AnnotationUpgradePushed]; ok {
+		if err := common.UpdateNode(ctx, r, node, func(node *corev1.Node) {
+			delete(node.Annotations, constants.AnnotationUpgradePushed)
+		}); err != nil {
+			logrus.Errorf("unable to delete %s node annotation: %v", node.Name, err)
 			return err
 		}
 	}
 	return nil
 }
 
+// isNodeUpgradeHealthy reports whether an upgraded node is Ready and reports the versions of the spec,
+// otherwise it returns what the node is waiting for. Nodes that are not upgrading are always healthy.
+func isNodeUpgradeHealthy(upInstance *housekeeperiov1alpha1.Update, node *corev1.Node, osVersion string) (bool, string) {
+	if _, ok := node.Labels[constants.LabelUpgrading]; !ok {
+		return true, ""
+	}
+	if !isNodeReady(node) {
+		return false, "node is not Ready"
+	}
+	if upInstance.Spec.CordonOnly {
+		return true, ""
+	}
+	nodeInfo := node.Status.NodeInfo
+	if len(upInstance.Spec.KubeVersion) > 0 {
+		// 分阶段升级etcd或控制平面时不升级kubelet，其版本不能反映升级结果
+		component, err := common.ParseUpgradeComponent(upInstance.Spec.UpgradeComponent)
+		if err != nil || component != common.UpgradeComponentAll {
+			return true, ""
+		}
+		if strings.TrimPrefix(nodeInfo.KubeletVersion, "v") != strings.TrimPrefix(upInstance.Spec.KubeVersion, "v") {
+			return false, fmt.Sprintf("kubelet version is %s, want %s", nodeInfo.KubeletVersion, upInstance.Spec.KubeVersion)
+		}
+		return true, ""
+	}
+	// ostree ref 和无法解析的os镜像版本只能依据标记文件判断
+	if len(upInstance.Spec.OSRef) > 0 {
+		return true, ""
+	}
+	if _, err := parseOSVersion(nodeInfo.OSImage); err == nil && !isOSVersionCurrent(nodeInfo.OSImage, osVersion) {
+		return false, fmt.Sprintf("os image is %s, want %s", nodeInfo.OSImage, osVersion)
+	}
+	return true, ""
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// isUpgradePushed reports whether the node is cordoned and the daemon has acknowledged its upgrade
+func isUpgradePushed(node *corev1.Node) bool {
+	_, ok := node.Annotations[constants.AnnotationUpgradePushed]
+	return ok && node.Spec.Unschedulable
+}
+
+// isTargetPushed reports whether the upgrade to the versions of the spec was already pushed to the node
+func isTargetPushed(node *corev1.Node, upInstance *housekeeperiov1alpha1.Update) bool {
+	if !isUpgradePushed(node) {
+		return false
+	}
+	osVersion, err := common.GetOSVersion(upInstance.Spec.OSImageURL, upInstance.Spec.OSRef)
+	if err != nil {
+		return false
+	}
+	return node.Annotations[constants.AnnotationTargetOSVersion] == osVersion &&
+		node.Annotations[constants.AnnotationTargetKubeVersion] == upInstance.Spec.KubeVersion
+}
+
+func markUpgradePushed(ctx context.Context, r common.ReadWriterClient, node *corev1.Node) error {
+	if _, ok := node.Annotations[constants.AnnotationUpgradePushed]; ok {
+		return nil
+	}
+	// 驱逐时节点已被修改，UpdateNode 每次都重新获取最新版本
+	if err := common.UpdateNode(ctx, r, node, func(node *corev1.Node) {
+		if node.Annotations == nil {
+			node.Annotations = map[string]string{}
+		}
+		node.Annotations[constants.AnnotationUpgradePushed] = ""
+	}); err != nil {
+		logrus.Errorf("unable to add %s node annotation: %v", constants.AnnotationUpgradePushed, err)
+		return err
+	}
+	return nil
+}
+
+// markTargetVersions records the versions pushed to the node, versions not requested are removed
+func markTargetVersions(ctx context.Context, r common.ReadWriterClient, node *corev1.Node,
+	upInstance *housekeeperiov1alpha1.Update) error {
+	osVersion, err := common.GetOSVersion(upInstance.Spec.OSImageURL, upInstance.Spec.OSRef)
+	if err != nil {
+		return err
+	}
+	targets := map[string]string{
+		constants.AnnotationTargetOSVersion:   osVersion,
+		constants.AnnotationTargetKubeVersion: upInstance.Spec.KubeVersion,
+	}
+	// 驱逐时节点已被修改，UpdateNode 每次都重新获取最新版本
+	if err := common.UpdateNode(ctx, r, node, func(node *corev1.Node) {
+		if node.Annotations == nil {
+			node.Annotations = map[string]string{}
+		}
+		for key, version := range targets {
+			if version == "" {
+				delete(node.Annotations, key)
+			} else {
+				node.Annotations[key] = version
+			}
+		}
+	}); err != nil {
+		logrus.Errorf("unable to record target versions of node %s: %v", node.Name, err)
+		return err
+	}
+	return nil
+}
+
+// isForceApplied reports whether the forced upgrade of the generation was already pushed to the node
+func isForceApplied(node *corev1.Node, generation int64) bool {
+	return node.Annotations[constants.AnnotationForcedGeneration] == strconv.FormatInt(generation, 10)
+}
+
+func markForceApplied(ctx context.Context, r common.ReadWriterClient, node *corev1.Node, generation int64) error {
+	if err := common.UpdateNode(ctx, r, node, func(node *corev1.Node) {
+		if node.Annotations == nil {
+			node.Annotations = map[string]string{}
+		}
+		node.Annotations[constants.AnnotationForcedGeneration] = strconv.FormatInt(generation, 10)
+	}); err != nil {
+		logrus.Errorf("unable to add %s node annotation: %v", constants.AnnotationForcedGeneration, err)
+		return err
+	}
+	return nil
+}
+
+// removeUpgradeStamps removes the stamp files in stampDir the daemon skips the upgrade for
+func removeUpgradeStamps(stampDir string, upInstance *housekeeperiov1alpha1.Update) error {
+	var stamps []string
+	if len(upInstance.Spec.KubeVersion) > 0 {
+		component, err := common.ParseUpgradeComponent(upInstance.Spec.UpgradeComponent)
+		if err != nil {
+			return err
+		}
+		stamps = append(stamps, kubeStampFile(stampDir, upInstance.Spec.KubeVersion, component))
+	}
+	if len(upInstance.Spec.OSImageURL) > 0 || len(upInstance.Spec.OSRef) > 0 {
+		osVersion, err := common.GetOSVersion(upInstance.Spec.OSImageURL, upInstance.Spec.OSRef)
+		if err != nil {
+			return err
+		}
+		stamps = append(stamps, osStampFile(stampDir, osVersion))
+	}
+	for _, stamp := range stamps {
+		if err := os.Remove(stamp); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// addUpgradeCompletedLabel replaces the upgrading label of the node with the upgrade completed label
 func addUpgradeCompletedLabel(ctx context.Context, r common.ReadWriterClient, node *corev1.Node) error {
-	node.Labels[constants.LabelUpgradeCompleted] = ""
-	if err := r.Update(ctx, node); err != nil {
+	if err := common.UpdateNode(ctx, r, node, func(node *corev1.Node) {
+		if node.Labels == nil {
+			node.Labels = map[string]string{}
+		}
+		node.Labels[constants.LabelUpgradeCompleted] = ""
+		delete(node.Labels, constants.LabelUpgrading)
+	}); err != nil {
 		logrus.Errorf("unable to add %s node label: %v", constants.LabelUpgradeCompleted, err)
 		return err
 	}
@@ -188,7 +700,7 @@ func drainNode(drainer *drain.Helper, node *corev1.Node) error {
 	// Attempt drain
 	logrus.Info(node.Name, " initiating drain")
 	if err := drain.RunNodeDrain(drainer, node.Name); err != nil {
-		return fmt.Errorf("unable to drain: %v", err)
+		return fmt.Errorf("unable to drain: %w", err)
 	}
 	return nil
 }
@@ -206,24 +718,70 @@ func reqInstance(ctx context.Context, r common.ReadWriterClient, name types.Name
 	return
 }
 
-// Check if the version is upgraded
-func checkUpgrade(osImageUrlSpec string, kubeVersionSpec string) bool {
+// Check if the version is upgraded, the os is also up to date when the node already reports the os version.
+// The stamp files are those the daemon creates in stampDir, staged upgrades are stamped per component.
+func checkUpgrade(stampDir string, osVersion string, nodeOSImage string, kubeVersionSpec string,
+	upgradeComponent string, force bool) bool {
+	if force {
+		return true
+	}
 	if len(kubeVersionSpec) > 0 {
-		markFile := fmt.Sprintf("%s/%s/%s%s", constants.SockDir, "kube", kubeVersionSpec, ".stamp")
-		// fmt.Printf("markkubeFile: %s\n", markFile)
-		if common.IsFileExist(markFile) {
+		component, err := common.ParseUpgradeComponent(upgradeComponent)
+		if err != nil {
+			// 由守护进程拒绝无效的组件
+			return true
+		}
+		if common.IsFileExist(kubeStampFile(stampDir, kubeVersionSpec, component)) {
 			return false
 		}
 	} else {
-		markFile := fmt.Sprintf("%s/%s/%s%s", constants.SockDir, "os", osImageUrlSpec, ".stamp")
-		// fmt.Printf("markosFile: %s\n", markFile)
-		if common.IsFileExist(markFile) {
+		if len(nodeOSImage) > 0 && isOSVersionCurrent(nodeOSImage, osVersion) {
+			return false
+		}
+		if common.IsFileExist(osStampFile(stampDir, osVersion)) {
 			return false
 		}
 	}
 	return true
 }
 
+// kubeStampFile returns the stamp file the daemon creates once it upgrades the component to the kubernetes version
+func kubeStampFile(stampDir string, kubeVersion string, component string) string {
+	return filepath.Join(stampDir, "kube", common.KubeStampName(kubeVersion, component)+".stamp")
+}
+
+// osStampFile returns the stamp file the daemon creates once it upgrades the os to the version
+func osStampFile(stampDir string, osVersion string) string {
+	return filepath.Join(stampDir, "os", common.OSStampName(osVersion)+".stamp")
+}
+
+// osVersionPattern matches a dotted version with optional suffixes, e.g. 22.03-LTS-SP2.20230928.0
+var osVersionPattern = regexp.MustCompile(`\d+(\.\d+)+([-.+~][0-9A-Za-z]+)*`)
+
+// parseOSVersion extracts the version from the free-form os image reported by the node,
+// e.g. "NestOS For Container 22.03-LTS-SP2.20230928.0" or "openEuler 22.03 (LTS-SP1)"
+func parseOSVersion(osImage string) (string, error) {
+	version := osVersionPattern.FindString(osImage)
+	if version == "" {
+		return "", fmt.Errorf("no version in os image %q", osImage)
+	}
+	return version, nil
+}
+
+// isOSVersionCurrent reports whether the os image of the node has the version the os is upgraded to,
+// versions that cannot be parsed are never current so the stamp file decides
+func isOSVersionCurrent(nodeOSImage string, osVersion string) bool {
+	current, err := parseOSVersion(nodeOSImage)
+	if err != nil {
+		return false
+	}
+	target, err := parseOSVersion(osVersion)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(current, target)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *UpdateReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -0,0 +1,463 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	housekeeperiov1alpha1 "housekeeper.io/operator/api/v1alpha1"
+	"housekeeper.io/pkg/common"
+	"housekeeper.io/pkg/constants"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestGetNodeName(t *testing.T) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name         string
+		nodeName     string
+		fromHostname bool
+		want         string
+		wantErr      bool
+	}{
+		{name: "set", nodeName: "worker1", want: "worker1"},
+		{name: "trimmed", nodeName: " worker1\n", want: "worker1"},
+		{name: "empty", nodeName: "", wantErr: true},
+		{name: "blank", nodeName: "  ", wantErr: true},
+		{name: "hostname fallback", nodeName: "", fromHostname: true, want: strings.ToLower(hostname)},
+		{name: "set with fallback", nodeName: "worker1", fromHostname: true, want: "worker1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(constants.EnvNodeName, tt.nodeName)
+			got, err := getNodeName(tt.fromHostname)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getNodeName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("getNodeName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// 节点名为空时不创建控制器，否则会调和其他节点
+	t.Setenv(constants.EnvNodeName, "")
+	if _, err := NewUpdateReconciler(nil, false); err == nil {
+		t.Error("NewUpdateReconciler() without a node name succeeded")
+	}
+}
+
+func TestParseOSVersion(t *testing.T) {
+	tests := []struct {
+		osImage string
+		want    string
+		wantErr bool
+	}{
+		{osImage: "NestOS For Container 22.03-LTS-SP2.20230928.0", want: "22.03-LTS-SP2.20230928.0"},
+		{osImage: "openEuler 22.03 (LTS-SP1)", want: "22.03"},
+		{osImage: "NestOS", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseOSVersion(tt.osImage)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("parseOSVersion(%q) error = %v, wantErr %v", tt.osImage, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("parseOSVersion(%q) = %q, want %q", tt.osImage, got, tt.want)
+		}
+	}
+}
+
+func TestIsOSVersionCurrent(t *testing.T) {
+	nodeOSImage := "NestOS For Container 22.03-LTS-SP2.20230928.0"
+	if !isOSVersionCurrent(nodeOSImage, "22.03-lts-sp2.20230928.0") {
+		t.Error("same version is not current")
+	}
+	if isOSVersionCurrent(nodeOSImage, "22.03-LTS-SP2.20231228.0") {
+		t.Error("older version is current")
+	}
+	if isOSVersionCurrent("NestOS", "22.03") {
+		t.Error("unparsable os image is current")
+	}
+}
+
+func TestIsNodeUpgradeHealthy(t *testing.T) {
+	upgrading := map[string]string{constants.LabelUpgrading: ""}
+	notReady := newNode("node1", upgrading)
+	notReady.Status.Conditions[0].Status = corev1.ConditionFalse
+	withVersions := func(kubelet string, osImage string) *corev1.Node {
+		node := newNode("node1", upgrading)
+		node.Status.NodeInfo = corev1.NodeSystemInfo{KubeletVersion: kubelet, OSImage: osImage}
+		return node
+	}
+	tests := []struct {
+		name      string
+		spec      housekeeperiov1alpha1.UpdateSpec
+		node      *corev1.Node
+		osVersion string
+		want      bool
+	}{
+		{name: "not upgrading", node: newNode("node1", nil), want: true},
+		{name: "not ready", node: notReady, want: false},
+		{name: "kubelet upgraded", spec: housekeeperiov1alpha1.UpdateSpec{KubeVersion: "v1.23.10"},
+			node: withVersions("v1.23.10", ""), want: true},
+		{name: "kubelet not upgraded", spec: housekeeperiov1alpha1.UpdateSpec{KubeVersion: "v1.23.10"},
+			node: withVersions("v1.23.1", ""), want: false},
+		{name: "etcd staged", spec: housekeeperiov1alpha1.UpdateSpec{KubeVersion: "v1.23.10", UpgradeComponent: "etcd"},
+			node: withVersions("v1.23.1", ""), want: true},
+		{name: "control plane staged", spec: housekeeperiov1alpha1.UpdateSpec{KubeVersion: "v1.23.10", UpgradeComponent: "controlplane"},
+			node: withVersions("v1.23.1", ""), want: true},
+		{name: "os upgraded", spec: housekeeperiov1alpha1.UpdateSpec{OSImageURL: "nestos:22.03.20231228.0"},
+			node: withVersions("", "NestOS For Container 22.03.20231228.0"), osVersion: "22.03.20231228.0", want: true},
+		{name: "os not upgraded", spec: housekeeperiov1alpha1.UpdateSpec{OSImageURL: "nestos:22.03.20231228.0"},
+			node: withVersions("", "NestOS For Container 22.03.20230928.0"), osVersion: "22.03.20231228.0", want: false},
+		{name: "ostree ref", spec: housekeeperiov1alpha1.UpdateSpec{OSRef: "nestos:stable"},
+			node: withVersions("", "NestOS For Container 22.03.20230928.0"), osVersion: "nestos:stable", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := isNodeUpgradeHealthy(newUpdate(tt.spec), tt.node, tt.osVersion)
+			if got != tt.want {
+				t.Errorf("isNodeUpgradeHealthy() = %v, %q, want %v", got, reason, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckUpgrade(t *testing.T) {
+	stampDir := t.TempDir()
+	for _, stamp := range []string{"kube/v1.23.10-etcd.stamp", "kube/v1.23.11.stamp", "os/v2.stamp"} {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(stampDir, stamp)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(stampDir, stamp), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		name        string
+		osVersion   string
+		nodeOSImage string
+		kubeVersion string
+		component   string
+		force       bool
+		want        bool
+	}{
+		{name: "etcd stamped", kubeVersion: "v1.23.10", component: "etcd", want: false},
+		{name: "control plane not stamped", kubeVersion: "v1.23.10", component: "controlplane", want: true},
+		{name: "all not stamped", kubeVersion: "v1.23.10", want: true},
+		{name: "all stamped", kubeVersion: "v1.23.11", component: "all", want: false},
+		{name: "invalid component", kubeVersion: "v1.23.11", component: "kubelet", want: true},
+		{name: "forced", kubeVersion: "v1.23.11", force: true, want: true},
+		{name: "os stamped", osVersion: "v2", want: false},
+		{name: "os not stamped", osVersion: "v3", want: true},
+		{name: "os reported", osVersion: "22.03.20231228.0", nodeOSImage: "NestOS For Container 22.03.20231228.0", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkUpgrade(stampDir, tt.osVersion, tt.nodeOSImage, tt.kubeVersion, tt.component, tt.force); got != tt.want {
+				t.Errorf("checkUpgrade() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkTargetVersions(t *testing.T) {
+	ctx := context.Background()
+	node := newNode("node1", nil)
+	node.Annotations = map[string]string{constants.AnnotationTargetKubeVersion: "v1.23.1"}
+	r := newFakeClient(t, node)
+
+	upInstance := newUpdate(housekeeperiov1alpha1.UpdateSpec{OSImageURL: "nestos:v2"})
+	if err := markTargetVersions(ctx, r, node, upInstance); err != nil {
+		t.Fatalf("markTargetVersions() error = %v", err)
+	}
+	var got corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: "node1"}, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{constants.AnnotationTargetOSVersion: "v2"}
+	if !reflect.DeepEqual(got.Annotations, want) {
+		t.Errorf("annotations = %v, want %v", got.Annotations, want)
+	}
+}
+
+func TestAddUpgradeCompletedLabel(t *testing.T) {
+	ctx := context.Background()
+	node := newNode("node1", map[string]string{constants.LabelUpgrading: "", "pool": "a"})
+	r := newFakeClient(t, node)
+	if err := addUpgradeCompletedLabel(ctx, r, node); err != nil {
+		t.Fatalf("addUpgradeCompletedLabel() error = %v", err)
+	}
+	want := map[string]string{constants.LabelUpgradeCompleted: "", "pool": "a"}
+	if !reflect.DeepEqual(node.Labels, want) {
+		t.Errorf("labels = %v, want %v", node.Labels, want)
+	}
+}
+
+func TestForceApplied(t *testing.T) {
+	ctx := context.Background()
+	node := newNode("node1", nil)
+	r := newFakeClient(t, node)
+	if isForceApplied(node, 2) {
+		t.Fatal("force applied before it is marked")
+	}
+	if err := markForceApplied(ctx, r, node, 2); err != nil {
+		t.Fatalf("markForceApplied() error = %v", err)
+	}
+	if !isForceApplied(node, 2) || isForceApplied(node, 3) {
+		t.Errorf("force applied annotation = %q", node.Annotations[constants.AnnotationForcedGeneration])
+	}
+}
+
+func TestGetCriticalPods(t *testing.T) {
+	critical := map[string]string{constants.AnnotationCriticalPod: "true"}
+	isController := true
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "coredns", Annotations: critical}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "done", Annotations: critical},
+			Status: corev1.PodStatus{Phase: corev1.PodSucceeded}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "proxy", Annotations: critical,
+			OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "proxy", Controller: &isController}}}},
+	}
+	want := []string{"kube-system/coredns"}
+	if got := getCriticalPods(pods); !reflect.DeepEqual(got, want) {
+		t.Errorf("getCriticalPods() = %v, want %v", got, want)
+	}
+}
+
+// hasEvent reports whether an event of the type and reason is among the recorded events
+func hasEvent(events []string, eventType string, reason string) bool {
+	for _, event := range events {
+		if strings.HasPrefix(event, eventType+" "+reason+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+func TestReconcileDrainTimeout(t *testing.T) {
+	node := newNode("node1", map[string]string{constants.LabelUpgrading: ""})
+	upInstance := newUpdate(housekeeperiov1alpha1.UpdateSpec{OSImageURL: "nestos:v2", DrainTimeoutSeconds: 1})
+	daemon := &fakeDaemon{}
+	r, drainer := newTestReconciler(t, daemon, upInstance, node)
+	drainer.err = fmt.Errorf("unable to drain: %w", context.DeadlineExceeded)
+
+	result, err := r.Reconcile(context.Background(), updateRequest)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter < common.RequeueAfter.RequeueAfter {
+		t.Errorf("Reconcile() requeues after %v, want at least %v", result.RequeueAfter, common.RequeueAfter.RequeueAfter)
+	}
+	if len(drainer.drained) != 1 {
+		t.Errorf("drained nodes = %v, want node1", drainer.drained)
+	}
+	if events := recordedEvents(r); !hasEvent(events, corev1.EventTypeWarning, "DrainTimeout") {
+		t.Errorf("events = %v, want a DrainTimeout warning", events)
+	}
+	if pushes := daemon.pushes(); len(pushes) != 0 {
+		t.Errorf("upgrade pushed after the drain timed out: %v", pushes)
+	}
+	failed := nodeCondition(t, r.Client, "node1", housekeeperiov1alpha1.UpdateConditionFailed)
+	if failed == nil || failed.Status != metav1.ConditionTrue || failed.Reason != "DrainTimeout" {
+		t.Errorf("Failed condition of node1 = %v", failed)
+	}
+}
+
+func TestReconcileAfterRestart(t *testing.T) {
+	tests := []struct {
+		name       string
+		targetOS   string
+		wantPushes int
+	}{
+		// 控制器在升级下发后重启
+		{name: "same target", targetOS: "v2", wantPushes: 0},
+		// 上次下发后升级配置已修改
+		{name: "new target", targetOS: "v1", wantPushes: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := newNode("node1", map[string]string{constants.LabelUpgrading: ""})
+			node.Spec.Unschedulable = true
+			node.Annotations = map[string]string{
+				constants.AnnotationUpgradePushed:   "",
+				constants.AnnotationTargetOSVersion: tt.targetOS,
+			}
+			upInstance := newUpdate(housekeeperiov1alpha1.UpdateSpec{OSImageURL: "nestos:v2"})
+			daemon := &fakeDaemon{}
+			r, drainer := newTestReconciler(t, daemon, upInstance, node)
+
+			if _, err := r.Reconcile(context.Background(), updateRequest); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if len(drainer.drained) != 0 {
+				t.Errorf("drained nodes = %v, want none", drainer.drained)
+			}
+			if pushes := daemon.pushes(); len(pushes) != tt.wantPushes {
+				t.Errorf("pushed %d upgrades, want %d", len(pushes), tt.wantPushes)
+			}
+		})
+	}
+}
+
+func TestReconcilePushesTargetVersions(t *testing.T) {
+	ctx := context.Background()
+	node := newNode("node1", map[string]string{constants.LabelUpgrading: "", constants.LabelMaster: ""})
+	upInstance := newUpdate(housekeeperiov1alpha1.UpdateSpec{KubeVersion: "v1.23.10", OSImageURL: "nestos:v2"})
+	daemon := &fakeDaemon{}
+	r, _ := newTestReconciler(t, daemon, upInstance, node)
+
+	if _, err := r.Reconcile(ctx, updateRequest); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	pushes := daemon.pushes()
+	if len(pushes) != 1 {
+		t.Fatalf("pushed %d upgrades, want 1", len(pushes))
+	}
+	var got corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: "node1"}, &got); err != nil {
+		t.Fatal(err)
+	}
+	pushedOSVersion, err := common.GetOSVersion(pushes[0].GetOsImageUrl(), pushes[0].GetOsRef())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target := got.Annotations[constants.AnnotationTargetOSVersion]; target != "v2" || target != pushedOSVersion {
+		t.Errorf("target os version = %q, pushed %q", target, pushedOSVersion)
+	}
+	if target := got.Annotations[constants.AnnotationTargetKubeVersion]; target != "v1.23.10" || target != pushes[0].GetKubeVersion() {
+		t.Errorf("target kube version = %q, pushed %q", target, pushes[0].GetKubeVersion())
+	}
+	if _, ok := got.Annotations[constants.AnnotationUpgradePushed]; !ok {
+		t.Errorf("annotations = %v, want %s", got.Annotations, constants.AnnotationUpgradePushed)
+	}
+}
+
+func TestReconcileCordonOnlyThenUpgrade(t *testing.T) {
+	ctx := context.Background()
+	node := newNode("node1", map[string]string{constants.LabelUpgrading: ""})
+	upInstance := newUpdate(housekeeperiov1alpha1.UpdateSpec{OSImageURL: "nestos:v2", CordonOnly: true})
+	daemon := &fakeDaemon{}
+	r, drainer := newTestReconciler(t, daemon, upInstance, node)
+	started := testutil.ToFloat64(upgradesStarted)
+
+	if _, err := r.Reconcile(ctx, updateRequest); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	var got corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: "node1"}, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Spec.Unschedulable {
+		t.Error("node not cordoned")
+	}
+	if len(drainer.drained) != 0 || len(daemon.pushes()) != 0 {
+		t.Errorf("cordon only drained %v and pushed %d upgrades", drainer.drained, len(daemon.pushes()))
+	}
+	if events := recordedEvents(r); !hasEvent(events, corev1.EventTypeNormal, "Cordoned") {
+		t.Errorf("events = %v, want Cordoned", events)
+	}
+	completed := nodeCondition(t, r.Client, "node1", housekeeperiov1alpha1.UpdateConditionCompleted)
+	if completed == nil || completed.Status != metav1.ConditionFalse || completed.Reason != "CordonOnly" {
+		t.Errorf("Completed condition of node1 = %v", completed)
+	}
+
+	// 清除cordonOnly后驱逐并升级节点
+	var update housekeeperiov1alpha1.Update
+	if err := r.Get(ctx, updateRequest.NamespacedName, &update); err != nil {
+		t.Fatal(err)
+	}
+	update.Spec.CordonOnly = false
+	if err := r.Update(ctx, &update); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, updateRequest); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if !reflect.DeepEqual(drainer.drained, []string{"node1"}) {
+		t.Errorf("drained nodes = %v, want node1", drainer.drained)
+	}
+	if pushes := daemon.pushes(); len(pushes) != 1 || pushes[0].GetOsImageUrl() != "nestos:v2" {
+		t.Errorf("pushed upgrades = %v", pushes)
+	}
+	// 只在cordon时计入一次升级
+	if got := testutil.ToFloat64(upgradesStarted) - started; got != 1 {
+		t.Errorf("started upgrades = %v, want 1", got)
+	}
+}
+
+func TestReconcileCriticalPods(t *testing.T) {
+	tests := []struct {
+		name       string
+		block      bool
+		wantDrains int
+	}{
+		{name: "warn", block: false, wantDrains: 1},
+		{name: "block", block: true, wantDrains: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := newNode("node1", map[string]string{constants.LabelUpgrading: ""})
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "coredns",
+					Annotations: map[string]string{constants.AnnotationCriticalPod: "true"}},
+				Spec: corev1.PodSpec{NodeName: "node1"},
+			}
+			upInstance := newUpdate(housekeeperiov1alpha1.UpdateSpec{OSImageURL: "nestos:v2", BlockOnCriticalPods: tt.block})
+			daemon := &fakeDaemon{}
+			r, drainer := newTestReconciler(t, daemon, upInstance, node, pod)
+
+			result, err := r.Reconcile(context.Background(), updateRequest)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if events := recordedEvents(r); !hasEvent(events, corev1.EventTypeWarning, "CriticalPods") {
+				t.Errorf("events = %v, want a CriticalPods warning", events)
+			}
+			if len(drainer.drained) != tt.wantDrains || len(daemon.pushes()) != tt.wantDrains {
+				t.Errorf("drained %v and pushed %d upgrades, want %d", drainer.drained, len(daemon.pushes()), tt.wantDrains)
+			}
+			failed := nodeCondition(t, r.Client, "node1", housekeeperiov1alpha1.UpdateConditionFailed)
+			if tt.block {
+				if failed == nil || failed.Status != metav1.ConditionTrue || failed.Reason != "CriticalPods" {
+					t.Errorf("Failed condition of node1 = %v", failed)
+				}
+				if result.RequeueAfter < common.RequeueAfter.RequeueAfter {
+					t.Errorf("Reconcile() requeues after %v, want at least %v", result.RequeueAfter, common.RequeueAfter.RequeueAfter)
+				}
+			} else if failed != nil && failed.Status == metav1.ConditionTrue {
+				t.Errorf("Failed condition of node1 = %v", failed)
+			}
+		})
+	}
+}
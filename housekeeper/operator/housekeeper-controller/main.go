@@ -20,6 +20,7 @@ import (
 	"flag"
 	"os"
 	"path/filepath"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -51,6 +52,14 @@ func init() {
 
 func main() {
 	var err error
+	var nodeNameFromHostname bool
+	var metricsAddr string
+	var upgradeTimeout time.Duration
+	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to")
+	flag.BoolVar(&nodeNameFromHostname, "node-name-from-hostname", false,
+		"Use the hostname as the node name when NODE_NAME is not set")
+	flag.DurationVar(&upgradeTimeout, "upgrade-timeout", time.Hour,
+		"Deadline of an upgrade pushed to housekeeper-daemon, covering the os image pull, rebase and kubeadm upgrade")
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
@@ -59,6 +68,7 @@ func main() {
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		MetricsBindAddress:     metricsAddr,
 		HealthProbeBindAddress: "0",
 	})
 	if err != nil {
@@ -66,8 +76,25 @@ func main() {
 		os.Exit(1)
 	}
 
-	reconciler := controllers.NewUpdateReconciler(mgr)
-	if reconciler.Connection, err = connection.New("unix://" + filepath.Join(constants.SockDir, constants.SockName)); err != nil {
+	reconciler, err := controllers.NewUpdateReconciler(mgr, nodeNameFromHostname)
+	if err != nil {
+		logrus.Errorf("unable to create housekeeper-controller: %v", err)
+		os.Exit(1)
+	}
+	var credentials connection.Option
+	if connection.Insecure() {
+		logrus.Warnf("%s is set, connecting to housekeeper-daemon without TLS", constants.EnvInsecure)
+		credentials = connection.WithInsecure()
+	} else {
+		tlsConfig, err := connection.ClientTLSConfig(constants.PKIDir)
+		if err != nil {
+			logrus.Errorf("unable to load TLS config: %v", err)
+			os.Exit(1)
+		}
+		credentials = connection.WithTLS(tlsConfig)
+	}
+	if reconciler.Connection, err = connection.New("unix://"+filepath.Join(constants.SockDir, constants.SockName),
+		credentials, connection.WithUpgradeTimeout(upgradeTimeout)); err != nil {
 		logrus.Errorf("unable running housekeeper-controller: %v", err)
 	}
 	if err = reconciler.SetupWithManager(mgr); err != nil {
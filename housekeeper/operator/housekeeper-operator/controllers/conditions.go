@@ -0,0 +1,132 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	housekeeperiov1alpha1 "housekeeper.io/operator/api/v1alpha1"
+	"housekeeper.io/pkg/common"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// nodeConditionSummaries are the reasons of the cluster conditions true while any node has the condition
+var nodeConditionSummaries = []struct {
+	conditionType string
+	trueReason    string
+	falseReason   string
+}{
+	{conditionType: housekeeperiov1alpha1.UpdateConditionDraining, trueReason: "NodesDraining", falseReason: "NoNodesDraining"},
+	{conditionType: housekeeperiov1alpha1.UpdateConditionUpgrading, trueReason: "NodesUpgrading", falseReason: "NoNodesUpgrading"},
+	{conditionType: housekeeperiov1alpha1.UpdateConditionFailed, trueReason: "NodesFailed", falseReason: "NoNodesFailed"},
+}
+
+// summarizeConditions sets the conditions of the cluster from the conditions the controllers set for each node,
+// the status is only written when the summary changes. The controllers update the node statuses of the same
+// instance concurrently, so the summary is computed on the latest version fetched again on every conflict.
+// Failing to record the status does not fail the reconcile.
+func summarizeConditions(ctx context.Context, r common.ReadWriterClient, update *housekeeperiov1alpha1.Update,
+	allNodes []corev1.Node) {
+	key := client.ObjectKeyFromObject(update)
+	var latest housekeeperiov1alpha1.Update
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, key, &latest); err != nil {
+			return err
+		}
+		conditions := append([]metav1.Condition{}, latest.Status.Conditions...)
+		for _, condition := range clusterConditions(&latest, allNodes) {
+			meta.SetStatusCondition(&conditions, condition)
+		}
+		if reflect.DeepEqual(conditions, latest.Status.Conditions) {
+			return nil
+		}
+		latest.Status.Conditions = conditions
+		return r.Status().Update(ctx, &latest)
+	})
+	if err != nil {
+		logrus.Errorf("unable to update status of update instance %s: %v", key.Name, err)
+		return
+	}
+	update.Status = latest.Status
+}
+
+// clusterConditions summarizes the node conditions observed for the current generation of the Update,
+// Completed is true once every node of the cluster has been upgraded
+func clusterConditions(update *housekeeperiov1alpha1.Update, allNodes []corev1.Node) []metav1.Condition {
+	trueNodes := make(map[string][]string)
+	for _, nodeStatus := range update.Status.Nodes {
+		for _, condition := range nodeStatus.Conditions {
+			// 忽略上一次升级遗留的节点状态
+			if condition.ObservedGeneration == update.Generation && condition.Status == metav1.ConditionTrue {
+				trueNodes[condition.Type] = append(trueNodes[condition.Type], nodeStatus.Name)
+			}
+		}
+	}
+
+	var conditions []metav1.Condition
+	for _, summary := range nodeConditionSummaries {
+		nodes := trueNodes[summary.conditionType]
+		condition := metav1.Condition{
+			Type:               summary.conditionType,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: update.Generation,
+			Reason:             summary.falseReason,
+			Message:            fmt.Sprintf("no nodes %s", strings.ToLower(summary.conditionType)),
+		}
+		if len(nodes) > 0 {
+			sort.Strings(nodes)
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = summary.trueReason
+			condition.Message = fmt.Sprintf("nodes %s: %s", strings.ToLower(summary.conditionType),
+				strings.Join(nodes, ", "))
+		}
+		conditions = append(conditions, condition)
+	}
+
+	upgraded := make(map[string]bool)
+	for _, name := range trueNodes[housekeeperiov1alpha1.UpdateConditionCompleted] {
+		upgraded[name] = true
+	}
+	completed := 0
+	for _, node := range allNodes {
+		if upgraded[node.Name] {
+			completed++
+		}
+	}
+	condition := metav1.Condition{
+		Type:               housekeeperiov1alpha1.UpdateConditionCompleted,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: update.Generation,
+		Reason:             "UpgradeInProgress",
+		Message:            fmt.Sprintf("%d of %d nodes upgraded", completed, len(allNodes)),
+	}
+	if len(allNodes) > 0 && completed == len(allNodes) {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "AllNodesUpgraded"
+	}
+	return append(conditions, condition)
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	housekeeperiov1alpha1 "housekeeper.io/operator/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func nodeStatus(name string, generation int64, trueTypes ...string) housekeeperiov1alpha1.NodeStatus {
+	status := housekeeperiov1alpha1.NodeStatus{Name: name}
+	for _, conditionType := range trueTypes {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{Type: conditionType,
+			Status: metav1.ConditionTrue, ObservedGeneration: generation, Reason: "Test"})
+	}
+	return status
+}
+
+func TestClusterConditions(t *testing.T) {
+	allNodes := []corev1.Node{*newNode("master1"), *newNode("worker1"), *newNode("worker2")}
+	tests := []struct {
+		name  string
+		nodes []housekeeperiov1alpha1.NodeStatus
+		want  map[string]metav1.ConditionStatus
+	}{
+		{name: "not started", want: map[string]metav1.ConditionStatus{
+			housekeeperiov1alpha1.UpdateConditionDraining:  metav1.ConditionFalse,
+			housekeeperiov1alpha1.UpdateConditionUpgrading: metav1.ConditionFalse,
+			housekeeperiov1alpha1.UpdateConditionFailed:    metav1.ConditionFalse,
+			housekeeperiov1alpha1.UpdateConditionCompleted: metav1.ConditionFalse,
+		}},
+		{name: "one node failed while another drains", nodes: []housekeeperiov1alpha1.NodeStatus{
+			nodeStatus("master1", 2, housekeeperiov1alpha1.UpdateConditionCompleted),
+			nodeStatus("worker1", 2, housekeeperiov1alpha1.UpdateConditionFailed),
+			nodeStatus("worker2", 2, housekeeperiov1alpha1.UpdateConditionDraining),
+		}, want: map[string]metav1.ConditionStatus{
+			housekeeperiov1alpha1.UpdateConditionDraining:  metav1.ConditionTrue,
+			housekeeperiov1alpha1.UpdateConditionUpgrading: metav1.ConditionFalse,
+			housekeeperiov1alpha1.UpdateConditionFailed:    metav1.ConditionTrue,
+			housekeeperiov1alpha1.UpdateConditionCompleted: metav1.ConditionFalse,
+		}},
+		{name: "all nodes upgraded", nodes: []housekeeperiov1alpha1.NodeStatus{
+			nodeStatus("master1", 2, housekeeperiov1alpha1.UpdateConditionCompleted),
+			nodeStatus("worker1", 2, housekeeperiov1alpha1.UpdateConditionCompleted),
+			nodeStatus("worker2", 2, housekeeperiov1alpha1.UpdateConditionCompleted),
+		}, want: map[string]metav1.ConditionStatus{
+			housekeeperiov1alpha1.UpdateConditionFailed:    metav1.ConditionFalse,
+			housekeeperiov1alpha1.UpdateConditionCompleted: metav1.ConditionTrue,
+		}},
+		{name: "nodes upgraded by a previous generation", nodes: []housekeeperiov1alpha1.NodeStatus{
+			nodeStatus("master1", 1, housekeeperiov1alpha1.UpdateConditionCompleted),
+			nodeStatus("worker1", 2, housekeeperiov1alpha1.UpdateConditionCompleted),
+			nodeStatus("worker2", 1, housekeeperiov1alpha1.UpdateConditionCompleted, housekeeperiov1alpha1.UpdateConditionFailed),
+		}, want: map[string]metav1.ConditionStatus{
+			housekeeperiov1alpha1.UpdateConditionFailed:    metav1.ConditionFalse,
+			housekeeperiov1alpha1.UpdateConditionCompleted: metav1.ConditionFalse,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := &housekeeperiov1alpha1.Update{ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status: housekeeperiov1alpha1.UpdateStatus{Nodes: tt.nodes}}
+			conditions := clusterConditions(update, allNodes)
+			if len(conditions) != 4 {
+				t.Fatalf("clusterConditions() = %v", conditions)
+			}
+			for conditionType, want := range tt.want {
+				if got := meta.FindStatusCondition(conditions, conditionType); got.Status != want {
+					t.Errorf("%s condition = %v, want status %s", conditionType, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileSummarizesConditions(t *testing.T) {
+	ctx := context.Background()
+	r := newFakeClient(t, housekeeperiov1alpha1.UpdateSpec{OSImageURL: "nestos:v2", MaxUnavailable: 2},
+		newNode("worker1"), newNode("worker2"))
+	var update housekeeperiov1alpha1.Update
+	if err := r.Get(ctx, updateRequest.NamespacedName, &update); err != nil {
+		t.Fatal(err)
+	}
+	update.Status.Nodes = []housekeeperiov1alpha1.NodeStatus{
+		nodeStatus("worker1", update.Generation, housekeeperiov1alpha1.UpdateConditionFailed),
+	}
+	if err := r.Status().Update(ctx, &update); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reconcile(ctx, r, updateRequest); err != nil {
+		t.Fatalf("reconcile() error = %v", err)
+	}
+	if err := r.Get(ctx, updateRequest.NamespacedName, &update); err != nil {
+		t.Fatal(err)
+	}
+	failed := meta.FindStatusCondition(update.Status.Conditions, housekeeperiov1alpha1.UpdateConditionFailed)
+	if failed == nil || failed.Status != metav1.ConditionTrue || failed.Message != "nodes failed: worker1" {
+		t.Errorf("Failed condition = %v", failed)
+	}
+	completed := meta.FindStatusCondition(update.Status.Conditions, housekeeperiov1alpha1.UpdateConditionCompleted)
+	if completed == nil || completed.Status != metav1.ConditionFalse || completed.Message != "0 of 2 nodes upgraded" {
+		t.Errorf("Completed condition = %v", completed)
+	}
+}
+
+// setNodeStatuses records the node statuses the controllers report on the Update
+func setNodeStatuses(t *testing.T, r client.Client, nodes ...housekeeperiov1alpha1.NodeStatus) {
+	t.Helper()
+	var update housekeeperiov1alpha1.Update
+	if err := r.Get(context.Background(), updateRequest.NamespacedName, &update); err != nil {
+		t.Fatal(err)
+	}
+	update.Status.Nodes = nodes
+	if err := r.Status().Update(context.Background(), &update); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReconcileConditionTransitions(t *testing.T) {
+	ctx := context.Background()
+	r := newFakeClient(t, housekeeperiov1alpha1.UpdateSpec{OSImageURL: "nestos:v2", MaxUnavailable: 2},
+		newNode("worker1"), newNode("worker2"))
+	reconciler := &UpdateReconciler{Client: r}
+	var update housekeeperiov1alpha1.Update
+	if err := r.Get(ctx, updateRequest.NamespacedName, &update); err != nil {
+		t.Fatal(err)
+	}
+	generation := update.Generation
+
+	steps := []struct {
+		name  string
+		nodes []housekeeperiov1alpha1.NodeStatus
+		want  map[string]string // condition type -> reason
+	}{
+		{
+			name: "upgrade started",
+			nodes: []housekeeperiov1alpha1.NodeStatus{
+				nodeStatus("worker1", generation, housekeeperiov1alpha1.UpdateConditionUpgrading),
+			},
+			want: map[string]string{
+				housekeeperiov1alpha1.UpdateConditionUpgrading: "NodesUpgrading",
+				housekeeperiov1alpha1.UpdateConditionFailed:    "NoNodesFailed",
+				housekeeperiov1alpha1.UpdateConditionCompleted: "UpgradeInProgress",
+			},
+		},
+		{
+			name: "node failed",
+			nodes: []housekeeperiov1alpha1.NodeStatus{
+				nodeStatus("worker1", generation, housekeeperiov1alpha1.UpdateConditionFailed),
+			},
+			want: map[string]string{
+				housekeeperiov1alpha1.UpdateConditionUpgrading: "NoNodesUpgrading",
+				housekeeperiov1alpha1.UpdateConditionFailed:    "NodesFailed",
+				housekeeperiov1alpha1.UpdateConditionCompleted: "UpgradeInProgress",
+			},
+		},
+		{
+			name: "retried and upgraded",
+			nodes: []housekeeperiov1alpha1.NodeStatus{
+				nodeStatus("worker1", generation, housekeeperiov1alpha1.UpdateConditionCompleted),
+				nodeStatus("worker2", generation, housekeeperiov1alpha1.UpdateConditionCompleted),
+			},
+			want: map[string]string{
+				housekeeperiov1alpha1.UpdateConditionUpgrading: "NoNodesUpgrading",
+				housekeeperiov1alpha1.UpdateConditionFailed:    "NoNodesFailed",
+				housekeeperiov1alpha1.UpdateConditionCompleted: "AllNodesUpgraded",
+			},
+		},
+	}
+	previous := map[string]metav1.Condition{}
+	for _, step := range steps {
+		setNodeStatuses(t, r, step.nodes...)
+		// 状态变化之间间隔一秒，使条件的变化时间可以区分
+		time.Sleep(time.Second)
+		if _, err := reconciler.Reconcile(ctx, updateRequest); err != nil {
+			t.Fatalf("%s: Reconcile() error = %v", step.name, err)
+		}
+		if err := r.Get(ctx, updateRequest.NamespacedName, &update); err != nil {
+			t.Fatal(err)
+		}
+		for conditionType, reason := range step.want {
+			condition := meta.FindStatusCondition(update.Status.Conditions, conditionType)
+			if condition == nil || condition.Reason != reason {
+				t.Errorf("%s: %s condition = %v, want reason %s", step.name, conditionType, condition, reason)
+				continue
+			}
+			// 只有状态改变时才更新变化时间
+			if last, ok := previous[conditionType]; ok {
+				changed := last.Status != condition.Status
+				if changed == last.LastTransitionTime.Equal(&condition.LastTransitionTime) {
+					t.Errorf("%s: %s condition changed from %s to %s at %v, previously %v", step.name, conditionType,
+						last.Status, condition.Status, condition.LastTransitionTime, last.LastTransitionTime)
+				}
+			}
+			previous[conditionType] = *condition
+		}
+	}
+}
+
+func TestSummarizeConditionsStaleUpdate(t *testing.T) {
+	ctx := context.Background()
+	r := newFakeClient(t, housekeeperiov1alpha1.UpdateSpec{OSImageURL: "nestos:v2", MaxUnavailable: 1},
+		newNode("worker1"))
+	var stale housekeeperiov1alpha1.Update
+	if err := r.Get(ctx, updateRequest.NamespacedName, &stale); err != nil {
+		t.Fatal(err)
+	}
+	// A controller records the failure of its node after the operator fetched the Update
+	setNodeStatuses(t, r, nodeStatus("worker1", stale.Generation, housekeeperiov1alpha1.UpdateConditionFailed))
+
+	summarizeConditions(ctx, r, &stale, []corev1.Node{*newNode("worker1")})
+
+	var update housekeeperiov1alpha1.Update
+	if err := r.Get(ctx, updateRequest.NamespacedName, &update); err != nil {
+		t.Fatal(err)
+	}
+	if len(update.Status.Nodes) != 1 || update.Status.Nodes[0].Name != "worker1" {
+		t.Errorf("Expected the node status of worker1 to be kept, got %v", update.Status.Nodes)
+	}
+	failed := meta.FindStatusCondition(update.Status.Conditions, housekeeperiov1alpha1.UpdateConditionFailed)
+	if failed == nil || failed.Status != metav1.ConditionTrue {
+		t.Errorf("Failed condition = %v", failed)
+	}
+	if len(stale.Status.Nodes) != 1 || len(stale.Status.Conditions) != len(update.Status.Conditions) {
+		t.Errorf("Expected the recorded status to be returned, got %v", stale.Status)
+	}
+}
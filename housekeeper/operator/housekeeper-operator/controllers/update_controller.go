@@ -80,8 +80,13 @@ func reconcile(ctx context.Context, r common.ReadWriterClient, req ctrl.Request)
 		logrus.Errorf("unable to fetch update instance: %v", err)
 		return common.NoRequeue, err
 	}
-	if len(update.Spec.OSImageURL) == 0 {
-		logrus.Warning("os upgrade image url is required")
+	if len(update.Spec.OSImageURL) == 0 && len(update.Spec.OSRef) == 0 {
+		logrus.Warning("os upgrade image url or ostree ref is required")
+		return common.RequeueAfter, nil
+	}
+	// CRD的oneOf已拒绝两者同时设置，此处兜底旧版本CRD下创建的对象
+	if len(update.Spec.OSImageURL) > 0 && len(update.Spec.OSRef) > 0 {
+		logrus.Warning("only one of os upgrade image url and ostree ref can be set")
 		return common.RequeueAfter, nil
 	}
 
@@ -89,6 +94,7 @@ func reconcile(ctx context.Context, r common.ReadWriterClient, req ctrl.Request)
 	if err != nil {
 		return common.RequeueNow, err
 	}
+	summarizeConditions(ctx, r, &update, allNodes)
 
 	allNodesUpgraded := true
 	for _, node := range allNodes {
@@ -99,8 +105,9 @@ func reconcile(ctx context.Context, r common.ReadWriterClient, req ctrl.Request)
 	}
 	if allNodesUpgraded {
 		for _, node := range allNodes {
-			delete(node.Labels, constants.LabelUpgradeCompleted)
-			if err := r.Update(ctx, &node); err != nil {
+			if err := common.UpdateNode(ctx, r, &node, func(node *corev1.Node) {
+				delete(node.Labels, constants.LabelUpgradeCompleted)
+			}); err != nil {
 				return common.RequeueNow, err
 			}
 		}
@@ -114,11 +121,17 @@ func reconcile(ctx context.Context, r common.ReadWriterClient, req ctrl.Request)
 	if err != nil {
 		return common.RequeueNow, err
 	}
-	if assignUpdated(ctx, r, masterNodesItems, 1, update); err != nil {
+	if err := assignUpdated(ctx, r, masterNodesItems, 1, update); err != nil {
 		return common.RequeueNow, err
 	}
+	if len(update.Spec.PoolPolicies) > 0 {
+		if err := assignPoolUpdated(ctx, r, allNodes, workerNodesItems, update, time.Now()); err != nil {
+			return common.RequeueNow, err
+		}
+		return common.RequeueNow, nil
+	}
 	maxUnavailable := min(update.Spec.MaxUnavailable, len(workerNodesItems))
-	if assignUpdated(ctx, r, workerNodesItems, maxUnavailable, update); err != nil {
+	if err := assignUpdated(ctx, r, workerNodesItems, maxUnavailable, update); err != nil {
 		return common.RequeueNow, err
 	}
 
@@ -210,8 +223,7 @@ func assignUpdated(ctx context.Context, r common.ReadWriterClient, nodeList []co
 			}
 			max = upInstance.Spec.MaxUnavailable
 		}
-		node.Labels[constants.LabelUpgrading] = ""
-		if err := r.Update(ctx, &node); err != nil {
+		if err := common.UpdateNode(ctx, r, &node, addUpgradingLabel); err != nil {
 			return err
 		}
 		max--
@@ -219,6 +231,72 @@ func assignUpdated(ctx context.Context, r common.ReadWriterClient, nodeList []co
 	return nil
 }
 
+// Add the label to worker nodes within the rollout budget of their node pool,
+// nodes of a pool whose maintenance window is closed at now are left for a later reconcile
+func assignPoolUpdated(ctx context.Context, r common.ReadWriterClient, allNodes []corev1.Node,
+	nodeList []corev1.Node, upInstance housekeeperiov1alpha1.Update, now time.Time) error {
+	upgrading := make(map[string]int)
+	completed := make(map[string]int)
+	for _, node := range allNodes {
+		if _, ok := node.Labels[constants.LabelMaster]; ok {
+			continue
+		}
+		pool := node.Labels[constants.LabelNodePool]
+		if _, ok := node.Labels[constants.LabelUpgrading]; ok {
+			upgrading[pool]++
+		} else if hasUpgradeCompletedLabel(node) {
+			completed[pool]++
+		}
+	}
+
+	for _, node := range nodeList {
+		pool := node.Labels[constants.LabelNodePool]
+		if getPoolBudget(upInstance, pool, upgrading[pool], completed[pool], now) <= 0 {
+			continue
+		}
+		if err := common.UpdateNode(ctx, r, &node, addUpgradingLabel); err != nil {
+			return err
+		}
+		upgrading[pool]++
+	}
+	return nil
+}
+
+func addUpgradingLabel(node *corev1.Node) {
+	if node.Labels == nil {
+		node.Labels = map[string]string{}
+	}
+	node.Labels[constants.LabelUpgrading] = ""
+}
+
+// Number of nodes in the pool that are allowed to start upgrading at now,
+// none while the maintenance window of the pool is closed
+func getPoolBudget(upInstance housekeeperiov1alpha1.Update, pool string, upgrading int, completed int,
+	now time.Time) int {
+	maxUnavailable := upInstance.Spec.MaxUnavailable
+	canary := 0
+	for _, policy := range upInstance.Spec.PoolPolicies {
+		if policy.Pool == pool {
+			open, _, err := common.CheckUpgradeWindow(policy.Window, now)
+			if err != nil {
+				logrus.Errorf("invalid maintenance window of node pool %s: %v", pool, err)
+				return 0
+			}
+			if !open {
+				return 0
+			}
+			maxUnavailable = policy.MaxUnavailable
+			canary = policy.Canary
+			break
+		}
+	}
+	// canary nodes are upgraded one at a time
+	if completed < canary {
+		maxUnavailable = 1
+	}
+	return maxUnavailable - upgrading
+}
+
 func waitForUpgradeComplete(ctx context.Context, node corev1.Node) error {
 	timeoutCtx, cancel := context.WithTimeout(ctx, constants.NodeTimeout)
 	defer cancel()
@@ -0,0 +1,233 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	housekeeperiov1alpha1 "housekeeper.io/operator/api/v1alpha1"
+	"housekeeper.io/pkg/common"
+	"housekeeper.io/pkg/constants"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var updateRequest = ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "update"}}
+
+// newFakeClient returns a client serving the Update with the spec and the nodes from memory
+func newFakeClient(t *testing.T, spec housekeeperiov1alpha1.UpdateSpec, nodes ...*corev1.Node) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := housekeeperiov1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	objects := []client.Object{&housekeeperiov1alpha1.Update{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "update"},
+		Spec:       spec,
+	}}
+	for _, node := range nodes {
+		objects = append(objects, node)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func newNode(name string, labels ...string) *corev1.Node {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{}}}
+	for i := 0; i+1 < len(labels); i += 2 {
+		node.Labels[labels[i]] = labels[i+1]
+	}
+	return node
+}
+
+// upgradingNodes returns the names of the nodes labeled for upgrade
+func upgradingNodes(t *testing.T, r client.Client) []string {
+	t.Helper()
+	var nodeList corev1.NodeList
+	if err := r.List(context.Background(), &nodeList, client.HasLabels{constants.LabelUpgrading}); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, node := range nodeList.Items {
+		names = append(names, node.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestReconcileRequiresOSImage(t *testing.T) {
+	r := newFakeClient(t, housekeeperiov1alpha1.UpdateSpec{KubeVersion: "v1.23.10"}, newNode("worker1"))
+	result, err := reconcile(context.Background(), r, updateRequest)
+	if err != nil || result != common.RequeueAfter {
+		t.Errorf("reconcile() = %v, %v", result, err)
+	}
+	if got := upgradingNodes(t, r); len(got) != 0 {
+		t.Errorf("nodes %v labeled without an os image", got)
+	}
+}
+
+func TestReconcileRejectsOSImageAndRef(t *testing.T) {
+	r := newFakeClient(t, housekeeperiov1alpha1.UpdateSpec{OSImageURL: "nestos:v2", OSRef: "nestos:stable"},
+		newNode("worker1"))
+	result, err := reconcile(context.Background(), r, updateRequest)
+	if err != nil || result != common.RequeueAfter {
+		t.Errorf("reconcile() = %v, %v", result, err)
+	}
+	if got := upgradingNodes(t, r); len(got) != 0 {
+		t.Errorf("nodes %v labeled with both an os image and an ostree ref", got)
+	}
+}
+
+func TestReconcileAssignsNodes(t *testing.T) {
+	r := newFakeClient(t, housekeeperiov1alpha1.UpdateSpec{OSImageURL: "nestos:v2", MaxUnavailable: 2},
+		newNode("master1", constants.LabelMaster, ""),
+		newNode("worker1"),
+		newNode("worker2"),
+		newNode("worker3", constants.LabelUpgradeCompleted, ""))
+	if _, err := reconcile(context.Background(), r, updateRequest); err != nil {
+		t.Fatalf("reconcile() error = %v", err)
+	}
+	want := []string{"master1", "worker1", "worker2"}
+	if got := upgradingNodes(t, r); !reflect.DeepEqual(got, want) {
+		t.Errorf("upgrading nodes = %v, want %v", got, want)
+	}
+}
+
+func TestReconcileRemovesCompletedLabels(t *testing.T) {
+	r := newFakeClient(t, housekeeperiov1alpha1.UpdateSpec{OSImageURL: "nestos:v2", MaxUnavailable: 1},
+		newNode("master1", constants.LabelMaster, "", constants.LabelUpgradeCompleted, ""),
+		newNode("worker1", constants.LabelUpgradeCompleted, ""))
+	result, err := reconcile(context.Background(), r, updateRequest)
+	if err != nil || result != common.NoRequeue {
+		t.Fatalf("reconcile() = %v, %v", result, err)
+	}
+	var nodeList corev1.NodeList
+	if err := r.List(context.Background(), &nodeList, client.HasLabels{constants.LabelUpgradeCompleted}); err != nil {
+		t.Fatal(err)
+	}
+	if len(nodeList.Items) != 0 {
+		t.Errorf("%d nodes still labeled upgrade completed", len(nodeList.Items))
+	}
+}
+
+func TestReconcileGatesPoolsByMaxUnavailable(t *testing.T) {
+	r := newFakeClient(t, housekeeperiov1alpha1.UpdateSpec{
+		OSImageURL:     "nestos:v2",
+		MaxUnavailable: 1,
+		PoolPolicies: []housekeeperiov1alpha1.PoolPolicy{
+			{Pool: "web", MaxUnavailable: 1},
+			{Pool: "batch", MaxUnavailable: 2},
+		},
+	},
+		newNode("web1", constants.LabelNodePool, "web"),
+		newNode("web2", constants.LabelNodePool, "web"),
+		newNode("web3", constants.LabelNodePool, "web"),
+		newNode("batch1", constants.LabelNodePool, "batch"),
+		newNode("batch2", constants.LabelNodePool, "batch"),
+		newNode("batch3", constants.LabelNodePool, "batch"))
+	for i := 0; i < 2; i++ {
+		// 再次调和时预算已用完，不会标记更多节点
+		if _, err := reconcile(context.Background(), r, updateRequest); err != nil {
+			t.Fatalf("reconcile() error = %v", err)
+		}
+	}
+	want := []string{"batch1", "batch2", "web1"}
+	if got := upgradingNodes(t, r); !reflect.DeepEqual(got, want) {
+		t.Errorf("upgrading nodes = %v, want %v", got, want)
+	}
+}
+
+func TestAssignPoolUpdatedWindow(t *testing.T) {
+	spec := housekeeperiov1alpha1.UpdateSpec{
+		OSImageURL:     "nestos:v2",
+		MaxUnavailable: 1,
+		PoolPolicies: []housekeeperiov1alpha1.PoolPolicy{
+			{Pool: "web", MaxUnavailable: 1, Window: &housekeeperiov1alpha1.UpgradeWindow{Start: "01:00", End: "05:00"}},
+			{Pool: "batch", MaxUnavailable: 1},
+		},
+	}
+	nodes := []*corev1.Node{
+		newNode("web1", constants.LabelNodePool, "web"),
+		newNode("batch1", constants.LabelNodePool, "batch"),
+	}
+	tests := []struct {
+		name string
+		now  time.Time
+		want []string
+	}{
+		{name: "closed", now: time.Date(2023, 10, 6, 12, 0, 0, 0, time.UTC), want: []string{"batch1"}},
+		{name: "open", now: time.Date(2023, 10, 6, 2, 0, 0, 0, time.UTC), want: []string{"batch1", "web1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newFakeClient(t, spec, nodes[0].DeepCopy(), nodes[1].DeepCopy())
+			var nodeList []corev1.Node
+			for _, node := range nodes {
+				nodeList = append(nodeList, *node)
+			}
+			if err := assignPoolUpdated(context.Background(), r, nodeList, nodeList,
+				housekeeperiov1alpha1.Update{Spec: spec}, tt.now); err != nil {
+				t.Fatalf("assignPoolUpdated() error = %v", err)
+			}
+			if got := upgradingNodes(t, r); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("upgrading nodes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPoolBudget(t *testing.T) {
+	upInstance := housekeeperiov1alpha1.Update{Spec: housekeeperiov1alpha1.UpdateSpec{
+		MaxUnavailable: 1,
+		PoolPolicies: []housekeeperiov1alpha1.PoolPolicy{
+			{Pool: "batch", MaxUnavailable: 3},
+			{Pool: "web", MaxUnavailable: 2, Canary: 1},
+		},
+	}}
+	tests := []struct {
+		pool      string
+		upgrading int
+		completed int
+		want      int
+	}{
+		{pool: "batch", want: 3},
+		{pool: "batch", upgrading: 2, want: 1},
+		{pool: "web", want: 1},
+		{pool: "web", upgrading: 1, want: 0},
+		{pool: "web", completed: 1, want: 2},
+		{pool: "", want: 1},
+		{pool: "other", upgrading: 1, want: 0},
+	}
+	for _, tt := range tests {
+		if got := getPoolBudget(upInstance, tt.pool, tt.upgrading, tt.completed, time.Now()); got != tt.want {
+			t.Errorf("getPoolBudget(%q, %d, %d) = %d, want %d", tt.pool, tt.upgrading, tt.completed, got, tt.want)
+		}
+	}
+}
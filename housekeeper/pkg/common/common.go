@@ -16,11 +16,15 @@ limitations under the License.
 package common
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -60,3 +64,182 @@ func ExtractImageTag(imageURL string) (string, error) {
 	}
 	return "", fmt.Errorf("unable to extract the mirror tag from image URL: %s", imageURL)
 }
+
+// GetOSVersion returns the version the os is upgraded to, the ostree ref when set or the image tag
+func GetOSVersion(osImageURL string, osRef string) (string, error) {
+	if len(osRef) > 0 {
+		if strings.HasPrefix(osRef, "-") || strings.ContainsAny(osRef, " \t\n") {
+			return "", fmt.Errorf("invalid ostree ref %q", osRef)
+		}
+		return osRef, nil
+	}
+	return ExtractImageTag(osImageURL)
+}
+
+// OSStampName turns the os version into the name of its stamp file, ostree refs contain ':' and '/'
+func OSStampName(osVersion string) string {
+	return strings.NewReplacer(":", "_", "/", "_").Replace(osVersion)
+}
+
+// Kubernetes components upgraded on master nodes, etcd can be staged before the control plane
+const (
+	UpgradeComponentAll          = "all"
+	UpgradeComponentEtcd         = "etcd"
+	UpgradeComponentControlPlane = "controlplane"
+)
+
+// ParseUpgradeComponent checks the upgrade component, an empty component means all
+func ParseUpgradeComponent(component string) (string, error) {
+	switch component = strings.ToLower(strings.TrimSpace(component)); component {
+	case "":
+		return UpgradeComponentAll, nil
+	case UpgradeComponentAll, UpgradeComponentEtcd, UpgradeComponentControlPlane:
+		return component, nil
+	default:
+		return "", fmt.Errorf("invalid upgrade component %q, expected %s, %s or %s", component,
+			UpgradeComponentEtcd, UpgradeComponentControlPlane, UpgradeComponentAll)
+	}
+}
+
+// KubeStampName returns the name of the stamp file of a kubernetes upgrade,
+// staged upgrades of a component are stamped separately from upgrading all components
+func KubeStampName(kubeVersion string, component string) string {
+	if component == "" || component == UpgradeComponentAll {
+		return kubeVersion
+	}
+	return kubeVersion + "-" + component
+}
+
+// parseMajorMinor parses the major and minor version from a version like v1.23.10
+func parseMajorMinor(version string) (int, int, error) {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(version), "v"), ".")
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("invalid kubernetes version: %s", version)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid kubernetes version: %s", version)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid kubernetes version: %s", version)
+	}
+	return major, minor, nil
+}
+
+// parsePatch parses the patch version from a version like v1.23.10, ignoring pre-release and build suffixes
+func parsePatch(version string) (int, error) {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(version), "v"), ".")
+	if len(parts) < 3 {
+		return 0, fmt.Errorf("invalid kubernetes version: %s", version)
+	}
+	patchPart := parts[2]
+	if i := strings.IndexAny(patchPart, "-+"); i >= 0 {
+		patchPart = patchPart[:i]
+	}
+	patch, err := strconv.Atoi(patchPart)
+	if err != nil {
+		return 0, fmt.Errorf("invalid kubernetes version: %s", version)
+	}
+	return patch, nil
+}
+
+// ValidateKubeVersion checks that the version is a kubernetes release version like v1.23.10
+func ValidateKubeVersion(version string) error {
+	if _, _, err := parseMajorMinor(version); err != nil {
+		return err
+	}
+	_, err := parsePatch(version)
+	return err
+}
+
+// CheckKubeadmUpgradeTarget checks that kubeadm can upgrade the node to the target version,
+// kubeadm only upgrades to versions of its own minor release that are not newer than itself.
+func CheckKubeadmUpgradeTarget(kubeadmVersion string, targetVersion string) error {
+	kubeadmMajor, kubeadmMinor, err := parseMajorMinor(kubeadmVersion)
+	if err != nil {
+		return err
+	}
+	kubeadmPatch, err := parsePatch(kubeadmVersion)
+	if err != nil {
+		return err
+	}
+	targetMajor, targetMinor, err := parseMajorMinor(targetVersion)
+	if err != nil {
+		return err
+	}
+	targetPatch, err := parsePatch(targetVersion)
+	if err != nil {
+		return err
+	}
+	if targetMajor != kubeadmMajor || targetMinor != kubeadmMinor || targetPatch > kubeadmPatch {
+		return fmt.Errorf("kubeadm %s on the node cannot upgrade to kubernetes %s, "+
+			"upgrade the os to an image shipping kubeadm v%d.%d.%d or later of the same minor release first",
+			kubeadmVersion, targetVersion, targetMajor, targetMinor, targetPatch)
+	}
+	return nil
+}
+
+// VersionSkewError is returned when upgrading the kubelet to a version would violate the version skew policy
+type VersionSkewError struct {
+	APIServerVersion string
+	KubeletVersion   string
+	Reason           string
+}
+
+func (e *VersionSkewError) Error() string {
+	return fmt.Sprintf("kubelet version %s violates the version skew policy with apiserver version %s: %s",
+		e.KubeletVersion, e.APIServerVersion, e.Reason)
+}
+
+// CheckKubeletVersionSkew checks that the kubelet version is not newer than the apiserver
+// and at most one minor version older than it.
+func CheckKubeletVersionSkew(apiserverVersion string, kubeletVersion string) error {
+	apiMajor, apiMinor, err := parseMajorMinor(apiserverVersion)
+	if err != nil {
+		return err
+	}
+	kubeletMajor, kubeletMinor, err := parseMajorMinor(kubeletVersion)
+	if err != nil {
+		return err
+	}
+	skewError := func(reason string) error {
+		return &VersionSkewError{APIServerVersion: apiserverVersion, KubeletVersion: kubeletVersion, Reason: reason}
+	}
+	if apiMajor != kubeletMajor {
+		return skewError("major versions differ")
+	}
+	if kubeletMinor > apiMinor {
+		return skewError("kubelet is newer than the control plane")
+	}
+	if apiMinor-kubeletMinor > 1 {
+		return skewError("kubelet is more than one minor version older than the control plane")
+	}
+	if kubeletMinor == apiMinor {
+		apiPatch, err := parsePatch(apiserverVersion)
+		if err != nil {
+			return err
+		}
+		kubeletPatch, err := parsePatch(kubeletVersion)
+		if err != nil {
+			return err
+		}
+		if kubeletPatch > apiPatch {
+			return skewError("kubelet is newer than the control plane")
+		}
+	}
+	return nil
+}
+
+// UpdateNode applies mutate to the latest version of the node and updates it, the node is
+// fetched again and mutate applied again on every conflict. On success node is the updated node.
+func UpdateNode(ctx context.Context, r ReadWriterClient, node *corev1.Node, mutate func(node *corev1.Node)) error {
+	name := node.Name
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, client.ObjectKey{Name: name}, node); err != nil {
+			return err
+		}
+		mutate(node)
+		return r.Update(ctx, node)
+	})
+}
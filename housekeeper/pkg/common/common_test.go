@@ -0,0 +1,198 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetOSVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		osImageURL string
+		osRef      string
+		want       string
+		wantErr    bool
+	}{
+		{name: "image tag", osImageURL: "hub.oepkgs.net/nestos/nestos:22.03-LTS-SP2.20230928.0", want: "22.03-LTS-SP2.20230928.0"},
+		{name: "registry port", osImageURL: "registry:5000/nestos/nestos:v2", want: "v2"},
+		{name: "no tag", osImageURL: "registry:5000/nestos/nestos", wantErr: true},
+		{name: "ostree ref", osImageURL: "ignored:v1", osRef: "nestos:nestos/x86_64/stable", want: "nestos:nestos/x86_64/stable"},
+		{name: "ref is an option", osRef: "--install=evil", wantErr: true},
+		{name: "ref with spaces", osRef: "nestos:nestos stable", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetOSVersion(tt.osImageURL, tt.osRef)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetOSVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetOSVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOSStampName(t *testing.T) {
+	if got := OSStampName("nestos:nestos/x86_64/stable"); got != "nestos_nestos_x86_64_stable" {
+		t.Errorf("OSStampName() = %q", got)
+	}
+}
+
+func TestParseUpgradeComponent(t *testing.T) {
+	tests := []struct {
+		component string
+		want      string
+		wantErr   bool
+	}{
+		{component: "", want: UpgradeComponentAll},
+		{component: "all", want: UpgradeComponentAll},
+		{component: " Etcd ", want: UpgradeComponentEtcd},
+		{component: "controlplane", want: UpgradeComponentControlPlane},
+		{component: "kubelet", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseUpgradeComponent(tt.component)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ParseUpgradeComponent(%q) error = %v, wantErr %v", tt.component, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseUpgradeComponent(%q) = %q, want %q", tt.component, got, tt.want)
+		}
+	}
+}
+
+func TestKubeStampName(t *testing.T) {
+	tests := []struct {
+		component string
+		want      string
+	}{
+		{component: "", want: "v1.23.10"},
+		{component: UpgradeComponentAll, want: "v1.23.10"},
+		{component: UpgradeComponentEtcd, want: "v1.23.10-etcd"},
+		{component: UpgradeComponentControlPlane, want: "v1.23.10-controlplane"},
+	}
+	for _, tt := range tests {
+		if got := KubeStampName("v1.23.10", tt.component); got != tt.want {
+			t.Errorf("KubeStampName(%q) = %q, want %q", tt.component, got, tt.want)
+		}
+	}
+}
+
+func TestValidateKubeVersion(t *testing.T) {
+	for _, version := range []string{"v1.23.10", "1.23.10", "v1.24.0-rc.1"} {
+		if err := ValidateKubeVersion(version); err != nil {
+			t.Errorf("ValidateKubeVersion(%q) = %v", version, err)
+		}
+	}
+	for _, version := range []string{"", "v1.23", "latest", "v1.x.0", "--force"} {
+		if err := ValidateKubeVersion(version); err == nil {
+			t.Errorf("ValidateKubeVersion(%q) accepted an invalid version", version)
+		}
+	}
+}
+
+func TestCheckKubeadmUpgradeTarget(t *testing.T) {
+	tests := []struct {
+		kubeadm string
+		target  string
+		wantErr bool
+	}{
+		{kubeadm: "v1.23.10", target: "v1.23.10"},
+		{kubeadm: "v1.23.10", target: "v1.23.1"},
+		{kubeadm: "v1.23.10", target: "v1.23.11", wantErr: true},
+		{kubeadm: "v1.23.10", target: "v1.24.0", wantErr: true},
+		{kubeadm: "v1.24.0", target: "v1.23.10", wantErr: true},
+		{kubeadm: "unknown", target: "v1.23.10", wantErr: true},
+	}
+	for _, tt := range tests {
+		err := CheckKubeadmUpgradeTarget(tt.kubeadm, tt.target)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckKubeadmUpgradeTarget(%q, %q) error = %v, wantErr %v", tt.kubeadm, tt.target, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCheckKubeletVersionSkew(t *testing.T) {
+	tests := []struct {
+		apiserver string
+		kubelet   string
+		wantSkew  bool
+	}{
+		{apiserver: "v1.23.10", kubelet: "v1.23.10"},
+		{apiserver: "v1.24.1", kubelet: "v1.23.10"},
+		{apiserver: "v1.23.10", kubelet: "v1.23.11", wantSkew: true},
+		{apiserver: "v1.23.10", kubelet: "v1.24.0", wantSkew: true},
+		{apiserver: "v1.25.0", kubelet: "v1.23.10", wantSkew: true},
+		{apiserver: "v2.23.0", kubelet: "v1.23.0", wantSkew: true},
+	}
+	for _, tt := range tests {
+		err := CheckKubeletVersionSkew(tt.apiserver, tt.kubelet)
+		var skewErr *VersionSkewError
+		if errors.As(err, &skewErr) != tt.wantSkew {
+			t.Errorf("CheckKubeletVersionSkew(%q, %q) = %v, want skew %v", tt.apiserver, tt.kubelet, err, tt.wantSkew)
+		}
+	}
+}
+
+func TestUpdateNode(t *testing.T) {
+	ctx := context.Background()
+	r := fake.NewClientBuilder().WithScheme(scheme.Scheme).
+		WithObjects(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}).Build()
+
+	// 传入的节点已过期，UpdateNode 应基于最新版本修改
+	stale := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+	var latest corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: "node1"}, &latest); err != nil {
+		t.Fatal(err)
+	}
+	latest.Annotations = map[string]string{"kept": ""}
+	if err := r.Update(ctx, &latest); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := UpdateNode(ctx, r, &stale, func(node *corev1.Node) {
+		if node.Labels == nil {
+			node.Labels = map[string]string{}
+		}
+		node.Labels["updated"] = ""
+	}); err != nil {
+		t.Fatalf("UpdateNode() error = %v", err)
+	}
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: "node1"}, &node); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := node.Labels["updated"]; !ok {
+		t.Errorf("label not added: %v", node.Labels)
+	}
+	if _, ok := node.Annotations["kept"]; !ok {
+		t.Errorf("concurrent update lost: %v", node.Annotations)
+	}
+	if stale.ResourceVersion != node.ResourceVersion {
+		t.Errorf("node not refreshed, resource version %s, want %s", stale.ResourceVersion, node.ResourceVersion)
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	// 容器镜像中可能没有时区数据库
+	_ "time/tzdata"
+
+	housekeeperiov1alpha1 "housekeeper.io/operator/api/v1alpha1"
+)
+
+// CheckUpgradeWindow reports whether now is inside the upgrade window,
+// otherwise it returns how long until the window opens next
+func CheckUpgradeWindow(window *housekeeperiov1alpha1.UpgradeWindow, now time.Time) (bool, time.Duration, error) {
+	if window == nil {
+		return true, 0, nil
+	}
+	start, err := parseTimeOfDay(window.Start)
+	if err != nil {
+		return false, 0, fmt.Errorf("invalid upgrade window start: %w", err)
+	}
+	end, err := parseTimeOfDay(window.End)
+	if err != nil {
+		return false, 0, fmt.Errorf("invalid upgrade window end: %w", err)
+	}
+	days := make(map[time.Weekday]bool, len(window.Days))
+	for _, day := range window.Days {
+		weekday, ok := parseWeekday(day)
+		if !ok {
+			return false, 0, fmt.Errorf("invalid upgrade window day %q", day)
+		}
+		days[weekday] = true
+	}
+	location := time.UTC
+	if window.TimeZone != "" {
+		if location, err = time.LoadLocation(window.TimeZone); err != nil {
+			return false, 0, fmt.Errorf("invalid upgrade window time zone: %w", err)
+		}
+	}
+
+	// 窗口跨越午夜时属于开始的那一天，因此从前一天开始检查
+	now = now.In(location)
+	duration := time.Duration(end-start) * time.Minute
+	if duration <= 0 {
+		duration += 24 * time.Hour
+	}
+	var wait time.Duration
+	for i := -1; i <= 7; i++ {
+		day := time.Date(now.Year(), now.Month(), now.Day()+i, 0, 0, 0, 0, location)
+		if len(days) > 0 && !days[day.Weekday()] {
+			continue
+		}
+		opens := time.Date(day.Year(), day.Month(), day.Day(), start/60, start%60, 0, 0, location)
+		if !now.Before(opens) && now.Before(opens.Add(duration)) {
+			return true, 0, nil
+		}
+		if opens.After(now) {
+			wait = opens.Sub(now)
+			break
+		}
+	}
+	return false, wait, nil
+}
+
+// parseTimeOfDay parses HH:MM as the minutes since midnight
+func parseTimeOfDay(value string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("expected HH:MM, got %q", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// parseWeekday parses the name of a weekday or its first three letters, such as Monday or Mon
+func parseWeekday(value string) (time.Weekday, bool) {
+	value = strings.TrimSpace(value)
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(value, weekday.String()) || strings.EqualFold(value, weekday.String()[:3]) {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	housekeeperiov1alpha1 "housekeeper.io/operator/api/v1alpha1"
+)
+
+func TestCheckUpgradeWindow(t *testing.T) {
+	// 2023-10-06 is a Friday
+	friday := func(hour, minute int) time.Time {
+		return time.Date(2023, 10, 6, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		window   *housekeeperiov1alpha1.UpgradeWindow
+		now      time.Time
+		wantOpen bool
+		wantWait time.Duration
+		wantErr  bool
+	}{
+		{name: "no window", now: friday(12, 0), wantOpen: true},
+		{name: "inside", window: &housekeeperiov1alpha1.UpgradeWindow{Start: "10:00", End: "14:00"},
+			now: friday(12, 0), wantOpen: true},
+		{name: "before", window: &housekeeperiov1alpha1.UpgradeWindow{Start: "14:00", End: "16:00"},
+			now: friday(12, 0), wantWait: 2 * time.Hour},
+		{name: "after", window: &housekeeperiov1alpha1.UpgradeWindow{Start: "10:00", End: "11:00"},
+			now: friday(12, 0), wantWait: 22 * time.Hour},
+		{name: "past midnight", window: &housekeeperiov1alpha1.UpgradeWindow{Start: "22:00", End: "02:00"},
+			now: friday(1, 0), wantOpen: true},
+		{name: "weekend", window: &housekeeperiov1alpha1.UpgradeWindow{Start: "00:00", End: "00:00", Days: []string{"Sat", "Sunday"}},
+			now: friday(12, 0), wantWait: 12 * time.Hour},
+		{name: "time zone", window: &housekeeperiov1alpha1.UpgradeWindow{Start: "18:00", End: "20:00", TimeZone: "Asia/Shanghai"},
+			now: friday(11, 0), wantOpen: true},
+		{name: "invalid start", window: &housekeeperiov1alpha1.UpgradeWindow{Start: "25:00", End: "02:00"}, wantErr: true},
+		{name: "invalid day", window: &housekeeperiov1alpha1.UpgradeWindow{Start: "22:00", End: "02:00", Days: []string{"Someday"}}, wantErr: true},
+		{name: "invalid time zone", window: &housekeeperiov1alpha1.UpgradeWindow{Start: "22:00", End: "02:00", TimeZone: "Mars/Olympus"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			open, wait, err := CheckUpgradeWindow(tt.window, tt.now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckUpgradeWindow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if open != tt.wantOpen || wait != tt.wantWait {
+				t.Errorf("CheckUpgradeWindow() = %v, %v, want %v, %v", open, wait, tt.wantOpen, tt.wantWait)
+			}
+		})
+	}
+}
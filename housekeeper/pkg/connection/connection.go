@@ -18,46 +18,330 @@ package connection
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 
 	pb "housekeeper.io/pkg/connection/proto"
 )
 
+// the daemon answers the health check without waiting for a running upgrade
+const healthCheckTimeout = 10 * time.Second
+
+const (
+	defaultDialTimeout = 3 * time.Second
+	// Reset和ApplyFiles需要运行kubeadm reset或重启服务
+	defaultCallTimeout = 2 * time.Minute
+	// 升级包括拉取OS镜像、rebase和kubeadm升级，守护进程在客户端超时断开后仍会完成升级
+	defaultUpgradeTimeout   = time.Hour
+	defaultKeepaliveTime    = 30 * time.Second
+	defaultKeepaliveTimeout = 10 * time.Second
+)
+
+// ErrNodeUnreachable is returned when the daemon can not be dialed or does not answer in time,
+// the request can be retried later
+var ErrNodeUnreachable = errors.New("housekeeper daemon unreachable")
+
+// ErrPushTransient is returned when pushing an upgrade fails for a reason that may clear up,
+// such as an unreachable daemon or a failed image pull, the push can be retried later
+var ErrPushTransient = errors.New("upgrade push failed")
+
+// ErrPushRejected is returned when the daemon rejects the upgrade request as invalid for the node,
+// pushing the same request again fails the same way
+var ErrPushRejected = errors.New("upgrade rejected by housekeeper daemon")
+
 type Client struct {
 	socketAddress string
 	client        pb.UpgradeClusterClient
+	callTimeout   time.Duration
+	// deadline of an upgrade push, streamed or not
+	upgradeTimeout time.Duration
+}
+
+type options struct {
+	dialTimeout      time.Duration
+	callTimeout      time.Duration
+	upgradeTimeout   time.Duration
+	keepaliveTime    time.Duration
+	keepaliveTimeout time.Duration
+	creds            credentials.TransportCredentials
+	dialer           func(context.Context, string) (net.Conn, error)
+}
+
+// Option configures the Client created by New
+type Option func(*options)
+
+// WithTLS authenticates the daemon and presents the client certificate of the TLS config,
+// see ClientTLSConfig
+func WithTLS(config *tls.Config) Option {
+	return func(o *options) {
+		o.creds = credentials.NewTLS(config)
+	}
+}
+
+// WithInsecure connects to the daemon in plaintext, for development only
+func WithInsecure() Option {
+	return func(o *options) {
+		o.creds = insecure.NewCredentials()
+	}
+}
+
+// WithDialer connects to the daemon through dialer instead of the network, such as an in-memory listener
+func WithDialer(dialer func(ctx context.Context, addr string) (net.Conn, error)) Option {
+	return func(o *options) {
+		o.dialer = dialer
+	}
+}
+
+// WithDialTimeout sets the time New waits for the daemon, 3 seconds by default
+func WithDialTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.dialTimeout = timeout
+	}
+}
+
+// WithCallTimeout sets the deadline of Reset and ApplyFiles, 2 minutes by default
+func WithCallTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.callTimeout = timeout
+	}
+}
+
+// WithUpgradeTimeout sets the deadline of UpgradeKubeSpec and UpgradeKubeSpecStream, 1 hour by default.
+// It must cover pulling the os image, the rebase and the kubeadm upgrade.
+func WithUpgradeTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.upgradeTimeout = timeout
+	}
+}
+
+// WithKeepalive sets the interval of the keepalive pings and the time to wait for their ack
+// before the connection is closed, 30 and 10 seconds by default. The daemon rejects pings
+// more frequent than constants.KeepaliveMinTime.
+func WithKeepalive(interval time.Duration, timeout time.Duration) Option {
+	return func(o *options) {
+		o.keepaliveTime = interval
+		o.keepaliveTimeout = timeout
+	}
 }
 
 type PushInfo struct {
 	OSImageURL  string
+	OSRef       string
 	KubeVersion string
+	// digest the os image must have, not verified when empty
+	OSImageDigest string
+	// kubernetes components upgraded on master nodes, all when empty
+	UpgradeComponent string
+	// apiserver version workers are not upgraded beyond, not checked when empty
+	ControlPlaneVersion string
+	// disk write rate of the os image pull such as 10M, not limited when empty
+	PullDiskWriteLimit string
+	// network rate the os image is downloaded with such as 10M, not limited when empty
+	PullBandwidthLimit string
 }
 
 // Create a grpc channel
-func New(socketAddr string) (*Client, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+func New(socketAddr string, opts ...Option) (*Client, error) {
+	o := options{
+		dialTimeout:      defaultDialTimeout,
+		callTimeout:      defaultCallTimeout,
+		upgradeTimeout:   defaultUpgradeTimeout,
+		keepaliveTime:    defaultKeepaliveTime,
+		keepaliveTimeout: defaultKeepaliveTimeout,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.creds == nil {
+		return nil, errors.New("no transport credentials, connect with WithTLS or WithInsecure")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), o.dialTimeout)
 	defer cancel()
 
 	bc := backoff.DefaultConfig
 	bc.MaxDelay = 5 * time.Second
 
-	connection, err := grpc.DialContext(ctx, socketAddr, grpc.WithInsecure(), grpc.WithBlock(),
-		grpc.WithConnectParams(grpc.ConnectParams{Backoff: bc}))
+	// 心跳用于发现已失联的节点，避免请求一直阻塞调和循环
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(o.creds), grpc.WithBlock(),
+		grpc.WithConnectParams(grpc.ConnectParams{Backoff: bc}),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                o.keepaliveTime,
+			Timeout:             o.keepaliveTimeout,
+			PermitWithoutStream: true,
+		})}
+	if o.dialer != nil {
+		dialOpts = append(dialOpts, grpc.WithContextDialer(o.dialer))
+	}
+	connection, err := grpc.DialContext(ctx, socketAddr, dialOpts...)
 	if err != nil {
-		return nil, err
+		return nil, unreachable(err)
 	}
-	return &Client{socketAddress: socketAddr, client: pb.NewUpgradeClusterClient(connection)}, nil
+	return &Client{
+		socketAddress:  socketAddr,
+		client:         pb.NewUpgradeClusterClient(connection),
+		callTimeout:    o.callTimeout,
+		upgradeTimeout: o.upgradeTimeout,
+	}, nil
 }
 
-// send update requests
-func (c *Client) UpgradeKubeSpec(pushInfo *PushInfo) error {
-	_, err := c.client.Upgrade(context.Background(),
-		&pb.UpgradeRequest{
-			KubeVersion: pushInfo.KubeVersion,
-			OsImageUrl:  pushInfo.OSImageURL,
-		})
+// unreachable wraps the errors of a daemon that can not be reached with ErrNodeUnreachable
+func unreachable(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) ||
+		status.Code(err) == codes.DeadlineExceeded || status.Code(err) == codes.Unavailable {
+		return fmt.Errorf("%w: %v", ErrNodeUnreachable, err)
+	}
 	return err
 }
+
+// pushError is an error of an upgrade push matching ErrPushTransient or ErrPushRejected
+type pushError struct {
+	kind error
+	err  error
+}
+
+func (e *pushError) Error() string {
+	return e.err.Error()
+}
+
+func (e *pushError) Unwrap() error {
+	return e.err
+}
+
+func (e *pushError) Is(target error) bool {
+	return target == e.kind
+}
+
+// classifyPushError maps the status code of an upgrade push error onto ErrPushRejected or ErrPushTransient,
+// the daemon reports requests it will never run with codes.InvalidArgument or codes.FailedPrecondition
+func classifyPushError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange, codes.PermissionDenied:
+		return &pushError{
+			kind: ErrPushRejected,
+			err:  fmt.Errorf("%w: %s", ErrPushRejected, status.Convert(err).Message()),
+		}
+	default:
+		return &pushError{kind: ErrPushTransient, err: unreachable(err)}
+	}
+}
+
+// send update requests, the response reports the result of the upgrade on the node.
+// The errors match ErrPushRejected or ErrPushTransient.
+func (c *Client) UpgradeKubeSpec(pushInfo *PushInfo) (*pb.UpgradeResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.upgradeTimeout)
+	defer cancel()
+	return c.upgrade(ctx, pushInfo)
+}
+
+func (c *Client) upgrade(ctx context.Context, pushInfo *PushInfo) (*pb.UpgradeResponse, error) {
+	resp, err := c.client.Upgrade(ctx, upgradeRequest(pushInfo))
+	if err != nil {
+		return nil, classifyPushError(err)
+	}
+	return resp, nil
+}
+
+// send update requests like UpgradeKubeSpec, onProgress is called as the upgrade enters each phase.
+// The errors match ErrPushRejected or ErrPushTransient like those of UpgradeKubeSpec.
+// Daemons without UpgradeStream are sent the unary request and report no progress,
+// the upgrade timeout covers the stream and the unary request together.
+func (c *Client) UpgradeKubeSpecStream(pushInfo *PushInfo, onProgress func(phase string, message string)) (*pb.UpgradeResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.upgradeTimeout)
+	defer cancel()
+	stream, err := c.client.UpgradeStream(ctx, upgradeRequest(pushInfo))
+	if err != nil {
+		return nil, classifyPushError(err)
+	}
+	for received := false; ; received = true {
+		progress, err := stream.Recv()
+		if err == io.EOF {
+			return nil, classifyPushError(errors.New("upgrade stream closed before the upgrade finished"))
+		}
+		if !received && status.Code(err) == codes.Unimplemented {
+			return c.upgrade(ctx, pushInfo)
+		}
+		if err != nil {
+			return nil, classifyPushError(err)
+		}
+		// 最后一条消息携带升级结果
+		if progress.Result != nil {
+			return progress.Result, nil
+		}
+		onProgress(progress.Phase, progress.Message)
+	}
+}
+
+func upgradeRequest(pushInfo *PushInfo) *pb.UpgradeRequest {
+	return &pb.UpgradeRequest{
+		KubeVersion:         pushInfo.KubeVersion,
+		OsImageUrl:          pushInfo.OSImageURL,
+		OsRef:               pushInfo.OSRef,
+		OsImageDigest:       pushInfo.OSImageDigest,
+		UpgradeComponent:    pushInfo.UpgradeComponent,
+		ControlPlaneVersion: pushInfo.ControlPlaneVersion,
+		PullDiskWriteLimit:  pushInfo.PullDiskWriteLimit,
+		PullBandwidthLimit:  pushInfo.PullBandwidthLimit,
+	}
+}
+
+// probe the daemon, the response reports the versions the node runs
+func (c *Client) HealthCheck() (*pb.HealthCheckResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+	resp, err := c.client.HealthCheck(ctx, &pb.HealthCheckRequest{})
+	if err != nil {
+		return nil, unreachable(err)
+	}
+	return resp, nil
+}
+
+// remove the node from the cluster, a node never joined is left unchanged
+func (c *Client) Reset() (*pb.ResetResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.callTimeout)
+	defer cancel()
+	resp, err := c.client.Reset(ctx, &pb.ResetRequest{})
+	if err != nil {
+		return nil, unreachable(err)
+	}
+	return resp, nil
+}
+
+// FileWrite is a file written on the node by ApplyFiles
+type FileWrite struct {
+	Path    string
+	Content []byte
+	Mode    uint32
+}
+
+// send a batch of file writes and service restarts, applied on the node all or nothing
+func (c *Client) ApplyFiles(files []FileWrite, restartServices []string) error {
+	req := &pb.FileBatchRequest{RestartServices: restartServices}
+	for _, file := range files {
+		req.Files = append(req.Files, &pb.FileWrite{
+			Path:    file.Path,
+			Content: file.Content,
+			Mode:    file.Mode,
+		})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.callTimeout)
+	defer cancel()
+	if _, err := c.client.ApplyFiles(ctx, req); err != nil {
+		return unreachable(err)
+	}
+	return nil
+}
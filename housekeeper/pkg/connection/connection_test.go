@@ -0,0 +1,255 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connection
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "housekeeper.io/pkg/connection/proto"
+)
+
+// fakeDaemon answers the requests of the client with the functions set by the test
+type fakeDaemon struct {
+	pb.UnimplementedUpgradeClusterServer
+	upgrade       func(ctx context.Context, req *pb.UpgradeRequest) (*pb.UpgradeResponse, error)
+	upgradeStream func(req *pb.UpgradeRequest, stream pb.UpgradeCluster_UpgradeStreamServer) error
+	reset         func(ctx context.Context) (*pb.ResetResponse, error)
+}
+
+func (d *fakeDaemon) Upgrade(ctx context.Context, req *pb.UpgradeRequest) (*pb.UpgradeResponse, error) {
+	if d.upgrade == nil {
+		return d.UnimplementedUpgradeClusterServer.Upgrade(ctx, req)
+	}
+	return d.upgrade(ctx, req)
+}
+
+func (d *fakeDaemon) UpgradeStream(req *pb.UpgradeRequest, stream pb.UpgradeCluster_UpgradeStreamServer) error {
+	if d.upgradeStream == nil {
+		return d.UnimplementedUpgradeClusterServer.UpgradeStream(req, stream)
+	}
+	return d.upgradeStream(req, stream)
+}
+
+func (d *fakeDaemon) Reset(ctx context.Context, req *pb.ResetRequest) (*pb.ResetResponse, error) {
+	if d.reset == nil {
+		return d.UnimplementedUpgradeClusterServer.Reset(ctx, req)
+	}
+	return d.reset(ctx)
+}
+
+func (d *fakeDaemon) HealthCheck(context.Context, *pb.HealthCheckRequest) (*pb.HealthCheckResponse, error) {
+	return &pb.HealthCheckResponse{OsVersion: "22.03", KubeVersion: "v1.23.10"}, nil
+}
+
+// newTestClient serves the daemon on an in-memory listener and connects a client to it
+func newTestClient(t *testing.T, daemon *fakeDaemon, opts ...Option) *Client {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	pb.RegisterUpgradeClusterServer(s, daemon)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	opts = append([]Option{WithInsecure(), WithDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	})}, opts...)
+	c, err := New("bufnet", opts...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c
+}
+
+func TestNewRequiresCredentials(t *testing.T) {
+	if _, err := New("bufnet"); err == nil {
+		t.Fatal("New() without transport credentials succeeded")
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	c := newTestClient(t, &fakeDaemon{})
+	resp, err := c.HealthCheck()
+	if err != nil {
+		t.Fatalf("HealthCheck() error = %v", err)
+	}
+	if resp.GetOsVersion() != "22.03" || resp.GetKubeVersion() != "v1.23.10" {
+		t.Errorf("HealthCheck() = %v", resp)
+	}
+}
+
+func TestUpgradeKubeSpecClassifiesErrors(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want error
+	}{
+		{code: codes.InvalidArgument, want: ErrPushRejected},
+		{code: codes.FailedPrecondition, want: ErrPushRejected},
+		{code: codes.OutOfRange, want: ErrPushRejected},
+		{code: codes.PermissionDenied, want: ErrPushRejected},
+		{code: codes.Unknown, want: ErrPushTransient},
+		{code: codes.Internal, want: ErrPushTransient},
+		{code: codes.Unavailable, want: ErrPushTransient},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			c := newTestClient(t, &fakeDaemon{
+				upgrade: func(context.Context, *pb.UpgradeRequest) (*pb.UpgradeResponse, error) {
+					return nil, status.Error(tt.code, "refused")
+				},
+			})
+			_, err := c.UpgradeKubeSpec(&PushInfo{KubeVersion: "v1.23.10"})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("UpgradeKubeSpec() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpgradeKubeSpecStream(t *testing.T) {
+	var phases []string
+	c := newTestClient(t, &fakeDaemon{
+		upgradeStream: func(req *pb.UpgradeRequest, stream pb.UpgradeCluster_UpgradeStreamServer) error {
+			if req.GetKubeVersion() != "v1.23.10" || req.GetUpgradeComponent() != "etcd" {
+				return status.Errorf(codes.InvalidArgument, "unexpected request %v", req)
+			}
+			if err := stream.Send(&pb.UpgradeProgress{Phase: "kubeadm-upgrading"}); err != nil {
+				return err
+			}
+			return stream.Send(&pb.UpgradeProgress{Phase: "done",
+				Result: &pb.UpgradeResponse{KubeVersion: req.GetKubeVersion(), Actions: []string{"etcd-upgraded"}}})
+		},
+	})
+	resp, err := c.UpgradeKubeSpecStream(&PushInfo{KubeVersion: "v1.23.10", UpgradeComponent: "etcd"},
+		func(phase string, _ string) {
+			phases = append(phases, phase)
+		})
+	if err != nil {
+		t.Fatalf("UpgradeKubeSpecStream() error = %v", err)
+	}
+	if len(phases) != 1 || phases[0] != "kubeadm-upgrading" {
+		t.Errorf("reported phases %v", phases)
+	}
+	if len(resp.GetActions()) != 1 || resp.GetActions()[0] != "etcd-upgraded" {
+		t.Errorf("UpgradeKubeSpecStream() = %v", resp)
+	}
+}
+
+func TestUpgradeKubeSpecStreamFallsBackToUnary(t *testing.T) {
+	c := newTestClient(t, &fakeDaemon{
+		upgrade: func(_ context.Context, req *pb.UpgradeRequest) (*pb.UpgradeResponse, error) {
+			return &pb.UpgradeResponse{OsVersion: req.GetOsImageUrl()}, nil
+		},
+	})
+	resp, err := c.UpgradeKubeSpecStream(&PushInfo{OSImageURL: "nestos:v2"}, func(string, string) {
+		t.Error("progress reported by a unary upgrade")
+	})
+	if err != nil {
+		t.Fatalf("UpgradeKubeSpecStream() error = %v", err)
+	}
+	if resp.GetOsVersion() != "nestos:v2" {
+		t.Errorf("UpgradeKubeSpecStream() = %v", resp)
+	}
+}
+
+func TestUpgradeKubeSpecStreamClosedEarly(t *testing.T) {
+	c := newTestClient(t, &fakeDaemon{
+		upgradeStream: func(_ *pb.UpgradeRequest, stream pb.UpgradeCluster_UpgradeStreamServer) error {
+			return stream.Send(&pb.UpgradeProgress{Phase: "rebasing"})
+		},
+	})
+	_, err := c.UpgradeKubeSpecStream(&PushInfo{OSImageURL: "nestos:v2"}, func(string, string) {})
+	if !errors.Is(err, ErrPushTransient) {
+		t.Errorf("UpgradeKubeSpecStream() error = %v, want %v", err, ErrPushTransient)
+	}
+}
+
+// waitForDeadline blocks the request until the client gives up on it
+func waitForDeadline(ctx context.Context) error {
+	<-ctx.Done()
+	return status.FromContextError(ctx.Err()).Err()
+}
+
+func TestUpgradeOutlastsCallTimeout(t *testing.T) {
+	c := newTestClient(t, &fakeDaemon{
+		upgrade: func(ctx context.Context, _ *pb.UpgradeRequest) (*pb.UpgradeResponse, error) {
+			// 升级耗时超过普通请求的超时时间
+			time.Sleep(100 * time.Millisecond)
+			return &pb.UpgradeResponse{KubeVersion: "v1.23.10"}, nil
+		},
+	}, WithCallTimeout(10*time.Millisecond))
+	if _, err := c.UpgradeKubeSpecStream(&PushInfo{KubeVersion: "v1.23.10"}, func(string, string) {}); err != nil {
+		t.Errorf("UpgradeKubeSpecStream() error = %v", err)
+	}
+}
+
+func TestDeadlines(t *testing.T) {
+	daemon := &fakeDaemon{
+		upgrade: func(ctx context.Context, _ *pb.UpgradeRequest) (*pb.UpgradeResponse, error) {
+			return nil, waitForDeadline(ctx)
+		},
+		upgradeStream: func(_ *pb.UpgradeRequest, stream pb.UpgradeCluster_UpgradeStreamServer) error {
+			if err := stream.Send(&pb.UpgradeProgress{Phase: "rebasing"}); err != nil {
+				return err
+			}
+			return waitForDeadline(stream.Context())
+		},
+		reset: func(ctx context.Context) (*pb.ResetResponse, error) {
+			return nil, waitForDeadline(ctx)
+		},
+	}
+	c := newTestClient(t, daemon, WithCallTimeout(50*time.Millisecond), WithUpgradeTimeout(50*time.Millisecond))
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{name: "UpgradeKubeSpec", call: func() error {
+			_, err := c.UpgradeKubeSpec(&PushInfo{KubeVersion: "v1.23.10"})
+			return err
+		}},
+		{name: "UpgradeKubeSpecStream", call: func() error {
+			_, err := c.UpgradeKubeSpecStream(&PushInfo{KubeVersion: "v1.23.10"}, func(string, string) {})
+			return err
+		}},
+		{name: "Reset", call: func() error {
+			_, err := c.Reset()
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan error, 1)
+			go func() { done <- tt.call() }()
+			select {
+			case err := <-done:
+				if !errors.Is(err, ErrNodeUnreachable) {
+					t.Errorf("%s() error = %v, want %v", tt.name, err, ErrNodeUnreachable)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("%s() did not time out", tt.name)
+			}
+		})
+	}
+}
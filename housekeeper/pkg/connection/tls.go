@@ -0,0 +1,82 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connection
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"housekeeper.io/pkg/constants"
+)
+
+// Insecure reports whether TLS is disabled on the daemon socket by the environment
+func Insecure() bool {
+	insecure, _ := strconv.ParseBool(os.Getenv(constants.EnvInsecure))
+	return insecure
+}
+
+// ServerTLSConfig returns the TLS config of the daemon, only clients with a certificate
+// signed by the CA in dir are accepted
+func ServerTLSConfig(dir string) (*tls.Config, error) {
+	cert, pool, err := loadKeyPair(dir, constants.DaemonCertFile, constants.DaemonKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// ClientTLSConfig returns the TLS config of the controller, the daemon must present
+// a certificate for constants.DaemonServerName signed by the CA in dir
+func ClientTLSConfig(dir string) (*tls.Config, error) {
+	cert, pool, err := loadKeyPair(dir, constants.ControllerCertFile, constants.ControllerKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ServerName:   constants.DaemonServerName,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func loadKeyPair(dir string, certFile string, keyFile string) (tls.Certificate, *x509.CertPool, error) {
+	cert, err := tls.LoadX509KeyPair(filepath.Join(dir, certFile), filepath.Join(dir, keyFile))
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to load certificate %s: %w", certFile, err)
+	}
+	caFile := filepath.Join(dir, constants.CACertFile)
+	caCert, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return tls.Certificate{}, nil, fmt.Errorf("no certificate found in %s", caFile)
+	}
+	return cert, pool, nil
+}
@@ -23,15 +23,63 @@ const (
 	// LabelMaster defines the label associated with master node.
 	LabelMaster           = "node-role.kubernetes.io/master"
 	LabelUpgradeCompleted = "upgrade.housekeeper.io/upgradeCompleted"
+	// LabelNodePool is the key of the label recording the node pool of worker nodes
+	LabelNodePool = "upgrade.housekeeper.io/node-pool"
+	// AnnotationCriticalPod marks a singleton pod whose eviction disrupts a critical service
+	AnnotationCriticalPod = "upgrade.housekeeper.io/critical-pod"
+	// AnnotationUpgradePushed marks a drained node whose upgrade the daemon has acknowledged
+	AnnotationUpgradePushed = "upgrade.housekeeper.io/upgrade-pushed"
+	// AnnotationForcedGeneration records the generation of the Update whose forced upgrade was pushed to the node
+	AnnotationForcedGeneration = "upgrade.housekeeper.io/forced-generation"
+	// AnnotationTargetOSVersion and AnnotationTargetKubeVersion record the versions last pushed to the node,
+	// they are kept after the upgrade for auditing
+	AnnotationTargetOSVersion   = "housekeeper.io/target-os-version"
+	AnnotationTargetKubeVersion = "housekeeper.io/target-kube-version"
 )
 
+// EnvNodeName is the environment variable holding the name of the node the controller runs on
+const EnvNodeName = "NODE_NAME"
+
 // socket file
 const (
 	SockDir  = "/var/nkd"
 	SockName = "housekeeper-daemon.sock"
+	// NodeMetadataFile keeps the node metadata in SockDir while the os upgrade reboots the node
+	NodeMetadataFile = "node-metadata.json"
 )
 
+// certificates of the mutual TLS between the controller and the daemon, generated by the deployer
+const (
+	PKIDir             = "/etc/nkd/housekeeper/pki"
+	CACertFile         = "ca.crt"
+	DaemonCertFile     = "daemon.crt"
+	DaemonKeyFile      = "daemon.key"
+	ControllerCertFile = "controller.crt"
+	ControllerKeyFile  = "controller.key"
+	// DaemonServerName is the name in the daemon certificate the controller verifies
+	DaemonServerName = "housekeeper-daemon"
+	// EnvInsecure set to true disables TLS on the daemon socket, for development only
+	EnvInsecure = "HOUSEKEEPER_INSECURE"
+)
+
+// HookDir holds the pre-upgrade and post-upgrade directories of the scripts the daemon runs around an upgrade
+const HookDir = "/etc/nkd/hookfiles"
+
 const (
 	// node upgrade timeout
 	NodeTimeout = 3 * time.Minute
+	// node drain timeout
+	DrainTimeout = 15 * time.Minute
+	// default attempts of rpm-ostree rebase
+	RebaseMaxAttempts = 3
+	// environment variable overriding the attempts of rpm-ostree rebase
+	EnvRebaseMaxAttempts = "HOUSEKEEPER_REBASE_MAX_ATTEMPTS"
+	// time the daemon waits for the running upgrade when it is stopped, matches TimeoutStopSec of its service
+	ShutdownTimeout = 10 * time.Minute
+	// shortest keepalive ping interval of the controller the daemon accepts
+	KeepaliveMinTime = 10 * time.Second
+	// requeue delay after the first failed reconcile, doubled on every consecutive failure
+	RequeueBackoffBase = 5 * time.Second
+	// longest requeue delay after consecutive failed reconciles
+	RequeueBackoffMax = 5 * time.Minute
 )
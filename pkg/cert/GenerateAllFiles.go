@@ -20,8 +20,13 @@ import (
 	"crypto/x509"
 	"nestos-kubernetes-deployer/pkg/configmanager"
 	"nestos-kubernetes-deployer/pkg/configmanager/asset"
+	"nestos-kubernetes-deployer/pkg/ignition"
 	"nestos-kubernetes-deployer/pkg/utils"
 	"net"
+	"strings"
+	"time"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
 
 	netutils "k8s.io/utils/net"
 
@@ -32,6 +37,8 @@ type CertGenerator struct {
 	ClusterID  string
 	CaCertHash string
 	Node       *asset.NodeAsset
+	// 生成证书时读取并更新的集群配置，为空时使用configmanager中保存的配置
+	ClusterAsset *asset.ClusterAsset
 }
 
 func NewCertGenerator(clusterID string, node *asset.NodeAsset) *CertGenerator {
@@ -47,13 +54,23 @@ func (cg *CertGenerator) GenerateAllFiles() error {
 	var certs []utils.StorageContent
 	clusterID := cg.ClusterID
 	//读取配置
-	clusterconfig, _ := configmanager.GetClusterConfig(clusterID)
+	clusterconfig := cg.ClusterAsset
+	if clusterconfig == nil {
+		stored, err := configmanager.GetClusterConfig(clusterID)
+		if err != nil {
+			logrus.Errorf("failed to get cluster config of %s: %v", clusterID, err)
+			return err
+		}
+		clusterconfig = stored
+	}
 	globalconfig, _ := configmanager.GetGlobalConfig()
 
 	//获取node节点hostname和ip地址
 	hostname := cg.Node.Hostname
 	ipaddress := cg.Node.IP
 
+	caValidity, certValidity := validities(clusterconfig.CertAsset)
+
 	//用于后续kubeconfig生成
 	apiserverEndpoint := "https://" + clusterconfig.Kubernetes.ApiServerEndpoint
 
@@ -62,12 +79,13 @@ func (cg *CertGenerator) GenerateAllFiles() error {
 	        2. 新增判断，默认值取用Network.Service_Subnet并进行以下解析，如用户填充internalAPIServerVirtualIP
 			   则读取用户自定义内容
 			3. 持续调研service clusterip相关内容，是否有统一入口进行相关配置。*/
-	_, svcSubnet, err := net.ParseCIDR(clusterconfig.Network.ServiceSubnet)
+	svcSubnets, err := asset.ParseCIDRList(clusterconfig.Network.ServiceSubnet)
 	if err != nil {
 		logrus.Errorf("unable to get internal Kubernetes Service IP from the given service CIDR: %v\n", err)
 		return err
 	}
-	internalAPIServerVirtualIP, err := netutils.GetIndexedIP(svcSubnet, 1)
+	// 双栈集群中kubernetes服务的IP取自第一个service网段
+	internalAPIServerVirtualIP, err := netutils.GetIndexedIP(svcSubnets[0], 1)
 	if err != nil {
 		logrus.Errorf("unable to get the first IP address from the given CIDR: %v\n", err)
 		return err
@@ -76,7 +94,7 @@ func (cg *CertGenerator) GenerateAllFiles() error {
 	/* **********生成root CA 证书和密钥********** */
 
 	rootCACert, err := GenerateAllCA(clusterconfig.CertAsset.RootCaCertPath,
-		clusterconfig.CertAsset.RootCaKeyPath, "kubernetes", []string{"kubernetes"})
+		clusterconfig.CertAsset.RootCaKeyPath, "kubernetes", []string{"kubernetes"}, caValidity)
 	if err != nil {
 		logrus.Errorf("Error generating root CA:%v", err)
 		return err
@@ -120,7 +138,7 @@ func (cg *CertGenerator) GenerateAllFiles() error {
 	/* **********生成etcd CA 证书和密钥********** */
 
 	etcdCACert, err := GenerateAllCA(clusterconfig.CertAsset.EtcdCaCertPath,
-		clusterconfig.CertAsset.EtcdCaKeyPath, "etcd-ca", []string{"etcd-ca"})
+		clusterconfig.CertAsset.EtcdCaKeyPath, "etcd-ca", []string{"etcd-ca"}, caValidity)
 	if err != nil {
 		logrus.Errorf("Error generating etcd CA:%v", err)
 		return err
@@ -159,7 +177,7 @@ func (cg *CertGenerator) GenerateAllFiles() error {
 	/* **********生成front-proxy CA 证书和密钥********** */
 
 	frontProxyCACert, err := GenerateAllCA(clusterconfig.CertAsset.FrontProxyCaCertPath,
-		clusterconfig.CertAsset.FrontProxyCaKeyPath, "front-proxy-ca", []string{"front-proxy-ca"})
+		clusterconfig.CertAsset.FrontProxyCaKeyPath, "front-proxy-ca", []string{"front-proxy-ca"}, caValidity)
 	if err != nil {
 		logrus.Errorf("Error generating front-proxy CA:%v", err)
 		return err
@@ -197,41 +215,16 @@ func (cg *CertGenerator) GenerateAllFiles() error {
 
 	/* **********生成 sa.pub和sa.key********** */
 
-	sakeypair, err := GenerateKeyPair()
+	saKeyPair, err := GenerateServiceAccountKeyPair(clusterID)
 	if err != nil {
-		logrus.Errorf("Error generating sa keypair:%v", err)
 		return err
 	}
 
-	/*如果用户没有提供自定义路径，则将密钥对保存在以下目录；
-	  如果用户提供了自定义路径，也保存一份在以下路径，并反存到配置文件中*/
-	clusterconfig.CertAsset.SaKey = globalconfig.PersistDir + "/pki/sa.key"
-	clusterconfig.CertAsset.SaPub = globalconfig.PersistDir + "/pki/sa.pub"
+	//所有控制面节点共用持久化目录中的密钥对，并反存到配置文件中
+	clusterconfig.CertAsset.SaKey = globalconfig.PersistDir + "/" + clusterID + "/pki/sa.key"
+	clusterconfig.CertAsset.SaPub = globalconfig.PersistDir + "/" + clusterID + "/pki/sa.pub"
 
-	//保存密钥对到宿主机
-	err = SaveFileToLocal(globalconfig.PersistDir+"/"+clusterID+"/pki/sa.key", sakeypair.PrivateKeyPEM)
-	if err != nil {
-		return err
-	}
-
-	err = SaveFileToLocal(globalconfig.PersistDir+"/"+clusterID+"/pki/sa.pub", sakeypair.PublicKeyPEM)
-	if err != nil {
-		return err
-	}
-
-	saKeyContent := utils.StorageContent{
-		Path:    utils.SaKey,
-		Mode:    int(utils.CertFileMode),
-		Content: sakeypair.PrivateKeyPEM,
-	}
-
-	saPubContent := utils.StorageContent{
-		Path:    utils.SaPub,
-		Mode:    int(utils.CertFileMode),
-		Content: sakeypair.PublicKeyPEM,
-	}
-
-	certs = append(certs, saKeyContent, saPubContent)
+	certs = append(certs, saKeyPair...)
 
 	/* **********生成 /etcd/server.crt********** */
 
@@ -241,7 +234,7 @@ func (cg *CertGenerator) GenerateAllFiles() error {
 	ipAddresses := []net.IP{net.ParseIP(ipaddress), net.ParseIP("127.0.0.1")}
 
 	servercrt, err := GenerateAllSignedCert(commonName,
-		nil, dnsNames, extKeyUsage, ipAddresses, etcdCACert.CertRaw, etcdCACert.KeyRaw)
+		nil, dnsNames, extKeyUsage, ipAddresses, etcdCACert.CertRaw, etcdCACert.KeyRaw, certValidity)
 	if err != nil {
 		logrus.Errorf("Error generating /etcd/server cert:%v", err)
 		return err
@@ -269,7 +262,7 @@ func (cg *CertGenerator) GenerateAllFiles() error {
 	ipAddresses = []net.IP{net.ParseIP(ipaddress), net.ParseIP("127.0.0.1"), net.ParseIP("::1")}
 
 	peercrt, err := GenerateAllSignedCert(commonName,
-		nil, dnsNames, extKeyUsage, ipAddresses, etcdCACert.CertRaw, etcdCACert.KeyRaw)
+		nil, dnsNames, extKeyUsage, ipAddresses, etcdCACert.CertRaw, etcdCACert.KeyRaw, certValidity)
 	if err != nil {
 		logrus.Errorf("Error generating /etcd/peer cert:%v", err)
 		return err
@@ -298,7 +291,7 @@ func (cg *CertGenerator) GenerateAllFiles() error {
 	ipAddresses = []net.IP{net.ParseIP(ipaddress), net.ParseIP("127.0.0.1"), net.ParseIP(internalAPIServerVirtualIP.String())}
 
 	apiservercrt, err := GenerateAllSignedCert(commonName,
-		nil, dnsNames, extKeyUsage, ipAddresses, rootCACert.CertRaw, rootCACert.KeyRaw)
+		nil, dnsNames, extKeyUsage, ipAddresses, rootCACert.CertRaw, rootCACert.KeyRaw, certValidity)
 	if err != nil {
 		logrus.Errorf("Error generating apiserver cert:%v", err)
 		return err
@@ -324,7 +317,7 @@ func (cg *CertGenerator) GenerateAllFiles() error {
 	extKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
 
 	frontProxyClientcrt, err := GenerateAllSignedCert(commonName,
-		nil, nil, extKeyUsage, nil, frontProxyCACert.CertRaw, frontProxyCACert.KeyRaw)
+		nil, nil, extKeyUsage, nil, frontProxyCACert.CertRaw, frontProxyCACert.KeyRaw, certValidity)
 	if err != nil {
 		logrus.Errorf("Error generating front-proxy-client cert:%v", err)
 		return err
@@ -351,7 +344,7 @@ func (cg *CertGenerator) GenerateAllFiles() error {
 	extKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
 
 	apiserverKubeletClientcrt, err := GenerateAllSignedCert(commonName,
-		organization, nil, extKeyUsage, nil, rootCACert.CertRaw, rootCACert.KeyRaw)
+		organization, nil, extKeyUsage, nil, rootCACert.CertRaw, rootCACert.KeyRaw, certValidity)
 	if err != nil {
 		logrus.Errorf("Error generating apiserver-kubelet-client cert:%v", err)
 		return err
@@ -376,9 +369,10 @@ func (cg *CertGenerator) GenerateAllFiles() error {
 	commonName = "kube-apiserver-etcd-client"
 	organization = []string{"system:masters"}
 	extKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	dnsNames, ipAddresses = splitSANs(clusterconfig.CertAsset.EtcdClientSANs)
 
 	apiserverEtcdClient, err := GenerateAllSignedCert(commonName,
-		organization, nil, extKeyUsage, nil, etcdCACert.CertRaw, etcdCACert.KeyRaw)
+		organization, dnsNames, extKeyUsage, ipAddresses, etcdCACert.CertRaw, etcdCACert.KeyRaw, certValidity)
 	if err != nil {
 		logrus.Errorf("Error generating kube-apiserver-etcd-client cert:%v", err)
 		return err
@@ -405,7 +399,7 @@ func (cg *CertGenerator) GenerateAllFiles() error {
 	extKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
 
 	healthcheckcrt, err := GenerateAllSignedCert(commonName,
-		organization, nil, extKeyUsage, nil, etcdCACert.CertRaw, etcdCACert.KeyRaw)
+		organization, nil, extKeyUsage, nil, etcdCACert.CertRaw, etcdCACert.KeyRaw, certValidity)
 	if err != nil {
 		logrus.Errorf("Error generating healthcheck cert:%v", err)
 		return err
@@ -432,7 +426,7 @@ func (cg *CertGenerator) GenerateAllFiles() error {
 	extKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
 
 	admincrt, err := GenerateAllSignedCert(commonName,
-		organization, nil, extKeyUsage, nil, rootCACert.CertRaw, rootCACert.KeyRaw)
+		organization, nil, extKeyUsage, nil, rootCACert.CertRaw, rootCACert.KeyRaw, certValidity)
 	if err != nil {
 		logrus.Errorf("Error generate admin cert:%v", err)
 		return err
@@ -467,7 +461,7 @@ func (cg *CertGenerator) GenerateAllFiles() error {
 	extKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
 
 	controllerManagercrt, err := GenerateAllSignedCert(commonName,
-		nil, nil, extKeyUsage, nil, rootCACert.CertRaw, rootCACert.KeyRaw)
+		nil, nil, extKeyUsage, nil, rootCACert.CertRaw, rootCACert.KeyRaw, certValidity)
 	if err != nil {
 		logrus.Errorf("Error generate controller-manager cert:%v", err)
 		return err
@@ -494,7 +488,7 @@ func (cg *CertGenerator) GenerateAllFiles() error {
 	extKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
 
 	schedulercrt, err := GenerateAllSignedCert(commonName,
-		nil, nil, extKeyUsage, nil, rootCACert.CertRaw, rootCACert.KeyRaw)
+		nil, nil, extKeyUsage, nil, rootCACert.CertRaw, rootCACert.KeyRaw, certValidity)
 	if err != nil {
 		logrus.Errorf("Error generate scheduler cert:%v", err)
 		return err
@@ -522,7 +516,7 @@ func (cg *CertGenerator) GenerateAllFiles() error {
 	extKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
 
 	kubeletcrt, err := GenerateAllSignedCert(commonName,
-		organization, nil, extKeyUsage, nil, rootCACert.CertRaw, rootCACert.KeyRaw)
+		organization, nil, extKeyUsage, nil, rootCACert.CertRaw, rootCACert.KeyRaw, certValidity)
 	if err != nil {
 		logrus.Errorf("Error generate kubelet cert:%v", err)
 		return err
@@ -543,7 +537,75 @@ func (cg *CertGenerator) GenerateAllFiles() error {
 
 	certs = append(certs, kubeletKubeconfigContent)
 
+	/* **********校验所有签发证书的证书链********** */
+
+	serverUsage := []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	clientUsage := []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	chains := []CertChain{
+		{Name: "etcd/server", CACert: etcdCACert.CertRaw, Cert: servercrt.CertRaw, ExtKeyUsages: serverUsage, DNSName: hostname},
+		{Name: "etcd/peer", CACert: etcdCACert.CertRaw, Cert: peercrt.CertRaw, ExtKeyUsages: serverUsage, DNSName: hostname},
+		{Name: "apiserver", CACert: rootCACert.CertRaw, Cert: apiservercrt.CertRaw, ExtKeyUsages: serverUsage, DNSName: "kubernetes.default.svc"},
+		{Name: "front-proxy-client", CACert: frontProxyCACert.CertRaw, Cert: frontProxyClientcrt.CertRaw, ExtKeyUsages: clientUsage},
+		{Name: "apiserver-kubelet-client", CACert: rootCACert.CertRaw, Cert: apiserverKubeletClientcrt.CertRaw, ExtKeyUsages: clientUsage},
+		{Name: "apiserver-etcd-client", CACert: etcdCACert.CertRaw, Cert: apiserverEtcdClient.CertRaw, ExtKeyUsages: clientUsage},
+		{Name: "etcd/healthcheck-client", CACert: etcdCACert.CertRaw, Cert: healthcheckcrt.CertRaw, ExtKeyUsages: clientUsage},
+		{Name: "admin", CACert: rootCACert.CertRaw, Cert: admincrt.CertRaw, ExtKeyUsages: clientUsage},
+		{Name: "controller-manager", CACert: rootCACert.CertRaw, Cert: controllerManagercrt.CertRaw, ExtKeyUsages: clientUsage},
+		{Name: "scheduler", CACert: rootCACert.CertRaw, Cert: schedulercrt.CertRaw, ExtKeyUsages: clientUsage},
+		{Name: "kubelet", CACert: rootCACert.CertRaw, Cert: kubeletcrt.CertRaw, ExtKeyUsages: clientUsage},
+	}
+	if err := VerifyCertChains(chains); err != nil {
+		logrus.Errorf("Error verifying generated certs:%v", err)
+		return err
+	}
+
 	cg.Node.Certs = certs
 
 	return nil
 }
+
+// validities 返回CA证书和签发证书的有效期，未设置时使用默认值
+func validities(certAsset asset.CertAsset) (time.Duration, time.Duration) {
+	caValidity, certValidity := DefaultCAValidity, DefaultCertValidity
+	if certAsset.CAValidityDays != 0 {
+		caValidity = time.Hour * 24 * time.Duration(certAsset.CAValidityDays)
+	}
+	if certAsset.CertValidityDays != 0 {
+		certValidity = time.Hour * 24 * time.Duration(certAsset.CertValidityDays)
+	}
+	return caValidity, certValidity
+}
+
+// splitSANs 将用户提供的SAN按照IP地址和域名分开
+func splitSANs(sans []string) ([]string, []net.IP) {
+	var (
+		dnsNames    []string
+		ipAddresses []net.IP
+	)
+	for _, san := range sans {
+		if ip := net.ParseIP(san); ip != nil {
+			ipAddresses = append(ipAddresses, ip)
+		} else {
+			dnsNames = append(dnsNames, san)
+		}
+	}
+	return dnsNames, ipAddresses
+}
+
+// 生成所有证书文件和kubeconfig，并转换为可直接合并的ignition文件，私钥文件权限为0600
+func (cg *CertGenerator) GenerateAllFilesAsIgnition() ([]igntypes.File, error) {
+	if err := cg.GenerateAllFiles(); err != nil {
+		return nil, err
+	}
+
+	var files []igntypes.File
+	for _, cert := range cg.Node.Certs {
+		mode := cert.Mode
+		if strings.HasSuffix(cert.Path, ".key") {
+			mode = int(utils.KeyFileMode)
+		}
+		files = ignition.AppendFiles(files, ignition.FileWithContents(cert.Path, mode, cert.Content))
+	}
+
+	return files, nil
+}
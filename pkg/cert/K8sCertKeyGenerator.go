@@ -28,6 +28,13 @@ import (
 	"github.com/pkg/errors"
 )
 
+const (
+	// DefaultCAValidity 是生成的CA证书的默认有效期
+	DefaultCAValidity = time.Hour * 24 * 3650
+	// DefaultCertValidity 是签发证书的默认有效期
+	DefaultCertValidity = time.Hour * 24 * 365
+)
+
 // SetUserCA 读取用户提供的各类ca证书和密钥路径中的内容
 func setUserCA(a *SelfSignedCertKey, certPath, keyPath string) error {
 	cacert, err := os.ReadFile(certPath)
@@ -53,7 +60,8 @@ GenerateAllCA()用于生成
 /etc/kubernetes/pki/front-proxy-ca.crt
 /etc/kubernetes/pki/etcd/ca.crt   以及所有对应key
 */
-func GenerateAllCA(userCACertPath, userCAKeyPath, commonname string, dnsname []string) (*SelfSignedCertKey, error) {
+func GenerateAllCA(userCACertPath, userCAKeyPath, commonname string, dnsname []string,
+	validity time.Duration) (*SelfSignedCertKey, error) {
 
 	a := SelfSignedCertKey{}
 
@@ -65,10 +73,13 @@ func GenerateAllCA(userCACertPath, userCAKeyPath, commonname string, dnsname []s
 		}
 	} else {
 		// 如果用户没有提供自定义CA证书路径，则继续生成
+		if validity <= 0 {
+			return nil, errors.Errorf("invalid validity %v of CA %s", validity, commonname)
+		}
 		cfg := &CertConfig{
 			Subject:   pkix.Name{CommonName: commonname},
 			KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
-			Validity:  time.Hour * 36500 * 24,
+			Validity:  validity,
 			IsCA:      true,
 			DNSNames:  dnsname,
 		}
@@ -104,14 +115,17 @@ func GenerateKeyPair() (*KeyPairPEM, error) {
 
 //GenerateAllSignedCert()用于生成所有签发的证书
 func GenerateAllSignedCert(commonname string, org, dnsname []string, extkeyusage []x509.ExtKeyUsage,
-	ip []net.IP, cacert, cakey []byte) (*SignedCertKey, error) {
+	ip []net.IP, cacert, cakey []byte, validity time.Duration) (*SignedCertKey, error) {
+	if validity <= 0 {
+		return nil, errors.Errorf("invalid validity %v of cert %s", validity, commonname)
+	}
 	a := SignedCertKey{}
 
 	cfg := &CertConfig{
 		Subject:      pkix.Name{CommonName: commonname, Organization: org},
 		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
 		ExtKeyUsages: extkeyusage,
-		Validity:     time.Hour * 36500 * 24,
+		Validity:     validity,
 		IsCA:         false,
 		DNSNames:     dnsname,
 		IPAddresses:  ip,
@@ -0,0 +1,97 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cert
+
+import (
+	"crypto/x509"
+	"nestos-kubernetes-deployer/pkg/configmanager"
+	"nestos-kubernetes-deployer/pkg/configmanager/asset"
+	"nestos-kubernetes-deployer/pkg/utils"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// HousekeeperDaemonName 是守护进程证书的SAN，控制器通过unix socket连接时以此校验服务端
+	HousekeeperDaemonName     = "housekeeper-daemon"
+	HousekeeperControllerName = "housekeeper-controller"
+)
+
+// GenerateHousekeeperCerts 生成housekeeper控制器与守护进程双向TLS所需的证书，写入每个节点。
+// CA保存在持久化目录中，扩容节点时复用同一个CA签发证书。
+func GenerateHousekeeperCerts(clusterID string, certAsset asset.CertAsset) ([]utils.StorageContent, error) {
+	caValidity, certValidity := validities(certAsset)
+	pkiDir := filepath.Join(configmanager.GetPersistDir(), clusterID, "pki", "housekeeper")
+	caCertPath := filepath.Join(pkiDir, "ca.crt")
+	caKeyPath := filepath.Join(pkiDir, "ca.key")
+
+	var userCACertPath, userCAKeyPath string
+	if _, err := os.Stat(caCertPath); err == nil {
+		userCACertPath, userCAKeyPath = caCertPath, caKeyPath
+	}
+	caCert, err := GenerateAllCA(userCACertPath, userCAKeyPath, "housekeeper-ca", []string{"housekeeper-ca"}, caValidity)
+	if err != nil {
+		logrus.Errorf("Error generating housekeeper CA:%v", err)
+		return nil, err
+	}
+	if userCACertPath == "" {
+		if err := SaveFileToLocal(caCertPath, caCert.CertRaw); err != nil {
+			return nil, err
+		}
+		if err := SaveFileToLocal(caKeyPath, caCert.KeyRaw); err != nil {
+			return nil, err
+		}
+		if err := os.Chmod(caKeyPath, utils.KeyFileMode); err != nil {
+			return nil, err
+		}
+	}
+
+	serverUsage := []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	daemonCert, err := GenerateAllSignedCert(HousekeeperDaemonName, nil, []string{HousekeeperDaemonName},
+		serverUsage, nil, caCert.CertRaw, caCert.KeyRaw, certValidity)
+	if err != nil {
+		logrus.Errorf("Error generate housekeeper daemon cert:%v", err)
+		return nil, err
+	}
+
+	clientUsage := []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	controllerCert, err := GenerateAllSignedCert(HousekeeperControllerName, nil, nil,
+		clientUsage, nil, caCert.CertRaw, caCert.KeyRaw, certValidity)
+	if err != nil {
+		logrus.Errorf("Error generate housekeeper controller cert:%v", err)
+		return nil, err
+	}
+
+	chains := []CertChain{
+		{Name: "housekeeper-daemon", CACert: caCert.CertRaw, Cert: daemonCert.CertRaw, ExtKeyUsages: serverUsage, DNSName: HousekeeperDaemonName},
+		{Name: "housekeeper-controller", CACert: caCert.CertRaw, Cert: controllerCert.CertRaw, ExtKeyUsages: clientUsage},
+	}
+	if err := VerifyCertChains(chains); err != nil {
+		logrus.Errorf("Error verifying housekeeper certs:%v", err)
+		return nil, err
+	}
+
+	return []utils.StorageContent{
+		{Path: utils.HousekeeperCaCrt, Mode: int(utils.CertFileMode), Content: caCert.CertRaw},
+		{Path: utils.HousekeeperDaemonCrt, Mode: int(utils.CertFileMode), Content: daemonCert.CertRaw},
+		{Path: utils.HousekeeperDaemonKey, Mode: int(utils.KeyFileMode), Content: daemonCert.KeyRaw},
+		{Path: utils.HousekeeperControllerCrt, Mode: int(utils.CertFileMode), Content: controllerCert.CertRaw},
+		{Path: utils.HousekeeperControllerKey, Mode: int(utils.KeyFileMode), Content: controllerCert.KeyRaw},
+	}, nil
+}
@@ -17,10 +17,78 @@ limitations under the License.
 package cert
 
 import (
+	"crypto/x509"
+	"fmt"
+	"nestos-kubernetes-deployer/pkg/configmanager"
+	"nestos-kubernetes-deployer/pkg/utils"
+	"strings"
+
+	"github.com/sirupsen/logrus"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
+// kubeconfigClient 描述组件 kubeconfig 中客户端证书的身份
+type kubeconfigClient struct {
+	path         string
+	commonName   string
+	organization []string
+}
+
+// 与 kubeadm 生成的组件 kubeconfig 一致，kubelet 的 kubeconfig 与节点相关，由 GenerateAllFiles 生成
+var clusterKubeconfigClients = []kubeconfigClient{
+	{path: utils.AdminConfig, commonName: "kubernetes-admin", organization: []string{"system:masters"}},
+	{path: utils.ControllerManager, commonName: "system:kube-controller-manager"},
+	{path: utils.SchedulerConf, commonName: "system:kube-scheduler"},
+	{path: utils.KubeProxyConf, commonName: "system:kube-proxy"},
+}
+
+// GenerateKubeConfigs 使用集群的 root CA 签发客户端证书，生成 admin、controller-manager、scheduler 和 kube-proxy 的 kubeconfig，
+// kubeconfig 中包含私钥，文件权限为0600
+func GenerateKubeConfigs(clusterID string, apiServerEndpoint string) ([]utils.StorageContent, error) {
+	clusterconfig, err := configmanager.GetClusterConfig(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	if clusterconfig.CertAsset.RootCaCertPath == "" || clusterconfig.CertAsset.RootCaKeyPath == "" {
+		return nil, fmt.Errorf("root CA of cluster %s has not been generated", clusterID)
+	}
+	rootCACert, err := GenerateAllCA(clusterconfig.CertAsset.RootCaCertPath,
+		clusterconfig.CertAsset.RootCaKeyPath, "kubernetes", []string{"kubernetes"}, DefaultCAValidity)
+	if err != nil {
+		logrus.Errorf("Error reading root CA:%v", err)
+		return nil, err
+	}
+
+	_, certValidity := validities(clusterconfig.CertAsset)
+	if !strings.Contains(apiServerEndpoint, "://") {
+		apiServerEndpoint = "https://" + apiServerEndpoint
+	}
+
+	var kubeconfigs []utils.StorageContent
+	for _, c := range clusterKubeconfigClients {
+		clientcrt, err := GenerateAllSignedCert(c.commonName, c.organization, nil,
+			[]x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, nil, rootCACert.CertRaw, rootCACert.KeyRaw, certValidity)
+		if err != nil {
+			logrus.Errorf("Error generate %s cert:%v", c.commonName, err)
+			return nil, err
+		}
+		content, err := generateKubeconfig(rootCACert.CertRaw, clientcrt.CertRaw, clientcrt.KeyRaw,
+			apiServerEndpoint, c.commonName, c.commonName+"@kubernetes")
+		if err != nil {
+			logrus.Errorf("Error generate %s:%v", c.path, err)
+			return nil, err
+		}
+		kubeconfigs = append(kubeconfigs, utils.StorageContent{
+			Path:    c.path,
+			Mode:    int(utils.KeyFileMode),
+			Content: content,
+		})
+	}
+
+	return kubeconfigs, nil
+}
+
 // generateKubeconfig 生成指定角色的 kubeconfig 文件
 func generateKubeconfig(rootcaContent, certContent, keyContent []byte,
 	apiserverEndpoint, clientName, contextName string) ([]byte, error) {
@@ -0,0 +1,88 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cert
+
+import (
+	"crypto/x509"
+	"nestos-kubernetes-deployer/pkg/configmanager"
+	"nestos-kubernetes-deployer/pkg/configmanager/asset"
+	"nestos-kubernetes-deployer/pkg/utils"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// GenerateKubeletCerts 为关闭了服务端证书轮换的kubelet签发服务端证书，按节点hostname返回。
+// 证书由集群的root CA签发，SAN包含节点的hostname和IP，root CA需要已经生成并保存在持久化目录中。
+func GenerateKubeletCerts(clusterID string, nodes []asset.NodeAsset) (map[string][]utils.StorageContent, error) {
+	clusterAsset, err := configmanager.GetClusterConfig(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	_, certValidity := validities(clusterAsset.CertAsset)
+
+	pkiDir := filepath.Join(configmanager.GetPersistDir(), clusterID, "pki")
+	caCertPath := filepath.Join(pkiDir, "ca.crt")
+	caKeyPath := filepath.Join(pkiDir, "ca.key")
+	if _, err := os.Stat(caCertPath); err != nil {
+		return nil, errors.Wrapf(err, "root CA of cluster %s is not generated", clusterID)
+	}
+	rootCACert, err := GenerateAllCA(caCertPath, caKeyPath, "kubernetes", []string{"kubernetes"}, 0)
+	if err != nil {
+		logrus.Errorf("Error loading root CA:%v", err)
+		return nil, err
+	}
+
+	serverUsage := []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	certs := make(map[string][]utils.StorageContent, len(nodes))
+	for _, node := range nodes {
+		if node.Hostname == "" {
+			return nil, errors.New("node hostname is empty")
+		}
+		var ips []net.IP
+		if node.IP != "" {
+			ip := net.ParseIP(node.IP)
+			if ip == nil {
+				return nil, errors.Errorf("invalid IP address %q of node %s", node.IP, node.Hostname)
+			}
+			ips = append(ips, ip)
+		}
+
+		kubeletcrt, err := GenerateAllSignedCert("system:node:"+node.Hostname, []string{"system:nodes"},
+			[]string{node.Hostname}, serverUsage, ips, rootCACert.CertRaw, rootCACert.KeyRaw, certValidity)
+		if err != nil {
+			logrus.Errorf("Error generate kubelet serving cert of %s:%v", node.Hostname, err)
+			return nil, err
+		}
+		chain := CertChain{Name: "kubelet/" + node.Hostname, CACert: rootCACert.CertRaw, Cert: kubeletcrt.CertRaw,
+			ExtKeyUsages: serverUsage, DNSName: node.Hostname}
+		if err := VerifyCertChain(chain); err != nil {
+			logrus.Errorf("Error verifying kubelet serving cert of %s:%v", node.Hostname, err)
+			return nil, err
+		}
+
+		certs[node.Hostname] = []utils.StorageContent{
+			{Path: utils.KubeletServingCrt, Mode: int(utils.CertFileMode), Content: kubeletcrt.CertRaw},
+			{Path: utils.KubeletServingKey, Mode: int(utils.KeyFileMode), Content: kubeletcrt.KeyRaw},
+		}
+	}
+
+	return certs, nil
+}
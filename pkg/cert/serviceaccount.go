@@ -0,0 +1,85 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cert
+
+import (
+	"io/ioutil"
+	"nestos-kubernetes-deployer/pkg/configmanager"
+	"nestos-kubernetes-deployer/pkg/utils"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+)
+
+// GenerateServiceAccountKeyPair 返回集群签发service account token的sa.key和sa.pub。
+// 密钥对保存在持久化目录中，所有控制面节点使用同一个密钥对，否则其他节点签发的token无法校验。
+func GenerateServiceAccountKeyPair(clusterID string) ([]utils.StorageContent, error) {
+	pkiDir := filepath.Join(configmanager.GetPersistDir(), clusterID, "pki")
+	saKeyPath := filepath.Join(pkiDir, "sa.key")
+	saPubPath := filepath.Join(pkiDir, "sa.pub")
+
+	var keyPair *KeyPairPEM
+	if _, err := os.Stat(saKeyPath); err == nil {
+		keyPair, err = loadKeyPair(saKeyPath)
+		if err != nil {
+			logrus.Errorf("Error loading sa keypair %s:%v", saKeyPath, err)
+			return nil, err
+		}
+	} else {
+		keyPair, err = GenerateKeyPair()
+		if err != nil {
+			logrus.Errorf("Error generating sa keypair:%v", err)
+			return nil, err
+		}
+		if err := SaveFileToLocal(saKeyPath, keyPair.PrivateKeyPEM); err != nil {
+			return nil, err
+		}
+		if err := os.Chmod(saKeyPath, utils.KeyFileMode); err != nil {
+			return nil, err
+		}
+	}
+	// 公钥总是由私钥导出，保证与私钥匹配
+	if err := SaveFileToLocal(saPubPath, keyPair.PublicKeyPEM); err != nil {
+		return nil, err
+	}
+
+	return []utils.StorageContent{
+		{Path: utils.SaKey, Mode: int(utils.KeyFileMode), Content: keyPair.PrivateKeyPEM},
+		{Path: utils.SaPub, Mode: int(utils.CertFileMode), Content: keyPair.PublicKeyPEM},
+	}, nil
+}
+
+// loadKeyPair reads a PEM encoded RSA private key and derives its public key
+func loadKeyPair(keyPath string) (*KeyPairPEM, error) {
+	privateKeyPEM, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := PemToPrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	publicKeyPEM, err := PublicKeyToPem(&privateKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyPairPEM{
+		PrivateKeyPEM: privateKeyPEM,
+		PublicKeyPEM:  publicKeyPEM,
+	}, nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cert
+
+import (
+	"crypto/x509"
+
+	"github.com/pkg/errors"
+)
+
+// CertChain 描述一个待校验的叶子证书及其签发CA
+type CertChain struct {
+	Name         string
+	CACert       []byte
+	Cert         []byte
+	ExtKeyUsages []x509.ExtKeyUsage
+	DNSName      string // 为空时不校验SAN
+}
+
+// VerifyCertChain 校验叶子证书是否由CA签发，并检查有效期、密钥用途和SAN
+func VerifyCertChain(chain CertChain) error {
+	caCert, err := PemToCertificate(chain.CACert)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse CA certificate of %s", chain.Name)
+	}
+
+	cert, err := PemToCertificate(chain.Cert)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse certificate %s", chain.Name)
+	}
+
+	if cert.KeyUsage&x509.KeyUsageDigitalSignature == 0 {
+		return errors.Errorf("certificate %s does not allow digital signature", chain.Name)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+
+	opts := x509.VerifyOptions{
+		Roots:     roots,
+		DNSName:   chain.DNSName,
+		KeyUsages: chain.ExtKeyUsages,
+	}
+	if _, err := cert.Verify(opts); err != nil {
+		return errors.Wrapf(err, "failed to verify certificate %s", chain.Name)
+	}
+
+	return nil
+}
+
+// VerifyCertChains 依次校验所有证书链，任何一个校验失败都会返回错误
+func VerifyCertChains(chains []CertChain) error {
+	for _, chain := range chains {
+		if err := VerifyCertChain(chain); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -26,4 +26,7 @@ type CertAsset struct {
 	FrontProxyCaKeyPath  string
 	SaPub                string
 	SaKey                string
+	EtcdClientSANs       []string // apiserver-etcd-client证书的额外SAN，可以是域名或IP
+	CAValidityDays       int      // 生成的CA证书有效期（天），未设置时为10年
+	CertValidityDays     int      // 签发的证书有效期（天），未设置时为1年
 }
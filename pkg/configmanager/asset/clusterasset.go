@@ -18,11 +18,11 @@ package asset
 
 import (
 	"fmt"
-	mrand "math/rand"
 	"nestos-kubernetes-deployer/cmd/command/opts"
+	"nestos-kubernetes-deployer/pkg/tokens"
 	"nestos-kubernetes-deployer/pkg/utils"
 	"os"
-	"time"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -60,34 +60,6 @@ func setUIntValue(target *uint, value uint, defaultValue uint) {
 	}
 }
 
-// Generate token
-func generateToken() string {
-	// Generate a character set for lowercase letters and numbers.
-	charset := "abcdefghijklmnopqrstuvwxyz0123456789"
-	charsetLength := len(charset)
-
-	mrand.Seed(time.Now().UnixNano())
-
-	const tokenLength = 6
-	token := make([]byte, tokenLength)
-	for i := range token {
-		token[i] = charset[mrand.Intn(charsetLength)]
-	}
-
-	// Add a dot.
-	token = append(token, '.')
-
-	// Generate a 16-bit random string.
-	const randomPartLength = 16
-	randomPart := make([]byte, randomPartLength)
-	for i := range randomPart {
-		randomPart[i] = charset[mrand.Intn(charsetLength)]
-	}
-
-	token = append(token, randomPart...)
-	return string(token)
-}
-
 func setMasterConfigs(mc []NodeAsset, opts *opts.MasterConfig) []NodeAsset {
 	var confs []NodeAsset
 	if len(mc) >= len(opts.IP) {
@@ -146,6 +118,38 @@ func setWorkerHostname(wc []NodeAsset, opts *opts.WorkerConfig) []NodeAsset {
 	return confs
 }
 
+// addNodePoolWorkers appends workers to the pools with fewer workers than their count,
+// the added workers are named k8s-<pool>-workerNN
+func addNodePoolWorkers(wc []NodeAsset, pools []NodePool) []NodeAsset {
+	hostnames := make(map[string]bool)
+	poolWorkers := make(map[string]uint)
+	for _, worker := range wc {
+		hostnames[worker.Hostname] = true
+		poolWorkers[worker.Pool]++
+	}
+	for _, pool := range pools {
+		n := 0
+		for count := poolWorkers[pool.Name]; count < pool.Count; count++ {
+			var hostname string
+			for hostname == "" || hostnames[hostname] {
+				n++
+				hostname = fmt.Sprintf("k8s-%s-worker%02d", pool.Name, n)
+			}
+			hostnames[hostname] = true
+			wc = append(wc, NodeAsset{
+				Hostname: hostname,
+				Pool:     pool.Name,
+				HardwareInfo: HardwareInfo{
+					CPU:  4,
+					RAM:  8192,
+					Disk: 50,
+				},
+			})
+		}
+	}
+	return wc
+}
+
 // ========== Structure method ==========
 
 type ClusterAsset struct {
@@ -156,20 +160,36 @@ type ClusterAsset struct {
 	UserName string
 	Password string
 	SSHKey   string
+	// 除 SSHKey 文件中的公钥外，节点用户额外授权的 ssh 公钥，每项可以包含多行公钥
+	SSHKeys []string `yaml:"sshkeys,omitempty"`
+	// 节点的时区，默认为 Asia/Shanghai
+	Timezone string `yaml:"timezone,omitempty"`
 	Master   []NodeAsset
 	Worker   []NodeAsset
-	Runtime  string `yaml:"runtime"` //后续考虑增加os层面的配置管理，并将runtime放入OS层面的配置中
+	// 节点池，worker节点通过Pool字段引用，未引用节点池的worker属于默认节点池
+	NodePools []NodePool `yaml:"nodepools,omitempty"`
+	Runtime   string     `yaml:"runtime"` //后续考虑增加os层面的配置管理，并将runtime放入OS层面的配置中
 	Kubernetes
 	Housekeeper
 	CertAsset
 	HookConf `yaml:"hooks,omitempty"`
+	// 为节点生成固定的ssh主机密钥，重新生成ignition或重装节点后主机身份不变
+	ProvisionSSHHostKeys bool `yaml:"provision-ssh-host-keys,omitempty"`
+	// 节点ignition配置的spec版本，为空时使用支持的最高版本，部分NestOS版本只支持较低的版本
+	IgnitionVersion string `yaml:"ignition-version,omitempty"`
+	// 主机上的目录，其中的文件按相对路径写入所有节点，路径相同时覆盖内置的文件，.template文件会先渲染
+	ExtraFilesDir string `yaml:"extra-files-dir,omitempty"`
+	// 持久化配置的格式版本，加载旧版本的配置时会先迁移到当前版本
+	SchemaVersion string `yaml:"schema-version,omitempty"`
 }
 
 type HookConf struct {
-	PreHookScript string      `yaml:"prehookscript,omitempty"`
-	PostHookYaml  string      `yaml:"posthookyaml,omitempty"`
-	ShellFiles    []ShellFile `yaml:"-"`
-	PostHookFiles []string    `yaml:"-"`
+	PreHookScript string       `yaml:"prehookscript,omitempty"`
+	PostHookYaml  string       `yaml:"posthookyaml,omitempty"`
+	UnitDropInDir string       `yaml:"unitdropins,omitempty"` // 目录结构为 <unit>.d/<name>.conf
+	ShellFiles    []ShellFile  `yaml:"-"`
+	PostHookFiles []string     `yaml:"-"`
+	UnitDropIns   []UnitDropIn `yaml:"-"`
 }
 
 type ShellFile struct {
@@ -178,6 +198,13 @@ type ShellFile struct {
 	Content []byte `json:"content" yaml:"-"`
 }
 
+// UnitDropIn is a systemd drop-in written to /etc/systemd/system/<Unit>.d/<Name>
+type UnitDropIn struct {
+	Unit     string `json:"unit" yaml:"-"`
+	Name     string `json:"name" yaml:"-"`
+	Contents []byte `json:"contents" yaml:"-"`
+}
+
 type InfraPlatform interface {
 }
 
@@ -192,16 +219,64 @@ type Kubernetes struct {
 	AdminKubeConfig      string
 	CertificateKey       string
 	CaCertHash           string `json:"-" yaml:"-"`
+	// 控制面组件的额外启动参数，feature-gates 由 FeatureGates 统一设置
+	APIServerExtraArgs         map[string]string `yaml:"apiserver-extra-args,omitempty"`
+	ControllerManagerExtraArgs map[string]string `yaml:"controller-manager-extra-args,omitempty"`
+	SchedulerExtraArgs         map[string]string `yaml:"scheduler-extra-args,omitempty"`
+	FeatureGates               map[string]bool   `yaml:"feature-gates,omitempty"`
+	Registry                   RegistryConfig    `yaml:"registry,omitempty"`
+	Kubelet                    KubeletConfig     `yaml:"kubelet,omitempty"`
+	CgroupDriver               string            `yaml:"cgroup-driver,omitempty"` // systemd or cgroupfs
+	// 镜像仓库拉取 pause 镜像失败时按顺序尝试的备用仓库，仅作用于 pause 镜像，
+	// 通过 containers-registries.conf 生效，因此只支持 crio 运行时
+	PauseImageFallbacks []string `yaml:"pause-image-fallbacks,omitempty"`
 
 	Network
 }
 
+// Network 的网段为单个IPv4或IPv6 CIDR，双栈集群使用逗号分隔的IPv4和IPv6 CIDR
 type Network struct {
 	ServiceSubnet string `yaml:"service-subnet"`
 	PodSubnet     string `yaml:"pod-subnet"`
 	Plugin        string
 }
 
+// RegistryConfig is the container registry config of the nodes, the registries are host[:port]
+// optionally followed by a repository path
+type RegistryConfig struct {
+	Mirrors  map[string][]string `yaml:"mirrors,omitempty"`  // registry -> mirrors tried before the registry
+	Insecure []string            `yaml:"insecure,omitempty"` // registries accessed over plain http or unverified tls
+}
+
+func (r *RegistryConfig) IsEmpty() bool {
+	return len(r.Mirrors) == 0 && len(r.Insecure) == 0
+}
+
+// IsInsecure reports whether the location is insecure, a namespaced location such as host/pause is insecure
+// when its registry is
+func (r *RegistryConfig) IsInsecure(location string) bool {
+	for {
+		for _, registry := range r.Insecure {
+			if registry == location {
+				return true
+			}
+		}
+		i := strings.LastIndex(location, "/")
+		if i < 0 {
+			return false
+		}
+		location = location[:i]
+	}
+}
+
+// KubeletConfig holds the kubelet settings of all nodes, unset fields keep the kubelet defaults
+type KubeletConfig struct {
+	MaxPods        int32             `yaml:"max-pods,omitempty"`
+	SystemReserved map[string]string `yaml:"system-reserved,omitempty"` // resource -> quantity, such as memory: 1Gi
+	KubeReserved   map[string]string `yaml:"kube-reserved,omitempty"`
+	EvictionHard   map[string]string `yaml:"eviction-hard,omitempty"` // signal -> threshold, such as memory.available: 500Mi
+}
+
 type Housekeeper struct {
 	DeployHousekeeper  bool
 	OperatorImageUrl   string
@@ -266,6 +341,9 @@ func (clusterAsset *ClusterAsset) InitClusterAsset(infraAsset InfraAsset, opts *
 		}
 	}
 
+	// add the workers missing from the node pools
+	clusterAsset.Worker = addNodePoolWorkers(clusterAsset.Worker, clusterAsset.NodePools)
+
 	if opts.Worker.CPU != 0 {
 		for i, _ := range clusterAsset.Worker {
 			clusterAsset.Worker[i].HardwareInfo.CPU = opts.Worker.CPU
@@ -293,13 +371,14 @@ func (clusterAsset *ClusterAsset) InitClusterAsset(infraAsset InfraAsset, opts *
 	setStringValue(&clusterAsset.Kubernetes.ImageRegistry, opts.ImageRegistry, cf.ImageRegistry)
 	setStringValue(&clusterAsset.Kubernetes.PauseImage, opts.PauseImage, cf.PauseImage)
 	setStringValue(&clusterAsset.Kubernetes.ReleaseImageURL, opts.ReleaseImageUrl, cf.ReleaseImageURL)
-	setStringValue(&clusterAsset.Kubernetes.CertificateKey, opts.CertificateKey, opts.CertificateKey)
+	setStringValue(&clusterAsset.Kubernetes.CertificateKey, opts.CertificateKey, cf.CertificateKey)
 	setStringValue(&clusterAsset.Kubernetes.Token, opts.Token, cf.Token)
 	setStringValue(&clusterAsset.Kubernetes.Network.ServiceSubnet, opts.NetWork.ServiceSubnet, cf.ServiceSubnet)
 	setStringValue(&clusterAsset.Kubernetes.Network.PodSubnet, opts.NetWork.PodSubnet, cf.Network.PodSubnet)
 	setStringValue(&clusterAsset.Kubernetes.Network.Plugin, opts.NetWork.Plugin, cf.Network.Plugin)
 	setStringValue(&clusterAsset.PreHookScript, opts.PreHookScript, "")
 	setStringValue(&clusterAsset.PostHookYaml, opts.PostHookYaml, "")
+	setStringValue(&clusterAsset.UnitDropInDir, opts.UnitDropIns, "")
 
 	apiVersion, err := utils.GetKubernetesApiVersion(opts.KubernetesAPIVersion)
 	if err != nil {
@@ -322,9 +401,48 @@ func (clusterAsset *ClusterAsset) InitClusterAsset(infraAsset InfraAsset, opts *
 		return nil, err
 	}
 
+	if err := clusterAsset.EnsureSSHHostKeys(); err != nil {
+		logrus.Errorf("error in initializing ssh host keys: %v", err)
+		return nil, err
+	}
+
 	return clusterAsset, nil
 }
 
+// LoadClusterAsset reads the user-supplied cluster config file,
+// values set on the command line are applied on top of it by InitClusterAsset.
+func LoadClusterAsset(path string) (*ClusterAsset, error) {
+	configData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read cluster config file %s", path)
+	}
+
+	clusterAsset := &ClusterAsset{}
+	if err := yaml.Unmarshal(configData, clusterAsset); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse cluster config file %s", path)
+	}
+
+	version, err := parseSchemaVersion(clusterAsset.SchemaVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cluster config file %s", path)
+	}
+	if err := migrateAsset(clusterAsset, version, currentSchemaVersion); err != nil {
+		return nil, errors.Wrapf(err, "failed to migrate cluster config file %s", path)
+	}
+
+	return clusterAsset, nil
+}
+
+// GetNodePool returns the node pool with the given name
+func (clusterAsset *ClusterAsset) GetNodePool(name string) (*NodePool, error) {
+	for i := range clusterAsset.NodePools {
+		if clusterAsset.NodePools[i].Name == name {
+			return &clusterAsset.NodePools[i], nil
+		}
+	}
+	return nil, errors.Errorf("node pool %s not found", name)
+}
+
 func (clusterAsset *ClusterAsset) Delete(dir string) error {
 	if err := os.RemoveAll(dir); err != nil {
 		return err
@@ -333,13 +451,16 @@ func (clusterAsset *ClusterAsset) Delete(dir string) error {
 }
 
 func (clusterAsset *ClusterAsset) Persist(dir string) error {
+	clusterAsset.SchemaVersion = formatSchemaVersion(currentSchemaVersion)
+
 	// Serialize the cluster asset to yaml.
 	clusterData, err := yaml.Marshal(clusterAsset)
 	if err != nil {
 		return err
 	}
 
-	if err := os.WriteFile(dir+"/cluster_config.yaml", clusterData, 0644); err != nil {
+	// 集群配置中可能包含ssh主机私钥
+	if err := os.WriteFile(dir+"/cluster_config.yaml", clusterData, 0600); err != nil {
 		return err
 	}
 
@@ -361,6 +482,10 @@ func GetDefaultClusterConfig(arch string) (*ClusterAsset, error) {
 	default:
 		return nil, errors.New("unsupported architecture")
 	}
+	token, err := tokens.GenerateBootstrapToken()
+	if err != nil {
+		return nil, err
+	}
 
 	return &ClusterAsset{
 		Cluster_ID:   "cluster",
@@ -399,8 +524,9 @@ func GetDefaultClusterConfig(arch string) (*ClusterAsset, error) {
 			ImageRegistry:        "k8s.gcr.io",
 			PauseImage:           "pause:3.6",
 			ReleaseImageURL:      "",
-			Token:                generateToken(),
+			Token:                token,
 			CertificateKey:       "a301c9c55596c54c5d4c7173aa1e3b6fd304130b0c703bb23149c0c69f94b8e0",
+			CgroupDriver:         DefaultCgroupDriver,
 			Network: Network{
 				ServiceSubnet: "10.96.0.0/16",
 				PodSubnet:     "10.244.0.0/16",
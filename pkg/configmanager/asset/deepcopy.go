@@ -0,0 +1,199 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asset
+
+import "nestos-kubernetes-deployer/pkg/utils"
+
+// DeepCopy returns a copy of the cluster asset that shares no slices, maps or infra platform with it,
+// so the copy can be modified while other goroutines read the original
+func (clusterAsset *ClusterAsset) DeepCopy() *ClusterAsset {
+	if clusterAsset == nil {
+		return nil
+	}
+	out := *clusterAsset
+	out.InfraPlatform = copyInfraPlatform(clusterAsset.InfraPlatform)
+	out.Master = copyNodeAssets(clusterAsset.Master)
+	out.Worker = copyNodeAssets(clusterAsset.Worker)
+	if clusterAsset.NodePools != nil {
+		out.NodePools = make([]NodePool, len(clusterAsset.NodePools))
+		for i, pool := range clusterAsset.NodePools {
+			out.NodePools[i] = pool
+			out.NodePools[i].Labels = copyStringMap(pool.Labels)
+			out.NodePools[i].Taints = copyStrings(pool.Taints)
+		}
+	}
+
+	out.APIServerExtraArgs = copyStringMap(clusterAsset.APIServerExtraArgs)
+	out.ControllerManagerExtraArgs = copyStringMap(clusterAsset.ControllerManagerExtraArgs)
+	out.SchedulerExtraArgs = copyStringMap(clusterAsset.SchedulerExtraArgs)
+	if clusterAsset.FeatureGates != nil {
+		out.FeatureGates = make(map[string]bool, len(clusterAsset.FeatureGates))
+		for k, v := range clusterAsset.FeatureGates {
+			out.FeatureGates[k] = v
+		}
+	}
+	if clusterAsset.Registry.Mirrors != nil {
+		out.Registry.Mirrors = make(map[string][]string, len(clusterAsset.Registry.Mirrors))
+		for registry, mirrors := range clusterAsset.Registry.Mirrors {
+			out.Registry.Mirrors[registry] = copyStrings(mirrors)
+		}
+	}
+	out.Registry.Insecure = copyStrings(clusterAsset.Registry.Insecure)
+	out.Kubelet.SystemReserved = copyStringMap(clusterAsset.Kubelet.SystemReserved)
+	out.Kubelet.KubeReserved = copyStringMap(clusterAsset.Kubelet.KubeReserved)
+	out.Kubelet.EvictionHard = copyStringMap(clusterAsset.Kubelet.EvictionHard)
+	out.PauseImageFallbacks = copyStrings(clusterAsset.PauseImageFallbacks)
+	out.SSHKeys = copyStrings(clusterAsset.SSHKeys)
+
+	out.EtcdClientSANs = copyStrings(clusterAsset.EtcdClientSANs)
+
+	if clusterAsset.ShellFiles != nil {
+		out.ShellFiles = make([]ShellFile, len(clusterAsset.ShellFiles))
+		for i, file := range clusterAsset.ShellFiles {
+			file.Content = copyBytes(file.Content)
+			out.ShellFiles[i] = file
+		}
+	}
+	out.PostHookFiles = copyStrings(clusterAsset.PostHookFiles)
+	if clusterAsset.UnitDropIns != nil {
+		out.UnitDropIns = make([]UnitDropIn, len(clusterAsset.UnitDropIns))
+		for i, dropIn := range clusterAsset.UnitDropIns {
+			dropIn.Contents = copyBytes(dropIn.Contents)
+			out.UnitDropIns[i] = dropIn
+		}
+	}
+	return &out
+}
+
+// DeepCopy returns a copy of the node asset that shares no slices with it
+func (n *NodeAsset) DeepCopy() *NodeAsset {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.CreateIgnContent = copyBytes(n.CreateIgnContent)
+	if n.Certs != nil {
+		out.Certs = make([]utils.StorageContent, len(n.Certs))
+		for i, cert := range n.Certs {
+			cert.Content = copyBytes(cert.Content)
+			out.Certs[i] = cert
+		}
+	}
+	out.Network.DNS = copyStrings(n.Network.DNS)
+	if n.SSHHostKeys != nil {
+		out.SSHHostKeys = make([]SSHHostKey, len(n.SSHHostKeys))
+		copy(out.SSHHostKeys, n.SSHHostKeys)
+	}
+	out.Labels = copyStringMap(n.Labels)
+	out.Taints = copyStrings(n.Taints)
+	if n.Disks != nil {
+		out.Disks = make([]DiskConfig, len(n.Disks))
+		for i, disk := range n.Disks {
+			if disk.Partitions != nil {
+				disk.Partitions = append([]PartitionConfig{}, disk.Partitions...)
+			}
+			out.Disks[i] = disk
+		}
+	}
+	return &out
+}
+
+func copyNodeAssets(nodes []NodeAsset) []NodeAsset {
+	if nodes == nil {
+		return nil
+	}
+	out := make([]NodeAsset, len(nodes))
+	for i := range nodes {
+		out[i] = *nodes[i].DeepCopy()
+	}
+	return out
+}
+
+// copyInfraPlatform copies the infra asset, or the raw map it is loaded as from a persisted config
+func copyInfraPlatform(platform InfraPlatform) InfraPlatform {
+	switch p := platform.(type) {
+	case *OpenStackAsset:
+		if p == nil {
+			return p
+		}
+		out := *p
+		return &out
+	case *LibvirtAsset:
+		if p == nil {
+			return p
+		}
+		out := *p
+		return &out
+	default:
+		return copyValue(platform)
+	}
+}
+
+// copyValue copies the maps and slices yaml decodes into, other values are immutable or copied by value
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = copyValue(item)
+		}
+		return out
+	case map[interface{}]interface{}:
+		out := make(map[interface{}]interface{}, len(v))
+		for key, item := range v {
+			out[key] = copyValue(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = copyValue(item)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+func copyStrings(in []string) []string {
+	if in == nil {
+		return nil
+	}
+	out := make([]string, len(in))
+	copy(out, in)
+	return out
+}
+
+func copyBytes(in []byte) []byte {
+	if in == nil {
+		return nil
+	}
+	out := make([]byte, len(in))
+	copy(out, in)
+	return out
+}
+
+func copyStringMap(in map[string]string) map[string]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
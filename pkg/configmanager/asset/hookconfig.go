@@ -36,6 +36,8 @@ const (
 	YAMLFileType    = "yaml"
 	YAMLFileExt     = ".yaml"
 	YMLFileExt      = ".yml"
+	DropInDirExt    = ".d"
+	DropInFileExt   = ".conf"
 )
 
 //若传入的是一个目录，则会解析当前目录下的文件（注意：不会递归处理子目录下的文件）
@@ -61,9 +63,60 @@ func GetCmdHooks(conf *HookConf) error {
 		conf.PostHookFiles = postHookFiles
 	}
 
+	if conf.UnitDropInDir != "" {
+		unitDropIns, err := getUnitDropIns(conf.UnitDropInDir)
+		if err != nil {
+			return err
+		}
+		conf.UnitDropIns = unitDropIns
+	}
+
 	return nil
 }
 
+//解析 <unit>.d 子目录下的 .conf 文件，与 /etc/systemd/system 下的 drop-in 目录结构一致
+func getUnitDropIns(p string) ([]UnitDropIn, error) {
+	var (
+		dropIns       []UnitDropIn
+		totalFileSize int64
+	)
+	unitDirs, err := os.ReadDir(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %s", err)
+	}
+	for _, unitDir := range unitDirs {
+		if !unitDir.IsDir() || filepath.Ext(unitDir.Name()) != DropInDirExt {
+			logrus.Debugf("skipping %s, not a unit drop-in directory", unitDir.Name())
+			continue
+		}
+		unit := strings.TrimSuffix(unitDir.Name(), DropInDirExt)
+		files, err := os.ReadDir(filepath.Join(p, unitDir.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read directory: %s", err)
+		}
+		for _, file := range files {
+			filePath := filepath.Join(p, unitDir.Name(), file.Name())
+			if !file.Type().IsRegular() || filepath.Ext(file.Name()) != DropInFileExt {
+				logrus.Debugf("skipping %s, not a unit drop-in file", filePath)
+				continue
+			}
+			contents, err := os.ReadFile(filePath)
+			if err != nil {
+				return nil, err
+			}
+			totalFileSize += int64(len(contents))
+			dropIns = append(dropIns, UnitDropIn{Unit: unit, Name: file.Name(), Contents: contents})
+		}
+	}
+	if len(dropIns) == 0 {
+		return nil, fmt.Errorf("no valid unit drop-ins found in directory: %s", p)
+	}
+	if totalFileSize > MaxHookFileSize {
+		return nil, fmt.Errorf("total size of unit drop-ins in the directory exceeds the limit: %d bytes (max: %d bytes)", totalFileSize, MaxHookFileSize)
+	}
+	return dropIns, nil
+}
+
 func getDirAndShells(p string) ([]ShellFile, error) {
 	var (
 		hookFiles     []ShellFile
@@ -0,0 +1,69 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asset
+
+import (
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ParseCIDRList parses a subnet of the cluster network, either a single IPv4 or IPv6 CIDR,
+// or an IPv4 and an IPv6 CIDR separated by a comma for dual-stack clusters
+func ParseCIDRList(subnets string) ([]*net.IPNet, error) {
+	if strings.TrimSpace(subnets) == "" {
+		return nil, errors.New("must not be empty")
+	}
+	entries := strings.Split(subnets, ",")
+	if len(entries) > 2 {
+		return nil, errors.Errorf("at most an IPv4 and an IPv6 CIDR are allowed, got %d CIDRs", len(entries))
+	}
+
+	var ipNets []*net.IPNet
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, errors.Errorf("invalid CIDR %q", entry)
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	if len(ipNets) == 2 && isIPv6(ipNets[0]) == isIPv6(ipNets[1]) {
+		return nil, errors.Errorf("dual-stack requires an IPv4 and an IPv6 CIDR, got %s and %s", ipNets[0], ipNets[1])
+	}
+	return ipNets, nil
+}
+
+// NormalizeCIDRList removes the spaces around the CIDRs of a subnet list
+func NormalizeCIDRList(subnets string) string {
+	entries := strings.Split(subnets, ",")
+	for i := range entries {
+		entries[i] = strings.TrimSpace(entries[i])
+	}
+	return strings.Join(entries, ",")
+}
+
+// IsDualStack reports whether the subnet list holds both an IPv4 and an IPv6 CIDR
+func IsDualStack(subnets string) bool {
+	ipNets, err := ParseCIDRList(subnets)
+	return err == nil && len(ipNets) == 2
+}
+
+func isIPv6(ipNet *net.IPNet) bool {
+	return ipNet.IP.To4() == nil
+}
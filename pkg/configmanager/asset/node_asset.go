@@ -23,7 +23,61 @@ type NodeAsset struct {
 	IP       string
 	HardwareInfo
 	Ignitions `json:"ignitions"`
-	Certs     []utils.StorageContent `json:"-" yaml:"-"` // Certificates content (not printed in JSON and YAML)
+	Certs     []utils.StorageContent `json:"-" yaml:"-"`        // Certificates content (not printed in JSON and YAML)
+	Pool      string                 `yaml:"pool,omitempty"`    // Name of the node pool, empty means the default pool
+	Network   NetworkConfig          `yaml:"network,omitempty"` // Static network config, empty means DHCP
+	// Fixed ssh host keys, only generated when ProvisionSSHHostKeys of the cluster is set
+	SSHHostKeys []SSHHostKey `yaml:"ssh-host-keys,omitempty"`
+	// Partitions created on the disks of the node, such as a dedicated /var or /var/lib/etcd partition
+	Disks []DiskConfig `yaml:"disks,omitempty"`
+	// Labels and taints the kubelet registers the node with, added to those of the node pool
+	Labels map[string]string `yaml:"labels,omitempty"`
+	Taints []string          `yaml:"taints,omitempty"` // key=value:effect
+}
+
+// DiskConfig describes the partitions created on a disk of the node
+type DiskConfig struct {
+	Device     string            `yaml:"device"`               // such as /dev/vdb or /dev/disk/by-id/<id>
+	WipeTable  bool              `yaml:"wipe-table,omitempty"` // remove the existing partitions of the disk
+	Partitions []PartitionConfig `yaml:"partitions"`
+}
+
+// PartitionConfig describes a partition, the filesystem created on it and where it is mounted
+type PartitionConfig struct {
+	Label      string `yaml:"label"`                // partition label, the partition is referenced by /dev/disk/by-partlabel/<label>
+	Number     int    `yaml:"number,omitempty"`     // 0 uses the next free partition number
+	StartMiB   int    `yaml:"start-mib,omitempty"`  // 0 uses the start of the largest free block
+	SizeMiB    int    `yaml:"size-mib,omitempty"`   // 0 fills the free space
+	Format     string `yaml:"format"`               // xfs, ext4, btrfs, vfat or swap
+	MountPoint string `yaml:"mountpoint,omitempty"` // absolute path, not allowed for swap
+}
+
+// NetworkConfig describes the static address of a node interface
+type NetworkConfig struct {
+	Interface string   `yaml:"interface,omitempty"` // defaults to eth0
+	IP        string   `yaml:"ip,omitempty"`
+	Gateway   string   `yaml:"gateway,omitempty"`
+	Prefix    int      `yaml:"prefix,omitempty"`
+	DNS       []string `yaml:"dns,omitempty"`
+}
+
+// IsEmpty reports whether no static network config is set
+func (n *NetworkConfig) IsEmpty() bool {
+	return n.Interface == "" && n.IP == "" && n.Gateway == "" && n.Prefix == 0 && len(n.DNS) == 0
+}
+
+// NodePool describes a group of worker nodes sharing the same node configuration
+type NodePool struct {
+	Name   string
+	Labels map[string]string `yaml:"labels,omitempty"`
+	Taints []string          `yaml:"taints,omitempty"` // key=value:effect
+	// OS image of the workers of the pool, the osimage path on libvirt or the glance image name on openstack,
+	// empty uses the image of the platform
+	Image string `yaml:"image,omitempty"`
+	// Number of workers of the pool, workers missing from the worker list are added with default hardware
+	Count uint `yaml:"count,omitempty"`
+	// Path of an Ignition config merged into the ignition of the workers of the pool
+	Ignition string `yaml:"ignition,omitempty"`
 }
 
 type HardwareInfo struct {
@@ -18,20 +18,69 @@ package asset
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
 var (
+	// dockershim 已从 kubelet 移除，docker 通过 cri-dockerd 接入
 	mapRuntime = map[string]string{
-		"isulad": "/var/run/isulad.sock",
-		"docker": "/var/run/dockershim.sock",
-		"crio":   "unix:///var/run/crio/crio.sock",
+		"isulad":     "unix:///var/run/isulad.sock",
+		"docker":     "unix:///var/run/cri-dockerd.sock",
+		"containerd": "unix:///run/containerd/containerd.sock",
+		"crio":       "unix:///var/run/crio/crio.sock",
 	}
+
+	// kubelet 与容器运行时必须使用相同的 cgroup 驱动
+	supportedCgroupDrivers = []string{"cgroupfs", "systemd"}
 )
 
+const DefaultCgroupDriver = "systemd"
+
+// UnsupportedRuntimeError is returned for a container runtime without a known CRI socket
+type UnsupportedRuntimeError struct {
+	Runtime string
+}
+
+func (e *UnsupportedRuntimeError) Error() string {
+	return fmt.Sprintf("unsupported runtime %q, supported runtimes are %s",
+		e.Runtime, strings.Join(SupportedRuntimes(), ", "))
+}
+
+// SupportedRuntimes returns the sorted names of the supported container runtimes
+func SupportedRuntimes() []string {
+	var runtimes []string
+	for runtime := range mapRuntime {
+		runtimes = append(runtimes, runtime)
+	}
+	sort.Strings(runtimes)
+	return runtimes
+}
+
+// NormalizeRuntime returns the runtime name as used in templates, lowercased without surrounding spaces
+func NormalizeRuntime(runtime string) string {
+	return strings.ToLower(strings.TrimSpace(runtime))
+}
+
 func GetRuntimeCriSocket(runtime string) (string, error) {
-	if content, ok := mapRuntime[strings.ToLower(runtime)]; ok {
+	if content, ok := mapRuntime[NormalizeRuntime(runtime)]; ok {
 		return content, nil
 	}
-	return "", fmt.Errorf("runtime %s not found", runtime)
+	return "", &UnsupportedRuntimeError{Runtime: runtime}
+}
+
+// GetCgroupDriver returns the cgroup driver of the kubelet and the container runtime,
+// an empty driver defaults to systemd
+func GetCgroupDriver(driver string) (string, error) {
+	driver = strings.ToLower(strings.TrimSpace(driver))
+	if driver == "" {
+		return DefaultCgroupDriver, nil
+	}
+	for _, supported := range supportedCgroupDrivers {
+		if driver == supported {
+			return driver, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported cgroup driver %q, supported cgroup drivers are %s",
+		driver, strings.Join(supportedCgroupDrivers, ", "))
 }
@@ -0,0 +1,68 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asset
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// 集群配置的格式版本，修改已持久化字段的含义或结构时递增，并在migrations中增加对应的迁移
+const currentSchemaVersion = 1
+
+// migrations upgrade a cluster asset of the version to the next version
+var migrations = map[int]func(clusterAsset *ClusterAsset) error{
+	// 未记录格式版本的配置与版本1结构相同
+	0: func(clusterAsset *ClusterAsset) error { return nil },
+}
+
+func formatSchemaVersion(version int) string {
+	return "v" + strconv.Itoa(version)
+}
+
+// parseSchemaVersion parses versions like v1, configs persisted without a version are version 0
+func parseSchemaVersion(version string) (int, error) {
+	if version == "" {
+		return 0, nil
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(version, "v"))
+	if err != nil || !strings.HasPrefix(version, "v") || number < 1 {
+		return 0, errors.Errorf("invalid schema version %q", version)
+	}
+	return number, nil
+}
+
+// migrateAsset upgrades the cluster asset from the old schema version to the new one step by step
+func migrateAsset(clusterAsset *ClusterAsset, oldVersion int, newVersion int) error {
+	if oldVersion > newVersion {
+		return errors.Errorf("schema version %s is newer than the supported version %s, upgrade nkd to load it",
+			formatSchemaVersion(oldVersion), formatSchemaVersion(newVersion))
+	}
+	for version := oldVersion; version < newVersion; version++ {
+		migrate, ok := migrations[version]
+		if !ok {
+			return errors.Errorf("no migration from schema version %s", formatSchemaVersion(version))
+		}
+		if err := migrate(clusterAsset); err != nil {
+			return errors.Wrapf(err, "failed to migrate from schema version %s", formatSchemaVersion(version))
+		}
+	}
+	clusterAsset.SchemaVersion = formatSchemaVersion(newVersion)
+	return nil
+}
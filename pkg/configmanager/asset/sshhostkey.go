@@ -0,0 +1,173 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asset
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+const sshHostRSAKeyBits = 3072
+
+// SSHHostKey is a host key pair provisioned to /etc/ssh/ssh_host_<type>_key,
+// PublicKey is in the authorized_keys format.
+type SSHHostKey struct {
+	Type       string `yaml:"type"` // ed25519, ecdsa or rsa
+	PrivateKey string `yaml:"private-key"`
+	PublicKey  string `yaml:"public-key"`
+}
+
+// EnsureSSHHostKeys generates host keys for the nodes that have none when host key provisioning is enabled,
+// keys already stored in the asset are kept so the host identity is stable across regeneration.
+func (clusterAsset *ClusterAsset) EnsureSSHHostKeys() error {
+	if !clusterAsset.ProvisionSSHHostKeys {
+		return nil
+	}
+	for _, nodes := range [][]NodeAsset{clusterAsset.Master, clusterAsset.Worker} {
+		for i := range nodes {
+			if len(nodes[i].SSHHostKeys) > 0 {
+				continue
+			}
+			keys, err := GenerateSSHHostKeys(nodes[i].Hostname)
+			if err != nil {
+				return errors.Wrapf(err, "failed to generate ssh host keys of %s", nodes[i].Hostname)
+			}
+			nodes[i].SSHHostKeys = keys
+		}
+	}
+	return nil
+}
+
+// GenerateSSHHostKeys generates the ed25519, ecdsa and rsa host keys of a node,
+// the hostname is used as the comment of the public keys.
+func GenerateSSHHostKeys(hostname string) ([]SSHHostKey, error) {
+	var keys []SSHHostKey
+
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	edBlob := sshPublicKeyBlob("ssh-ed25519", sshString([]byte(edPub)))
+	edPrivPEM, err := marshalOpenSSHEd25519(edPub, edPriv, edBlob, hostname)
+	if err != nil {
+		return nil, err
+	}
+	keys = append(keys, SSHHostKey{
+		Type:       "ed25519",
+		PrivateKey: string(edPrivPEM),
+		PublicKey:  authorizedKey("ssh-ed25519", edBlob, hostname),
+	})
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		return nil, err
+	}
+	ecBlob := sshPublicKeyBlob("ecdsa-sha2-nistp256", sshString([]byte("nistp256")),
+		sshString(elliptic.Marshal(elliptic.P256(), ecKey.X, ecKey.Y)))
+	keys = append(keys, SSHHostKey{
+		Type:       "ecdsa",
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER})),
+		PublicKey:  authorizedKey("ecdsa-sha2-nistp256", ecBlob, hostname),
+	})
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, sshHostRSAKeyBits)
+	if err != nil {
+		return nil, err
+	}
+	rsaBlob := sshPublicKeyBlob("ssh-rsa", sshMpint(big.NewInt(int64(rsaKey.E))), sshMpint(rsaKey.N))
+	keys = append(keys, SSHHostKey{
+		Type:       "rsa",
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})),
+		PublicKey:  authorizedKey("ssh-rsa", rsaBlob, hostname),
+	})
+
+	return keys, nil
+}
+
+// sshString encodes a byte string in the ssh wire format
+func sshString(b []byte) []byte {
+	buf := make([]byte, 4, 4+len(b))
+	binary.BigEndian.PutUint32(buf, uint32(len(b)))
+	return append(buf, b...)
+}
+
+// sshMpint encodes a non-negative big integer in the ssh wire format
+func sshMpint(n *big.Int) []byte {
+	b := n.Bytes()
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	return sshString(b)
+}
+
+func sshPublicKeyBlob(keyType string, fields ...[]byte) []byte {
+	blob := sshString([]byte(keyType))
+	for _, field := range fields {
+		blob = append(blob, field...)
+	}
+	return blob
+}
+
+func authorizedKey(keyType string, blob []byte, comment string) string {
+	return keyType + " " + base64.StdEncoding.EncodeToString(blob) + " " + comment + "\n"
+}
+
+// marshalOpenSSHEd25519 encodes an unencrypted ed25519 private key in the openssh-key-v1 format,
+// ed25519 keys have no PEM encoding that sshd loads.
+func marshalOpenSSHEd25519(pub ed25519.PublicKey, priv ed25519.PrivateKey, pubBlob []byte, comment string) ([]byte, error) {
+	checkBytes := make([]byte, 4)
+	if _, err := rand.Read(checkBytes); err != nil {
+		return nil, err
+	}
+
+	var private bytes.Buffer
+	private.Write(checkBytes)
+	private.Write(checkBytes)
+	private.Write(sshString([]byte("ssh-ed25519")))
+	private.Write(sshString([]byte(pub)))
+	private.Write(sshString([]byte(priv)))
+	private.Write(sshString([]byte(comment)))
+	for i := byte(1); private.Len()%8 != 0; i++ {
+		private.WriteByte(i)
+	}
+
+	var key bytes.Buffer
+	key.WriteString("openssh-key-v1\x00")
+	key.Write(sshString([]byte("none")))
+	key.Write(sshString([]byte("none")))
+	key.Write(sshString(nil))
+	key.Write([]byte{0, 0, 0, 1})
+	key.Write(sshString(pubBlob))
+	key.Write(sshString(private.Bytes()))
+
+	return pem.EncodeToMemory(&pem.Block{Type: "OPENSSH PRIVATE KEY", Bytes: key.Bytes()}), nil
+}
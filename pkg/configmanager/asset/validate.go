@@ -0,0 +1,440 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asset
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	hostnamePattern       = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*$`)
+	repositoryPathPattern = regexp.MustCompile(`^[a-z0-9]+([._-][a-z0-9]+)*(/[a-z0-9]+([._-][a-z0-9]+)*)*$`)
+	certificateKeyPattern = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+	partitionLabelPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,36}$`)
+	labelNamePattern      = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9_.-]{0,61}[A-Za-z0-9])?$`)
+	// zoneinfo names such as UTC, Asia/Shanghai or America/Argentina/Buenos_Aires
+	timezonePattern = regexp.MustCompile(`^[A-Za-z0-9_+-]+(/[A-Za-z0-9_+-]+)*$`)
+)
+
+// kubelet --register-with-taints 支持的污点效果
+var taintEffects = map[string]bool{"NoSchedule": true, "PreferNoSchedule": true, "NoExecute": true}
+
+// 分区支持的文件系统，swap分区不挂载
+var partitionFormats = map[string]bool{"xfs": true, "ext4": true, "btrfs": true, "vfat": true, "swap": true}
+
+// 由操作系统管理的路径不能挂载单独的分区
+var reservedMountPoints = map[string]bool{"/": true, "/boot": true, "/usr": true, "/sysroot": true}
+
+// FieldError describes an invalid field of the cluster asset,
+// Field is the path of the field in the cluster config file.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationErrors is the list of all problems found in a cluster asset
+type ValidationErrors []*FieldError
+
+func (errs ValidationErrors) Error() string {
+	var msgs []string
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return "invalid cluster config: " + strings.Join(msgs, "; ")
+}
+
+// Validate checks the cluster asset and reports every invalid field,
+// it returns nil when the cluster asset is valid.
+func (clusterAsset *ClusterAsset) Validate() error {
+	var errs ValidationErrors
+	addError := func(field string, format string, args ...interface{}) {
+		errs = append(errs, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if clusterAsset.Cluster_ID == "" {
+		addError("cluster_id", "must not be empty")
+	}
+	if len(clusterAsset.Master) == 0 {
+		addError("master", "at least one master node is required")
+	}
+	if clusterAsset.Runtime == "" {
+		addError("runtime", "must not be empty")
+	} else if _, err := GetRuntimeCriSocket(clusterAsset.Runtime); err != nil {
+		addError("runtime", "unsupported runtime %q", clusterAsset.Runtime)
+	}
+
+	hostnames := make(map[string]string)
+	checkNode := func(field string, node NodeAsset, requireIP bool) {
+		if node.Hostname == "" {
+			addError(field+".hostname", "must not be empty")
+		} else if other, ok := hostnames[node.Hostname]; ok {
+			addError(field+".hostname", "duplicate hostname %q, already used by %s", node.Hostname, other)
+		} else {
+			hostnames[node.Hostname] = field
+		}
+		if node.IP == "" {
+			if requireIP {
+				addError(field+".ip", "must not be empty")
+			}
+		} else if net.ParseIP(node.IP) == nil {
+			addError(field+".ip", "invalid IP address %q", node.IP)
+		}
+		validateDisks(field+".disks", node.Disks, addError)
+		validateLabels(field+".labels", node.Labels, addError)
+		validateTaints(field+".taints", node.Taints, addError)
+	}
+	for i, master := range clusterAsset.Master {
+		checkNode(fmt.Sprintf("master[%d]", i), master, true)
+	}
+
+	pools := make(map[string]struct{})
+	for i, pool := range clusterAsset.NodePools {
+		poolField := fmt.Sprintf("nodepools[%d]", i)
+		validateLabels(poolField+".labels", pool.Labels, addError)
+		validateTaints(poolField+".taints", pool.Taints, addError)
+		if pool.Ignition != "" {
+			if err := validateIgnitionFile(pool.Ignition); err != nil {
+				addError(poolField+".ignition", "%v", err)
+			}
+		}
+		field := poolField + ".name"
+		if pool.Name == "" {
+			addError(field, "must not be empty")
+			continue
+		}
+		// the name is the value of the node pool label and part of the hostnames of the workers added for the pool count
+		if !labelNamePattern.MatchString(pool.Name) || !hostnamePattern.MatchString(pool.Name) {
+			addError(field, "invalid node pool name %q", pool.Name)
+		}
+		if _, ok := pools[pool.Name]; ok {
+			addError(field, "duplicate node pool %q", pool.Name)
+		}
+		pools[pool.Name] = struct{}{}
+	}
+	poolWorkers := make(map[string]uint)
+	for i, worker := range clusterAsset.Worker {
+		field := fmt.Sprintf("worker[%d]", i)
+		checkNode(field, worker, false)
+		if worker.Pool == "" {
+			continue
+		}
+		poolWorkers[worker.Pool]++
+		if _, ok := pools[worker.Pool]; !ok {
+			addError(field+".pool", "node pool %q is not defined", worker.Pool)
+		}
+	}
+	for i, pool := range clusterAsset.NodePools {
+		if pool.Count != 0 && poolWorkers[pool.Name] > pool.Count {
+			addError(fmt.Sprintf("nodepools[%d].count", i), "node pool %q has %d workers, more than its count %d",
+				pool.Name, poolWorkers[pool.Name], pool.Count)
+		}
+	}
+
+	checkSubnet := func(field string, subnet string) []*net.IPNet {
+		ipNets, err := ParseCIDRList(subnet)
+		if err != nil {
+			addError(field, "%v", err)
+			return nil
+		}
+		return ipNets
+	}
+	serviceSubnets := checkSubnet("kubernetes.network.service-subnet", clusterAsset.Network.ServiceSubnet)
+	podSubnets := checkSubnet("kubernetes.network.pod-subnet", clusterAsset.Network.PodSubnet)
+	if serviceSubnets != nil && podSubnets != nil {
+		// 双栈集群的service和pod网段需要同时包含IPv4和IPv6
+		if len(serviceSubnets) != len(podSubnets) ||
+			(len(podSubnets) == 1 && isIPv6(serviceSubnets[0]) != isIPv6(podSubnets[0])) {
+			addError("kubernetes.network.pod-subnet", "IP families differ from service subnet %s",
+				clusterAsset.Network.ServiceSubnet)
+		}
+		for _, serviceSubnet := range serviceSubnets {
+			for _, podSubnet := range podSubnets {
+				if serviceSubnet.Contains(podSubnet.IP) || podSubnet.Contains(serviceSubnet.IP) {
+					addError("kubernetes.network.pod-subnet", "overlaps with service subnet %s", serviceSubnet)
+				}
+			}
+		}
+	}
+
+	if clusterAsset.CertAsset.CAValidityDays < 0 {
+		addError("certasset.cavaliditydays", "must be positive, got %d", clusterAsset.CertAsset.CAValidityDays)
+	}
+	if clusterAsset.CertAsset.CertValidityDays < 0 {
+		addError("certasset.certvaliditydays", "must be positive, got %d", clusterAsset.CertAsset.CertValidityDays)
+	}
+
+	if _, err := GetCgroupDriver(clusterAsset.CgroupDriver); err != nil {
+		addError("kubernetes.cgroup-driver", "unsupported cgroup driver %q", clusterAsset.CgroupDriver)
+	}
+	// 其他master节点加入控制面时使用certificate key解密kubeadm init上传的证书
+	if len(clusterAsset.Master) > 1 && !certificateKeyPattern.MatchString(clusterAsset.CertificateKey) {
+		addError("kubernetes.certificatekey", "a hex encoded 32 byte key is required for %d masters to join the control plane",
+			len(clusterAsset.Master)-1)
+	}
+	if clusterAsset.Kubelet.MaxPods < 0 {
+		addError("kubernetes.kubelet.max-pods", "must be positive, got %d", clusterAsset.Kubelet.MaxPods)
+	}
+
+	checkRegistry := func(field string, registry string) {
+		if !isValidRegistry(registry) {
+			addError(field, "invalid registry %q, expected host[:port][/path]", registry)
+		}
+	}
+	var registries []string
+	for registry := range clusterAsset.Registry.Mirrors {
+		registries = append(registries, registry)
+	}
+	sort.Strings(registries)
+	for _, registry := range registries {
+		field := "kubernetes.registry.mirrors." + registry
+		checkRegistry(field, registry)
+		if len(clusterAsset.Registry.Mirrors[registry]) == 0 {
+			addError(field, "at least one mirror is required")
+		}
+		for i, mirror := range clusterAsset.Registry.Mirrors[registry] {
+			checkRegistry(fmt.Sprintf("%s[%d]", field, i), mirror)
+		}
+	}
+	for i, registry := range clusterAsset.Registry.Insecure {
+		checkRegistry(fmt.Sprintf("kubernetes.registry.insecure[%d]", i), registry)
+	}
+	// 备用仓库写入 containers-registries.conf，isulad、docker、containerd 不读取该配置
+	if len(clusterAsset.PauseImageFallbacks) > 0 && NormalizeRuntime(clusterAsset.Runtime) != "crio" {
+		addError("kubernetes.pause-image-fallbacks", "only supported with the crio runtime, got %q", clusterAsset.Runtime)
+	}
+	for i, registry := range clusterAsset.PauseImageFallbacks {
+		checkRegistry(fmt.Sprintf("kubernetes.pause-image-fallbacks[%d]", i), registry)
+	}
+
+	if clusterAsset.Timezone != "" && !IsValidTimezone(clusterAsset.Timezone) {
+		addError("timezone", "invalid timezone %q, expected a zoneinfo name such as Asia/Shanghai", clusterAsset.Timezone)
+	}
+	for i, keys := range clusterAsset.SSHKeys {
+		if !isValidAuthorizedKeys(keys) {
+			addError(fmt.Sprintf("sshkeys[%d]", i), "invalid ssh public key %q", keys)
+		}
+	}
+
+	if clusterAsset.ExtraFilesDir != "" {
+		if info, err := os.Stat(clusterAsset.ExtraFilesDir); err != nil {
+			addError("extra-files-dir", "%v", err)
+		} else if !info.IsDir() {
+			addError("extra-files-dir", "%s is not a directory", clusterAsset.ExtraFilesDir)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateDisks checks the partitions of the node, labels and mountpoints must be unique on the node
+func validateDisks(field string, disks []DiskConfig, addError func(field string, format string, args ...interface{})) {
+	labels := make(map[string]bool)
+	mountPoints := make(map[string]bool)
+	for i, disk := range disks {
+		diskField := fmt.Sprintf("%s[%d]", field, i)
+		if !strings.HasPrefix(disk.Device, "/dev/") {
+			addError(diskField+".device", "must be a device path under /dev, got %q", disk.Device)
+		}
+		if len(disk.Partitions) == 0 {
+			addError(diskField+".partitions", "at least one partition is required")
+		}
+		for j, partition := range disk.Partitions {
+			partField := fmt.Sprintf("%s.partitions[%d]", diskField, j)
+			if !partitionLabelPattern.MatchString(partition.Label) {
+				addError(partField+".label", "invalid partition label %q, expected at most 36 letters, digits, '_', '.' or '-'",
+					partition.Label)
+			} else if labels[partition.Label] {
+				addError(partField+".label", "duplicate partition label %q", partition.Label)
+			}
+			labels[partition.Label] = true
+			if partition.Number < 0 {
+				addError(partField+".number", "must not be negative, got %d", partition.Number)
+			}
+			if partition.StartMiB < 0 {
+				addError(partField+".start-mib", "must not be negative, got %d", partition.StartMiB)
+			}
+			if partition.SizeMiB < 0 {
+				addError(partField+".size-mib", "must not be negative, got %d", partition.SizeMiB)
+			}
+			if !partitionFormats[partition.Format] {
+				addError(partField+".format", "unsupported filesystem %q, expected xfs, ext4, btrfs, vfat or swap",
+					partition.Format)
+			}
+
+			mountPoint := partition.MountPoint
+			switch {
+			case partition.Format == "swap":
+				if mountPoint != "" {
+					addError(partField+".mountpoint", "swap partitions are not mounted")
+				}
+			case mountPoint == "":
+				addError(partField+".mountpoint", "must not be empty")
+			case !path.IsAbs(mountPoint) || path.Clean(mountPoint) != mountPoint:
+				addError(partField+".mountpoint", "must be a clean absolute path, got %q", mountPoint)
+			case reservedMountPoints[mountPoint]:
+				addError(partField+".mountpoint", "%s is managed by the operating system", mountPoint)
+			case mountPoints[mountPoint]:
+				addError(partField+".mountpoint", "duplicate mountpoint %s", mountPoint)
+			}
+			mountPoints[mountPoint] = true
+		}
+	}
+}
+
+// validateLabels checks the node labels are valid kubernetes labels, prefix/name=value with an optional prefix
+func validateLabels(field string, labels map[string]string, addError func(field string, format string, args ...interface{})) {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !isValidLabelKey(key) {
+			addError(field, "invalid label key %q", key)
+		}
+		if value := labels[key]; value != "" && !labelNamePattern.MatchString(value) {
+			addError(field, "invalid value %q of label %s", value, key)
+		}
+	}
+}
+
+// validateTaints checks the taints have the key=value:effect or key:effect format of kubelet --register-with-taints
+func validateTaints(field string, taints []string, addError func(field string, format string, args ...interface{})) {
+	for i, taint := range taints {
+		taintField := fmt.Sprintf("%s[%d]", field, i)
+		sep := strings.LastIndex(taint, ":")
+		if sep < 0 {
+			addError(taintField, "invalid taint %q, expected key=value:effect", taint)
+			continue
+		}
+		keyValue, effect := taint[:sep], taint[sep+1:]
+		if !taintEffects[effect] {
+			addError(taintField, "invalid effect %q of taint %q, expected NoSchedule, PreferNoSchedule or NoExecute",
+				effect, taint)
+		}
+		key, value := keyValue, ""
+		if eq := strings.Index(keyValue, "="); eq >= 0 {
+			key, value = keyValue[:eq], keyValue[eq+1:]
+		}
+		if !isValidLabelKey(key) {
+			addError(taintField, "invalid key %q of taint %q", key, taint)
+		}
+		if value != "" && !labelNamePattern.MatchString(value) {
+			addError(taintField, "invalid value %q of taint %q", value, taint)
+		}
+	}
+}
+
+// validateIgnitionFile checks that the file is an Ignition config with a version
+func validateIgnitionFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var config struct {
+		Ignition struct {
+			Version string `json:"version"`
+		} `json:"ignition"`
+	}
+	if err := json.Unmarshal(content, &config); err != nil {
+		return fmt.Errorf("invalid ignition config %s: %v", path, err)
+	}
+	if config.Ignition.Version == "" {
+		return fmt.Errorf("invalid ignition config %s: ignition.version is not set", path)
+	}
+	return nil
+}
+
+// isValidLabelKey reports whether the key is a name with an optional DNS subdomain prefix, such as example.com/gpu
+func isValidLabelKey(key string) bool {
+	name := key
+	if slash := strings.Index(key, "/"); slash >= 0 {
+		prefix := key[:slash]
+		if len(prefix) > 253 || !hostnamePattern.MatchString(prefix) {
+			return false
+		}
+		name = key[slash+1:]
+	}
+	return labelNamePattern.MatchString(name)
+}
+
+// isValidRegistry reports whether the registry is a hostname or IP address with an optional port,
+// followed by an optional repository path. Schemes are not allowed.
+func isValidRegistry(registry string) bool {
+	parts := strings.SplitN(registry, "/", 2)
+	host := parts[0]
+	if len(parts) == 2 && !repositoryPathPattern.MatchString(parts[1]) {
+		return false
+	}
+	if h, port, err := net.SplitHostPort(host); err == nil {
+		if n, err := strconv.Atoi(port); err != nil || n <= 0 || n > 65535 {
+			return false
+		}
+		host = h
+	} else if strings.Contains(host, ":") {
+		return false
+	}
+	return net.ParseIP(host) != nil || hostnamePattern.MatchString(host)
+}
+
+// IsValidTimezone reports whether the timezone is a zoneinfo name, the name is a path below /usr/share/zoneinfo
+func IsValidTimezone(timezone string) bool {
+	return timezonePattern.MatchString(timezone)
+}
+
+// isValidAuthorizedKeys reports whether every line of an authorized_keys entry holds a public key,
+// blank lines and comments are allowed. The key blob starts with the key type in the ssh wire format.
+func isValidAuthorizedKeys(keys string) bool {
+	for _, line := range strings.Split(keys, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// 公钥前可以有 authorized_keys 选项
+		fields := strings.Fields(line)
+		valid := false
+		for i := 0; i+1 < len(fields) && !valid; i++ {
+			blob, err := base64.StdEncoding.DecodeString(fields[i+1])
+			if err != nil || len(blob) < 4 {
+				continue
+			}
+			typeLen := int(binary.BigEndian.Uint32(blob))
+			valid = typeLen == len(fields[i]) && len(blob) >= 4+typeLen && string(blob[4:4+typeLen]) == fields[i]
+		}
+		if !valid {
+			return false
+		}
+	}
+	return true
+}
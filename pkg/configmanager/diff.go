@@ -0,0 +1,93 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configmanager
+
+import (
+	"fmt"
+	"nestos-kubernetes-deployer/pkg/configmanager/asset"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// DiffClusterConfig compares the proposed cluster config with the persisted config of the cluster,
+// the changes are returned in a human-readable form, an empty list means nothing changed
+func DiffClusterConfig(clusterID string, proposed *asset.ClusterAsset) ([]string, error) {
+	if clusterID == "" {
+		return nil, errors.New("ClusterID is empty")
+	}
+	if proposed == nil {
+		return nil, errors.New("proposed cluster config is empty")
+	}
+
+	persisted, err := asset.LoadClusterAsset(filepath.Join(GetPersistDir(), clusterID, clusterConfigFile))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load persisted config of cluster %s", clusterID)
+	}
+
+	var changes []string
+	changes = append(changes, diffNodes("master", persisted.Master, proposed.Master)...)
+	changes = append(changes, diffNodes("worker", persisted.Worker, proposed.Worker)...)
+
+	fields := []struct {
+		name     string
+		old, new string
+	}{
+		{"runtime", persisted.Runtime, proposed.Runtime},
+		{"kubernetes-version", persisted.KubernetesVersion, proposed.KubernetesVersion},
+		{"apiserver-endpoint", persisted.ApiServerEndpoint, proposed.ApiServerEndpoint},
+		{"image-registry", persisted.ImageRegistry, proposed.ImageRegistry},
+		{"pause-image", persisted.PauseImage, proposed.PauseImage},
+		{"network.service-subnet", persisted.Network.ServiceSubnet, proposed.Network.ServiceSubnet},
+		{"network.pod-subnet", persisted.Network.PodSubnet, proposed.Network.PodSubnet},
+		{"network.plugin", persisted.Network.Plugin, proposed.Network.Plugin},
+	}
+	for _, field := range fields {
+		if field.old != field.new {
+			changes = append(changes, fmt.Sprintf("%s changed: %q -> %q", field.name, field.old, field.new))
+		}
+	}
+
+	return changes, nil
+}
+
+// diffNodes reports the nodes added, removed or readdressed, nodes are matched by hostname
+func diffNodes(role string, persisted, proposed []asset.NodeAsset) []string {
+	var changes []string
+	persistedNodes := make(map[string]asset.NodeAsset, len(persisted))
+	for _, node := range persisted {
+		persistedNodes[node.Hostname] = node
+	}
+	proposedNodes := make(map[string]bool, len(proposed))
+	for _, node := range proposed {
+		proposedNodes[node.Hostname] = true
+		old, ok := persistedNodes[node.Hostname]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("%s %s added", role, node.Hostname))
+			continue
+		}
+		if old.IP != node.IP {
+			changes = append(changes, fmt.Sprintf("%s %s ip changed: %q -> %q", role, node.Hostname, old.IP, node.IP))
+		}
+	}
+	for _, node := range persisted {
+		if !proposedNodes[node.Hostname] {
+			changes = append(changes, fmt.Sprintf("%s %s removed", role, node.Hostname))
+		}
+	}
+	return changes
+}
@@ -22,16 +22,22 @@ import (
 	"nestos-kubernetes-deployer/pkg/utils"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 )
 
-const GlobalConfigFile = "global_config.yaml"
+const (
+	GlobalConfigFile = "global_config.yaml"
+	DefaultLogLevel  = "info"
+	// 旧版本持久化的默认日志级别，按默认级别处理
+	legacyDefaultLogLevel = "default log level"
+)
 
 func InitGlobalConfig(opts *opts.OptionsList) (*GlobalConfig, error) {
 	globalAsset := &GlobalConfig{
-		Log_Level:          "default log level",
+		Log_Level:          DefaultLogLevel,
 		ClusterConfig_Path: "",
 		PersistDir:         opts.RootOptDir, // default persist directory
 		BootstrapUrl: BootstrapUrl{
@@ -40,21 +46,22 @@ func InitGlobalConfig(opts *opts.OptionsList) (*GlobalConfig, error) {
 	}
 	configFile := filepath.Join(globalAsset.PersistDir, GlobalConfigFile)
 	if _, err := os.Stat(configFile); err == nil {
-		configData, err := os.ReadFile(configFile)
-		if err != nil {
-			logrus.Errorf("Failed to read config file: %s\n", err)
-			return nil, err
-		}
-		err = yaml.Unmarshal(configData, globalAsset)
-		if err != nil {
-			logrus.Errorf("Failed to unmarshal config data: %s\n", err)
+		if err := globalAsset.LoadGlobalConfig(configFile); err != nil {
 			return nil, err
 		}
+		// 配置文件所在目录即持久化目录，不使用文件中记录的旧目录
+		globalAsset.PersistDir = opts.RootOptDir
 	}
 
 	if opts.NKD.Log_Level != "" {
 		globalAsset.Log_Level = opts.NKD.Log_Level
 	}
+	if globalAsset.Log_Level == legacyDefaultLogLevel {
+		globalAsset.Log_Level = DefaultLogLevel
+	}
+	if _, err := ParseLogLevel(globalAsset.Log_Level); err != nil {
+		return nil, err
+	}
 	if opts.NKD.BootstrapIgnHost != "" {
 		globalAsset.BootstrapIgnHost = opts.NKD.BootstrapIgnHost
 	}
@@ -89,7 +96,8 @@ func InitGlobalConfig(opts *opts.OptionsList) (*GlobalConfig, error) {
 // ========== Structure method ==========
 
 type GlobalConfig struct {
-	Log_Level          string
+	Log_Level          string // debug, info, warn or error
+	Cluster_Log        bool   // 将日志同时写入持久化目录下所操作集群的logs/nkd.log
 	ClusterConfig_Path string
 	PersistDir         string // default: /etc/nkd
 	BootstrapUrl
@@ -100,6 +108,23 @@ type BootstrapUrl struct {
 	BootstrapIgnPort string `yaml:"bootstrap_ign_port"`
 }
 
+// ParseLogLevel parses the log level of the global config, an empty level is the default level
+func ParseLogLevel(level string) (logrus.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "":
+		return logrus.InfoLevel, nil
+	case "debug":
+		return logrus.DebugLevel, nil
+	case "info":
+		return logrus.InfoLevel, nil
+	case "warn", "warning":
+		return logrus.WarnLevel, nil
+	case "error":
+		return logrus.ErrorLevel, nil
+	}
+	return logrus.InfoLevel, fmt.Errorf("invalid log level %q, must be one of debug, info, warn or error", level)
+}
+
 // Delete deletes the global asset.
 func (ga *GlobalConfig) Delete(persistFilePath string) error {
 	if _, err := os.Stat(persistFilePath); os.IsNotExist(err) {
@@ -114,13 +139,54 @@ func (ga *GlobalConfig) Delete(persistFilePath string) error {
 	return nil
 }
 
+// LoadGlobalConfig populates the global config with the values in the file,
+// fields that are not present in the file keep their current values.
+func (ga *GlobalConfig) LoadGlobalConfig(path string) error {
+	configData, err := os.ReadFile(path)
+	if err != nil {
+		logrus.Errorf("Failed to read config file: %s\n", err)
+		return err
+	}
+	if err := yaml.Unmarshal(configData, ga); err != nil {
+		logrus.Errorf("Failed to unmarshal config data: %s\n", err)
+		return err
+	}
+	return nil
+}
+
+// Persist writes the global config to the file under the persist directory InitGlobalConfig loads it from.
 func (ga *GlobalConfig) Persist() error {
 	globalConfigData, err := yaml.Marshal(ga)
 	if err != nil {
 		logrus.Errorf("failed to marshal global config: %v", err)
 		return err
 	}
-	if err := os.WriteFile(filepath.Join(ga.PersistDir, GlobalConfigFile), globalConfigData, 0644); err != nil {
+
+	configFile := filepath.Join(ga.PersistDir, GlobalConfigFile)
+
+	// Write to a temporary file first and rename it, so that an interrupted
+	// write never leaves a truncated config file behind.
+	tmpFile, err := os.CreateTemp(filepath.Dir(configFile), filepath.Base(configFile)+".tmp")
+	if err != nil {
+		logrus.Errorf("failed to create temporary global config file: %v", err)
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(globalConfigData); err != nil {
+		tmpFile.Close()
+		logrus.Errorf("failed to write global config file: %v", err)
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		logrus.Errorf("failed to write global config file: %v", err)
+		return err
+	}
+	if err := os.Chmod(tmpFile.Name(), 0644); err != nil {
+		logrus.Errorf("failed to set global config file mode: %v", err)
+		return err
+	}
+	if err := os.Rename(tmpFile.Name(), configFile); err != nil {
 		logrus.Errorf("failed to write global config file: %v", err)
 		return err
 	}
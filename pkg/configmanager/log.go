@@ -0,0 +1,128 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configmanager
+
+import (
+	"io"
+	"nestos-kubernetes-deployer/cmd/command"
+	"nestos-kubernetes-deployer/pkg/configmanager/globalconfig"
+	"os"
+	"path/filepath"
+
+	"github.com/natefinch/lumberjack"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	clusterLogFile    = "logs/nkd.log"
+	clusterLogMaxSize = 10 // 日志文件的最大大小（MB）
+	clusterLogBackups = 5  // 保留旧日志文件的最大个数
+)
+
+// clusterLogHook writes the log entries up to its level to the log file of a cluster
+type clusterLogHook struct {
+	file      io.WriteCloser
+	level     logrus.Level
+	formatter logrus.Formatter
+}
+
+// clusterLog is the hook of the cluster currently logged to, only accessed while holding mu
+var clusterLog *clusterLogHook
+
+func (h *clusterLogHook) Levels() []logrus.Level {
+	var levels []logrus.Level
+	for _, level := range logrus.AllLevels {
+		if level <= h.level {
+			levels = append(levels, level)
+		}
+	}
+	return levels
+}
+
+func (h *clusterLogHook) Fire(entry *logrus.Entry) error {
+	line, err := h.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+	_, err = h.file.Write(line)
+	return err
+}
+
+// applyLogConfig sets the log level of the global config on the console output and, when Cluster_Log is set,
+// also writes the logs to the log file of the cluster under the persist dir. The logger itself stays at
+// TraceLevel so the log file of nkd set up by the commands keeps every entry.
+func applyLogConfig(globalConfig *globalconfig.GlobalConfig, clusterID string) error {
+	level, err := globalconfig.ParseLogLevel(globalConfig.Log_Level)
+	if err != nil {
+		return err
+	}
+	logrus.SetLevel(logrus.TraceLevel)
+	command.SetConsoleLevel(level)
+
+	mu.Lock()
+	defer mu.Unlock()
+	closeClusterLog()
+	if !globalConfig.Cluster_Log || clusterID == "" {
+		return nil
+	}
+
+	path := filepath.Join(globalConfig.PersistDir, clusterID, clusterLogFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrapf(err, "failed to create log directory of cluster %s", clusterID)
+	}
+	clusterLog = &clusterLogHook{
+		file: &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    clusterLogMaxSize,
+			MaxBackups: clusterLogBackups,
+			Compress:   true,
+			LocalTime:  true,
+		},
+		level: level,
+		formatter: &logrus.TextFormatter{
+			DisableColors: true,
+			FullTimestamp: true,
+		},
+	}
+	logrus.AddHook(clusterLog)
+	return nil
+}
+
+// CloseClusterLog stops writing the logs to the log file of the cluster
+func CloseClusterLog() {
+	mu.Lock()
+	defer mu.Unlock()
+	closeClusterLog()
+}
+
+func closeClusterLog() {
+	if clusterLog == nil {
+		return
+	}
+	hooks := logrus.LevelHooks{}
+	for level, levelHooks := range logrus.StandardLogger().Hooks {
+		for _, hook := range levelHooks {
+			if hook != logrus.Hook(clusterLog) {
+				hooks[level] = append(hooks[level], hook)
+			}
+		}
+	}
+	logrus.StandardLogger().ReplaceHooks(hooks)
+	clusterLog.file.Close()
+	clusterLog = nil
+}
@@ -17,19 +17,25 @@ limitations under the License.
 package configmanager
 
 import (
-	"errors"
 	"nestos-kubernetes-deployer/cmd/command/opts"
 	"nestos-kubernetes-deployer/pkg/configmanager/asset"
 	"nestos-kubernetes-deployer/pkg/configmanager/globalconfig"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
 
-	"gopkg.in/yaml.v2"
+	"github.com/pkg/errors"
 )
 
 // Set global data
 var GlobalConfig *globalconfig.GlobalConfig
-var ClusterAsset = map[string]*asset.ClusterAsset{}
+
+// clusterAssets is only accessed while holding mu, mu also guards the GlobalConfig set by Initial
+var (
+	mu            sync.RWMutex
+	clusterAssets = map[string]*asset.ClusterAsset{}
+)
 
 // var InfraAsset = map[string]*asset.InfraAsset{}
 
@@ -41,7 +47,12 @@ func Initial(opts *opts.OptionsList) error {
 	if err != nil {
 		return err
 	}
+	mu.Lock()
 	GlobalConfig = globalConfig
+	mu.Unlock()
+	if err := applyLogConfig(globalConfig, opts.ClusterID); err != nil {
+		return err
+	}
 
 	files, err := filepath.Glob(filepath.Join(globalConfig.PersistDir, "*", clusterConfigFile))
 	if err != nil {
@@ -57,16 +68,11 @@ func Initial(opts *opts.OptionsList) error {
 	}
 
 	for _, file := range files {
-		configData, err := os.ReadFile(file)
+		fileData, err := asset.LoadClusterAsset(file)
 		if err != nil {
 			return err
 		}
 
-		fileData := &asset.ClusterAsset{}
-		if err := yaml.Unmarshal(configData, fileData); err != nil {
-			return err
-		}
-
 		if err := initializeClusterAsset(fileData, opts); err != nil {
 			return err
 		}
@@ -88,28 +94,42 @@ func initializeClusterAsset(fileData *asset.ClusterAsset, opts *opts.OptionsList
 		return err
 	}
 
-	ClusterAsset[fileData.Cluster_ID] = clusterAsset
+	if err := clusterAsset.Validate(); err != nil {
+		return err
+	}
+
+	SetClusterConfig(clusterAsset)
 	return nil
 }
 
 func GetGlobalConfig() (*globalconfig.GlobalConfig, error) {
+	mu.RLock()
+	defer mu.RUnlock()
 	return GlobalConfig, nil
 }
 
 func GetPersistDir() string {
+	mu.RLock()
+	defer mu.RUnlock()
 	return GlobalConfig.PersistDir
 }
 
 func GetBootstrapIgnPort() string {
+	mu.RLock()
+	defer mu.RUnlock()
 	return GlobalConfig.BootstrapIgnPort
 }
 
 func GetBootstrapIgnHost() string {
+	mu.RLock()
+	defer mu.RUnlock()
 	return GlobalConfig.BootstrapIgnHost
 }
 
 func GetClusterConfig(clusterID string) (*asset.ClusterAsset, error) {
-	clusterConfig, ok := ClusterAsset[clusterID]
+	mu.RLock()
+	defer mu.RUnlock()
+	clusterConfig, ok := clusterAssets[clusterID]
 	if !ok {
 		return nil, errors.New("ClusterID not found")
 	}
@@ -117,12 +137,65 @@ func GetClusterConfig(clusterID string) (*asset.ClusterAsset, error) {
 	return clusterConfig, nil
 }
 
+// GetClusterConfigCopy returns a deep copy of the cluster asset, callers that modify the asset
+// change the copy and store it with SetClusterConfig once done so readers never see a partial update
+func GetClusterConfigCopy(clusterID string) (*asset.ClusterAsset, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	clusterConfig, ok := clusterAssets[clusterID]
+	if !ok {
+		return nil, errors.New("ClusterID not found")
+	}
+
+	return clusterConfig.DeepCopy(), nil
+}
+
+// SetClusterConfig adds the cluster asset, replacing the asset with the same cluster id
+func SetClusterConfig(clusterAsset *asset.ClusterAsset) {
+	mu.Lock()
+	defer mu.Unlock()
+	clusterAssets[clusterAsset.Cluster_ID] = clusterAsset
+}
+
+// RemoveClusterConfig removes the cluster asset without deleting its persisted files
+func RemoveClusterConfig(clusterID string) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(clusterAssets, clusterID)
+}
+
+// ListClusters returns the sorted ids of the clusters
+func ListClusters() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	clusterIDs := make([]string, 0, len(clusterAssets))
+	for clusterID := range clusterAssets {
+		clusterIDs = append(clusterIDs, clusterID)
+	}
+	sort.Strings(clusterIDs)
+	return clusterIDs
+}
+
+// GetAllClusterConfigs returns the cluster assets sorted by cluster id
+func GetAllClusterConfigs() []*asset.ClusterAsset {
+	mu.RLock()
+	defer mu.RUnlock()
+	list := make([]*asset.ClusterAsset, 0, len(clusterAssets))
+	for _, clusterAsset := range clusterAssets {
+		list = append(list, clusterAsset)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Cluster_ID < list[j].Cluster_ID
+	})
+	return list
+}
+
 func Persist() error {
 	// Get persist dir
 	persistDir := GetPersistDir()
 
 	// Persist cluster
-	for _, clusterAsset := range ClusterAsset {
+	for _, clusterAsset := range GetAllClusterConfigs() {
 		clusterDir := filepath.Join(persistDir, clusterAsset.Cluster_ID)
 		if err := os.MkdirAll(clusterDir, 0644); err != nil {
 			return err
@@ -136,7 +209,67 @@ func Persist() error {
 	return nil
 }
 
+// RemoveWorkerNodes removes the workers with the hostnames from the cluster and persists the cluster,
+// the ignition files no remaining worker uses are deleted. Nothing is removed if a hostname is not found.
+func RemoveWorkerNodes(clusterID string, hostnames []string) error {
+	clusterAsset, err := GetClusterConfigCopy(clusterID)
+	if err != nil {
+		return err
+	}
+
+	remove := make(map[string]struct{}, len(hostnames))
+	for _, hostname := range hostnames {
+		remove[hostname] = struct{}{}
+	}
+	var kept, removed []asset.NodeAsset
+	for _, worker := range clusterAsset.Worker {
+		if _, ok := remove[worker.Hostname]; ok {
+			removed = append(removed, worker)
+			delete(remove, worker.Hostname)
+			continue
+		}
+		kept = append(kept, worker)
+	}
+	for _, hostname := range hostnames {
+		if _, ok := remove[hostname]; ok {
+			return errors.Errorf("worker %s not found in cluster %s", hostname, clusterID)
+		}
+	}
+
+	clusterAsset.Worker = kept
+	clusterDir := filepath.Join(GetPersistDir(), clusterID)
+	if err := os.MkdirAll(clusterDir, 0644); err != nil {
+		return err
+	}
+	if err := clusterAsset.Persist(clusterDir); err != nil {
+		return errors.Wrapf(err, "failed to persist cluster %s", clusterID)
+	}
+	SetClusterConfig(clusterAsset)
+
+	// workers of the same node pool share ignition files
+	used := make(map[string]struct{})
+	for _, worker := range kept {
+		used[worker.CreateIgnPath] = struct{}{}
+		used[worker.MergeIgnPath] = struct{}{}
+	}
+	for _, worker := range removed {
+		for _, path := range []string{worker.CreateIgnPath, worker.MergeIgnPath} {
+			if _, ok := used[path]; ok || path == "" {
+				continue
+			}
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return errors.Wrapf(err, "failed to delete ignition file of worker %s", worker.Hostname)
+			}
+		}
+	}
+	return nil
+}
+
 func Delete(clusterID string) error {
+	if clusterID == "" {
+		return errors.New("ClusterID is empty")
+	}
+
 	// Get persist dir
 	persistDir := GetPersistDir()
 
@@ -146,8 +279,9 @@ func Delete(clusterID string) error {
 	}
 
 	if err := clusterAsset.Delete(filepath.Join(persistDir, clusterID)); err != nil {
-		return err
+		return errors.Wrapf(err, "failed to delete persisted cluster %s", clusterID)
 	}
+	RemoveClusterConfig(clusterID)
 
 	return nil
 }
@@ -19,6 +19,7 @@ package httpserver
 
 import (
 	"errors"
+	"net"
 	"net/http"
 	"os"
 	"sync"
@@ -87,17 +88,20 @@ func (fs *HttpFileService) Start() error {
 		Addr: ":" + fs.Port,
 	}
 
+	// 先同步监听端口，Start返回后即可接受请求
+	listener, err := net.Listen("tcp", fs.server.Addr)
+	if err != nil {
+		logrus.Errorf("Listen(): %v", err)
+		return err
+	}
+	fs.running = true
+
 	go func() {
 		logrus.Infof("HTTP server listening on port %s...\n", fs.Port)
-		fs.running = true
-		if fs.server != nil {
-			if err := fs.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-				logrus.Errorf("ListenAndServe(): %v", err)
-				fs.running = false
-				return
-			}
-		} else {
-			logrus.Error("Server is nil. Cannot start.")
+		if err := fs.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logrus.Errorf("Serve(): %v", err)
+			fs.running = false
+			return
 		}
 	}()
 
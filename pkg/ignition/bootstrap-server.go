@@ -0,0 +1,164 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ignition
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// IgnitionContentType is the media type of the served ignition configs
+	IgnitionContentType = "application/vnd.coreos.ignition+json"
+	// unixAddrPrefix marks a Serve address as the path of a unix socket
+	unixAddrPrefix = "unix:"
+)
+
+// BootstrapServer serves the ignition config of each node role at the path the merge config
+// generated by GenerateMergeIgnition fetches, i.e. /<role>
+type BootstrapServer struct {
+	mutex    sync.RWMutex
+	configs  map[string][]byte
+	server   *http.Server
+	listener net.Listener
+}
+
+// SetConfigs replaces the served configs, keyed by the role passed to GenerateMergeIgnition
+func (s *BootstrapServer) SetConfigs(configs map[string]*igntypes.Config) error {
+	contents := make(map[string][]byte, len(configs))
+	for role, config := range configs {
+		if config == nil {
+			return fmt.Errorf("ignition config of role %s is nil", role)
+		}
+		data, err := Marshal(config)
+		if err != nil {
+			return fmt.Errorf("failed to marshal ignition config of role %s: %v", role, err)
+		}
+		contents["/"+strings.TrimPrefix(role, "/")] = data
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.configs = contents
+	return nil
+}
+
+/*
+Serve starts serving the configs in the background, it returns once the address is listened on
+Parameters:
+  - addr: host:port to listen on, or unix:<path> for a unix socket
+  - configs: ignition configs keyed by the role passed to GenerateMergeIgnition
+*/
+func (s *BootstrapServer) Serve(addr string, configs map[string]*igntypes.Config) error {
+	if err := s.SetConfigs(configs); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.server != nil {
+		return errors.New("bootstrap server is already serving")
+	}
+	network := "tcp"
+	if strings.HasPrefix(addr, unixAddrPrefix) {
+		network = "unix"
+		addr = strings.TrimPrefix(addr, unixAddrPrefix)
+		// 清理上次异常退出残留的socket文件
+		if err := os.Remove(addr); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove stale socket %s: %v", addr, err)
+		}
+	}
+	listener, err := net.Listen(network, addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %v", addr, err)
+	}
+
+	server := &http.Server{Handler: s}
+	s.server = server
+	s.listener = listener
+	go func() {
+		logrus.Infof("Bootstrap ignition server listening on %s", listener.Addr())
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logrus.Errorf("Bootstrap ignition server stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Addr returns the address the server listens on, nil when it is not serving
+func (s *BootstrapServer) Addr() net.Addr {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// ServeHTTP responds with the ignition config of the role in the request path
+func (s *BootstrapServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mutex.RLock()
+	data, ok := s.configs[r.URL.Path]
+	s.mutex.RUnlock()
+	if !ok {
+		logrus.Warnf("No ignition config for %s requested by %s", r.URL.Path, r.RemoteAddr)
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", IgnitionContentType)
+	w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err := w.Write(data); err != nil {
+		logrus.Errorf("Error writing ignition config %s: %v", r.URL.Path, err)
+	}
+}
+
+// Shutdown stops the server gracefully, waiting for active requests until the context is done
+func (s *BootstrapServer) Shutdown(ctx context.Context) error {
+	s.mutex.Lock()
+	server := s.server
+	s.server = nil
+	s.listener = nil
+	s.mutex.Unlock()
+
+	if server == nil {
+		return nil
+	}
+	logrus.Info("Stopping bootstrap ignition server...")
+	if err := server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("error shutting down bootstrap ignition server: %v", err)
+	}
+	return nil
+}
@@ -19,18 +19,55 @@ import (
 	"fmt"
 	"nestos-kubernetes-deployer/data"
 	"nestos-kubernetes-deployer/pkg/configmanager/asset"
+	"nestos-kubernetes-deployer/pkg/tokens"
 	"nestos-kubernetes-deployer/pkg/utils"
+	"net"
+	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	ignutil "github.com/coreos/ignition/v2/config/util"
 	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
 	"github.com/sirupsen/logrus"
+	"github.com/vincent-petithory/dataurl"
 )
 
 const hookFilesPath = "/etc/nkd/hookfiles/"
 
+const (
+	DefaultTimezone = "Asia/Shanghai"
+	zoneinfoDir     = "/usr/share/zoneinfo"
+)
+
+const flexVolumePluginDir = "/opt/libexec/kubernetes/kubelet-plugins/volume/exec/"
+
+const (
+	sshDir                = "/etc/ssh"
+	sshHostKeyFileMode    = 0600
+	sshHostPubKeyFileMode = 0644
+)
+
+const (
+	defaultInterface     = "eth0"
+	nmConnectionsDir     = "/etc/NetworkManager/system-connections"
+	nmConnectionFileMode = 0600
+)
+
+const (
+	registriesConfPath     = "/etc/containers/registries.conf.d/99-nkd-registries.conf"
+	registriesConfFileMode = 0644
+)
+
+const (
+	NodeRoleMaster = "master"
+	NodeRoleWorker = "worker"
+)
+
+// the bootstrap node serves the ignition of the other nodes
+const NodeTypeBootstrap = "bootstrap"
+
 var (
 	EnabledServices = []string{
 		"kubelet.service",
@@ -40,15 +77,27 @@ var (
 		"release-image-pivot.service",
 		"join-worker.service",
 	}
+
+	// services enabled only on the nodes of the node type, in addition to EnabledServices
+	nodeTypeEnabledServices = map[string][]string{
+		NodeTypeBootstrap: {"nkd-bootstrap-httpd.socket"},
+	}
 )
 
+// GetEnabledServices returns the services enabled on the nodes of the node type
+func GetEnabledServices(nodeType string) []string {
+	return append(append([]string{}, EnabledServices...), nodeTypeEnabledServices[nodeType]...)
+}
+
 type TmplData struct {
 	NodeName          string
 	APIServerURL      string
 	ImageRegistry     string
 	Runtime           string
 	CriSocket         string
+	CgroupDriver      string // shared by the kubelet and the container runtime
 	PauseImage        string
+	SandboxImage      string // full pause image reference used by the container runtime
 	KubeVersion       string
 	ServiceSubnet     string
 	PodSubnet         string
@@ -59,6 +108,18 @@ type TmplData struct {
 	Hsip              string //HostName + IP
 	KubeadmApiVersion string
 	HookFilesPath     string
+	NodeRole          string // master or worker, empty means not specified
+	FirstMaster       bool   // runs kubeadm init, the other masters join the control plane with the CertificateKey
+	NodeLabels        string // comma separated key=value pairs
+	NodeTaints        string // comma separated key=value:effect taints
+
+	APIServerExtraArgs         map[string]string
+	ControllerManagerExtraArgs map[string]string
+	SchedulerExtraArgs         map[string]string
+
+	KubeletConfig asset.KubeletConfig // rendered into the KubeletConfiguration of the cluster
+
+	PauseImageFallbacks []string // registries tried in order for the pause image only, rendered for the crio runtime
 }
 
 type Common struct {
@@ -69,50 +130,272 @@ type Common struct {
 	TmplData        interface{}
 	EnabledServices []string
 	Config          *igntypes.Config
+	DryRun          bool   // preview the rendered ignition without writing files
+	Timezone        string // zoneinfo name of the node, defaults to Asia/Shanghai
+	Network         *asset.NetworkConfig
+	SSHHostKeys     []asset.SSHHostKey // fixed host keys, sshd generates its own on first boot when empty
+	SSHKeys         []string           // authorized keys of the user, added to the keys in SSHKey
+	Registry        *asset.RegistryConfig
+	IgnitionVersion string             // ignition spec version of the config, defaults to MaxVersion
+	ExtraFilesDir   string             // host directory of files added after the embedded files, replacing those at the same path
+	Disks           []asset.DiskConfig // partitions created on the node with their filesystems and mount units
+}
+
+// authorizedKeys returns the keys of SSHKeys and SSHKey, an entry may hold several keys one per line
+// like an authorized_keys file. Blank lines and comments are skipped and duplicated keys are kept once.
+func (c *Common) authorizedKeys() []igntypes.SSHAuthorizedKey {
+	var keys []igntypes.SSHAuthorizedKey
+	seen := make(map[string]struct{})
+	for _, entry := range append(append([]string{}, c.SSHKeys...), c.SSHKey) {
+		for _, line := range strings.Split(entry, "\n") {
+			key := strings.TrimSpace(line)
+			if key == "" || strings.HasPrefix(key, "#") {
+				continue
+			}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			keys = append(keys, igntypes.SSHAuthorizedKey(key))
+		}
+	}
+	return keys
+}
+
+// RenderToBytes generates the ignition config and returns it marshaled instead of saving it
+func (c *Common) RenderToBytes() ([]byte, error) {
+	if err := c.Generate(); err != nil {
+		return nil, err
+	}
+
+	data, err := Marshal(c.Config)
+	if err != nil {
+		logrus.Errorf("failed to Marshal ignition config: %v", err)
+		return nil, err
+	}
+	return data, nil
 }
 
 func (c *Common) Generate() error {
+	timezone := c.Timezone
+	if timezone == "" {
+		timezone = DefaultTimezone
+	}
+	if !asset.IsValidTimezone(timezone) {
+		logrus.Errorf("invalid timezone %s", timezone)
+		return fmt.Errorf("invalid timezone %s", timezone)
+	}
+
+	ignitionVersion, err := ResolveIgnitionVersion(c.IgnitionVersion)
+	if err != nil {
+		logrus.Errorf("invalid ignition version: %v", err)
+		return err
+	}
+
 	c.Config = &igntypes.Config{
 		Ignition: igntypes.Ignition{
-			Version: igntypes.MaxVersion.String(),
+			Version: ignitionVersion,
 		},
 		Passwd: igntypes.Passwd{
 			Users: []igntypes.PasswdUser{
 				{
-					Name: c.UserName,
-					SSHAuthorizedKeys: []igntypes.SSHAuthorizedKey{
-						igntypes.SSHAuthorizedKey(c.SSHKey),
-					},
-					PasswordHash: &c.PassWord,
+					Name:              c.UserName,
+					SSHAuthorizedKeys: c.authorizedKeys(),
+					PasswordHash:      &c.PassWord,
 				},
 			},
 		},
 		Storage: igntypes.Storage{
 			Links: []igntypes.Link{
 				{
-					Node: igntypes.Node{Path: "/etc/local/time"},
+					Node: igntypes.Node{
+						Path:      "/etc/localtime",
+						Overwrite: ignutil.BoolToPtr(true),
+					},
 					LinkEmbedded1: igntypes.LinkEmbedded1{
-						Target: "/usr/share/zoneinfo/Asia/Shanghai",
+						Target: path.Join(zoneinfoDir, timezone),
 					},
 				},
 			},
 		},
 	}
 
+	if c.Network != nil && !c.Network.IsEmpty() {
+		iface, keyfile, err := networkKeyfile(c.Network)
+		if err != nil {
+			logrus.Errorf("failed to generate network config: %v", err)
+			return err
+		}
+		ignFile := FileWithContents(path.Join(nmConnectionsDir, iface+".nmconnection"), nmConnectionFileMode, keyfile)
+		c.Config.Storage.Files = AppendFiles(c.Config.Storage.Files, ignFile)
+	}
+
+	registry := c.Registry
+	if tmplData, ok := c.TmplData.(*TmplData); ok {
+		registry = withPauseImageFallbacks(registry, tmplData.ImageRegistry, tmplData.PauseImage,
+			tmplData.PauseImageFallbacks)
+	}
+	if registry != nil && !registry.IsEmpty() {
+		c.Config.Storage.Files = AppendFiles(c.Config.Storage.Files,
+			FileWithContents(registriesConfPath, registriesConfFileMode, registriesConf(registry)))
+	}
+
+	for _, key := range c.SSHHostKeys {
+		keyPath := path.Join(sshDir, fmt.Sprintf("ssh_host_%s_key", key.Type))
+		c.Config.Storage.Files = AppendFiles(c.Config.Storage.Files,
+			FileWithContents(keyPath, sshHostKeyFileMode, []byte(key.PrivateKey)))
+		c.Config.Storage.Files = AppendFiles(c.Config.Storage.Files,
+			FileWithContents(keyPath+".pub", sshHostPubKeyFileMode, []byte(key.PublicKey)))
+	}
+
+	appendDisks(c.Config, c.Disks)
+
 	nodeFilesPath := fmt.Sprintf("ignition/%s/files", c.NodeType)
 	if err := appendStorageFiles(c.Config, "/", nodeFilesPath, c.TmplData); err != nil {
 		logrus.Errorf("failed to add files to a ignition config: %v", err)
 		return err
 	}
+	if c.ExtraFilesDir != "" {
+		if err := appendExtraFiles(c.Config, c.ExtraFilesDir, c.TmplData); err != nil {
+			logrus.Errorf("failed to add extra files to a ignition config: %v", err)
+			return err
+		}
+	}
 	nodeUnitPath := fmt.Sprintf("ignition/%s/systemd/", c.NodeType)
 	if err := appendSystemdUnits(c.Config, nodeUnitPath, c.TmplData, c.EnabledServices); err != nil {
 		logrus.Errorf("failed to add systemd units to a ignition config: %v", err)
 		return err
 	}
 
+	// 模板错误在生成时报告，而不是等到节点启动失败
+	if err := Validate(c.Config); err != nil {
+		logrus.Errorf("failed to validate %s ignition config: %v", c.NodeType, err)
+		return err
+	}
+
 	return nil
 }
 
+// registriesConf renders a containers-registries.conf(5) drop-in with the mirrors and insecure registries,
+// the registries are sorted so the rendered ignition is stable
+func registriesConf(r *asset.RegistryConfig) []byte {
+	insecureRegistries := make(map[string]bool)
+	for _, registry := range r.Insecure {
+		insecureRegistries[registry] = true
+	}
+	var registries []string
+	for registry := range r.Mirrors {
+		registries = append(registries, registry)
+	}
+	for registry := range insecureRegistries {
+		if _, ok := r.Mirrors[registry]; !ok {
+			registries = append(registries, registry)
+		}
+	}
+	sort.Strings(registries)
+
+	var b strings.Builder
+	for i, registry := range registries {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "[[registry]]\nprefix = %q\nlocation = %q\n", registry, registry)
+		if r.IsInsecure(registry) {
+			b.WriteString("insecure = true\n")
+		}
+		for _, mirror := range r.Mirrors[registry] {
+			fmt.Fprintf(&b, "\n[[registry.mirror]]\nlocation = %q\n", mirror)
+			if r.IsInsecure(mirror) {
+				b.WriteString("insecure = true\n")
+			}
+		}
+	}
+	return []byte(b.String())
+}
+
+// withPauseImageFallbacks adds a registry entry scoped to the pause image repository of the image registry,
+// so only the pause image is pulled from the fallbacks and the other images of the registry keep their mirrors.
+// Mirrors are tried before the registry location, so the configured mirrors and the registry itself are listed
+// ahead of the fallbacks. The registry config is returned unchanged without fallbacks.
+func withPauseImageFallbacks(r *asset.RegistryConfig, imageRegistry string, pauseImage string,
+	fallbacks []string) *asset.RegistryConfig {
+	if len(fallbacks) == 0 {
+		return r
+	}
+	merged := &asset.RegistryConfig{Mirrors: make(map[string][]string)}
+	if r != nil {
+		for registry, mirrors := range r.Mirrors {
+			merged.Mirrors[registry] = mirrors
+		}
+		merged.Insecure = r.Insecure
+	}
+
+	repository := pauseImageRepository(pauseImage)
+	var mirrors []string
+	seen := make(map[string]bool)
+	locations := append(append(append([]string{}, merged.Mirrors[imageRegistry]...),
+		imageRegistry), fallbacks...)
+	for _, location := range locations {
+		mirror := location + "/" + repository
+		if !seen[mirror] {
+			seen[mirror] = true
+			mirrors = append(mirrors, mirror)
+		}
+	}
+	merged.Mirrors[imageRegistry+"/"+repository] = mirrors
+	return merged
+}
+
+// RuntimeRegistryConfig returns the registry config the container runtime of the nodes pulls the images with
+func RuntimeRegistryConfig(c *asset.ClusterAsset) *asset.RegistryConfig {
+	return withPauseImageFallbacks(&c.Kubernetes.Registry, c.Kubernetes.ImageRegistry, c.Kubernetes.PauseImage,
+		c.Kubernetes.PauseImageFallbacks)
+}
+
+// pauseImageRepository strips the tag or digest from the pause image, e.g. pause:3.6 becomes pause
+func pauseImageRepository(pauseImage string) string {
+	repository := pauseImage
+	if i := strings.Index(repository, "@"); i >= 0 {
+		repository = repository[:i]
+	}
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+	return repository
+}
+
+// networkKeyfile renders a NetworkManager keyfile configuring a static address on the interface
+func networkKeyfile(n *asset.NetworkConfig) (string, []byte, error) {
+	iface := n.Interface
+	if iface == "" {
+		iface = defaultInterface
+	}
+	ip := net.ParseIP(n.IP)
+	if ip == nil || ip.To4() == nil {
+		return "", nil, fmt.Errorf("invalid IPv4 address %q of interface %s", n.IP, iface)
+	}
+	if n.Prefix <= 0 || n.Prefix > 32 {
+		return "", nil, fmt.Errorf("invalid prefix %d of interface %s", n.Prefix, iface)
+	}
+
+	address := fmt.Sprintf("%s/%d", n.IP, n.Prefix)
+	if n.Gateway != "" {
+		if net.ParseIP(n.Gateway) == nil {
+			return "", nil, fmt.Errorf("invalid gateway %q of interface %s", n.Gateway, iface)
+		}
+		address += "," + n.Gateway
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[connection]\nid=%s\ntype=ethernet\ninterface-name=%s\n\n", iface, iface)
+	fmt.Fprintf(&b, "[ipv4]\nmethod=manual\naddress1=%s\n", address)
+	if len(n.DNS) > 0 {
+		fmt.Fprintf(&b, "dns=%s;\n", strings.Join(n.DNS, ";"))
+	}
+	b.WriteString("\n[ipv6]\nmethod=ignore\n")
+	return iface, []byte(b.String()), nil
+}
+
 /*
 AppendStorageFiles add files to a ignition config
 Parameters:
@@ -150,13 +433,53 @@ func appendStorageFiles(config *igntypes.Config, base string, uri string, tmplDa
 	}
 	_, data, err := utils.GetCompleteFile(info.Name(), file, tmplData)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to render %s: %w", uri, err)
 	}
 	ignFile := FileWithContents(strings.TrimSuffix(base, ".template"), 0755, data)
 	config.Storage.Files = AppendFiles(config.Storage.Files, ignFile)
 	return nil
 }
 
+/*
+appendExtraFiles adds the files under a host directory to a ignition config at their path relative
+to the directory, files already in the config at the same path are replaced
+Parameters:
+  - config: the ignition config to be modified
+  - dir: the host directory holding the file tree
+  - tmplData: struct to used to render the .template files
+*/
+func appendExtraFiles(config *igntypes.Config, dir string, tmplData interface{}) error {
+	return filepath.Walk(dir, func(hostPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("extra file %s is not a regular file", hostPath)
+		}
+		rel, err := filepath.Rel(dir, hostPath)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(hostPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, data, err := utils.GetCompleteFile(info.Name(), file, tmplData)
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", hostPath, err)
+		}
+		nodePath := strings.TrimSuffix(path.Join("/", filepath.ToSlash(rel)), ".template")
+		ignFile := FileWithContents(nodePath, int(info.Mode().Perm()), data)
+		config.Storage.Files = AppendFiles(config.Storage.Files, ignFile)
+		return nil
+	})
+}
+
 /*
 Add systemd units to a ignition config
 Parameters:
@@ -190,7 +513,7 @@ func appendSystemdUnits(config *igntypes.Config, uri string, tmplData interface{
 		defer file.Close()
 		name, contents, err := utils.GetCompleteFile(childInfo.Name(), file, tmplData)
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to render %s: %w", dir, err)
 		}
 		unit := igntypes.Unit{
 			Name:     name,
@@ -205,6 +528,48 @@ func appendSystemdUnits(config *igntypes.Config, uri string, tmplData interface{
 }
 
 func GetTmplData(c *asset.ClusterAsset) (*TmplData, error) {
+	// 缺少这些字段时渲染出的 kubeadm 配置不可用，节点会静默地加入失败
+	var missing []string
+	if c.Kubernetes.ApiServerEndpoint == "" {
+		missing = append(missing, "kubernetes.apiserver-endpoint")
+	}
+	if c.Kubernetes.KubernetesVersion == "" {
+		missing = append(missing, "kubernetes.kubernetes-version")
+	}
+	if c.Kubernetes.ImageRegistry == "" {
+		missing = append(missing, "kubernetes.image-registry")
+	}
+	if len(missing) > 0 {
+		err := fmt.Errorf("missing required template data: %s", strings.Join(missing, ", "))
+		logrus.Errorf("Error getting template data of cluster %s: %v", c.Cluster_ID, err)
+		return nil, err
+	}
+
+	// 未指定时生成token，写回集群配置以保证master和worker使用相同的token
+	if c.Kubernetes.Token == "" {
+		token, err := tokens.GenerateBootstrapToken()
+		if err != nil {
+			logrus.Errorf("Error generating bootstrap token of cluster %s: %v", c.Cluster_ID, err)
+			return nil, err
+		}
+		c.Kubernetes.Token = token
+	}
+	// 由集群生成的root CA在生成证书时计算，这里只处理用户指定的root CA
+	if c.Kubernetes.CaCertHash == "" && c.CertAsset.RootCaCertPath != "" {
+		caCert, err := os.ReadFile(c.CertAsset.RootCaCertPath)
+		if err != nil {
+			logrus.Errorf("Error reading root CA certificate of cluster %s: %v", c.Cluster_ID, err)
+			return nil, err
+		}
+		caCertHash := tokens.ComputeCACertHash(caCert)
+		if caCertHash == "" {
+			err := fmt.Errorf("invalid root CA certificate %s", c.CertAsset.RootCaCertPath)
+			logrus.Errorf("Error computing CA cert hash of cluster %s: %v", c.Cluster_ID, err)
+			return nil, err
+		}
+		c.Kubernetes.CaCertHash = caCertHash
+	}
+
 	var hsip string
 	for i := 0; i < len(c.Master); i++ {
 		temp := c.Master[i].IP + " " + c.Master[i].Hostname + "\n"
@@ -216,24 +581,104 @@ func GetTmplData(c *asset.ClusterAsset) (*TmplData, error) {
 		logrus.Errorf("Error getting runtime %s: %v\n", c.Runtime, err)
 		return nil, err
 	}
+	cgroupDriver, err := asset.GetCgroupDriver(c.Kubernetes.CgroupDriver)
+	if err != nil {
+		logrus.Errorf("Error getting cgroup driver of cluster %s: %v", c.Cluster_ID, err)
+		return nil, err
+	}
 
-	return &TmplData{
+	tmplData := &TmplData{
 		APIServerURL:      c.Kubernetes.ApiServerEndpoint,
 		ImageRegistry:     c.Kubernetes.ImageRegistry,
-		Runtime:           c.Runtime,
+		Runtime:           asset.NormalizeRuntime(c.Runtime),
 		CriSocket:         criSocket,
+		CgroupDriver:      cgroupDriver,
 		PauseImage:        c.Kubernetes.PauseImage,
+		SandboxImage:      c.Kubernetes.ImageRegistry + "/" + c.Kubernetes.PauseImage,
 		KubeVersion:       c.Kubernetes.KubernetesVersion,
 		KubeadmApiVersion: c.Kubernetes.KubernetesAPIVersion,
-		ServiceSubnet:     c.Network.ServiceSubnet,
-		PodSubnet:         c.Network.PodSubnet,
+		ServiceSubnet:     asset.NormalizeCIDRList(c.Network.ServiceSubnet),
+		PodSubnet:         asset.NormalizeCIDRList(c.Network.PodSubnet),
 		Token:             c.Kubernetes.Token,
 		CaCertHash:        c.Kubernetes.CaCertHash,
 		ReleaseImageURl:   c.Kubernetes.ReleaseImageURL,
 		CertificateKey:    c.Kubernetes.CertificateKey,
 		Hsip:              hsip,
 		HookFilesPath:     hookFilesPath,
-	}, nil
+	}
+	if tmplData.KubeadmApiVersion == "" {
+		tmplData.KubeadmApiVersion = utils.ResolveKubeadmAPIVersion(c.Kubernetes.KubernetesVersion)
+	}
+	featureGates := getNetworkFeatureGates(c)
+	apiServerDefaults := map[string]string{}
+	if tmplData.ServiceSubnet != "" {
+		// 双栈集群的service网段包含IPv4和IPv6两个CIDR
+		apiServerDefaults["service-cluster-ip-range"] = tmplData.ServiceSubnet
+	}
+	tmplData.APIServerExtraArgs = getExtraArgs(c.Kubernetes.APIServerExtraArgs, apiServerDefaults, featureGates)
+	tmplData.ControllerManagerExtraArgs = getExtraArgs(c.Kubernetes.ControllerManagerExtraArgs,
+		map[string]string{"flex-volume-plugin-dir": flexVolumePluginDir}, featureGates)
+	tmplData.SchedulerExtraArgs = getExtraArgs(c.Kubernetes.SchedulerExtraArgs, nil, featureGates)
+	tmplData.KubeletConfig = c.Kubernetes.Kubelet
+	tmplData.PauseImageFallbacks = c.Kubernetes.PauseImageFallbacks
+
+	return tmplData, nil
+}
+
+// getNetworkFeatureGates returns the feature gates of the cluster with the gates its network requires,
+// dual-stack is only behind the IPv6DualStack gate before kubernetes 1.21, gates set explicitly are kept
+func getNetworkFeatureGates(c *asset.ClusterAsset) map[string]bool {
+	minor, ok := utils.KubeMinorVersion(c.Kubernetes.KubernetesVersion)
+	if !ok || minor >= 21 || !asset.IsDualStack(c.Network.ServiceSubnet) {
+		return c.Kubernetes.FeatureGates
+	}
+	featureGates := map[string]bool{"IPv6DualStack": true}
+	for gate, enabled := range c.Kubernetes.FeatureGates {
+		featureGates[gate] = enabled
+	}
+	return featureGates
+}
+
+// getExtraArgs merges the configured extra args of a control plane component over its defaults,
+// feature gates are passed to every component as a sorted feature-gates arg.
+// kubeadm expects the flag names without dashes, args written as command line flags are accepted as well.
+func getExtraArgs(args map[string]string, defaults map[string]string, featureGates map[string]bool) map[string]string {
+	extraArgs := make(map[string]string, len(defaults)+len(args)+1)
+	for key, value := range defaults {
+		extraArgs[key] = value
+	}
+	for key, value := range args {
+		extraArgs[strings.TrimLeft(key, "-")] = value
+	}
+	if len(featureGates) > 0 {
+		var gates []string
+		for gate, enabled := range featureGates {
+			gates = append(gates, fmt.Sprintf("%s=%t", gate, enabled))
+		}
+		sort.Strings(gates)
+		extraArgs["feature-gates"] = strings.Join(gates, ",")
+	}
+	return extraArgs
+}
+
+// GetMasterTmplData returns the template data used to render master ignition
+func GetMasterTmplData(c *asset.ClusterAsset) (*TmplData, error) {
+	tmplData, err := GetTmplData(c)
+	if err != nil {
+		return nil, err
+	}
+	tmplData.NodeRole = NodeRoleMaster
+	return tmplData, nil
+}
+
+// GetWorkerTmplData returns the template data used to render worker ignition
+func GetWorkerTmplData(c *asset.ClusterAsset) (*TmplData, error) {
+	tmplData, err := GetTmplData(c)
+	if err != nil {
+		return nil, err
+	}
+	tmplData.NodeRole = NodeRoleWorker
+	return tmplData, nil
 }
 
 // Merge hook files into ignition.Config
@@ -243,3 +688,44 @@ func MergeHookFilesIntoConfig(config *igntypes.Config, hookFiles []asset.ShellFi
 		config.Storage.Files = AppendFiles(config.Storage.Files, ignFile)
 	}
 }
+
+// Merge an Ignition config into ignition.Config, it is embedded as a data url merged by ignition
+func MergeIgnitionOverride(config *igntypes.Config, override []byte) {
+	config.Ignition.Config.Merge = append(config.Ignition.Config.Merge, igntypes.Resource{
+		Source: ignutil.StrToPtr(dataurl.EncodeBytes(override)),
+	})
+}
+
+// Merge unit drop-ins into ignition.Config, a drop-in replaces the one with the same name of its unit
+func MergeUnitDropInsIntoConfig(config *igntypes.Config, dropIns []asset.UnitDropIn) {
+	for _, dropIn := range dropIns {
+		index := -1
+		for i, unit := range config.Systemd.Units {
+			if unit.Name == dropIn.Unit {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			config.Systemd.Units = append(config.Systemd.Units, igntypes.Unit{Name: dropIn.Unit})
+			index = len(config.Systemd.Units) - 1
+		}
+
+		unit := &config.Systemd.Units[index]
+		ignDropIn := igntypes.Dropin{
+			Name:     dropIn.Name,
+			Contents: ignutil.StrToPtr(string(dropIn.Contents)),
+		}
+		replaced := false
+		for i := range unit.Dropins {
+			if unit.Dropins[i].Name == dropIn.Name {
+				unit.Dropins[i] = ignDropIn
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			unit.Dropins = append(unit.Dropins, ignDropIn)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ignition
+
+import (
+	"fmt"
+	"nestos-kubernetes-deployer/pkg/configmanager/asset"
+	"path"
+	"strings"
+
+	ignutil "github.com/coreos/ignition/v2/config/util"
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+)
+
+const partLabelDir = "/dev/disk/by-partlabel"
+
+/*
+appendDisks adds the partitions of the disks to a ignition config with their filesystems,
+and a systemd mount or swap unit for each partition so it is used on every boot
+Parameters:
+  - config: the ignition config to be modified
+  - disks: the disks of the node, validated by the cluster asset
+*/
+func appendDisks(config *igntypes.Config, disks []asset.DiskConfig) {
+	for _, disk := range disks {
+		ignDisk := igntypes.Disk{
+			Device: disk.Device,
+		}
+		if disk.WipeTable {
+			ignDisk.WipeTable = ignutil.BoolToPtr(true)
+		}
+		for _, partition := range disk.Partitions {
+			ignPartition := igntypes.Partition{
+				Label:  ignutil.StrToPtr(partition.Label),
+				Number: partition.Number,
+			}
+			if partition.StartMiB > 0 {
+				ignPartition.StartMiB = ignutil.IntToPtr(partition.StartMiB)
+			}
+			if partition.SizeMiB > 0 {
+				ignPartition.SizeMiB = ignutil.IntToPtr(partition.SizeMiB)
+			}
+			ignDisk.Partitions = append(ignDisk.Partitions, ignPartition)
+
+			device := path.Join(partLabelDir, partition.Label)
+			filesystem := igntypes.Filesystem{
+				Device: device,
+				Format: ignutil.StrToPtr(partition.Format),
+				Label:  ignutil.StrToPtr(partition.Label),
+				// 重建分区表时分区上原有的文件系统也不再保留
+				WipeFilesystem: ignutil.BoolToPtr(disk.WipeTable),
+			}
+			if partition.Format == "swap" {
+				config.Storage.Filesystems = append(config.Storage.Filesystems, filesystem)
+				config.Systemd.Units = append(config.Systemd.Units, swapUnit(device))
+				continue
+			}
+			// ignition 在写入文件前挂载该路径，写入挂载点下的文件会落在新分区上
+			filesystem.Path = ignutil.StrToPtr(partition.MountPoint)
+			config.Storage.Filesystems = append(config.Storage.Filesystems, filesystem)
+			config.Systemd.Units = append(config.Systemd.Units, mountUnit(device, partition.MountPoint, partition.Format))
+		}
+		config.Storage.Disks = append(config.Storage.Disks, ignDisk)
+	}
+}
+
+// mountUnit returns the systemd mount unit mounting the device at the mountpoint before local-fs.target
+func mountUnit(device string, mountPoint string, format string) igntypes.Unit {
+	fsck := fmt.Sprintf("systemd-fsck@%s.service", escapeSystemdPath(device))
+	contents := fmt.Sprintf(`[Unit]
+Description=Mount %s
+Requires=%s
+After=%s
+Before=local-fs.target
+
+[Mount]
+What=%s
+Where=%s
+Type=%s
+
+[Install]
+RequiredBy=local-fs.target
+`, mountPoint, fsck, fsck, device, mountPoint, format)
+	return igntypes.Unit{
+		Name:     escapeSystemdPath(mountPoint) + ".mount",
+		Enabled:  ignutil.BoolToPtr(true),
+		Contents: ignutil.StrToPtr(contents),
+	}
+}
+
+// swapUnit returns the systemd swap unit activating the swap device
+func swapUnit(device string) igntypes.Unit {
+	contents := fmt.Sprintf(`[Unit]
+Description=Swap on %s
+
+[Swap]
+What=%s
+
+[Install]
+WantedBy=swap.target
+`, device, device)
+	return igntypes.Unit{
+		Name:     escapeSystemdPath(device) + ".swap",
+		Enabled:  ignutil.BoolToPtr(true),
+		Contents: ignutil.StrToPtr(contents),
+	}
+}
+
+// escapeSystemdPath escapes a path like systemd-escape --path, e.g. /var/lib/containers is var-lib-containers
+// and /dev/disk/by-partlabel/var is dev-disk-by\x2dpartlabel-var
+func escapeSystemdPath(p string) string {
+	p = strings.Trim(path.Clean(p), "/")
+	if p == "" {
+		return "-"
+	}
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		switch {
+		case c == '/':
+			b.WriteByte('-')
+		case c == '.' && i == 0:
+			fmt.Fprintf(&b, `\x%02x`, c)
+		case c == '_' || c == '.' || c == ':' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, `\x%02x`, c)
+		}
+	}
+	return b.String()
+}
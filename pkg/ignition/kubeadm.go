@@ -0,0 +1,64 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ignition
+
+import (
+	"fmt"
+	"nestos-kubernetes-deployer/data"
+	"nestos-kubernetes-deployer/pkg/configmanager"
+	"nestos-kubernetes-deployer/pkg/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+// kubeadm config embedded in the ignition of the first master, the other nodes join with kubeadm flags
+const kubeadmConfigTemplate = "ignition/controlplane/files/etc/nkd/init-config.yaml.template"
+
+// RenderKubeadmConfig returns the kubeadm config the node of the role uses, as embedded in its ignition
+func RenderKubeadmConfig(clusterID string, role string) ([]byte, error) {
+	if role != NodeRoleMaster {
+		return nil, fmt.Errorf("no kubeadm config is rendered for %s nodes", role)
+	}
+
+	clusterAsset, err := configmanager.GetClusterConfig(clusterID)
+	if err != nil {
+		logrus.Errorf("failed to get cluster %s config: %v", clusterID, err)
+		return nil, err
+	}
+	if len(clusterAsset.Master) == 0 {
+		return nil, fmt.Errorf("cluster %s has no master node", clusterID)
+	}
+
+	tmplData, err := GetMasterTmplData(clusterAsset)
+	if err != nil {
+		logrus.Errorf("failed to get template data for cluster %s: %v", clusterID, err)
+		return nil, err
+	}
+	tmplData.NodeName = clusterAsset.Master[0].Hostname
+
+	file, err := data.Assets.Open(kubeadmConfigTemplate)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	_, config, err := utils.GetCompleteFile(kubeadmConfigTemplate, file, tmplData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", kubeadmConfigTemplate, err)
+	}
+	return config, nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"nestos-kubernetes-deployer/pkg/configmanager"
+	"nestos-kubernetes-deployer/pkg/configmanager/asset"
+	"nestos-kubernetes-deployer/pkg/ignition"
+	"os"
+	"path"
+	"path/filepath"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	BootstrapIgnFilename = "bootstrap.ign"
+	// directory on the bootstrap node the ignition of the other nodes is served from
+	bootstrapIgnitionDir = "/var/lib/nkd/ignition"
+)
+
+// BootstrapTmplData is used to render the templates of the bootstrap node
+type BootstrapTmplData struct {
+	IgnitionDir string
+	Port        string
+}
+
+// Bootstrap generates the ignition of a bootstrap node serving the master and worker ignition over http,
+// the master and worker ignition must be generated first.
+type Bootstrap struct {
+	ClusterAsset     *asset.ClusterAsset
+	Port             string
+	CreateIgnPath    string
+	CreateIgnContent []byte
+}
+
+func (b *Bootstrap) GenerateFiles() error {
+	sshkeyContent, err := os.ReadFile(b.ClusterAsset.SSHKey)
+	if err != nil {
+		logrus.Debug("Failed to read sshkey content:", err)
+		return err
+	}
+	if b.Port == "" {
+		return errors.New("bootstrap ignition port is empty")
+	}
+
+	generateFile := ignition.Common{
+		UserName:        b.ClusterAsset.UserName,
+		SSHKey:          string(sshkeyContent),
+		SSHKeys:         b.ClusterAsset.SSHKeys,
+		Timezone:        b.ClusterAsset.Timezone,
+		PassWord:        b.ClusterAsset.Password,
+		NodeType:        ignition.NodeTypeBootstrap,
+		TmplData:        &BootstrapTmplData{IgnitionDir: bootstrapIgnitionDir, Port: b.Port},
+		EnabledServices: ignition.GetEnabledServices(ignition.NodeTypeBootstrap),
+		Config:          &igntypes.Config{},
+		IgnitionVersion: b.ClusterAsset.IgnitionVersion,
+		ExtraFilesDir:   b.ClusterAsset.ExtraFilesDir,
+	}
+	if err := generateFile.Generate(); err != nil {
+		logrus.Errorf("failed to generate bootstrap ignition file: %v", err)
+		return err
+	}
+	ignitions, err := collectNodeIgnitions(b.ClusterAsset)
+	if err != nil {
+		return err
+	}
+	for _, name := range ignitions.names {
+		generateFile.Config.Storage.Files = ignition.AppendFiles(generateFile.Config.Storage.Files,
+			ignition.FileWithContents(path.Join(bootstrapIgnitionDir, name), 0644, ignitions.contents[name]))
+	}
+
+	ignitionDir := filepath.Join(configmanager.GetPersistDir(), b.ClusterAsset.Cluster_ID, "ignition")
+	if _, err := ignition.SaveFile(generateFile.Config, ignitionDir, BootstrapIgnFilename); err != nil {
+		return err
+	}
+	data, err := ignition.Marshal(generateFile.Config)
+	if err != nil {
+		logrus.Errorf("failed to Marshal ignition config: %v", err)
+		return err
+	}
+	b.CreateIgnPath = filepath.Join(ignitionDir, BootstrapIgnFilename)
+	b.CreateIgnContent = data
+	return nil
+}
+
+type nodeIgnitions struct {
+	names    []string
+	contents map[string][]byte
+}
+
+// collectNodeIgnitions collects the ignition files the merge ignition of the nodes fetch,
+// nodes sharing an ignition file are served the same file
+func collectNodeIgnitions(clusterAsset *asset.ClusterAsset) (*nodeIgnitions, error) {
+	ignitions := &nodeIgnitions{contents: make(map[string][]byte)}
+	for _, nodes := range [][]asset.NodeAsset{clusterAsset.Master, clusterAsset.Worker} {
+		for _, node := range nodes {
+			if node.CreateIgnPath == "" || len(node.CreateIgnContent) == 0 {
+				return nil, fmt.Errorf("ignition of node %s has not been generated", node.Hostname)
+			}
+			name := filepath.Base(node.CreateIgnPath)
+			if _, ok := ignitions.contents[name]; !ok {
+				ignitions.names = append(ignitions.names, name)
+			}
+			ignitions.contents[name] = node.CreateIgnContent
+		}
+	}
+	return ignitions, nil
+}
+
+// NodeIgnitionConfigs returns the ignition configs of the masters and workers keyed by the file name
+// their merge ignition fetches, as served by ignition.BootstrapServer
+func NodeIgnitionConfigs(clusterAsset *asset.ClusterAsset) (map[string]*igntypes.Config, error) {
+	ignitions, err := collectNodeIgnitions(clusterAsset)
+	if err != nil {
+		return nil, err
+	}
+	configs := make(map[string]*igntypes.Config, len(ignitions.names))
+	for _, name := range ignitions.names {
+		config := &igntypes.Config{}
+		if err := json.Unmarshal(ignitions.contents[name], config); err != nil {
+			logrus.Errorf("failed to parse ignition file %s: %v", name, err)
+			return nil, err
+		}
+		configs[name] = config
+	}
+	return configs, nil
+}
@@ -16,6 +16,8 @@ limitations under the License.
 package machine
 
 import (
+	"fmt"
+	"nestos-kubernetes-deployer/pkg/cert"
 	"nestos-kubernetes-deployer/pkg/configmanager"
 	"nestos-kubernetes-deployer/pkg/configmanager/asset"
 	"nestos-kubernetes-deployer/pkg/ignition"
@@ -47,24 +49,41 @@ func (m *Master) GenerateFiles() error {
 	}
 
 	// Get template dependency configuration
-	masterTemplateData, err := ignition.GetTmplData(m.ClusterAsset)
+	masterTemplateData, err := ignition.GetMasterTmplData(m.ClusterAsset)
 	if err != nil {
+		logrus.Errorf("failed to get template data for cluster %s: %v", m.ClusterAsset.Cluster_ID, err)
 		return err
 	}
 	ignitionDir := filepath.Join(configmanager.GetPersistDir(), m.ClusterAsset.Cluster_ID, "ignition")
 
+	housekeeperCerts, err := getHousekeeperCerts(m.ClusterAsset)
+	if err != nil {
+		return err
+	}
+
 	for i, master := range m.ClusterAsset.Master {
-		nodeType := getNodeTypeName(i)
 		masterTemplateData.NodeName = master.Hostname
+		masterTemplateData.FirstMaster = i == 0
+		masterTemplateData.NodeLabels = joinLabels("", master.Labels)
+		masterTemplateData.NodeTaints = joinTaints(master.Taints)
+		nodeType := getNodeTypeName(masterTemplateData.FirstMaster)
 
 		generateFile := ignition.Common{
 			UserName:        m.ClusterAsset.UserName,
 			SSHKey:          string(sshkeyContent),
+			SSHKeys:         m.ClusterAsset.SSHKeys,
+			Timezone:        m.ClusterAsset.Timezone,
 			PassWord:        m.ClusterAsset.Password,
 			NodeType:        nodeType,
 			TmplData:        masterTemplateData,
-			EnabledServices: ignition.EnabledServices,
+			EnabledServices: ignition.GetEnabledServices(nodeType),
 			Config:          &igntypes.Config{},
+			IgnitionVersion: m.ClusterAsset.IgnitionVersion,
+			ExtraFilesDir:   m.ClusterAsset.ExtraFilesDir,
+			Network:         &m.ClusterAsset.Master[i].Network,
+			SSHHostKeys:     master.SSHHostKeys,
+			Disks:           master.Disks,
+			Registry:        &m.ClusterAsset.Registry,
 		}
 
 		// Generate Ignition data
@@ -73,27 +92,33 @@ func (m *Master) GenerateFiles() error {
 			return err
 		}
 
-		filename := MasterIgnFilename
-		mergeFilename := masterMergeIgnFilename
-		if i == 0 {
-			filename = ControlplaneIgnFilename
-			mergeFilename = controlplaneMergeIgnFilename
+		filename, mergeFilename := getMasterIgnFilenames(master.Hostname, masterTemplateData.FirstMaster)
+		if masterTemplateData.FirstMaster {
 			mergeCertificatesIntoConfig(generateFile.Config, master.Certs)
 		}
+		mergeCertificatesIntoConfig(generateFile.Config, housekeeperCerts)
 
 		if len(m.ClusterAsset.ShellFiles) > 0 {
 			ignition.MergeHookFilesIntoConfig(generateFile.Config, m.ClusterAsset.ShellFiles)
 		}
+		if len(m.ClusterAsset.UnitDropIns) > 0 {
+			ignition.MergeUnitDropInsIntoConfig(generateFile.Config, m.ClusterAsset.UnitDropIns)
+		}
 
 		m.ClusterAsset.Master[i].Ignitions.CreateIgnPath = filepath.Join(ignitionDir, filename)
 		m.ClusterAsset.Master[i].Ignitions.MergeIgnPath = filepath.Join(ignitionDir, mergeFilename)
 
-		if err := ignition.SaveFile(generateFile.Config, ignitionDir, filename); err != nil {
+		if _, err := ignition.SaveFile(generateFile.Config, ignitionDir, filename); err != nil {
 			return err
 		}
 
-		mergerConfig := ignition.GenerateMergeIgnition(m.BootstrapBaseurl, filename)
-		if err := ignition.SaveFile(mergerConfig, ignitionDir, mergeFilename); err != nil {
+		mergerConfig, err := ignition.GenerateMergeIgnition(m.BootstrapBaseurl, filename, "http", nil,
+			m.ClusterAsset.IgnitionVersion)
+		if err != nil {
+			logrus.Errorf("failed to generate merge ignition of %s: %v", filename, err)
+			return err
+		}
+		if _, err := ignition.SaveFile(mergerConfig, ignitionDir, mergeFilename); err != nil {
 			return err
 		}
 
@@ -108,13 +133,40 @@ func (m *Master) GenerateFiles() error {
 	return nil
 }
 
-func getNodeTypeName(index int) string {
-	if index == 0 {
+// getMasterIgnFilenames returns the ignition files of a master, every joining master has its own files
+// since the network, host keys, disks, labels and taints are set per master
+func getMasterIgnFilenames(hostname string, firstMaster bool) (string, string) {
+	if firstMaster {
+		return ControlplaneIgnFilename, controlplaneMergeIgnFilename
+	}
+	if hostname == "" {
+		return MasterIgnFilename, masterMergeIgnFilename
+	}
+	return fmt.Sprintf("master-%s.ign", hostname), fmt.Sprintf("master-%s-merge.ign", hostname)
+}
+
+// getNodeTypeName returns the template directory of a master, the first master initializes the cluster
+func getNodeTypeName(firstMaster bool) string {
+	if firstMaster {
 		return "controlplane"
 	}
 	return "master"
 }
 
+// getHousekeeperCerts returns the certificates of the housekeeper grpc connection written to every node,
+// there are none when housekeeper is not deployed
+func getHousekeeperCerts(clusterAsset *asset.ClusterAsset) ([]utils.StorageContent, error) {
+	if !clusterAsset.Housekeeper.DeployHousekeeper {
+		return nil, nil
+	}
+	certs, err := cert.GenerateHousekeeperCerts(clusterAsset.Cluster_ID, clusterAsset.CertAsset)
+	if err != nil {
+		logrus.Errorf("failed to generate housekeeper certs for cluster %s: %v", clusterAsset.Cluster_ID, err)
+		return nil, err
+	}
+	return certs, nil
+}
+
 // Merge certificates into ignition.Config
 func mergeCertificatesIntoConfig(config *igntypes.Config, certs []utils.StorageContent) {
 	for _, file := range certs {
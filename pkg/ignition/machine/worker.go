@@ -16,11 +16,14 @@ limitations under the License.
 package machine
 
 import (
+	"fmt"
 	"nestos-kubernetes-deployer/pkg/configmanager"
 	"nestos-kubernetes-deployer/pkg/configmanager/asset"
 	"nestos-kubernetes-deployer/pkg/ignition"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
 	"github.com/sirupsen/logrus"
@@ -29,11 +32,14 @@ import (
 const (
 	WorkerIgnFilename      = "worker.ign"
 	workerMergeIgnFilename = "worker-merge.ign"
+	// The label used by housekeeper to apply per-pool upgrade policies
+	nodePoolLabel = "upgrade.housekeeper.io/node-pool"
 )
 
 type Worker struct {
 	ClusterAsset     *asset.ClusterAsset
 	BootstrapBaseurl string
+	DryRun           bool
 }
 
 func (w *Worker) GenerateFiles() error {
@@ -43,52 +49,189 @@ func (w *Worker) GenerateFiles() error {
 		return err
 	}
 
-	workerTemplateData, err := ignition.GetTmplData(w.ClusterAsset)
+	workerTemplateData, err := ignition.GetWorkerTmplData(w.ClusterAsset)
 	if err != nil {
+		logrus.Errorf("failed to get template data for cluster %s: %v", w.ClusterAsset.Cluster_ID, err)
 		return err
 	}
-	generateFile := ignition.Common{
-		UserName:        w.ClusterAsset.UserName,
-		SSHKey:          string(sshkeyContent),
-		PassWord:        w.ClusterAsset.Password,
-		NodeType:        "worker",
-		TmplData:        workerTemplateData,
-		EnabledServices: ignition.EnabledServices,
-		Config:          &igntypes.Config{},
-	}
 
-	// Generate Ignition data
-	if err := generateFile.Generate(); err != nil {
-		logrus.Errorf("failed to generate %s ignition file: %v", w.ClusterAsset.Worker[0].Hostname, err)
+	ignitionDir := filepath.Join(configmanager.GetPersistDir(), w.ClusterAsset.Cluster_ID, "ignition")
+
+	housekeeperCerts, err := getHousekeeperCerts(w.ClusterAsset)
+	if err != nil {
 		return err
 	}
 
-	if len(w.ClusterAsset.HookConf.ShellFiles) > 0 {
-		ignition.MergeHookFilesIntoConfig(generateFile.Config, w.ClusterAsset.ShellFiles)
+	// Workers in the same node pool share one ignition file,
+	// workers with a static network config, ssh host keys, disks, labels or taints get their own
+	var groups []workerGroup
+	groupWorkers := make(map[workerGroup][]int)
+	for i, worker := range w.ClusterAsset.Worker {
+		group := workerGroup{pool: worker.Pool}
+		if !worker.Network.IsEmpty() || len(worker.SSHHostKeys) > 0 || len(worker.Disks) > 0 ||
+			len(worker.Labels) > 0 || len(worker.Taints) > 0 {
+			group.hostname = worker.Hostname
+		}
+		if _, ok := groupWorkers[group]; !ok {
+			groups = append(groups, group)
+		}
+		groupWorkers[group] = append(groupWorkers[group], i)
 	}
 
-	ignitionDir := filepath.Join(configmanager.GetPersistDir(), w.ClusterAsset.Cluster_ID, "ignition")
-
-	if err := ignition.SaveFile(generateFile.Config, ignitionDir, WorkerIgnFilename); err != nil {
-		return err
+	for _, group := range groups {
+		pool := group.pool
+		poolTemplateData := *workerTemplateData
+		nodePool := &asset.NodePool{}
+		if pool != "" {
+			nodePool, err = w.ClusterAsset.GetNodePool(pool)
+			if err != nil {
+				logrus.Errorf("failed to get node pool of cluster %s: %v", w.ClusterAsset.Cluster_ID, err)
+				return err
+			}
+		}
+		var nodeLabels map[string]string
+		var nodeTaints []string
+		if group.hostname != "" {
+			worker := &w.ClusterAsset.Worker[groupWorkers[group][0]]
+			nodeLabels, nodeTaints = worker.Labels, worker.Taints
+		}
+		poolTemplateData.NodeLabels = joinLabels(pool, nodePool.Labels, nodeLabels)
+		poolTemplateData.NodeTaints = joinTaints(nodePool.Taints, nodeTaints)
+
+		generateFile := ignition.Common{
+			UserName:        w.ClusterAsset.UserName,
+			SSHKey:          string(sshkeyContent),
+			SSHKeys:         w.ClusterAsset.SSHKeys,
+			Timezone:        w.ClusterAsset.Timezone,
+			PassWord:        w.ClusterAsset.Password,
+			NodeType:        "worker",
+			TmplData:        &poolTemplateData,
+			EnabledServices: ignition.GetEnabledServices("worker"),
+			Config:          &igntypes.Config{},
+			IgnitionVersion: w.ClusterAsset.IgnitionVersion,
+			ExtraFilesDir:   w.ClusterAsset.ExtraFilesDir,
+			DryRun:          w.DryRun,
+			Registry:        &w.ClusterAsset.Registry,
+		}
+		if group.hostname != "" {
+			worker := &w.ClusterAsset.Worker[groupWorkers[group][0]]
+			if !worker.Network.IsEmpty() {
+				generateFile.Network = &worker.Network
+			}
+			generateFile.SSHHostKeys = worker.SSHHostKeys
+			generateFile.Disks = worker.Disks
+		}
+
+		// Generate Ignition data
+		if err := generateFile.Generate(); err != nil {
+			logrus.Errorf("failed to generate %s ignition file: %v", w.ClusterAsset.Worker[groupWorkers[group][0]].Hostname, err)
+			return err
+		}
+
+		if nodePool.Ignition != "" {
+			override, err := os.ReadFile(nodePool.Ignition)
+			if err != nil {
+				logrus.Errorf("failed to read ignition of node pool %s: %v", pool, err)
+				return err
+			}
+			ignition.MergeIgnitionOverride(generateFile.Config, override)
+		}
+		mergeCertificatesIntoConfig(generateFile.Config, housekeeperCerts)
+		if len(w.ClusterAsset.HookConf.ShellFiles) > 0 {
+			ignition.MergeHookFilesIntoConfig(generateFile.Config, w.ClusterAsset.ShellFiles)
+		}
+		if len(w.ClusterAsset.HookConf.UnitDropIns) > 0 {
+			ignition.MergeUnitDropInsIntoConfig(generateFile.Config, w.ClusterAsset.UnitDropIns)
+		}
+
+		data, err := ignition.Marshal(generateFile.Config)
+		if err != nil {
+			logrus.Errorf("failed to Marshal ignition config: %v", err)
+			return err
+		}
+
+		filename, mergeFilename := getWorkerIgnFilenames(group)
+		if generateFile.DryRun {
+			logrus.Infof("dry run: rendered %s with %d bytes", filename, len(data))
+			for _, i := range groupWorkers[group] {
+				w.ClusterAsset.Worker[i].CreateIgnContent = data
+			}
+			continue
+		}
+
+		if _, err := ignition.SaveFile(generateFile.Config, ignitionDir, filename); err != nil {
+			return err
+		}
+
+		mergerConfig, err := ignition.GenerateMergeIgnition(w.BootstrapBaseurl, filename, "http", nil,
+			w.ClusterAsset.IgnitionVersion)
+		if err != nil {
+			logrus.Errorf("failed to generate merge ignition of %s: %v", filename, err)
+			return err
+		}
+		if _, err := ignition.SaveFile(mergerConfig, ignitionDir, mergeFilename); err != nil {
+			return err
+		}
+
+		for _, i := range groupWorkers[group] {
+			w.ClusterAsset.Worker[i].Ignitions.CreateIgnPath = filepath.Join(ignitionDir, filename)
+			w.ClusterAsset.Worker[i].Ignitions.MergeIgnPath = filepath.Join(ignitionDir, mergeFilename)
+			w.ClusterAsset.Worker[i].CreateIgnContent = data
+		}
 	}
 
-	mergerConfig := ignition.GenerateMergeIgnition(w.BootstrapBaseurl, WorkerIgnFilename)
-	if err := ignition.SaveFile(mergerConfig, ignitionDir, workerMergeIgnFilename); err != nil {
-		return err
-	}
+	return nil
+}
 
-	data, err := ignition.Marshal(generateFile.Config)
-	if err != nil {
-		logrus.Errorf("failed to Marshal ignition config: %v", err)
-		return err
+// workerGroup identifies the workers sharing one ignition file
+type workerGroup struct {
+	pool     string
+	hostname string // only set for a worker with a static network config or ssh host keys
+}
+
+// The default pool keeps the original worker ignition file names
+func getWorkerIgnFilenames(group workerGroup) (string, string) {
+	name := group.pool
+	if group.hostname != "" {
+		name = group.hostname
+	}
+	if name == "" {
+		return WorkerIgnFilename, workerMergeIgnFilename
 	}
+	return fmt.Sprintf("worker-%s.ign", name), fmt.Sprintf("worker-%s-merge.ign", name)
+}
 
-	for i, _ := range w.ClusterAsset.Worker {
-		w.ClusterAsset.Worker[i].Ignitions.CreateIgnPath = filepath.Join(ignitionDir, WorkerIgnFilename)
-		w.ClusterAsset.Worker[i].Ignitions.MergeIgnPath = filepath.Join(ignitionDir, workerMergeIgnFilename)
-		w.ClusterAsset.Worker[i].CreateIgnContent = data
+// Join the label sets and the node pool label as sorted key=value pairs, later sets override earlier ones.
+// The node pool label is only added for the nodes of a pool and is never overridden.
+func joinLabels(pool string, labelSets ...map[string]string) string {
+	merged := make(map[string]string)
+	for _, labels := range labelSets {
+		for key, value := range labels {
+			merged[key] = value
+		}
 	}
+	if pool != "" {
+		merged[nodePoolLabel] = pool
+	}
+	pairs := make([]string, 0, len(merged))
+	for key, value := range merged {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
 
-	return nil
+// Join the taint sets in order, a taint listed more than once is kept once
+func joinTaints(taintSets ...[]string) string {
+	var taints []string
+	seen := make(map[string]bool)
+	for _, set := range taintSets {
+		for _, taint := range set {
+			if !seen[taint] {
+				seen[taint] = true
+				taints = append(taints, taint)
+			}
+		}
+	}
+	return strings.Join(taints, ",")
 }
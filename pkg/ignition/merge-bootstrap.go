@@ -16,10 +16,13 @@ limitations under the License.
 package ignition
 
 import (
+	"errors"
+	"fmt"
 	"net/url"
 
 	ignutil "github.com/coreos/ignition/v2/config/util"
 	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/vincent-petithory/dataurl"
 )
 
 // set-hostname.service用于动态设置节点hostname，
@@ -39,17 +42,37 @@ WantedBy=multi-user.target`
 	return unit
 }
 
-func GenerateMergeIgnition(bootstrapIgnitionHost string, role string) *igntypes.Config {
+/*
+GenerateMergeIgnition generates the ignition config merging the node ignition served by the bootstrap host
+Parameters:
+  - bootstrapIgnitionHost: host and port serving the node ignition
+  - role: name of the node ignition file
+  - scheme: http or https
+  - caCert: PEM encoded CA certificate trusted for https, optional
+  - ignitionVersion: ignition spec version of the config, MaxVersion when empty
+*/
+func GenerateMergeIgnition(bootstrapIgnitionHost string, role string, scheme string, caCert []byte,
+	ignitionVersion string) (*igntypes.Config, error) {
+	if bootstrapIgnitionHost == "" {
+		return nil, errors.New("bootstrap ignition host is empty")
+	}
+	if scheme != "http" && scheme != "https" {
+		return nil, fmt.Errorf("unsupported bootstrap ignition scheme %s", scheme)
+	}
+	version, err := ResolveIgnitionVersion(ignitionVersion)
+	if err != nil {
+		return nil, err
+	}
 	setHostnameUnit := createSetHostnameUnit()
 
 	ign := igntypes.Config{
 		Ignition: igntypes.Ignition{
-			Version: igntypes.MaxVersion.String(),
+			Version: version,
 			Config: igntypes.IgnitionConfig{
 				Merge: []igntypes.Resource{{
 					Source: ignutil.StrToPtr(func() *url.URL {
 						return &url.URL{
-							Scheme: "http",
+							Scheme: scheme,
 							Host:   bootstrapIgnitionHost,
 							Path:   role,
 						}
@@ -67,5 +90,10 @@ func GenerateMergeIgnition(bootstrapIgnitionHost string, role string) *igntypes.
 			},
 		},
 	}
-	return &ign
+	if scheme == "https" && len(caCert) > 0 {
+		ign.Ignition.Security.TLS.CertificateAuthorities = []igntypes.Resource{{
+			Source: ignutil.StrToPtr(dataurl.EncodeBytes(caCert)),
+		}}
+	}
+	return &ign, nil
 }
@@ -16,6 +16,7 @@ limitations under the License.
 package ignition
 
 import (
+	"crypto/sha256"
 	"os"
 	"path/filepath"
 
@@ -68,26 +69,52 @@ func AppendFiles(files []igntypes.File, file igntypes.File) []igntypes.File {
 }
 
 /*
-Save the ignition config
+Save the ignition config, the file is left untouched when it already has the same content
 Parameters:
 config - the ignition config to be saved
 filePath - the path to save the file
 fileName - the name to save the file
+Returns whether the file was written
 */
-func SaveFile(config *igntypes.Config, filePath string, fileName string) error {
+func SaveFile(config *igntypes.Config, filePath string, fileName string) (bool, error) {
 	data, err := Marshal(config)
 	if err != nil {
 		logrus.Errorf("failed to Marshal ignition config: %v", err)
-		return err
+		return false, err
 	}
 	fullPath := filepath.Join(filePath, fileName)
+	if existing, err := os.ReadFile(fullPath); err == nil && sha256.Sum256(existing) == sha256.Sum256(data) {
+		logrus.Debugf("ignition file %s is unchanged", fullPath)
+		return false, nil
+	}
 	if err := os.MkdirAll(filepath.Dir(fullPath), 0750); err != nil {
 		logrus.Errorf("failed to Mkdir: %v", err)
-		return err
+		return false, err
+	}
+
+	// 先写临时文件再重命名，中断的写入不会留下不完整的文件
+	tmpFile, err := os.CreateTemp(filepath.Dir(fullPath), filepath.Base(fullPath)+".tmp")
+	if err != nil {
+		logrus.Errorf("failed to create temporary ignition file: %v", err)
+		return false, err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		logrus.Errorf("failed to save ignition file: %v", err)
+		return false, err
+	}
+	if err := tmpFile.Close(); err != nil {
+		logrus.Errorf("failed to save ignition file: %v", err)
+		return false, err
+	}
+	if err := os.Chmod(tmpFile.Name(), 0644); err != nil {
+		logrus.Errorf("failed to save ignition file: %v", err)
+		return false, err
 	}
-	if err := os.WriteFile(fullPath, data, 0644); err != nil {
+	if err := os.Rename(tmpFile.Name(), fullPath); err != nil {
 		logrus.Errorf("failed to save ignition file: %v", err)
-		return err
+		return false, err
 	}
-	return nil
+	return true, nil
 }
@@ -0,0 +1,90 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ignition
+
+import (
+	"fmt"
+	"reflect"
+
+	ignerrors "github.com/coreos/ignition/v2/config/shared/errors"
+	ignutil "github.com/coreos/ignition/v2/config/util"
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/coreos/vcontext/path"
+	"github.com/coreos/vcontext/report"
+	"github.com/coreos/vcontext/validate"
+)
+
+// Validate runs the ignition v3.2 validation over the config and returns the first error,
+// the error names the field of the config it is found at.
+// A config of an older supported spec version is validated as v3.2, which its fields are a subset of.
+func Validate(config *igntypes.Config) error {
+	cfg := *config
+	if _, err := ResolveIgnitionVersion(cfg.Ignition.Version); cfg.Ignition.Version != "" && err == nil {
+		cfg.Ignition.Version = igntypes.MaxVersion.String()
+	}
+	r := validate.Validate(cfg, "json")
+	r.Merge(validate.ValidateCustom(cfg, "json", validateDups))
+	for _, entry := range r.Entries {
+		if entry.Kind.IsFatal() {
+			return fmt.Errorf("invalid ignition config at %s: %s", entry.Context.String(), entry.Message)
+		}
+	}
+	return nil
+}
+
+// validateDups reports the entries of a list sharing the same key, such as two files with the same path,
+// it is the duplicate check of the ignition config validation
+func validateDups(v reflect.Value, c path.ContextPath) (r report.Report) {
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	dupsLists := map[string]map[string]struct{}{}
+	ignoreDups := map[string]struct{}{}
+	if i, ok := v.Interface().(ignutil.IgnoresDups); ok {
+		ignoreDups = i.IgnoreDuplicates()
+	}
+	mergedKeys := map[string]string{}
+	if m, ok := v.Interface().(ignutil.MergesKeys); ok {
+		mergedKeys = m.MergedKeys()
+	}
+
+	for _, field := range validate.GetFields(v) {
+		if field.Type.Kind() != reflect.Slice {
+			continue
+		}
+		if _, ignored := ignoreDups[field.Name]; ignored {
+			continue
+		}
+		dupListName := field.Name
+		if mergedName, ok := mergedKeys[field.Name]; ok {
+			dupListName = mergedName
+		}
+		dupList := dupsLists[dupListName]
+		if dupList == nil {
+			dupList = make(map[string]struct{}, field.Value.Len())
+			dupsLists[dupListName] = dupList
+		}
+		for i := 0; i < field.Value.Len(); i++ {
+			key := ignutil.CallKey(field.Value.Index(i))
+			if _, isDup := dupList[key]; isDup {
+				r.AddOnError(c.Append(validate.FieldName(field, c.Tag), i), ignerrors.ErrDuplicate)
+			}
+			dupList[key] = struct{}{}
+		}
+	}
+	return
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ignition
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+)
+
+// ignitionVersionPattern matches the released ignition spec versions of v3, such as 3.1.0
+var ignitionVersionPattern = regexp.MustCompile(`^3\.([0-9]+)\.0$`)
+
+// ResolveIgnitionVersion returns the ignition spec version written to the configs, MaxVersion when empty.
+// Only v3 spec versions up to MaxVersion are supported, since the configs are built with the types of MaxVersion.
+func ResolveIgnitionVersion(version string) (string, error) {
+	if version == "" {
+		return igntypes.MaxVersion.String(), nil
+	}
+	matches := ignitionVersionPattern.FindStringSubmatch(version)
+	if matches == nil {
+		return "", fmt.Errorf("unsupported ignition version %q, expected 3.x.0", version)
+	}
+	minor, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil || minor > igntypes.MaxVersion.Minor {
+		return "", fmt.Errorf("unsupported ignition version %q, the newest supported version is %s",
+			version, igntypes.MaxVersion.String())
+	}
+	return version, nil
+}
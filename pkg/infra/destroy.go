@@ -0,0 +1,95 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infra
+
+import (
+	"fmt"
+	"nestos-kubernetes-deployer/pkg/configmanager"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DeployStages are the node groups of a cluster in the order they are deployed, each has its own terraform directory
+var DeployStages = []string{"master", "worker"}
+
+// StageDestroyer destroys the infrastructure of a node group of the cluster
+type StageDestroyer interface {
+	DestroyStage(clusterID string, stage string) error
+}
+
+// StageError is the failure to destroy a node group
+type StageError struct {
+	Stage string
+	Err   error
+}
+
+func (e *StageError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Stage, e.Err)
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// DestroyErrors is the list of node groups that failed to be destroyed
+type DestroyErrors []*StageError
+
+func (errs DestroyErrors) Error() string {
+	var msgs []string
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return "failed to destroy cluster: " + strings.Join(msgs, "; ")
+}
+
+type terraformDestroyer struct {
+	persistDir string
+}
+
+func (d *terraformDestroyer) DestroyStage(clusterID string, stage string) error {
+	return InstanceCluster(d.persistDir, clusterID, stage, 0).Destroy()
+}
+
+// DestroyCluster destroys the infrastructure of the cluster with terraform and deletes its asset files
+func DestroyCluster(clusterID string) error {
+	return DestroyClusterWithDestroyer(clusterID, &terraformDestroyer{persistDir: configmanager.GetPersistDir()})
+}
+
+// DestroyClusterWithDestroyer destroys the node groups in the reverse order of deployment,
+// a failed node group does not stop the others. The asset files hold the terraform state,
+// so they are only deleted when every node group is destroyed and the destroy can be retried.
+func DestroyClusterWithDestroyer(clusterID string, destroyer StageDestroyer) error {
+	var errs DestroyErrors
+	for i := len(DeployStages) - 1; i >= 0; i-- {
+		stage := DeployStages[i]
+		if err := destroyer.DestroyStage(clusterID, stage); err != nil {
+			logrus.Errorf("Failed to destroy %s nodes of cluster %s: %v", stage, clusterID, err)
+			errs = append(errs, &StageError{Stage: stage, Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	// delete asset files
+	if err := configmanager.Delete(clusterID); err != nil {
+		logrus.Errorf("Failed to clean the asset files of cluster %s: %v", clusterID, err)
+		return err
+	}
+	return nil
+}
@@ -33,6 +33,8 @@ import (
 
 type Platform interface {
 	SetPlatform(asset.InfraAsset)
+	// OSImage returns the image of the nodes not overriding it
+	OSImage() string
 }
 
 type OpenStack struct {
@@ -61,6 +63,10 @@ func (openstack *OpenStack) SetPlatform(infraAsset asset.InfraAsset) {
 	}
 }
 
+func (openstack *OpenStack) OSImage() string {
+	return openstack.Glance_Name
+}
+
 type Libvirt struct {
 	URI          string
 	OSImage_Path string
@@ -77,6 +83,10 @@ func (libvirt *Libvirt) SetPlatform(infraAsset asset.InfraAsset) {
 	}
 }
 
+func (libvirt *Libvirt) OSImage() string {
+	return libvirt.OSImage_Path
+}
+
 type Infra struct {
 	ClusterID string
 	Platform
@@ -93,6 +103,7 @@ type Node struct {
 	Hostname []string
 	IP       []string
 	Ign_Path []string
+	OSImage  []string
 }
 
 func (infra *Infra) Generate(conf *asset.ClusterAsset, node string) (err error) {
@@ -160,6 +171,7 @@ func (infra *Infra) Generate(conf *asset.ClusterAsset, node string) (err error)
 			worker_hostname []string
 			worker_ip       []string
 			worker_ignPath  []string
+			worker_osimage  []string
 		)
 
 		infra.Worker.Count = len(conf.Worker)
@@ -173,6 +185,17 @@ func (infra *Infra) Generate(conf *asset.ClusterAsset, node string) (err error)
 			worker_ip = append(worker_ip, worker.IP)
 			worker_hostname = append(worker_hostname, worker.Hostname)
 			worker_ignPath = append(worker_ignPath, worker.Ignitions.MergeIgnPath)
+			osImage := infra.Platform.OSImage()
+			if worker.Pool != "" {
+				pool, err := conf.GetNodePool(worker.Pool)
+				if err != nil {
+					return err
+				}
+				if pool.Image != "" {
+					osImage = pool.Image
+				}
+			}
+			worker_osimage = append(worker_osimage, osImage)
 		}
 		infra.Worker.CPU, err = convertSliceToStrings(worker_cpu)
 		if err != nil {
@@ -198,6 +221,10 @@ func (infra *Infra) Generate(conf *asset.ClusterAsset, node string) (err error)
 		if err != nil {
 			return err
 		}
+		infra.Worker.OSImage, err = convertSliceToStrings(worker_osimage)
+		if err != nil {
+			return err
+		}
 	}
 
 	switch conf.Architecture {
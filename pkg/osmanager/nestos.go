@@ -28,12 +28,13 @@ import (
 )
 
 type NestOS struct {
-	conf           *asset.ClusterAsset
-	certs          *cert.CertGenerator
-	ignitionMaster *machine.Master
-	ignitionWorker *machine.Worker
-	infraMaster    *infra.Infra
-	infraWorker    *infra.Infra
+	conf              *asset.ClusterAsset
+	certs             *cert.CertGenerator
+	ignitionMaster    *machine.Master
+	ignitionWorker    *machine.Worker
+	ignitionBootstrap *machine.Bootstrap
+	infraMaster       *infra.Infra
+	infraWorker       *infra.Infra
 }
 
 func NewNestOS(conf *asset.ClusterAsset) (*NestOS, error) {
@@ -43,6 +44,8 @@ func NewNestOS(conf *asset.ClusterAsset) (*NestOS, error) {
 	}
 	hostport := configmanager.GetBootstrapIgnHost() + ":" + configmanager.GetBootstrapIgnPort()
 	cg := cert.NewCertGenerator(conf.Cluster_ID, &conf.Master[0])
+	// 证书路径等生成结果写入正在生成的配置，而不是configmanager中保存的配置
+	cg.ClusterAsset = conf
 	return &NestOS{
 		conf:  conf,
 		certs: cg,
@@ -54,6 +57,10 @@ func NewNestOS(conf *asset.ClusterAsset) (*NestOS, error) {
 			ClusterAsset:     conf,
 			BootstrapBaseurl: hostport,
 		},
+		ignitionBootstrap: &machine.Bootstrap{
+			ClusterAsset: conf,
+			Port:         configmanager.GetBootstrapIgnPort(),
+		},
 		infraMaster: &infra.Infra{},
 		infraWorker: &infra.Infra{},
 	}, nil
@@ -76,6 +83,14 @@ func (n *NestOS) GenerateResourceFiles() error {
 		return err
 	}
 
+	// 节点从部署机获取ignition，部署机不可用时可以用bootstrap节点提供ignition
+	if err := n.ignitionBootstrap.GenerateFiles(); err != nil {
+		logrus.Errorf("failed to generate bootstrap ignition file: %v", err)
+		return err
+	}
+	logrus.Infof("Boot a node with %s and set bootstrap_ign_host to its address to serve the node ignition without nkd",
+		n.ignitionBootstrap.CreateIgnPath)
+
 	if err := n.infraMaster.Generate(n.conf, "master"); err != nil {
 		logrus.Errorf("Failed to generate master terraform file")
 		return err
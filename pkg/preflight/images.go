@@ -0,0 +1,156 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"nestos-kubernetes-deployer/pkg/configmanager"
+	"nestos-kubernetes-deployer/pkg/configmanager/asset"
+	"nestos-kubernetes-deployer/pkg/ignition"
+	"nestos-kubernetes-deployer/pkg/utils"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ImageStatus string
+
+const (
+	ImageFound        ImageStatus = "found"
+	ImageNotFound     ImageStatus = "not-found"
+	ImageUnauthorized ImageStatus = "unauthorized"
+	ImageCheckFailed  ImageStatus = "error"
+)
+
+// kubeadm 部署时需要的控制面组件镜像
+var componentImages = []string{
+	"kube-apiserver",
+	"kube-controller-manager",
+	"kube-scheduler",
+	"kube-proxy",
+}
+
+// kubeadm 各个版本默认部署的 etcd 和 coredns 镜像版本
+var addonVersions = map[int]struct {
+	etcd    string
+	coreDNS string
+}{
+	20: {etcd: "3.4.13-0", coreDNS: "1.7.0"},
+	21: {etcd: "3.4.13-0", coreDNS: "v1.8.0"},
+	22: {etcd: "3.5.0-0", coreDNS: "v1.8.4"},
+	23: {etcd: "3.5.1-0", coreDNS: "v1.8.6"},
+	24: {etcd: "3.5.3-0", coreDNS: "v1.8.6"},
+	25: {etcd: "3.5.4-0", coreDNS: "v1.9.3"},
+	26: {etcd: "3.5.6-0", coreDNS: "v1.9.3"},
+	27: {etcd: "3.5.7-0", coreDNS: "v1.10.1"},
+	28: {etcd: "3.5.9-0", coreDNS: "v1.10.1"},
+	29: {etcd: "3.5.10-0", coreDNS: "v1.11.1"},
+	30: {etcd: "3.5.12-0", coreDNS: "v1.11.1"},
+	31: {etcd: "3.5.15-0", coreDNS: "v1.11.3"},
+}
+
+// kubeadm 仅在默认仓库中使用 coredns/coredns 路径
+var defaultKubernetesRegistries = map[string]bool{
+	"k8s.gcr.io":      true,
+	"registry.k8s.io": true,
+}
+
+// ImageCheckResult is the result of checking a single image reference
+type ImageCheckResult struct {
+	Image  string
+	Status ImageStatus
+	Err    error
+}
+
+// RegistryClient resolves an image reference against its registry
+type RegistryClient interface {
+	CheckImage(image string) (ImageStatus, error)
+}
+
+// CheckImages checks that every image configured for the cluster exists in its registry,
+// the images are resolved with the registry config of the container runtime of the nodes
+func CheckImages(clusterID string) []ImageCheckResult {
+	clusterAsset, err := configmanager.GetClusterConfig(clusterID)
+	if err != nil {
+		logrus.Errorf("failed to get cluster %s config: %v", clusterID, err)
+		return []ImageCheckResult{{Status: ImageCheckFailed, Err: err}}
+	}
+	return checkImages(clusterAsset, NewRegistryClient(ignition.RuntimeRegistryConfig(clusterAsset)))
+}
+
+// CheckImagesWithClient checks the images configured for the cluster with the given registry client
+func CheckImagesWithClient(clusterID string, client RegistryClient) []ImageCheckResult {
+	clusterAsset, err := configmanager.GetClusterConfig(clusterID)
+	if err != nil {
+		logrus.Errorf("failed to get cluster %s config: %v", clusterID, err)
+		return []ImageCheckResult{{Status: ImageCheckFailed, Err: err}}
+	}
+	return checkImages(clusterAsset, client)
+}
+
+func checkImages(clusterAsset *asset.ClusterAsset, client RegistryClient) []ImageCheckResult {
+	var results []ImageCheckResult
+	for _, image := range getClusterImages(clusterAsset) {
+		status, err := client.CheckImage(image)
+		if err != nil {
+			logrus.Debugf("failed to check image %s: %v", image, err)
+		}
+		results = append(results, ImageCheckResult{Image: image, Status: status, Err: err})
+	}
+	return results
+}
+
+// getClusterImages returns the images the nodes pull, named like kubeadm and the runtime config name them
+func getClusterImages(clusterAsset *asset.ClusterAsset) []string {
+	registry := clusterAsset.Kubernetes.ImageRegistry
+	kubeVersion := clusterAsset.Kubernetes.KubernetesVersion
+
+	var images []string
+	if clusterAsset.Kubernetes.ReleaseImageURL != "" {
+		images = append(images, clusterAsset.Kubernetes.ReleaseImageURL)
+	}
+	if clusterAsset.Kubernetes.PauseImage != "" {
+		// 与运行时配置中的 sandbox 镜像一致
+		images = append(images, registry+"/"+clusterAsset.Kubernetes.PauseImage)
+	}
+	if kubeVersion != "" {
+		for _, component := range componentImages {
+			images = append(images, registry+"/"+component+":"+kubeVersion)
+		}
+		images = append(images, getAddonImages(registry, kubeVersion)...)
+	}
+	if clusterAsset.Housekeeper.DeployHousekeeper {
+		images = append(images, clusterAsset.Housekeeper.OperatorImageUrl, clusterAsset.Housekeeper.ControllerImageUrl)
+	}
+	return images
+}
+
+// getAddonImages returns the etcd and coredns images kubeadm deploys for the kubernetes version
+func getAddonImages(registry string, kubeVersion string) []string {
+	minor, ok := utils.KubeMinorVersion(kubeVersion)
+	if !ok {
+		return nil
+	}
+	versions, ok := addonVersions[minor]
+	if !ok {
+		logrus.Warnf("unknown etcd and coredns versions of kubernetes %s, skip checking them", kubeVersion)
+		return nil
+	}
+	coreDNS := registry + "/coredns:" + versions.coreDNS
+	if defaultKubernetesRegistries[registry] && minor >= 21 {
+		coreDNS = registry + "/coredns/coredns:" + versions.coreDNS
+	}
+	return []string{registry + "/etcd:" + versions.etcd, coreDNS}
+}
@@ -0,0 +1,379 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"nestos-kubernetes-deployer/pkg/configmanager/asset"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultRegistry     = "docker.io"
+	defaultRegistryHost = "registry-1.docker.io"
+	defaultTag          = "latest"
+)
+
+var manifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+}
+
+// imageReference is an image split into the registry host, repository and tag or digest
+type imageReference struct {
+	Registry   string
+	Repository string
+	Reference  string
+}
+
+func parseImageReference(image string) (*imageReference, error) {
+	if image == "" {
+		return nil, errors.New("empty image reference")
+	}
+
+	ref := &imageReference{Registry: defaultRegistry}
+	name := image
+	if i := strings.Index(name, "/"); i >= 0 {
+		host := name[:i]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			ref.Registry = host
+			name = name[i+1:]
+		}
+	}
+	if ref.Registry == defaultRegistry && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Repository, ref.Reference = name[:i], name[i+1:]
+	} else if i := strings.LastIndex(name, ":"); i >= 0 {
+		ref.Repository, ref.Reference = name[:i], name[i+1:]
+	} else {
+		ref.Repository, ref.Reference = name, defaultTag
+	}
+	if ref.Repository == "" || ref.Reference == "" {
+		return nil, errors.Errorf("invalid image reference %s", image)
+	}
+	return ref, nil
+}
+
+// RegistryAuth is the credential of a registry
+type RegistryAuth struct {
+	Username string
+	Password string
+}
+
+// defaultAuthFiles are the auth files podman reads the registry credentials from, REGISTRY_AUTH_FILE replaces them
+func defaultAuthFiles() []string {
+	if authFile := os.Getenv("REGISTRY_AUTH_FILE"); authFile != "" {
+		return []string{authFile}
+	}
+	var authFiles []string
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		authFiles = append(authFiles, filepath.Join(runtimeDir, "containers", "auth.json"))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		authFiles = append(authFiles, filepath.Join(home, ".docker", "config.json"))
+	}
+	return authFiles
+}
+
+// LoadAuthFile reads the credentials of a containers-auth.json(5) or docker config file,
+// the keys are a registry optionally followed by a repository path
+func LoadAuthFile(authFile string) (map[string]RegistryAuth, error) {
+	content, err := ioutil.ReadFile(authFile)
+	if err != nil {
+		return nil, err
+	}
+	var config struct {
+		Auths map[string]struct {
+			Auth string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(content, &config); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse auth file %s", authFile)
+	}
+
+	auths := make(map[string]RegistryAuth)
+	for key, entry := range config.Auths {
+		decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid auth of %s in %s", key, authFile)
+		}
+		credential := strings.SplitN(string(decoded), ":", 2)
+		if len(credential) != 2 {
+			return nil, errors.Errorf("invalid auth of %s in %s", key, authFile)
+		}
+		auths[normalizeAuthKey(key)] = RegistryAuth{Username: credential[0], Password: credential[1]}
+	}
+	return auths, nil
+}
+
+// normalizeAuthKey strips the scheme and api path docker writes, e.g. https://index.docker.io/v1/ becomes docker.io
+func normalizeAuthKey(key string) string {
+	if i := strings.Index(key, "://"); i >= 0 {
+		key = key[i+3:]
+		if j := strings.Index(key, "/"); j >= 0 {
+			key = key[:j]
+		}
+	}
+	key = strings.TrimSuffix(key, "/")
+	if key == "index.docker.io" || key == defaultRegistryHost {
+		return defaultRegistry
+	}
+	return key
+}
+
+// HTTPRegistryClient checks images with the registry v2 API, the images are resolved with the mirrors
+// and insecure registries of the registry config like the container runtime of the nodes does
+type HTTPRegistryClient struct {
+	Registry asset.RegistryConfig
+	// Auths maps a registry, optionally followed by a repository path, to its credential
+	Auths  map[string]RegistryAuth
+	Client *http.Client
+	// InsecureClient is used for insecure registries, it does not verify the tls certificate
+	InsecureClient *http.Client
+}
+
+// NewRegistryClient returns a client resolving the images with the registry config,
+// the credentials are read from the auth files podman uses
+func NewRegistryClient(registry *asset.RegistryConfig) *HTTPRegistryClient {
+	c := &HTTPRegistryClient{
+		Auths:  make(map[string]RegistryAuth),
+		Client: &http.Client{Timeout: 30 * time.Second},
+		InsecureClient: &http.Client{
+			Timeout: 30 * time.Second,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		},
+	}
+	if registry != nil {
+		c.Registry = *registry
+	}
+	authFiles := defaultAuthFiles()
+	// 与 podman 相同，前面的认证文件优先
+	for i := len(authFiles) - 1; i >= 0; i-- {
+		auths, err := LoadAuthFile(authFiles[i])
+		if err != nil {
+			continue
+		}
+		for key, auth := range auths {
+			c.Auths[key] = auth
+		}
+	}
+	return c
+}
+
+// CheckImage looks up the manifest of the image without downloading it, the mirrors of the image are
+// tried in order before its registry. The result of the registry is returned when no mirror has the image.
+func (c *HTTPRegistryClient) CheckImage(image string) (ImageStatus, error) {
+	ref, err := parseImageReference(image)
+	if err != nil {
+		return ImageCheckFailed, err
+	}
+
+	status, err := ImageCheckFailed, error(nil)
+	for _, location := range c.locations(ref) {
+		status, err = c.checkLocation(location, ref.Reference)
+		if status == ImageFound {
+			return status, nil
+		}
+	}
+	return status, err
+}
+
+// locations returns the mirrors of the image repository followed by the repository itself,
+// the mirrors of the longest matching registry prefix are used like in containers-registries.conf(5)
+func (c *HTTPRegistryClient) locations(ref *imageReference) []string {
+	name := ref.Registry + "/" + ref.Repository
+	prefix := ""
+	for registry := range c.Registry.Mirrors {
+		if (name == registry || strings.HasPrefix(name, registry+"/")) && len(registry) > len(prefix) {
+			prefix = registry
+		}
+	}
+
+	var locations []string
+	if prefix != "" {
+		for _, mirror := range c.Registry.Mirrors[prefix] {
+			locations = append(locations, mirror+name[len(prefix):])
+		}
+	}
+	return append(locations, name)
+}
+
+// checkLocation checks the manifest of the repository location, insecure registries are accessed
+// with unverified tls and over plain http when https fails
+func (c *HTTPRegistryClient) checkLocation(location string, reference string) (ImageStatus, error) {
+	host, repository := location, ""
+	if i := strings.Index(location, "/"); i >= 0 {
+		host, repository = location[:i], location[i+1:]
+	}
+	if host == defaultRegistry {
+		host = defaultRegistryHost
+	}
+
+	client, schemes := c.Client, []string{"https"}
+	if c.Registry.IsInsecure(location) {
+		client, schemes = c.InsecureClient, []string{"https", "http"}
+	}
+
+	var err error
+	for _, scheme := range schemes {
+		manifestURL := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", scheme, host, repository, reference)
+		var status ImageStatus
+		if status, err = checkManifest(client, manifestURL, c.auth(location)); err == nil {
+			return status, nil
+		}
+	}
+	return ImageCheckFailed, err
+}
+
+// auth returns the credential of the most specific auth key matching the location
+func (c *HTTPRegistryClient) auth(location string) *RegistryAuth {
+	for {
+		if auth, ok := c.Auths[location]; ok {
+			return &auth
+		}
+		i := strings.LastIndex(location, "/")
+		if i < 0 {
+			return nil
+		}
+		location = location[:i]
+	}
+}
+
+func checkManifest(client *http.Client, manifestURL string, auth *RegistryAuth) (ImageStatus, error) {
+	resp, err := headManifest(client, manifestURL, "")
+	if err != nil {
+		return ImageCheckFailed, err
+	}
+	// 匿名访问也需要先向认证服务获取token
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("Www-Authenticate")
+		authorization := ""
+		if strings.HasPrefix(challenge, "Bearer ") {
+			token, err := getToken(client, challenge, auth)
+			if err != nil {
+				return ImageCheckFailed, err
+			}
+			if token != "" {
+				authorization = "Bearer " + token
+			}
+		} else if auth != nil {
+			authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(auth.Username+":"+auth.Password))
+		}
+		if authorization != "" {
+			if resp, err = headManifest(client, manifestURL, authorization); err != nil {
+				return ImageCheckFailed, err
+			}
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return ImageFound, nil
+	case http.StatusNotFound:
+		return ImageNotFound, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ImageUnauthorized, nil
+	default:
+		return ImageCheckFailed, errors.Errorf("unexpected status %s requesting %s", resp.Status, manifestURL)
+	}
+}
+
+func headManifest(client *http.Client, manifestURL string, authorization string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ","))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to request %s", manifestURL)
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// getToken requests a bearer token from the auth service named in the registry challenge
+func getToken(client *http.Client, challenge string, auth *RegistryAuth) (string, error) {
+	params := map[string]string{}
+	for _, param := range strings.Split(strings.TrimPrefix(challenge, "Bearer "), ",") {
+		kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(kv) == 2 {
+			params[kv[0]] = strings.Trim(kv[1], `"`)
+		}
+	}
+	if params["realm"] == "" {
+		return "", errors.Errorf("invalid auth challenge %s", challenge)
+	}
+
+	query := url.Values{}
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+	req, err := http.NewRequest(http.MethodGet, params["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	if auth != nil {
+		req.SetBasicAuth(auth.Username, auth.Password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to request registry token")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", nil
+	}
+
+	var tokenResp struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", errors.Wrap(err, "failed to decode registry token")
+	}
+	if tokenResp.Token != "" {
+		return tokenResp.Token, nil
+	}
+	return tokenResp.AccessToken, nil
+}
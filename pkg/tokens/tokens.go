@@ -0,0 +1,77 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokens
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"regexp"
+)
+
+const (
+	// kubeadm bootstrap token 的格式为 <token-id>.<token-secret>
+	tokenCharset      = "abcdefghijklmnopqrstuvwxyz0123456789"
+	tokenIDLength     = 6
+	tokenSecretLength = 16
+)
+
+// BootstrapTokenPattern matches a valid kubeadm bootstrap token
+var BootstrapTokenPattern = regexp.MustCompile(`^[a-z0-9]{6}\.[a-z0-9]{16}$`)
+
+// GenerateBootstrapToken generates a random kubeadm bootstrap token, such as abcdef.0123456789abcdef
+func GenerateBootstrapToken() (string, error) {
+	id, err := randomString(tokenIDLength)
+	if err != nil {
+		return "", err
+	}
+	secret, err := randomString(tokenSecretLength)
+	if err != nil {
+		return "", err
+	}
+	return id + "." + secret, nil
+}
+
+func randomString(length int) (string, error) {
+	max := big.NewInt(int64(len(tokenCharset)))
+	b := make([]byte, length)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate bootstrap token: %v", err)
+		}
+		b[i] = tokenCharset[n.Int64()]
+	}
+	return string(b), nil
+}
+
+// ComputeCACertHash returns the sha256:<hex> hash of the public key of the PEM encoded CA certificate
+// used by kubeadm join --discovery-token-ca-cert-hash, or an empty string when the certificate is invalid
+func ComputeCACertHash(caCertPEM []byte) string {
+	block, _ := pem.Decode(caCertPEM)
+	if block == nil {
+		return ""
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(cert.RawSubjectPublicKeyInfo))
+}
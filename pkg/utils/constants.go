@@ -46,7 +46,20 @@ const (
 	KubeletConfig     = "/etc/kubernetes/kubelet.conf"
 	ControllerManager = "/etc/kubernetes/controller-manager.conf"
 	SchedulerConf     = "/etc/kubernetes/scheduler.conf"
+	KubeProxyConf     = "/etc/kubernetes/kube-proxy.conf"
+
+	// 关闭kubelet服务端证书轮换时预先签发的kubelet服务端证书
+	KubeletServingCrt = "/var/lib/kubelet/pki/kubelet.crt"
+	KubeletServingKey = "/var/lib/kubelet/pki/kubelet.key"
+
+	// housekeeper 控制器与守护进程之间 gRPC 连接的双向 TLS 证书
+	HousekeeperCaCrt         = "/etc/nkd/housekeeper/pki/ca.crt"
+	HousekeeperDaemonCrt     = "/etc/nkd/housekeeper/pki/daemon.crt"
+	HousekeeperDaemonKey     = "/etc/nkd/housekeeper/pki/daemon.key"
+	HousekeeperControllerCrt = "/etc/nkd/housekeeper/pki/controller.crt"
+	HousekeeperControllerKey = "/etc/nkd/housekeeper/pki/controller.key"
 
 	CertFileMode         os.FileMode = 0644
+	KeyFileMode          os.FileMode = 0600
 	DeployConfigFileMode os.FileMode = 0640
 )
@@ -46,6 +46,8 @@ func FetchAndUnmarshalUrl(url string, tmplData interface{}) ([]byte, error) {
 	return data, nil
 }
 
+// GetCompleteFile reads the file, a .template file is rendered with tmplData and returned without the suffix.
+// Besides the built-in functions the templates can use the functions listed in templatefuncs.go.
 func GetCompleteFile(name string, file io.Reader, tmplData interface{}) (realName string, data []byte, err error) {
 	data, err = io.ReadAll(file)
 	if err != nil {
@@ -54,24 +56,27 @@ func GetCompleteFile(name string, file io.Reader, tmplData interface{}) (realNam
 	}
 	if filepath.Ext(name) == ".template" {
 		name = strings.TrimSuffix(name, ".template")
-		tmpl := template.New(name)
+		tmpl := template.New(name).Funcs(TemplateFuncs())
 		tmpl, err := tmpl.Parse(string(data))
 		if err != nil {
 			logrus.Errorf("Error parsing template for file %s: %v\n", name, err)
 			return "", nil, err
 		}
-		stringData := applyTmplData(tmpl, tmplData)
+		stringData, err := applyTmplData(tmpl, tmplData)
+		if err != nil {
+			return "", nil, err
+		}
 		data = []byte(stringData)
 	}
 
 	return name, data, nil
 }
 
-func applyTmplData(tmpl *template.Template, data interface{}) string {
+func applyTmplData(tmpl *template.Template, data interface{}) (string, error) {
 	buf := &bytes.Buffer{}
 	if err := tmpl.Execute(buf, data); err != nil {
 		logrus.Errorf("Error applying template: %v\n", err)
-		panic(err)
+		return "", err
 	}
-	return buf.String()
+	return buf.String(), nil
 }
@@ -0,0 +1,108 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// Functions available to the templates rendered by GetCompleteFile besides the built-in ones:
+//
+//	b64enc VALUE          base64 encodes the value, e.g. {{ .Content | b64enc }}
+//	default DEFAULT VALUE the value, or DEFAULT when the value is empty, e.g. {{ .Runtime | default "isulad" }}
+//	indent N VALUE        indents every line of the value by N spaces, used to embed yaml in yaml
+//	quote VALUE           the value as a double-quoted string
+//
+// Callers add their own functions with RegisterTemplateFuncs.
+var (
+	templateFuncsMu sync.RWMutex
+	templateFuncs   = template.FuncMap{
+		"b64enc":  b64enc,
+		"default": defaultValue,
+		"indent":  indent,
+		"quote":   quote,
+	}
+)
+
+// RegisterTemplateFuncs adds the functions to the templates rendered by GetCompleteFile,
+// a function with the name of an existing one replaces it
+func RegisterTemplateFuncs(funcs template.FuncMap) {
+	templateFuncsMu.Lock()
+	defer templateFuncsMu.Unlock()
+	for name, fn := range funcs {
+		templateFuncs[name] = fn
+	}
+}
+
+// TemplateFuncs returns a copy of the functions available to the templates
+func TemplateFuncs() template.FuncMap {
+	templateFuncsMu.RLock()
+	defer templateFuncsMu.RUnlock()
+	funcs := make(template.FuncMap, len(templateFuncs))
+	for name, fn := range templateFuncs {
+		funcs[name] = fn
+	}
+	return funcs
+}
+
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func b64enc(value interface{}) string {
+	return base64.StdEncoding.EncodeToString([]byte(toString(value)))
+}
+
+func defaultValue(def interface{}, value interface{}) interface{} {
+	if value == nil {
+		return def
+	}
+	if v := reflect.ValueOf(value); v.IsZero() || (isCollection(v) && v.Len() == 0) {
+		return def
+	}
+	return value
+}
+
+func isCollection(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice:
+		return true
+	}
+	return false
+}
+
+func indent(spaces int, value interface{}) string {
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.ReplaceAll(toString(value), "\n", "\n"+pad)
+}
+
+func quote(value interface{}) string {
+	return strconv.Quote(toString(value))
+}
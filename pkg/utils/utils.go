@@ -21,6 +21,7 @@ import (
 	"net"
 	"os/user"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -30,12 +31,14 @@ const (
 	v1beta1 version = iota + 1
 	v1beta2
 	v1beta3
+	v1beta4
 )
 
 var versionMap = map[version]string{
 	v1beta1: "v1beta1",
 	v1beta2: "v1beta2",
 	v1beta3: "v1beta3",
+	v1beta4: "v1beta4",
 }
 
 func GetKubernetesApiVersion(versionNumber uint) (string, error) {
@@ -49,6 +52,43 @@ func GetKubernetesApiVersion(versionNumber uint) (string, error) {
 	return "", fmt.Errorf("unsupported kubernetes api version number: %d", versionNumber)
 }
 
+// ResolveKubeadmAPIVersion returns the kubeadm config api version matching the kubernetes version,
+// v1beta3 is used when the kubernetes version can not be parsed
+func ResolveKubeadmAPIVersion(kubeVersion string) string {
+	minor, ok := KubeMinorVersion(kubeVersion)
+	if !ok {
+		return versionMap[v1beta3]
+	}
+	// v1beta2 自 1.15 起可用，v1beta3 自 1.22 起可用，v1beta4 自 1.31 起可用
+	switch {
+	case minor >= 31:
+		return versionMap[v1beta4]
+	case minor >= 22:
+		return versionMap[v1beta3]
+	case minor >= 15:
+		return versionMap[v1beta2]
+	default:
+		return versionMap[v1beta1]
+	}
+}
+
+// KubeMinorVersion returns the minor version of a kubernetes 1.x version such as v1.23.10
+func KubeMinorVersion(kubeVersion string) (int, bool) {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(kubeVersion), "v"), ".")
+	if len(parts) < 2 {
+		return 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil || major != 1 {
+		return 0, false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, false
+	}
+	return minor, true
+}
+
 func GetDefaultPubKeyPath() string {
 	return filepath.Join(getSysHome(), ".ssh", "id_rsa.pub")
 }
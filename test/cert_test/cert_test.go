@@ -0,0 +1,571 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cert_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"nestos-kubernetes-deployer/pkg/cert"
+	"nestos-kubernetes-deployer/pkg/configmanager"
+	"nestos-kubernetes-deployer/pkg/configmanager/asset"
+	"nestos-kubernetes-deployer/pkg/configmanager/globalconfig"
+	"nestos-kubernetes-deployer/pkg/utils"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const testClusterID = "cluster"
+
+func setupClusterConfig(t *testing.T) *asset.ClusterAsset {
+	configmanager.GlobalConfig = &globalconfig.GlobalConfig{
+		PersistDir: t.TempDir(),
+	}
+	clusterAsset := &asset.ClusterAsset{
+		Cluster_ID: testClusterID,
+		Master: []asset.NodeAsset{
+			{Hostname: "k8s-master01", IP: "192.168.132.11"},
+		},
+		Kubernetes: asset.Kubernetes{
+			ApiServerEndpoint: "192.168.132.11:6443",
+			Network: asset.Network{
+				ServiceSubnet: "10.96.0.0/16",
+			},
+		},
+	}
+	configmanager.SetClusterConfig(clusterAsset)
+	return clusterAsset
+}
+
+func TestGenerateAllFilesAsIgnition(t *testing.T) {
+	clusterAsset := setupClusterConfig(t)
+
+	cg := cert.NewCertGenerator(testClusterID, &clusterAsset.Master[0])
+	files, err := cg.GenerateAllFilesAsIgnition()
+	if err != nil {
+		t.Fatalf("Error generating ignition files: %v", err)
+	}
+
+	modes := make(map[string]int, len(files))
+	for _, file := range files {
+		if file.Mode == nil {
+			t.Fatalf("File %s has no mode", file.Path)
+		}
+		modes[file.Path] = *file.Mode
+	}
+
+	expected := map[string]int{
+		utils.CaCrt:                     int(utils.CertFileMode),
+		utils.CaKey:                     int(utils.KeyFileMode),
+		utils.EtcdCaCrt:                 int(utils.CertFileMode),
+		utils.EtcdCaKey:                 int(utils.KeyFileMode),
+		utils.FrontProxyCaCrt:           int(utils.CertFileMode),
+		utils.FrontProxyCaKey:           int(utils.KeyFileMode),
+		utils.SaPub:                     int(utils.CertFileMode),
+		utils.SaKey:                     int(utils.KeyFileMode),
+		utils.ServerCrt:                 int(utils.CertFileMode),
+		utils.ServerKey:                 int(utils.KeyFileMode),
+		utils.PeerCrt:                   int(utils.CertFileMode),
+		utils.PeerKey:                   int(utils.KeyFileMode),
+		utils.ApiserverCrt:              int(utils.CertFileMode),
+		utils.ApiserverKey:              int(utils.KeyFileMode),
+		utils.FrontProxyClientCrt:       int(utils.CertFileMode),
+		utils.FrontProxyClientKey:       int(utils.KeyFileMode),
+		utils.ApiserverKubeletClientCrt: int(utils.CertFileMode),
+		utils.ApiserverKubeletClientKey: int(utils.KeyFileMode),
+		utils.ApiserverEtcdClientCrt:    int(utils.CertFileMode),
+		utils.ApiserverEtcdClientKey:    int(utils.KeyFileMode),
+		utils.HealthcheckClientCrt:      int(utils.CertFileMode),
+		utils.HealthcheckClientKey:      int(utils.KeyFileMode),
+		utils.AdminConfig:               int(utils.CertFileMode),
+		utils.ControllerManager:         int(utils.CertFileMode),
+		utils.SchedulerConf:             int(utils.CertFileMode),
+		utils.KubeletConfig:             int(utils.CertFileMode),
+	}
+
+	for path, mode := range expected {
+		got, ok := modes[path]
+		if !ok {
+			t.Errorf("Expected file %s in ignition files", path)
+			continue
+		}
+		if got != mode {
+			t.Errorf("Expected mode %o for %s, got %o", mode, path, got)
+		}
+	}
+}
+
+func TestVerifyCertChain(t *testing.T) {
+	caCert, err := cert.GenerateAllCA("", "", "kubernetes", []string{"kubernetes"}, cert.DefaultCAValidity)
+	if err != nil {
+		t.Fatalf("Error generating CA: %v", err)
+	}
+	otherCACert, err := cert.GenerateAllCA("", "", "etcd-ca", []string{"etcd-ca"}, cert.DefaultCAValidity)
+	if err != nil {
+		t.Fatalf("Error generating CA: %v", err)
+	}
+
+	extKeyUsage := []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	leafCert, err := cert.GenerateAllSignedCert("kube-apiserver", nil, []string{"kubernetes"},
+		extKeyUsage, nil, caCert.CertRaw, caCert.KeyRaw, cert.DefaultCertValidity)
+	if err != nil {
+		t.Fatalf("Error generating signed cert: %v", err)
+	}
+
+	chain := cert.CertChain{
+		Name:         "apiserver",
+		CACert:       caCert.CertRaw,
+		Cert:         leafCert.CertRaw,
+		ExtKeyUsages: extKeyUsage,
+		DNSName:      "kubernetes",
+	}
+	if err := cert.VerifyCertChain(chain); err != nil {
+		t.Errorf("Expected certificate to verify, got %v", err)
+	}
+
+	// A leaf signed by another CA must not verify
+	mismatched := chain
+	mismatched.CACert = otherCACert.CertRaw
+	if err := cert.VerifyCertChain(mismatched); err == nil {
+		t.Errorf("Expected an error verifying against a mismatched CA")
+	}
+
+	// A leaf without the expected SAN must not verify
+	wrongSAN := chain
+	wrongSAN.DNSName = "etcd"
+	if err := cert.VerifyCertChain(wrongSAN); err == nil {
+		t.Errorf("Expected an error verifying an unexpected SAN")
+	}
+
+	// A server cert must not verify for client usage
+	wrongUsage := chain
+	wrongUsage.ExtKeyUsages = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	if err := cert.VerifyCertChain(wrongUsage); err == nil {
+		t.Errorf("Expected an error verifying an unexpected key usage")
+	}
+}
+
+func TestEtcdClientSANs(t *testing.T) {
+	clusterAsset := setupClusterConfig(t)
+	clusterAsset.CertAsset.EtcdClientSANs = []string{"etcd.example.com", "10.0.0.5"}
+
+	cg := cert.NewCertGenerator(testClusterID, &clusterAsset.Master[0])
+	if err := cg.GenerateAllFiles(); err != nil {
+		t.Fatalf("Error generating certs: %v", err)
+	}
+
+	var certRaw []byte
+	for _, file := range clusterAsset.Master[0].Certs {
+		if file.Path == utils.ApiserverEtcdClientCrt {
+			certRaw = file.Content
+		}
+	}
+	if certRaw == nil {
+		t.Fatalf("Expected %s to be generated", utils.ApiserverEtcdClientCrt)
+	}
+
+	clientCert, err := cert.PemToCertificate(certRaw)
+	if err != nil {
+		t.Fatalf("Error parsing certificate: %v", err)
+	}
+	if len(clientCert.DNSNames) != 1 || clientCert.DNSNames[0] != "etcd.example.com" {
+		t.Errorf("Expected DNS SANs [etcd.example.com], got %v", clientCert.DNSNames)
+	}
+	if len(clientCert.IPAddresses) != 1 || clientCert.IPAddresses[0].String() != "10.0.0.5" {
+		t.Errorf("Expected IP SANs [10.0.0.5], got %v", clientCert.IPAddresses)
+	}
+}
+
+func TestGenerateAllFilesIntoClusterAsset(t *testing.T) {
+	stored := setupClusterConfig(t)
+	clusterAsset := stored.DeepCopy()
+
+	cg := cert.NewCertGenerator(testClusterID, &clusterAsset.Master[0])
+	cg.ClusterAsset = clusterAsset
+	if err := cg.GenerateAllFiles(); err != nil {
+		t.Fatalf("Error generating certs: %v", err)
+	}
+	if clusterAsset.CertAsset.RootCaCertPath == "" || clusterAsset.Kubernetes.AdminKubeConfig == "" {
+		t.Errorf("Expected the generated paths in the given cluster asset, got %+v", clusterAsset.CertAsset)
+	}
+	// The stored asset is left for the caller to replace once generation succeeded
+	if stored.CertAsset.RootCaCertPath != "" || stored.Kubernetes.AdminKubeConfig != "" {
+		t.Errorf("Expected the stored cluster asset to be unchanged, got %+v", stored.CertAsset)
+	}
+}
+
+func TestGenerateKubeConfigs(t *testing.T) {
+	clusterAsset := setupClusterConfig(t)
+	if _, err := cert.GenerateKubeConfigs(testClusterID, "192.168.132.11:6443"); err == nil {
+		t.Errorf("Expected an error generating kubeconfigs before the root CA")
+	}
+
+	cg := cert.NewCertGenerator(testClusterID, &clusterAsset.Master[0])
+	if err := cg.GenerateAllFiles(); err != nil {
+		t.Fatalf("Error generating certs: %v", err)
+	}
+	kubeconfigs, err := cert.GenerateKubeConfigs(testClusterID, "192.168.132.11:6443")
+	if err != nil {
+		t.Fatalf("Error generating kubeconfigs: %v", err)
+	}
+
+	expectedUsers := map[string]string{
+		utils.AdminConfig:       "kubernetes-admin",
+		utils.ControllerManager: "system:kube-controller-manager",
+		utils.SchedulerConf:     "system:kube-scheduler",
+		utils.KubeProxyConf:     "system:kube-proxy",
+	}
+	if len(kubeconfigs) != len(expectedUsers) {
+		t.Fatalf("Expected %d kubeconfigs, got %d", len(expectedUsers), len(kubeconfigs))
+	}
+	for _, kubeconfig := range kubeconfigs {
+		user, ok := expectedUsers[kubeconfig.Path]
+		if !ok {
+			t.Errorf("Unexpected kubeconfig %s", kubeconfig.Path)
+			continue
+		}
+		if kubeconfig.Mode != int(utils.KeyFileMode) {
+			t.Errorf("Expected mode %o for %s, got %o", utils.KeyFileMode, kubeconfig.Path, kubeconfig.Mode)
+		}
+
+		config, err := clientcmd.Load(kubeconfig.Content)
+		if err != nil {
+			t.Fatalf("Error parsing %s: %v", kubeconfig.Path, err)
+		}
+		context, ok := config.Contexts[config.CurrentContext]
+		if !ok || context.AuthInfo != user {
+			t.Fatalf("Expected current context of %s to use %s, got %+v", kubeconfig.Path, user, context)
+		}
+		if server := config.Clusters[context.Cluster].Server; server != "https://192.168.132.11:6443" {
+			t.Errorf("Expected server https://192.168.132.11:6443 in %s, got %s", kubeconfig.Path, server)
+		}
+
+		clientCert, err := cert.PemToCertificate(config.AuthInfos[user].ClientCertificateData)
+		if err != nil {
+			t.Fatalf("Error parsing client certificate of %s: %v", kubeconfig.Path, err)
+		}
+		if clientCert.Subject.CommonName != user {
+			t.Errorf("Expected common name %s in %s, got %s", user, kubeconfig.Path, clientCert.Subject.CommonName)
+		}
+		if err := cert.VerifyCertChain(cert.CertChain{
+			Name:         kubeconfig.Path,
+			CACert:       config.Clusters[context.Cluster].CertificateAuthorityData,
+			Cert:         config.AuthInfos[user].ClientCertificateData,
+			ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}); err != nil {
+			t.Errorf("Error verifying client certificate of %s: %v", kubeconfig.Path, err)
+		}
+		if kubeconfig.Path == utils.AdminConfig &&
+			(len(clientCert.Subject.Organization) != 1 || clientCert.Subject.Organization[0] != "system:masters") {
+			t.Errorf("Expected admin organization system:masters, got %v", clientCert.Subject.Organization)
+		}
+	}
+}
+
+func TestCertValidity(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		name             string
+		caValidityDays   int
+		certValidityDays int
+		caValidity       time.Duration
+		certValidity     time.Duration
+	}{
+		{name: "default", caValidity: 3650 * day, certValidity: 365 * day},
+		{name: "custom", caValidityDays: 30, certValidityDays: 7, caValidity: 30 * day, certValidity: 7 * day},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterAsset := setupClusterConfig(t)
+			clusterAsset.CertAsset.CAValidityDays = tt.caValidityDays
+			clusterAsset.CertAsset.CertValidityDays = tt.certValidityDays
+
+			cg := cert.NewCertGenerator(testClusterID, &clusterAsset.Master[0])
+			if err := cg.GenerateAllFiles(); err != nil {
+				t.Fatalf("Error generating certs: %v", err)
+			}
+
+			expected := map[string]time.Duration{
+				utils.CaCrt:           tt.caValidity,
+				utils.EtcdCaCrt:       tt.caValidity,
+				utils.FrontProxyCaCrt: tt.caValidity,
+				utils.ApiserverCrt:    tt.certValidity,
+				utils.ServerCrt:       tt.certValidity,
+			}
+			for _, file := range clusterAsset.Master[0].Certs {
+				validity, ok := expected[file.Path]
+				if !ok {
+					continue
+				}
+				delete(expected, file.Path)
+				certificate, err := cert.PemToCertificate(file.Content)
+				if err != nil {
+					t.Fatalf("Error parsing %s: %v", file.Path, err)
+				}
+				if diff := time.Until(certificate.NotAfter) - validity; diff > time.Minute || diff < -time.Minute {
+					t.Errorf("Expected %s to expire in %v, got %v", file.Path, validity, certificate.NotAfter)
+				}
+			}
+			if len(expected) > 0 {
+				t.Errorf("Expected certs %v to be generated", expected)
+			}
+		})
+	}
+
+	if _, err := cert.GenerateAllCA("", "", "kubernetes", []string{"kubernetes"}, 0); err == nil {
+		t.Errorf("Expected an error generating a CA without validity")
+	}
+}
+
+// housekeeperKeyPairs returns the daemon and controller key pairs and the CA pool of the housekeeper certs
+func housekeeperKeyPairs(t *testing.T, certs []utils.StorageContent) (tls.Certificate, tls.Certificate, *x509.CertPool) {
+	contents := make(map[string][]byte, len(certs))
+	for _, file := range certs {
+		contents[file.Path] = file.Content
+	}
+	daemon, err := tls.X509KeyPair(contents[utils.HousekeeperDaemonCrt], contents[utils.HousekeeperDaemonKey])
+	if err != nil {
+		t.Fatalf("Error loading daemon key pair: %v", err)
+	}
+	controller, err := tls.X509KeyPair(contents[utils.HousekeeperControllerCrt], contents[utils.HousekeeperControllerKey])
+	if err != nil {
+		t.Fatalf("Error loading controller key pair: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(contents[utils.HousekeeperCaCrt]) {
+		t.Fatal("Error loading housekeeper CA")
+	}
+	return daemon, controller, pool
+}
+
+// mutualTLSHandshake connects the controller to the daemon like the housekeeper grpc connection,
+// each side trusts the certificates signed by its own CAs
+func mutualTLSHandshake(daemon tls.Certificate, daemonCAs *x509.CertPool,
+	controller tls.Certificate, controllerCAs *x509.CertPool) error {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		conn := tls.Server(serverConn, &tls.Config{
+			Certificates: []tls.Certificate{daemon},
+			ClientCAs:    daemonCAs,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+		})
+		err := conn.Handshake()
+		// 握手失败时关闭连接，避免客户端一直等待
+		conn.Close()
+		serverErr <- err
+	}()
+	conn := tls.Client(clientConn, &tls.Config{
+		Certificates: []tls.Certificate{controller},
+		RootCAs:      controllerCAs,
+		ServerName:   cert.HousekeeperDaemonName,
+	})
+	clientErr := conn.Handshake()
+	if clientErr == nil {
+		// TLS 1.3 的客户端在服务端校验客户端证书前完成握手，读取以获得服务端的结果
+		_, clientErr = conn.Read(make([]byte, 1))
+		if clientErr == io.EOF {
+			clientErr = nil
+		}
+	}
+	conn.Close()
+	if err := <-serverErr; err != nil {
+		return err
+	}
+	return clientErr
+}
+
+func TestGenerateHousekeeperCerts(t *testing.T) {
+	clusterAsset := setupClusterConfig(t)
+
+	certs, err := cert.GenerateHousekeeperCerts(testClusterID, clusterAsset.CertAsset)
+	if err != nil {
+		t.Fatalf("Error generating housekeeper certs: %v", err)
+	}
+	modes := make(map[string]int)
+	for _, file := range certs {
+		modes[file.Path] = file.Mode
+	}
+	for _, key := range []string{utils.HousekeeperDaemonKey, utils.HousekeeperControllerKey} {
+		if modes[key] != int(utils.KeyFileMode) {
+			t.Errorf("Expected mode %o of %s, got %o", utils.KeyFileMode, key, modes[key])
+		}
+	}
+	daemon, controller, pool := housekeeperKeyPairs(t, certs)
+	if err := mutualTLSHandshake(daemon, pool, controller, pool); err != nil {
+		t.Errorf("Expected the controller to connect to the daemon, got %v", err)
+	}
+
+	// Certs generated again for new nodes are signed by the persisted CA
+	again, err := cert.GenerateHousekeeperCerts(testClusterID, clusterAsset.CertAsset)
+	if err != nil {
+		t.Fatalf("Error generating housekeeper certs again: %v", err)
+	}
+	newDaemon, _, _ := housekeeperKeyPairs(t, again)
+	if err := mutualTLSHandshake(newDaemon, pool, controller, pool); err != nil {
+		t.Errorf("Expected the controller to connect to a new daemon, got %v", err)
+	}
+
+	// Certs of another cluster are signed by another CA
+	other, err := cert.GenerateHousekeeperCerts("other", clusterAsset.CertAsset)
+	if err != nil {
+		t.Fatalf("Error generating housekeeper certs of another cluster: %v", err)
+	}
+	otherDaemon, otherController, otherPool := housekeeperKeyPairs(t, other)
+	if err := mutualTLSHandshake(daemon, pool, otherController, pool); err == nil {
+		t.Errorf("Expected the daemon to reject a controller of another cluster")
+	}
+	if err := mutualTLSHandshake(otherDaemon, pool, controller, pool); err == nil {
+		t.Errorf("Expected the controller to reject a daemon of another cluster")
+	}
+	if err := mutualTLSHandshake(otherDaemon, otherPool, otherController, otherPool); err != nil {
+		t.Errorf("Expected the certs of another cluster to work together, got %v", err)
+	}
+}
+
+func TestGenerateServiceAccountKeyPair(t *testing.T) {
+	clusterAsset := setupClusterConfig(t)
+
+	keyPair := func(files []utils.StorageContent) (key []byte, pub []byte) {
+		for _, file := range files {
+			switch file.Path {
+			case utils.SaKey:
+				key = file.Content
+			case utils.SaPub:
+				pub = file.Content
+			}
+		}
+		if key == nil || pub == nil {
+			t.Fatalf("Expected %s and %s in %v", utils.SaKey, utils.SaPub, files)
+		}
+		return key, pub
+	}
+
+	files, err := cert.GenerateServiceAccountKeyPair(testClusterID)
+	if err != nil {
+		t.Fatalf("Error generating sa keypair: %v", err)
+	}
+	key, pub := keyPair(files)
+	privateKey, err := cert.PemToPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Error parsing sa.key: %v", err)
+	}
+	derived, err := cert.PublicKeyToPem(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatalf("Error encoding public key: %v", err)
+	}
+	if string(derived) != string(pub) {
+		t.Errorf("Expected sa.pub to be derived from sa.key")
+	}
+
+	// Every control plane node of the cluster gets the same keypair
+	again, err := cert.GenerateServiceAccountKeyPair(testClusterID)
+	if err != nil {
+		t.Fatalf("Error generating sa keypair again: %v", err)
+	}
+	if againKey, againPub := keyPair(again); string(againKey) != string(key) || string(againPub) != string(pub) {
+		t.Errorf("Expected the persisted sa keypair to be reused")
+	}
+	clusterAsset.Master = append(clusterAsset.Master, asset.NodeAsset{Hostname: "k8s-master02", IP: "192.168.132.12"})
+	for i := range clusterAsset.Master {
+		if err := cert.NewCertGenerator(testClusterID, &clusterAsset.Master[i]).GenerateAllFiles(); err != nil {
+			t.Fatalf("Error generating files of %s: %v", clusterAsset.Master[i].Hostname, err)
+		}
+		if nodeKey, _ := keyPair(clusterAsset.Master[i].Certs); string(nodeKey) != string(key) {
+			t.Errorf("Expected %s to use the cluster sa.key", clusterAsset.Master[i].Hostname)
+		}
+	}
+
+	// Another cluster gets its own keypair
+	other, err := cert.GenerateServiceAccountKeyPair("other")
+	if err != nil {
+		t.Fatalf("Error generating sa keypair of another cluster: %v", err)
+	}
+	if otherKey, _ := keyPair(other); string(otherKey) == string(key) {
+		t.Errorf("Expected another cluster to get another sa keypair")
+	}
+}
+
+func TestGenerateKubeletCerts(t *testing.T) {
+	setupClusterConfig(t)
+	nodes := []asset.NodeAsset{
+		{Hostname: "k8s-worker01", IP: "192.168.132.21"},
+	}
+	if _, err := cert.GenerateKubeletCerts(testClusterID, nodes); err == nil {
+		t.Errorf("Expected an error without the root CA of the cluster")
+	}
+
+	pkiDir := filepath.Join(configmanager.GetPersistDir(), testClusterID, "pki")
+	rootCA, err := cert.GenerateAllCA("", "", "kubernetes", []string{"kubernetes"}, time.Hour)
+	if err != nil {
+		t.Fatalf("Error generating root CA: %v", err)
+	}
+	if err := cert.SaveFileToLocal(filepath.Join(pkiDir, "ca.crt"), rootCA.CertRaw); err != nil {
+		t.Fatalf("Error saving root CA cert: %v", err)
+	}
+	if err := cert.SaveFileToLocal(filepath.Join(pkiDir, "ca.key"), rootCA.KeyRaw); err != nil {
+		t.Fatalf("Error saving root CA key: %v", err)
+	}
+
+	certs, err := cert.GenerateKubeletCerts(testClusterID, nodes)
+	if err != nil {
+		t.Fatalf("Error generating kubelet certs: %v", err)
+	}
+	var certPEM []byte
+	for _, file := range certs["k8s-worker01"] {
+		switch file.Path {
+		case utils.KubeletServingCrt:
+			certPEM = file.Content
+		case utils.KubeletServingKey:
+			if file.Mode != int(utils.KeyFileMode) {
+				t.Errorf("Expected mode %o of %s, got %o", utils.KeyFileMode, file.Path, file.Mode)
+			}
+		}
+	}
+	servingCert, err := cert.PemToCertificate(certPEM)
+	if err != nil {
+		t.Fatalf("Error parsing kubelet serving cert: %v", err)
+	}
+	if servingCert.Subject.CommonName != "system:node:k8s-worker01" {
+		t.Errorf("Unexpected common name %s", servingCert.Subject.CommonName)
+	}
+	if org := servingCert.Subject.Organization; len(org) != 1 || org[0] != "system:nodes" {
+		t.Errorf("Expected organization system:nodes, got %v", org)
+	}
+	if len(servingCert.DNSNames) != 1 || servingCert.DNSNames[0] != "k8s-worker01" {
+		t.Errorf("Expected DNS SAN k8s-worker01, got %v", servingCert.DNSNames)
+	}
+	if len(servingCert.IPAddresses) != 1 || !servingCert.IPAddresses[0].Equal(net.ParseIP("192.168.132.21")) {
+		t.Errorf("Expected IP SAN 192.168.132.21, got %v", servingCert.IPAddresses)
+	}
+	chain := cert.CertChain{Name: "kubelet", CACert: rootCA.CertRaw, Cert: certPEM,
+		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, DNSName: "k8s-worker01"}
+	if err := cert.VerifyCertChain(chain); err != nil {
+		t.Errorf("Expected the kubelet serving cert to be signed by the root CA, got %v", err)
+	}
+
+	if _, err := cert.GenerateKubeletCerts(testClusterID, []asset.NodeAsset{{Hostname: "k8s-worker02", IP: "bad"}}); err == nil {
+		t.Errorf("Expected an error for an invalid node IP")
+	}
+}
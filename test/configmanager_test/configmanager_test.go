@@ -0,0 +1,1333 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configmanager_test
+
+import (
+	"bytes"
+	"fmt"
+	"nestos-kubernetes-deployer/cmd"
+	"nestos-kubernetes-deployer/cmd/command"
+	"nestos-kubernetes-deployer/cmd/command/opts"
+	"nestos-kubernetes-deployer/pkg/configmanager"
+	"nestos-kubernetes-deployer/pkg/configmanager/asset"
+	"nestos-kubernetes-deployer/pkg/configmanager/globalconfig"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func TestGlobalConfigPersistAndLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, globalconfig.GlobalConfigFile)
+
+	globalConfig := &globalconfig.GlobalConfig{
+		Log_Level:          "debug",
+		ClusterConfig_Path: filepath.Join(tmpDir, "cluster.yaml"),
+		PersistDir:         tmpDir,
+		BootstrapUrl: globalconfig.BootstrapUrl{
+			BootstrapIgnHost: "192.168.132.1",
+			BootstrapIgnPort: "9080",
+		},
+	}
+	if err := globalConfig.Persist(); err != nil {
+		t.Fatalf("Error persisting global config: %v", err)
+	}
+
+	// Only the config file should be left after the atomic write
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("Error reading persist directory: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != globalconfig.GlobalConfigFile {
+		t.Errorf("Expected only %s in persist directory, got %v", globalconfig.GlobalConfigFile, entries)
+	}
+
+	loaded := &globalconfig.GlobalConfig{}
+	if err := loaded.LoadGlobalConfig(configFile); err != nil {
+		t.Fatalf("Error loading global config: %v", err)
+	}
+	if !reflect.DeepEqual(globalConfig, loaded) {
+		t.Errorf("Expected %+v, got %+v", globalConfig, loaded)
+	}
+
+	// InitGlobalConfig reads the file Persist wrote
+	initialized, err := globalconfig.InitGlobalConfig(&opts.OptionsList{RootOptDir: tmpDir})
+	if err != nil {
+		t.Fatalf("Error initializing global config: %v", err)
+	}
+	if !reflect.DeepEqual(globalConfig, initialized) {
+		t.Errorf("Expected %+v, got %+v", globalConfig, initialized)
+	}
+}
+
+func TestGlobalConfigLoadKeepsUnsetFields(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), globalconfig.GlobalConfigFile)
+	if err := os.WriteFile(configFile, []byte("log_level: debug\n"), 0644); err != nil {
+		t.Fatalf("Error writing global config: %v", err)
+	}
+
+	globalConfig := &globalconfig.GlobalConfig{
+		PersistDir: "/etc/nkd",
+		BootstrapUrl: globalconfig.BootstrapUrl{
+			BootstrapIgnPort: "9080",
+		},
+	}
+	if err := globalConfig.LoadGlobalConfig(configFile); err != nil {
+		t.Fatalf("Error loading global config: %v", err)
+	}
+	if globalConfig.Log_Level != "debug" {
+		t.Errorf("Expected log level debug, got %s", globalConfig.Log_Level)
+	}
+	if globalConfig.PersistDir != "/etc/nkd" || globalConfig.BootstrapIgnPort != "9080" {
+		t.Errorf("Expected unset fields to keep their values, got %+v", globalConfig)
+	}
+}
+
+func TestDeleteCluster(t *testing.T) {
+	persistDir := t.TempDir()
+	configmanager.GlobalConfig = &globalconfig.GlobalConfig{
+		PersistDir: persistDir,
+	}
+	configmanager.SetClusterConfig(&asset.ClusterAsset{Cluster_ID: "cluster"})
+	configmanager.SetClusterConfig(&asset.ClusterAsset{Cluster_ID: "other"})
+	if err := configmanager.Persist(); err != nil {
+		t.Fatalf("Error persisting clusters: %v", err)
+	}
+
+	if err := configmanager.Delete(""); err == nil {
+		t.Errorf("Expected an error deleting an empty cluster id")
+	}
+	if err := configmanager.Delete("unknown"); err == nil {
+		t.Errorf("Expected an error deleting an unknown cluster")
+	}
+
+	if err := configmanager.Delete("cluster"); err != nil {
+		t.Fatalf("Error deleting cluster: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(persistDir, "cluster")); !os.IsNotExist(err) {
+		t.Errorf("Expected cluster persist directory to be removed, got %v", err)
+	}
+	if _, err := configmanager.GetClusterConfig("cluster"); err == nil {
+		t.Errorf("Expected cluster to be removed from the cluster map")
+	}
+
+	// Other clusters are left untouched
+	if _, err := os.Stat(filepath.Join(persistDir, "other")); err != nil {
+		t.Errorf("Expected other cluster persist directory to exist, got %v", err)
+	}
+	if _, err := configmanager.GetClusterConfig("other"); err != nil {
+		t.Errorf("Expected other cluster to be kept, got %v", err)
+	}
+	configmanager.RemoveClusterConfig("other")
+}
+
+func TestRemoveWorkerNodes(t *testing.T) {
+	persistDir := t.TempDir()
+	configmanager.GlobalConfig = &globalconfig.GlobalConfig{
+		PersistDir: persistDir,
+	}
+	ignitionDir := filepath.Join(persistDir, "scale-cluster", "ignition")
+	if err := os.MkdirAll(ignitionDir, 0755); err != nil {
+		t.Fatalf("Error creating ignition directory: %v", err)
+	}
+	ignitionFile := func(name string) string {
+		path := filepath.Join(ignitionDir, name)
+		if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+			t.Fatalf("Error writing ignition file: %v", err)
+		}
+		return path
+	}
+	worker01 := asset.NodeAsset{Hostname: "k8s-worker01", Ignitions: asset.Ignitions{
+		CreateIgnPath: ignitionFile("worker-k8s-worker01.ign"),
+		MergeIgnPath:  ignitionFile("worker-k8s-worker01-merge.ign"),
+	}}
+	// The other workers share the ignition files of the default pool
+	shared := asset.Ignitions{CreateIgnPath: ignitionFile("worker.ign"), MergeIgnPath: ignitionFile("worker-merge.ign")}
+	configmanager.SetClusterConfig(&asset.ClusterAsset{
+		Cluster_ID: "scale-cluster",
+		Worker: []asset.NodeAsset{
+			worker01,
+			{Hostname: "k8s-worker02", Ignitions: shared},
+			{Hostname: "k8s-worker03", Ignitions: shared},
+		},
+	})
+	defer configmanager.RemoveClusterConfig("scale-cluster")
+
+	if err := configmanager.RemoveWorkerNodes("scale-cluster", []string{"k8s-worker02", "k8s-worker09"}); err == nil {
+		t.Errorf("Expected an error removing an unknown worker")
+	}
+	clusterAsset, err := configmanager.GetClusterConfig("scale-cluster")
+	if err != nil {
+		t.Fatalf("Error getting cluster config: %v", err)
+	}
+	if len(clusterAsset.Worker) != 3 {
+		t.Errorf("Expected no worker to be removed on error, got %d workers", len(clusterAsset.Worker))
+	}
+
+	if err := configmanager.RemoveWorkerNodes("scale-cluster", []string{"k8s-worker01", "k8s-worker02"}); err != nil {
+		t.Fatalf("Error removing workers: %v", err)
+	}
+	// 删除节点修改的是副本，之前获取的集群配置保持不变
+	if len(clusterAsset.Worker) != 3 {
+		t.Errorf("Expected the previously returned cluster config to be unchanged, got %d workers", len(clusterAsset.Worker))
+	}
+	clusterAsset, err = configmanager.GetClusterConfig("scale-cluster")
+	if err != nil {
+		t.Fatalf("Error getting cluster config: %v", err)
+	}
+	if len(clusterAsset.Worker) != 1 || clusterAsset.Worker[0].Hostname != "k8s-worker03" {
+		t.Errorf("Expected only k8s-worker03 to be kept, got %+v", clusterAsset.Worker)
+	}
+	for _, path := range []string{worker01.CreateIgnPath, worker01.MergeIgnPath} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be deleted, got %v", path, err)
+		}
+	}
+	for _, path := range []string{shared.CreateIgnPath, shared.MergeIgnPath} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected %s used by k8s-worker03 to be kept, got %v", path, err)
+		}
+	}
+
+	persisted, err := asset.LoadClusterAsset(filepath.Join(persistDir, "scale-cluster", "cluster_config.yaml"))
+	if err != nil {
+		t.Fatalf("Error loading persisted cluster config: %v", err)
+	}
+	if len(persisted.Worker) != 1 || persisted.Worker[0].Hostname != "k8s-worker03" {
+		t.Errorf("Expected the persisted cluster to keep only k8s-worker03, got %+v", persisted.Worker)
+	}
+}
+
+func TestListClusters(t *testing.T) {
+	configmanager.SetClusterConfig(&asset.ClusterAsset{Cluster_ID: "list-cluster-b"})
+	configmanager.SetClusterConfig(&asset.ClusterAsset{Cluster_ID: "list-cluster-a"})
+	defer configmanager.RemoveClusterConfig("list-cluster-a")
+	defer configmanager.RemoveClusterConfig("list-cluster-b")
+
+	expected := []string{"list-cluster-a", "list-cluster-b"}
+	if clusterIDs := configmanager.ListClusters(); !reflect.DeepEqual(clusterIDs, expected) {
+		t.Errorf("Expected clusters %v, got %v", expected, clusterIDs)
+	}
+
+	var clusterIDs []string
+	for _, clusterAsset := range configmanager.GetAllClusterConfigs() {
+		clusterIDs = append(clusterIDs, clusterAsset.Cluster_ID)
+	}
+	if !reflect.DeepEqual(clusterIDs, expected) {
+		t.Errorf("Expected cluster configs %v, got %v", expected, clusterIDs)
+	}
+}
+
+func TestGetCmdHooksUnitDropIns(t *testing.T) {
+	dropInDir := t.TempDir()
+	files := map[string]string{
+		"kubelet.service.d/10-limits.conf": "[Service]\nMemoryMax=2G\n",
+		"kubelet.service.d/README":         "not a drop-in",
+		"crio.service.d/10-env.conf":       "[Service]\nEnvironment=A=1\n",
+		"notes.txt":                        "not a unit directory",
+	}
+	for name, content := range files {
+		path := filepath.Join(dropInDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Error creating directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Error writing drop-in: %v", err)
+		}
+	}
+
+	conf := &asset.HookConf{UnitDropInDir: dropInDir}
+	if err := asset.GetCmdHooks(conf); err != nil {
+		t.Fatalf("Error getting hooks: %v", err)
+	}
+	expected := []asset.UnitDropIn{
+		{Unit: "crio.service", Name: "10-env.conf", Contents: []byte("[Service]\nEnvironment=A=1\n")},
+		{Unit: "kubelet.service", Name: "10-limits.conf", Contents: []byte("[Service]\nMemoryMax=2G\n")},
+	}
+	if !reflect.DeepEqual(conf.UnitDropIns, expected) {
+		t.Errorf("Expected drop-ins %+v, got %+v", expected, conf.UnitDropIns)
+	}
+
+	if err := asset.GetCmdHooks(&asset.HookConf{UnitDropInDir: t.TempDir()}); err == nil {
+		t.Errorf("Expected an error for a directory without drop-ins")
+	}
+}
+
+func TestInitGlobalConfigLogLevel(t *testing.T) {
+	persistDir := t.TempDir()
+	configFile := filepath.Join(persistDir, globalconfig.GlobalConfigFile)
+	t.Cleanup(func() {
+		opts.Opts = opts.OptionsList{}
+		opts.RootOpts.LogLevel = ""
+	})
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		// The value persisted in the global config file is kept when no log level is given
+		{name: "persisted", want: "warn"},
+		// The log level given on the command line overrides the persisted value, even the default one
+		{name: "flag", args: []string{"--log-level", "debug"}, want: "debug"},
+		{name: "flag default", args: []string{"--log-level=info"}, want: "info"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(configFile, []byte("log_level: warn\n"), 0644); err != nil {
+				t.Fatalf("Error writing global config: %v", err)
+			}
+			opts.Opts = opts.OptionsList{
+				NKD: opts.NKDConfig{
+					BootstrapUrl: opts.BootstrapUrl{
+						BootstrapIgnHost: "127.0.0.1",
+						BootstrapIgnPort: "9081",
+					},
+				},
+			}
+			opts.RootOpts.LogLevel = ""
+
+			var globalConfig *globalconfig.GlobalConfig
+			rootCmd := cmd.NewRootCommand()
+			rootCmd.AddCommand(&cobra.Command{
+				Use: "init",
+				RunE: func(*cobra.Command, []string) error {
+					var err error
+					globalConfig, err = globalconfig.InitGlobalConfig(&opts.Opts)
+					return err
+				},
+			})
+			rootCmd.SetArgs(append([]string{"init", "--persist-dir", persistDir}, tt.args...))
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatalf("Error running the root command: %v", err)
+			}
+			if globalConfig.Log_Level != tt.want {
+				t.Errorf("Expected log level %s, got %s", tt.want, globalConfig.Log_Level)
+			}
+			if globalConfig.BootstrapIgnPort != "9081" || globalConfig.PersistDir != persistDir {
+				t.Errorf("Unexpected global config: %+v", globalConfig)
+			}
+		})
+	}
+}
+
+func TestPersistDirOverride(t *testing.T) {
+	envDir := t.TempDir()
+	flagDir := t.TempDir()
+
+	t.Setenv(opts.PersistDirEnv, "")
+	if dir := opts.ResolvePersistDir(""); dir != opts.DefaultPersistDir {
+		t.Errorf("Expected the default persist dir, got %s", dir)
+	}
+	t.Setenv(opts.PersistDirEnv, envDir)
+	if dir := opts.ResolvePersistDir(flagDir); dir != flagDir {
+		t.Errorf("Expected the flag to take precedence, got %s", dir)
+	}
+	if dir := opts.ResolvePersistDir(""); dir != envDir {
+		t.Errorf("Expected the persist dir of %s, got %s", opts.PersistDirEnv, dir)
+	}
+
+	// A persist dir recorded in the global config file does not override the resolved one
+	globalConfigFile := filepath.Join(envDir, globalconfig.GlobalConfigFile)
+	if err := os.WriteFile(globalConfigFile, []byte("persistdir: /etc/nkd\n"), 0644); err != nil {
+		t.Fatalf("Error writing global config: %v", err)
+	}
+	content := strings.Replace(completeClusterConfig, "cluster_id: file-cluster", "cluster_id: env-cluster", 1)
+	configFile := filepath.Join(t.TempDir(), "cluster.yaml")
+	if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Error writing cluster config: %v", err)
+	}
+	options := &opts.OptionsList{
+		RootOptDir:        opts.ResolvePersistDir(""),
+		ClusterConfigFile: configFile,
+		Arch:              "amd64",
+	}
+	options.NKD.BootstrapIgnHost = "127.0.0.1"
+	options.NKD.BootstrapIgnPort = "9083"
+	if err := configmanager.Initial(options); err != nil {
+		t.Fatalf("Error initializing config manager: %v", err)
+	}
+	defer configmanager.RemoveClusterConfig("env-cluster")
+
+	if dir := configmanager.GetPersistDir(); dir != envDir {
+		t.Errorf("Expected persist dir %s, got %s", envDir, dir)
+	}
+	if err := configmanager.Persist(); err != nil {
+		t.Fatalf("Error persisting cluster config: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(envDir, "env-cluster", "cluster_config.yaml")); err != nil {
+		t.Errorf("Expected the cluster config under %s: %v", envDir, err)
+	}
+	globalConfig, err := globalconfig.InitGlobalConfig(options)
+	if err != nil {
+		t.Fatalf("Error initializing global config: %v", err)
+	}
+	if globalConfig.PersistDir != envDir {
+		t.Errorf("Expected the global config to keep persist dir %s, got %s", envDir, globalConfig.PersistDir)
+	}
+}
+
+func TestValidateClusterAsset(t *testing.T) {
+	clusterAsset, err := asset.GetDefaultClusterConfig("amd64")
+	if err != nil {
+		t.Fatalf("Error getting default cluster config: %v", err)
+	}
+	if err := clusterAsset.Validate(); err != nil {
+		t.Fatalf("Expected default cluster config to be valid, got %v", err)
+	}
+
+	clusterAsset.Master[0].IP = "192.168.132"
+	clusterAsset.Worker[0].Pool = "gpu"
+	clusterAsset.Network.PodSubnet = "10.96.128.0/24"
+
+	err = clusterAsset.Validate()
+	errs, ok := err.(asset.ValidationErrors)
+	if !ok {
+		t.Fatalf("Expected validation errors, got %v", err)
+	}
+
+	var fields []string
+	for _, fieldErr := range errs {
+		fields = append(fields, fieldErr.Field)
+	}
+	sort.Strings(fields)
+	expected := []string{"kubernetes.network.pod-subnet", "master[0].ip", "worker[0].pool"}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Expected errors for %v, got %v", expected, fields)
+	}
+}
+
+func TestValidateClusterAssetCases(t *testing.T) {
+	poolIgnition := filepath.Join(t.TempDir(), "gpu.ign")
+	if err := os.WriteFile(poolIgnition, []byte(`{"ignition":{"version":"3.2.0"}}`), 0644); err != nil {
+		t.Fatalf("Error writing node pool ignition: %v", err)
+	}
+	invalidIgnition := filepath.Join(t.TempDir(), "invalid.ign")
+	if err := os.WriteFile(invalidIgnition, []byte(`{"storage":{}}`), 0644); err != nil {
+		t.Fatalf("Error writing node pool ignition: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(clusterAsset *asset.ClusterAsset)
+		fields []string
+	}{
+		{
+			name:   "valid",
+			modify: func(clusterAsset *asset.ClusterAsset) {},
+		},
+		{
+			name:   "empty runtime",
+			modify: func(clusterAsset *asset.ClusterAsset) { clusterAsset.Runtime = "" },
+			fields: []string{"runtime"},
+		},
+		{
+			name:   "unsupported runtime",
+			modify: func(clusterAsset *asset.ClusterAsset) { clusterAsset.Runtime = "rkt" },
+			fields: []string{"runtime"},
+		},
+		{
+			name:   "missing pod subnet",
+			modify: func(clusterAsset *asset.ClusterAsset) { clusterAsset.Network.PodSubnet = "" },
+			fields: []string{"kubernetes.network.pod-subnet"},
+		},
+		{
+			name:   "invalid service subnet",
+			modify: func(clusterAsset *asset.ClusterAsset) { clusterAsset.Network.ServiceSubnet = "10.96.0.0/33" },
+			fields: []string{"kubernetes.network.service-subnet"},
+		},
+		{
+			name: "overlapping subnets",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.Network.ServiceSubnet = "10.0.0.0/8"
+				clusterAsset.Network.PodSubnet = "10.244.0.0/16"
+			},
+			fields: []string{"kubernetes.network.pod-subnet"},
+		},
+		{
+			name: "ipv6 subnets",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.Network.ServiceSubnet = "fd00:10:96::/112"
+				clusterAsset.Network.PodSubnet = "fd00:10:244::/56"
+			},
+		},
+		{
+			name: "dual-stack subnets",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.Network.ServiceSubnet = "10.96.0.0/16, fd00:10:96::/112"
+				clusterAsset.Network.PodSubnet = "10.244.0.0/16,fd00:10:244::/56"
+			},
+		},
+		{
+			name: "dual-stack service subnet only",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.Network.ServiceSubnet = "10.96.0.0/16,fd00:10:96::/112"
+			},
+			fields: []string{"kubernetes.network.pod-subnet"},
+		},
+		{
+			name: "mismatched subnet families",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.Network.ServiceSubnet = "fd00:10:96::/112"
+			},
+			fields: []string{"kubernetes.network.pod-subnet"},
+		},
+		{
+			name: "malformed dual-stack subnet",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.Network.ServiceSubnet = "10.96.0.0/16,"
+				clusterAsset.Network.PodSubnet = "10.244.0.0/16,10.245.0.0/16"
+			},
+			fields: []string{"kubernetes.network.pod-subnet", "kubernetes.network.service-subnet"},
+		},
+		{
+			name: "negative cert validity",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.CertAsset.CAValidityDays = -1
+				clusterAsset.CertAsset.CertValidityDays = -30
+			},
+			fields: []string{"certasset.cavaliditydays", "certasset.certvaliditydays"},
+		},
+		{
+			name:   "unsupported cgroup driver",
+			modify: func(clusterAsset *asset.ClusterAsset) { clusterAsset.CgroupDriver = "cgroupv2" },
+			fields: []string{"kubernetes.cgroup-driver"},
+		},
+		{
+			name:   "negative max pods",
+			modify: func(clusterAsset *asset.ClusterAsset) { clusterAsset.Kubelet.MaxPods = -1 },
+			fields: []string{"kubernetes.kubelet.max-pods"},
+		},
+		{
+			name: "valid registries",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.Registry.Mirrors = map[string][]string{
+					"docker.io":          {"mirror.example.com:5000/dockerhub"},
+					"registry.k8s.io/ci": {"192.168.132.10:5000"},
+				}
+				clusterAsset.Registry.Insecure = []string{"192.168.132.10:5000", "hub.local"}
+			},
+		},
+		{
+			name: "invalid registries",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.Registry.Mirrors = map[string][]string{
+					"docker.io":    {"https://mirror.example.com"},
+					"quay.io":      nil,
+					"bad_host.com": {"mirror.example.com"},
+				}
+				clusterAsset.Registry.Insecure = []string{"hub.local:99999"}
+			},
+			fields: []string{
+				"kubernetes.registry.insecure[0]",
+				"kubernetes.registry.mirrors.bad_host.com",
+				"kubernetes.registry.mirrors.docker.io[0]",
+				"kubernetes.registry.mirrors.quay.io",
+			},
+		},
+		{
+			name: "missing certificate key of joining masters",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.Master = append(clusterAsset.Master,
+					asset.NodeAsset{Hostname: "k8s-master02", IP: "192.168.132.12"})
+				clusterAsset.CertificateKey = "not-a-hex-key"
+			},
+			fields: []string{"kubernetes.certificatekey"},
+		},
+		{
+			name:   "single master without certificate key",
+			modify: func(clusterAsset *asset.ClusterAsset) { clusterAsset.CertificateKey = "" },
+		},
+		{
+			name: "invalid pause image fallback",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.Runtime = "crio"
+				clusterAsset.PauseImageFallbacks = []string{"mirror.example.com", "http://mirror.example.com"}
+			},
+			fields: []string{"kubernetes.pause-image-fallbacks[1]"},
+		},
+		{
+			name: "pause image fallbacks without crio",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.PauseImageFallbacks = []string{"mirror.example.com"}
+			},
+			fields: []string{"kubernetes.pause-image-fallbacks"},
+		},
+		{
+			name:   "valid timezone",
+			modify: func(clusterAsset *asset.ClusterAsset) { clusterAsset.Timezone = "America/Argentina/Buenos_Aires" },
+		},
+		{
+			name:   "invalid timezone",
+			modify: func(clusterAsset *asset.ClusterAsset) { clusterAsset.Timezone = "../../etc/passwd" },
+			fields: []string{"timezone"},
+		},
+		{
+			name: "valid ssh keys",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.SSHKeys = []string{
+					"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAABAgMEBQYHCAkKCwwNDg8QERITFBUWFxgZGhscHR4f alice",
+					"# bob\nno-pty ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAABAgMEBQYHCAkKCwwNDg8QERITFBUWFxgZGhscHR4f\n",
+				}
+			},
+		},
+		{
+			name: "invalid ssh keys",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.SSHKeys = []string{
+					"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAABAgMEBQYHCAkKCwwNDg8QERITFBUWFxgZGhscHR4f",
+					"ssh-rsa AAAAC3NzaC1lZDI1NTE5AAAAIAABAgMEBQYHCAkKCwwNDg8QERITFBUWFxgZGhscHR4f",
+					"/root/.ssh/id_rsa.pub",
+				}
+			},
+			fields: []string{"sshkeys[1]", "sshkeys[2]"},
+		},
+		{
+			name:   "no master",
+			modify: func(clusterAsset *asset.ClusterAsset) { clusterAsset.Master = nil },
+			fields: []string{"master"},
+		},
+		{
+			name: "duplicate hostname",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.Worker[0].Hostname = clusterAsset.Master[0].Hostname
+			},
+			fields: []string{"worker[0].hostname"},
+		},
+		{
+			name: "separate var partition",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.Master[0].Disks = []asset.DiskConfig{{
+					Device:    "/dev/vdb",
+					WipeTable: true,
+					Partitions: []asset.PartitionConfig{
+						{Label: "var", Format: "xfs", MountPoint: "/var"},
+						{Label: "swap", SizeMiB: 2048, Format: "swap"},
+					},
+				}}
+			},
+		},
+		{
+			name: "invalid disks",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.Master[0].Disks = []asset.DiskConfig{
+					{Device: "vdb", Partitions: []asset.PartitionConfig{
+						{Label: "data", Format: "ntfs", MountPoint: "/data"},
+						{Label: "root", Format: "xfs", MountPoint: "/"},
+					}},
+					{Device: "/dev/vdc", Partitions: []asset.PartitionConfig{
+						{Label: "data", Format: "ext4", MountPoint: "/data"},
+						{Label: "swap", Format: "swap", MountPoint: "/swap"},
+					}},
+				}
+			},
+			fields: []string{
+				"master[0].disks[0].device",
+				"master[0].disks[0].partitions[0].format",
+				"master[0].disks[0].partitions[1].mountpoint",
+				"master[0].disks[1].partitions[0].label",
+				"master[0].disks[1].partitions[0].mountpoint",
+				"master[0].disks[1].partitions[1].mountpoint",
+			},
+		},
+		{
+			name: "node labels and taints",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.Worker = []asset.NodeAsset{{
+					Hostname: "k8s-worker01",
+					IP:       "192.168.132.21",
+					Labels:   map[string]string{"example.com/gpu": "a100", "dedicated": ""},
+					Taints:   []string{"gpu=true:NoSchedule", "example.com/maintenance:NoExecute"},
+				}}
+			},
+		},
+		{
+			name: "invalid node labels and taints",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.Master[0].Labels = map[string]string{"-gpu": "a100", "zone": "a,b"}
+				clusterAsset.Master[0].Taints = []string{"gpu=true", "gpu=true:NoScheduled", "=true:NoSchedule"}
+			},
+			fields: []string{
+				"master[0].labels",
+				"master[0].labels",
+				"master[0].taints[0]",
+				"master[0].taints[1]",
+				"master[0].taints[2]",
+			},
+		},
+		{
+			name: "node pool overrides",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.NodePools = []asset.NodePool{
+					{Name: "gpu", Image: "/images/gpu.qcow2", Count: 2, Ignition: poolIgnition},
+				}
+				clusterAsset.Worker = []asset.NodeAsset{
+					{Hostname: "k8s-gpu-worker01", Pool: "gpu"},
+					{Hostname: "k8s-gpu-worker02", Pool: "gpu"},
+				}
+			},
+		},
+		{
+			name: "invalid node pool overrides",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.NodePools = []asset.NodePool{
+					{Name: "gpu", Count: 1, Ignition: filepath.Join(t.TempDir(), "missing.ign")},
+					{Name: "memory_pool", Ignition: invalidIgnition},
+				}
+				clusterAsset.Worker = []asset.NodeAsset{
+					{Hostname: "k8s-gpu-worker01", Pool: "gpu"},
+					{Hostname: "k8s-gpu-worker02", Pool: "gpu"},
+				}
+			},
+			fields: []string{
+				"nodepools[0].count",
+				"nodepools[0].ignition",
+				"nodepools[1].ignition",
+				"nodepools[1].name",
+			},
+		},
+		{
+			name: "multiple problems",
+			modify: func(clusterAsset *asset.ClusterAsset) {
+				clusterAsset.Runtime = ""
+				clusterAsset.Master = nil
+				clusterAsset.Network.PodSubnet = ""
+			},
+			fields: []string{"kubernetes.network.pod-subnet", "master", "runtime"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clusterAsset, err := asset.GetDefaultClusterConfig("amd64")
+			if err != nil {
+				t.Fatalf("Error getting default cluster config: %v", err)
+			}
+			test.modify(clusterAsset)
+
+			err = clusterAsset.Validate()
+			if len(test.fields) == 0 {
+				if err != nil {
+					t.Fatalf("Expected no validation errors, got %v", err)
+				}
+				return
+			}
+			errs, ok := err.(asset.ValidationErrors)
+			if !ok {
+				t.Fatalf("Expected validation errors, got %v", err)
+			}
+			var fields []string
+			for _, fieldErr := range errs {
+				fields = append(fields, fieldErr.Field)
+			}
+			sort.Strings(fields)
+			if !reflect.DeepEqual(fields, test.fields) {
+				t.Errorf("Expected errors for %v, got %v", test.fields, fields)
+			}
+		})
+	}
+}
+
+func TestGetRuntimeCriSocket(t *testing.T) {
+	tests := map[string]string{
+		"docker":       "unix:///var/run/cri-dockerd.sock",
+		"containerd":   "unix:///run/containerd/containerd.sock",
+		"crio":         "unix:///var/run/crio/crio.sock",
+		"isulad":       "unix:///var/run/isulad.sock",
+		" Containerd ": "unix:///run/containerd/containerd.sock",
+		"ISULAD":       "unix:///var/run/isulad.sock",
+	}
+	for runtime, expected := range tests {
+		criSocket, err := asset.GetRuntimeCriSocket(runtime)
+		if err != nil {
+			t.Errorf("Error getting cri socket of runtime %q: %v", runtime, err)
+			continue
+		}
+		if criSocket != expected {
+			t.Errorf("Expected cri socket %s for runtime %q, got %s", expected, runtime, criSocket)
+		}
+	}
+
+	_, err := asset.GetRuntimeCriSocket("rkt")
+	runtimeErr, ok := err.(*asset.UnsupportedRuntimeError)
+	if !ok {
+		t.Fatalf("Expected an unsupported runtime error, got %v", err)
+	}
+	if runtimeErr.Runtime != "rkt" || !strings.Contains(err.Error(), "containerd, crio, docker, isulad") {
+		t.Errorf("Unexpected unsupported runtime error: %v", err)
+	}
+}
+
+// initFromClusterConfigFile writes content to a cluster config file and initializes the config manager from it
+func initFromClusterConfigFile(t *testing.T, content string, options *opts.OptionsList) error {
+	persistDir := t.TempDir()
+	configFile := filepath.Join(t.TempDir(), "cluster.yaml")
+	if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Error writing cluster config: %v", err)
+	}
+
+	options.RootOptDir = persistDir
+	options.ClusterConfigFile = configFile
+	options.Arch = "amd64"
+	options.NKD.BootstrapIgnHost = "127.0.0.1"
+	options.NKD.BootstrapIgnPort = "9082"
+	return configmanager.Initial(options)
+}
+
+const completeClusterConfig = `cluster_id: file-cluster
+platform: libvirt
+master:
+- hostname: master-a
+  ip: 10.0.0.11
+- hostname: master-b
+  ip: 10.0.0.12
+worker:
+- hostname: worker-a
+  ip: 10.0.0.21
+runtime: crio
+kubernetes:
+  kubernetes-version: v1.24.2
+  image-registry: registry.example.com
+  pause-image: pause:3.7
+  network:
+    service-subnet: 10.100.0.0/16
+    pod-subnet: 10.200.0.0/16
+`
+
+func TestInitialFromCompleteClusterConfigFile(t *testing.T) {
+	if err := initFromClusterConfigFile(t, completeClusterConfig, &opts.OptionsList{}); err != nil {
+		t.Fatalf("Error initializing from cluster config file: %v", err)
+	}
+	defer configmanager.RemoveClusterConfig("file-cluster")
+
+	clusterAsset, err := configmanager.GetClusterConfig("file-cluster")
+	if err != nil {
+		t.Fatalf("Error getting cluster config: %v", err)
+	}
+	if len(clusterAsset.Master) != 2 || clusterAsset.Master[1].Hostname != "master-b" || clusterAsset.Master[1].IP != "10.0.0.12" {
+		t.Errorf("Unexpected masters: %+v", clusterAsset.Master)
+	}
+	if len(clusterAsset.Worker) != 1 || clusterAsset.Worker[0].Hostname != "worker-a" {
+		t.Errorf("Unexpected workers: %+v", clusterAsset.Worker)
+	}
+	if clusterAsset.Runtime != "crio" || clusterAsset.KubernetesVersion != "v1.24.2" ||
+		clusterAsset.ImageRegistry != "registry.example.com" || clusterAsset.PauseImage != "pause:3.7" {
+		t.Errorf("Unexpected cluster config: %+v", clusterAsset)
+	}
+	if clusterAsset.Network.ServiceSubnet != "10.100.0.0/16" || clusterAsset.Network.PodSubnet != "10.200.0.0/16" {
+		t.Errorf("Unexpected network config: %+v", clusterAsset.Network)
+	}
+}
+
+func TestInitialLogLevel(t *testing.T) {
+	defer logrus.SetLevel(logrus.GetLevel())
+	defer configmanager.RemoveClusterConfig("file-cluster")
+
+	for level, expected := range map[string]logrus.Level{
+		"debug": logrus.DebugLevel,
+		"WARN":  logrus.WarnLevel,
+		"error": logrus.ErrorLevel,
+		"":      logrus.InfoLevel,
+	} {
+		if err := initFromClusterConfigFile(t, completeClusterConfig, &opts.OptionsList{
+			NKD: opts.NKDConfig{Log_Level: level},
+		}); err != nil {
+			t.Fatalf("Error initializing with log level %q: %v", level, err)
+		}
+		if command.ConsoleLevel() != expected {
+			t.Errorf("Expected console level %s for %q, got %s", expected, level, command.ConsoleLevel())
+		}
+		// The log file of nkd keeps the entries below the console level
+		if logrus.GetLevel() != logrus.TraceLevel {
+			t.Errorf("Expected the logger to stay at trace level for %q, got %s", level, logrus.GetLevel())
+		}
+	}
+
+	err := initFromClusterConfigFile(t, completeClusterConfig, &opts.OptionsList{
+		NKD: opts.NKDConfig{Log_Level: "verbose"},
+	})
+	if err == nil || !strings.Contains(err.Error(), `invalid log level "verbose"`) {
+		t.Errorf("Expected an invalid log level error, got %v", err)
+	}
+}
+
+func TestInitialClusterLog(t *testing.T) {
+	defer logrus.SetLevel(logrus.GetLevel())
+	defer configmanager.RemoveClusterConfig("file-cluster")
+	defer configmanager.CloseClusterLog()
+
+	// 旧版本持久化的默认日志级别按info处理
+	persistDir := t.TempDir()
+	globalConfig := "log_level: default log level\ncluster_log: true\n"
+	if err := os.WriteFile(filepath.Join(persistDir, globalconfig.GlobalConfigFile), []byte(globalConfig), 0644); err != nil {
+		t.Fatalf("Error writing global config: %v", err)
+	}
+	configFile := filepath.Join(t.TempDir(), "cluster.yaml")
+	if err := os.WriteFile(configFile, []byte(completeClusterConfig), 0644); err != nil {
+		t.Fatalf("Error writing cluster config: %v", err)
+	}
+	options := &opts.OptionsList{
+		RootOptDir:        persistDir,
+		ClusterConfigFile: configFile,
+		ClusterID:         "file-cluster",
+		Arch:              "amd64",
+	}
+	options.NKD.BootstrapIgnHost = "127.0.0.1"
+	options.NKD.BootstrapIgnPort = "9082"
+
+	// The console and the nkd log file are set up by the commands before the config is loaded
+	orgHooks := logrus.LevelHooks{}
+	for level, hooks := range logrus.StandardLogger().Hooks {
+		orgHooks[level] = hooks
+	}
+	defer logrus.StandardLogger().ReplaceHooks(orgHooks)
+	defer command.SetConsoleLevel(command.ConsoleLevel())
+	var console, nkdLog bytes.Buffer
+	command.AddConsoleHook(&console, logrus.TraceLevel, &logrus.TextFormatter{DisableColors: true})
+	logrus.AddHook(command.NewloggerHook(&nkdLog, logrus.TraceLevel, &logrus.TextFormatter{DisableColors: true}))
+
+	if err := configmanager.Initial(options); err != nil {
+		t.Fatalf("Error initializing: %v", err)
+	}
+	if command.ConsoleLevel() != logrus.InfoLevel {
+		t.Errorf("Expected console level info, got %s", command.ConsoleLevel())
+	}
+
+	logrus.Info("logged to the cluster log")
+	logrus.Debug("filtered by the log level")
+	configmanager.CloseClusterLog()
+	if !strings.Contains(console.String(), "logged to the cluster log") ||
+		strings.Contains(console.String(), "filtered by the log level") {
+		t.Errorf("Expected the console to filter the debug entry, got %s", console.String())
+	}
+	// The nkd log file keeps the entries below the configured level
+	if !strings.Contains(nkdLog.String(), "filtered by the log level") {
+		t.Errorf("Expected the debug entry in the nkd log, got %s", nkdLog.String())
+	}
+	logrus.Info("logged after the cluster log is closed")
+
+	data, err := os.ReadFile(filepath.Join(persistDir, "file-cluster", "logs", "nkd.log"))
+	if err != nil {
+		t.Fatalf("Error reading cluster log: %v", err)
+	}
+	if !strings.Contains(string(data), "logged to the cluster log") {
+		t.Errorf("Expected the info entry in the cluster log, got %s", data)
+	}
+	if strings.Contains(string(data), "filtered by the log level") || strings.Contains(string(data), "after the cluster log is closed") {
+		t.Errorf("Unexpected entries in the cluster log: %s", data)
+	}
+}
+
+func TestInitialFromPartialClusterConfigFileWithFlags(t *testing.T) {
+	content := `cluster_id: partial-cluster
+master:
+- hostname: master-a
+  ip: 10.0.0.11
+runtime: crio
+kubernetes:
+  network:
+    pod-subnet: 10.200.0.0/16
+`
+	options := &opts.OptionsList{
+		Runtime:     "isulad",
+		KubeVersion: "v1.25.0",
+	}
+	if err := initFromClusterConfigFile(t, content, options); err != nil {
+		t.Fatalf("Error initializing from cluster config file: %v", err)
+	}
+	defer configmanager.RemoveClusterConfig("partial-cluster")
+
+	clusterAsset, err := configmanager.GetClusterConfig("partial-cluster")
+	if err != nil {
+		t.Fatalf("Error getting cluster config: %v", err)
+	}
+	// Flags override the file, unset values fall back to the defaults
+	if clusterAsset.Runtime != "isulad" || clusterAsset.KubernetesVersion != "v1.25.0" {
+		t.Errorf("Expected flags to override the file, got runtime %s and version %s",
+			clusterAsset.Runtime, clusterAsset.KubernetesVersion)
+	}
+	if clusterAsset.Master[0].Hostname != "master-a" || clusterAsset.Network.PodSubnet != "10.200.0.0/16" {
+		t.Errorf("Expected file values to be kept, got %+v", clusterAsset)
+	}
+	if clusterAsset.Network.ServiceSubnet != "10.96.0.0/16" {
+		t.Errorf("Expected default service subnet, got %s", clusterAsset.Network.ServiceSubnet)
+	}
+}
+
+func TestInitialNodePoolCount(t *testing.T) {
+	content := `cluster_id: pool-cluster
+master:
+- hostname: master-a
+  ip: 10.0.0.11
+worker:
+- hostname: k8s-gpu-worker01
+  pool: gpu
+- hostname: worker-a
+nodepools:
+- name: gpu
+  image: /images/gpu.qcow2
+  count: 3
+- name: memory
+  count: 1
+`
+	if err := initFromClusterConfigFile(t, content, &opts.OptionsList{}); err != nil {
+		t.Fatalf("Error initializing from cluster config file: %v", err)
+	}
+	defer configmanager.RemoveClusterConfig("pool-cluster")
+
+	clusterAsset, err := configmanager.GetClusterConfig("pool-cluster")
+	if err != nil {
+		t.Fatalf("Error getting cluster config: %v", err)
+	}
+	var workers []string
+	for _, worker := range clusterAsset.Worker {
+		workers = append(workers, worker.Pool+"/"+worker.Hostname)
+	}
+	expected := []string{"gpu/k8s-gpu-worker01", "/worker-a", "gpu/k8s-gpu-worker02", "gpu/k8s-gpu-worker03",
+		"memory/k8s-memory-worker01"}
+	if !reflect.DeepEqual(workers, expected) {
+		t.Errorf("Expected workers %v, got %v", expected, workers)
+	}
+	added := clusterAsset.Worker[2].HardwareInfo
+	if added.CPU != 4 || added.RAM != 8192 || added.Disk != 50 {
+		t.Errorf("Expected the added workers to use the default hardware, got %+v", added)
+	}
+	if clusterAsset.NodePools[0].Image != "/images/gpu.qcow2" {
+		t.Errorf("Expected the node pool image to be loaded, got %+v", clusterAsset.NodePools[0])
+	}
+}
+
+func TestInitialFromMalformedClusterConfigFile(t *testing.T) {
+	err := initFromClusterConfigFile(t, "master: [hostname: master-a\n", &opts.OptionsList{})
+	if err == nil || !strings.Contains(err.Error(), "failed to parse cluster config file") {
+		t.Errorf("Expected a parse error, got %v", err)
+	}
+
+	content := strings.Replace(completeClusterConfig, "ip: 10.0.0.12", "ip: 10.0.0", 1)
+	err = initFromClusterConfigFile(t, content, &opts.OptionsList{})
+	if _, ok := err.(asset.ValidationErrors); !ok || !strings.Contains(err.Error(), "master[1].ip") {
+		t.Errorf("Expected a validation error for master[1].ip, got %v", err)
+	}
+}
+
+func TestConcurrentInitialAndGetClusterConfig(t *testing.T) {
+	const clusters = 4
+	optionsList := make([]*opts.OptionsList, clusters)
+	for i := range optionsList {
+		clusterID := fmt.Sprintf("concurrent-cluster-%d", i)
+		content := strings.Replace(completeClusterConfig, "cluster_id: file-cluster", "cluster_id: "+clusterID, 1)
+		configFile := filepath.Join(t.TempDir(), "cluster.yaml")
+		if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+			t.Fatalf("Error writing cluster config: %v", err)
+		}
+		optionsList[i] = &opts.OptionsList{
+			RootOptDir:        t.TempDir(),
+			ClusterConfigFile: configFile,
+			Arch:              "amd64",
+		}
+		optionsList[i].NKD.BootstrapIgnHost = "127.0.0.1"
+		optionsList[i].NKD.BootstrapIgnPort = strconv.Itoa(9090 + i)
+		defer configmanager.RemoveClusterConfig(clusterID)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, clusters)
+	for i := range optionsList {
+		wg.Add(2)
+		go func(options *opts.OptionsList) {
+			defer wg.Done()
+			errs <- configmanager.Initial(options)
+		}(optionsList[i])
+		go func(clusterID string) {
+			defer wg.Done()
+			// 集群可能尚未初始化，只检查并发读取
+			configmanager.GetClusterConfig(clusterID)
+		}(fmt.Sprintf("concurrent-cluster-%d", i))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Error initializing cluster concurrently: %v", err)
+		}
+	}
+
+	for i := 0; i < clusters; i++ {
+		clusterID := fmt.Sprintf("concurrent-cluster-%d", i)
+		clusterAsset, err := configmanager.GetClusterConfig(clusterID)
+		if err != nil {
+			t.Fatalf("Error getting cluster config %s: %v", clusterID, err)
+		}
+		if clusterAsset.Cluster_ID != clusterID {
+			t.Errorf("Expected cluster %s, got %s", clusterID, clusterAsset.Cluster_ID)
+		}
+	}
+}
+
+func TestLoadClusterAssetSchemaVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		err     string
+	}{
+		{name: "same version", version: "schema-version: v1\n"},
+		{name: "older version", version: ""},
+		{name: "newer version", version: "schema-version: v2\n", err: "newer than the supported version"},
+		{name: "invalid version", version: "schema-version: latest\n", err: "invalid schema version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), "cluster_config.yaml")
+			if err := os.WriteFile(configFile, []byte(completeClusterConfig+tt.version), 0644); err != nil {
+				t.Fatalf("Error writing cluster config: %v", err)
+			}
+
+			clusterAsset, err := asset.LoadClusterAsset(configFile)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("Expected an error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Error loading cluster config: %v", err)
+			}
+			if clusterAsset.SchemaVersion != "v1" || clusterAsset.Cluster_ID != "file-cluster" {
+				t.Errorf("Expected cluster file-cluster migrated to v1, got %s %s",
+					clusterAsset.Cluster_ID, clusterAsset.SchemaVersion)
+			}
+		})
+	}
+
+	// The schema version is written when the cluster asset is persisted
+	dir := t.TempDir()
+	if err := (&asset.ClusterAsset{Cluster_ID: "cluster"}).Persist(dir); err != nil {
+		t.Fatalf("Error persisting cluster config: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "cluster_config.yaml"))
+	if err != nil {
+		t.Fatalf("Error reading persisted cluster config: %v", err)
+	}
+	if !strings.Contains(string(content), "schema-version: v1\n") {
+		t.Errorf("Expected the persisted cluster config to have schema version v1, got:\n%s", content)
+	}
+}
+
+func TestDiffClusterConfig(t *testing.T) {
+	persistDir := t.TempDir()
+	configmanager.GlobalConfig = &globalconfig.GlobalConfig{
+		PersistDir: persistDir,
+	}
+	newCluster := func() *asset.ClusterAsset {
+		return &asset.ClusterAsset{
+			Cluster_ID: "diff-cluster",
+			Runtime:    "isulad",
+			Master: []asset.NodeAsset{
+				{Hostname: "k8s-master01", IP: "192.168.132.11"},
+			},
+			Worker: []asset.NodeAsset{
+				{Hostname: "k8s-worker01", IP: "192.168.132.21"},
+				{Hostname: "k8s-worker02", IP: "192.168.132.22"},
+			},
+			Kubernetes: asset.Kubernetes{
+				KubernetesVersion: "v1.23.10",
+				Network: asset.Network{
+					ServiceSubnet: "10.96.0.0/16",
+					PodSubnet:     "10.244.0.0/16",
+					Plugin:        "calico",
+				},
+			},
+		}
+	}
+	clusterDir := filepath.Join(persistDir, "diff-cluster")
+	if err := os.MkdirAll(clusterDir, 0755); err != nil {
+		t.Fatalf("Error creating cluster directory: %v", err)
+	}
+	if err := newCluster().Persist(clusterDir); err != nil {
+		t.Fatalf("Error persisting cluster: %v", err)
+	}
+
+	changes, err := configmanager.DiffClusterConfig("diff-cluster", newCluster())
+	if err != nil {
+		t.Fatalf("Error diffing cluster config: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("Expected no changes for the same config, got %v", changes)
+	}
+
+	proposed := newCluster()
+	proposed.Master = append(proposed.Master, asset.NodeAsset{Hostname: "k8s-master02", IP: "192.168.132.12"})
+	proposed.Worker = []asset.NodeAsset{{Hostname: "k8s-worker02", IP: "192.168.132.32"}}
+	proposed.KubernetesVersion = "v1.24.1"
+	proposed.Network.PodSubnet = "10.100.0.0/16"
+	changes, err = configmanager.DiffClusterConfig("diff-cluster", proposed)
+	if err != nil {
+		t.Fatalf("Error diffing cluster config: %v", err)
+	}
+	expected := []string{
+		"master k8s-master02 added",
+		`worker k8s-worker02 ip changed: "192.168.132.22" -> "192.168.132.32"`,
+		"worker k8s-worker01 removed",
+		`kubernetes-version changed: "v1.23.10" -> "v1.24.1"`,
+		`network.pod-subnet changed: "10.244.0.0/16" -> "10.100.0.0/16"`,
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected changes %q, got %q", expected, changes)
+	}
+
+	if _, err := configmanager.DiffClusterConfig("unknown", proposed); err == nil {
+		t.Errorf("Expected an error diffing a cluster that is not persisted")
+	}
+	if _, err := configmanager.DiffClusterConfig("", proposed); err == nil {
+		t.Errorf("Expected an error diffing an empty cluster id")
+	}
+}
+
+func TestParseCIDRList(t *testing.T) {
+	tests := []struct {
+		subnets string
+		cidrs   []string
+		valid   bool
+	}{
+		{subnets: "10.96.0.0/16", cidrs: []string{"10.96.0.0/16"}, valid: true},
+		{subnets: "fd00:10:96::/112", cidrs: []string{"fd00:10:96::/112"}, valid: true},
+		{subnets: "10.96.0.0/16, fd00:10:96::/112", cidrs: []string{"10.96.0.0/16", "fd00:10:96::/112"}, valid: true},
+		{subnets: "fd00:10:96::/112,10.96.0.0/16", cidrs: []string{"fd00:10:96::/112", "10.96.0.0/16"}, valid: true},
+		{subnets: ""},
+		{subnets: "10.96.0.0"},
+		{subnets: "10.96.0.0/16,"},
+		{subnets: "10.96.0.0/16,10.97.0.0/16"},
+		{subnets: "fd00:10:96::/112,fd00:10:97::/112"},
+		{subnets: "10.96.0.0/16,fd00:10:96::/112,10.97.0.0/16"},
+	}
+	for _, tt := range tests {
+		ipNets, err := asset.ParseCIDRList(tt.subnets)
+		if !tt.valid {
+			if err == nil {
+				t.Errorf("Expected an error parsing %q, got %v", tt.subnets, ipNets)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Error parsing %q: %v", tt.subnets, err)
+			continue
+		}
+		var cidrs []string
+		for _, ipNet := range ipNets {
+			cidrs = append(cidrs, ipNet.String())
+		}
+		if !reflect.DeepEqual(cidrs, tt.cidrs) {
+			t.Errorf("Expected %v parsing %q, got %v", tt.cidrs, tt.subnets, cidrs)
+		}
+		if asset.IsDualStack(tt.subnets) != (len(tt.cidrs) == 2) {
+			t.Errorf("Unexpected dual-stack of %q", tt.subnets)
+		}
+	}
+}
+
+func TestClusterAssetDeepCopy(t *testing.T) {
+	original := &asset.ClusterAsset{
+		Cluster_ID:    "copy-cluster",
+		InfraPlatform: &asset.LibvirtAsset{URI: "qemu:///system"},
+		Master: []asset.NodeAsset{{
+			Hostname:  "k8s-master01",
+			Ignitions: asset.Ignitions{CreateIgnContent: []byte("master")},
+			Network:   asset.NetworkConfig{DNS: []string{"8.8.8.8"}},
+		}},
+		Worker: []asset.NodeAsset{{
+			Hostname:  "k8s-worker01",
+			Ignitions: asset.Ignitions{MergeIgnPath: "/ign/worker-merge.ign"},
+		}},
+		NodePools: []asset.NodePool{{Name: "gpu", Labels: map[string]string{"gpu": "true"}, Image: "/images/gpu.qcow2",
+			Count: 2, Ignition: "/ign/gpu.ign"}},
+		Kubernetes: asset.Kubernetes{
+			APIServerExtraArgs: map[string]string{"v": "2"},
+			FeatureGates:       map[string]bool{"Foo": true},
+			Registry:           asset.RegistryConfig{Mirrors: map[string][]string{"docker.io": {"mirror.local"}}},
+		},
+	}
+	configmanager.SetClusterConfig(original)
+	defer configmanager.RemoveClusterConfig("copy-cluster")
+
+	copied, err := configmanager.GetClusterConfigCopy("copy-cluster")
+	if err != nil {
+		t.Fatalf("Error getting cluster config copy: %v", err)
+	}
+	if !reflect.DeepEqual(copied, original) {
+		t.Fatalf("Expected the copy to equal the original, got %+v", copied)
+	}
+
+	// 并发读取原配置的同时修改副本，竞态检测不应报告数据竞争
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			clusterAsset, err := configmanager.GetClusterConfig("copy-cluster")
+			if err != nil {
+				t.Errorf("Error getting cluster config: %v", err)
+				return
+			}
+			_ = clusterAsset.Worker[0].MergeIgnPath
+			_ = clusterAsset.Master[0].CreateIgnContent[0]
+			_ = clusterAsset.APIServerExtraArgs["v"]
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		copied.Worker[0].MergeIgnPath = fmt.Sprintf("/ign/worker-%d-merge.ign", i)
+		copied.Master[0].CreateIgnContent[0] = 'M'
+		copied.APIServerExtraArgs["v"] = strconv.Itoa(i)
+	}
+	copied.Master[0].Network.DNS[0] = "1.1.1.1"
+	copied.NodePools[0].Labels["gpu"] = "false"
+	copied.FeatureGates["Foo"] = false
+	copied.Registry.Mirrors["docker.io"][0] = "other.local"
+	copied.InfraPlatform.(*asset.LibvirtAsset).URI = "qemu+ssh://host/system"
+	close(stop)
+	wg.Wait()
+
+	if original.Worker[0].MergeIgnPath != "/ign/worker-merge.ign" || string(original.Master[0].CreateIgnContent) != "master" ||
+		original.APIServerExtraArgs["v"] != "2" || original.Master[0].Network.DNS[0] != "8.8.8.8" ||
+		original.NodePools[0].Labels["gpu"] != "true" || !original.FeatureGates["Foo"] ||
+		original.Registry.Mirrors["docker.io"][0] != "mirror.local" ||
+		original.InfraPlatform.(*asset.LibvirtAsset).URI != "qemu:///system" {
+		t.Errorf("Expected the original to be unchanged by modifying the copy, got %+v", original)
+	}
+
+	configmanager.SetClusterConfig(copied)
+	stored, err := configmanager.GetClusterConfig("copy-cluster")
+	if err != nil {
+		t.Fatalf("Error getting cluster config: %v", err)
+	}
+	if stored.Worker[0].MergeIgnPath != "/ign/worker-99-merge.ign" {
+		t.Errorf("Expected the stored copy to replace the original, got %s", stored.Worker[0].MergeIgnPath)
+	}
+}
@@ -0,0 +1,1995 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ignition_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"nestos-kubernetes-deployer/pkg/cert"
+	"nestos-kubernetes-deployer/pkg/configmanager"
+	"nestos-kubernetes-deployer/pkg/configmanager/asset"
+	"nestos-kubernetes-deployer/pkg/configmanager/globalconfig"
+	"nestos-kubernetes-deployer/pkg/ignition"
+	"nestos-kubernetes-deployer/pkg/ignition/machine"
+	"nestos-kubernetes-deployer/pkg/tokens"
+	"nestos-kubernetes-deployer/pkg/utils"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	igntypes "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/vincent-petithory/dataurl"
+	"golang.org/x/crypto/ssh"
+	"gopkg.in/yaml.v2"
+)
+
+const testBootstrapHost = "192.168.132.1:9080"
+
+func newTestClusterAsset() *asset.ClusterAsset {
+	return &asset.ClusterAsset{
+		Cluster_ID: "cluster",
+		Runtime:    "isulad",
+		Master: []asset.NodeAsset{
+			{Hostname: "k8s-master01", IP: "192.168.132.11"},
+		},
+		Worker: []asset.NodeAsset{
+			{Hostname: "k8s-worker01", IP: "192.168.132.21"},
+		},
+		Kubernetes: asset.Kubernetes{
+			KubernetesVersion: "v1.23.10",
+			ApiServerEndpoint: "192.168.132.11:6443",
+			ImageRegistry:     "registry.example.com",
+		},
+	}
+}
+
+// setupGenerateEnv prepares the persist directory, ssh key and template assets used by GenerateFiles
+func setupGenerateEnv(t *testing.T, clusterAsset *asset.ClusterAsset) {
+	tmpDir := t.TempDir()
+	configmanager.GlobalConfig = &globalconfig.GlobalConfig{
+		PersistDir: tmpDir,
+	}
+
+	sshKey := filepath.Join(tmpDir, "id_rsa.pub")
+	if err := os.WriteFile(sshKey, []byte("ssh-rsa AAAA test"), 0644); err != nil {
+		t.Fatalf("Error writing ssh key: %v", err)
+	}
+	clusterAsset.SSHKey = sshKey
+
+	// Templates are read relative to the data directory in non-release builds
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Error getting working directory: %v", err)
+	}
+	if err := os.Chdir("../../data"); err != nil {
+		t.Fatalf("Error changing to data directory: %v", err)
+	}
+	t.Cleanup(func() {
+		os.Chdir(wd)
+	})
+}
+
+// getIgnitionFile returns the decoded content of the file at path in the ignition config
+func getIgnitionFile(t *testing.T, content []byte, path string) string {
+	config := &igntypes.Config{}
+	if err := json.Unmarshal(content, config); err != nil {
+		t.Fatalf("Error unmarshaling ignition config: %v", err)
+	}
+	for _, file := range config.Storage.Files {
+		if file.Path != path || file.Contents.Source == nil {
+			continue
+		}
+		data, err := dataurl.DecodeString(*file.Contents.Source)
+		if err != nil {
+			t.Fatalf("Error decoding %s: %v", path, err)
+		}
+		return string(data.Data)
+	}
+	t.Fatalf("Expected file %s in ignition config", path)
+	return ""
+}
+
+func TestGetTmplDataNodeRole(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+
+	// The shared template data does not specify a node role
+	tmplData, err := ignition.GetTmplData(clusterAsset)
+	if err != nil {
+		t.Fatalf("Error getting template data: %v", err)
+	}
+	if tmplData.NodeRole != "" {
+		t.Errorf("Expected empty node role, got %s", tmplData.NodeRole)
+	}
+
+	workerTmplData, err := ignition.GetWorkerTmplData(clusterAsset)
+	if err != nil {
+		t.Fatalf("Error getting worker template data: %v", err)
+	}
+	if workerTmplData.NodeRole != ignition.NodeRoleWorker {
+		t.Errorf("Expected node role %s, got %s", ignition.NodeRoleWorker, workerTmplData.NodeRole)
+	}
+
+	masterTmplData, err := ignition.GetMasterTmplData(clusterAsset)
+	if err != nil {
+		t.Fatalf("Error getting master template data: %v", err)
+	}
+	if masterTmplData.NodeRole != ignition.NodeRoleMaster {
+		t.Errorf("Expected node role %s, got %s", ignition.NodeRoleMaster, masterTmplData.NodeRole)
+	}
+}
+
+func TestGetTmplDataRequiredFields(t *testing.T) {
+	tests := map[string]func(*asset.ClusterAsset){
+		"kubernetes.apiserver-endpoint": func(c *asset.ClusterAsset) { c.Kubernetes.ApiServerEndpoint = "" },
+		"kubernetes.kubernetes-version": func(c *asset.ClusterAsset) { c.Kubernetes.KubernetesVersion = "" },
+		"kubernetes.image-registry":     func(c *asset.ClusterAsset) { c.Kubernetes.ImageRegistry = "" },
+	}
+	for field, blank := range tests {
+		clusterAsset := newTestClusterAsset()
+		blank(clusterAsset)
+		if _, err := ignition.GetTmplData(clusterAsset); err == nil || !strings.Contains(err.Error(), field) {
+			t.Errorf("Expected error naming %s, got %v", field, err)
+		}
+	}
+
+	// All missing fields are reported together
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.Kubernetes = asset.Kubernetes{}
+	_, err := ignition.GetTmplData(clusterAsset)
+	if err == nil {
+		t.Fatal("Expected error for empty kubernetes config")
+	}
+	for field := range tests {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("Expected error naming %s, got %v", field, err)
+		}
+	}
+}
+
+func TestGetTmplDataBootstrapToken(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	tmplData, err := ignition.GetTmplData(clusterAsset)
+	if err != nil {
+		t.Fatalf("Error getting template data: %v", err)
+	}
+	if !tokens.BootstrapTokenPattern.MatchString(tmplData.Token) {
+		t.Errorf("Expected a generated bootstrap token, got %q", tmplData.Token)
+	}
+	// master和worker的模板数据必须使用同一个token
+	workerTmplData, err := ignition.GetWorkerTmplData(clusterAsset)
+	if err != nil {
+		t.Fatalf("Error getting worker template data: %v", err)
+	}
+	if workerTmplData.Token != tmplData.Token || clusterAsset.Kubernetes.Token != tmplData.Token {
+		t.Errorf("Expected the generated token %s to be kept, got %s", tmplData.Token, workerTmplData.Token)
+	}
+	if tmplData.CaCertHash != "" {
+		t.Errorf("Expected no CA cert hash without a user root CA, got %s", tmplData.CaCertHash)
+	}
+
+	// 用户指定root CA时计算其哈希
+	rootCA, err := cert.GenerateAllCA("", "", "kubernetes", []string{"kubernetes"}, time.Hour)
+	if err != nil {
+		t.Fatalf("Error generating root CA: %v", err)
+	}
+	caCertPath := filepath.Join(t.TempDir(), "ca.crt")
+	if err := os.WriteFile(caCertPath, rootCA.CertRaw, 0644); err != nil {
+		t.Fatalf("Error writing root CA: %v", err)
+	}
+	clusterAsset = newTestClusterAsset()
+	clusterAsset.Kubernetes.Token = "abcdef.0123456789abcdef"
+	clusterAsset.CertAsset.RootCaCertPath = caCertPath
+	tmplData, err = ignition.GetTmplData(clusterAsset)
+	if err != nil {
+		t.Fatalf("Error getting template data: %v", err)
+	}
+	if tmplData.Token != "abcdef.0123456789abcdef" {
+		t.Errorf("Expected the configured token to be kept, got %s", tmplData.Token)
+	}
+	if expected := tokens.ComputeCACertHash(rootCA.CertRaw); tmplData.CaCertHash != expected || expected == "" {
+		t.Errorf("Expected CA cert hash %s, got %s", expected, tmplData.CaCertHash)
+	}
+
+	if err := os.WriteFile(caCertPath, []byte("not a certificate"), 0644); err != nil {
+		t.Fatalf("Error writing root CA: %v", err)
+	}
+	clusterAsset = newTestClusterAsset()
+	clusterAsset.CertAsset.RootCaCertPath = caCertPath
+	if _, err := ignition.GetTmplData(clusterAsset); err == nil || !strings.Contains(err.Error(), "invalid root CA certificate") {
+		t.Errorf("Expected an invalid root CA error, got %v", err)
+	}
+}
+
+func TestResolveKubeadmAPIVersion(t *testing.T) {
+	tests := map[string]string{
+		"v1.22.0":  "v1beta3",
+		"v1.27.4":  "v1beta3",
+		"v1.31.1":  "v1beta4",
+		"v1.20.15": "v1beta2",
+		"invalid":  "v1beta3",
+	}
+	for kubeVersion, expected := range tests {
+		if apiVersion := utils.ResolveKubeadmAPIVersion(kubeVersion); apiVersion != expected {
+			t.Errorf("Expected kubeadm api version %s for %s, got %s", expected, kubeVersion, apiVersion)
+		}
+	}
+
+	// GetTmplData falls back to the resolved api version only when none is configured
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.Kubernetes.KubernetesVersion = "v1.31.1"
+	tmplData, err := ignition.GetTmplData(clusterAsset)
+	if err != nil {
+		t.Fatalf("Error getting template data: %v", err)
+	}
+	if tmplData.KubeadmApiVersion != "v1beta4" {
+		t.Errorf("Expected kubeadm api version v1beta4, got %s", tmplData.KubeadmApiVersion)
+	}
+	clusterAsset.Kubernetes.KubernetesAPIVersion = "v1beta3"
+	tmplData, err = ignition.GetTmplData(clusterAsset)
+	if err != nil {
+		t.Fatalf("Error getting template data: %v", err)
+	}
+	if tmplData.KubeadmApiVersion != "v1beta3" {
+		t.Errorf("Expected configured kubeadm api version v1beta3, got %s", tmplData.KubeadmApiVersion)
+	}
+}
+
+func TestGenerateFilesUnknownRuntime(t *testing.T) {
+	sshKey := filepath.Join(t.TempDir(), "id_rsa.pub")
+	if err := os.WriteFile(sshKey, []byte("ssh-rsa AAAA test"), 0644); err != nil {
+		t.Fatalf("Error writing ssh key: %v", err)
+	}
+
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.SSHKey = sshKey
+	clusterAsset.Runtime = "unknown"
+
+	_, expectedErr := asset.GetRuntimeCriSocket(clusterAsset.Runtime)
+	if expectedErr == nil {
+		t.Fatalf("Expected an error for runtime %s", clusterAsset.Runtime)
+	}
+
+	worker := &machine.Worker{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := worker.GenerateFiles(); err == nil || err.Error() != expectedErr.Error() {
+		t.Errorf("Expected worker error %v, got %v", expectedErr, err)
+	}
+
+	master := &machine.Master{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := master.GenerateFiles(); err == nil || err.Error() != expectedErr.Error() {
+		t.Errorf("Expected master error %v, got %v", expectedErr, err)
+	}
+}
+
+func TestGenerateFilesHAMasters(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.Master = []asset.NodeAsset{
+		{Hostname: "k8s-master01", IP: "192.168.132.11"},
+		{Hostname: "k8s-master02", IP: "192.168.132.12", Labels: map[string]string{"zone": "a"}},
+		{Hostname: "k8s-master03", IP: "192.168.132.13", Labels: map[string]string{"zone": "b"}},
+	}
+	// The apiserver endpoint is a load balancer VIP in front of the masters
+	clusterAsset.Kubernetes.ApiServerEndpoint = "192.168.132.100:6443"
+	clusterAsset.Kubernetes.CertificateKey = strings.Repeat("ab", 32)
+	setupGenerateEnv(t, clusterAsset)
+
+	master := &machine.Master{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := master.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating master files: %v", err)
+	}
+
+	var initMasters []string
+	for _, node := range clusterAsset.Master {
+		config := &igntypes.Config{}
+		if err := json.Unmarshal(node.CreateIgnContent, config); err != nil {
+			t.Fatalf("Error unmarshaling ignition config of %s: %v", node.Hostname, err)
+		}
+		units := make(map[string]igntypes.Unit)
+		for _, unit := range config.Systemd.Units {
+			units[unit.Name] = unit
+		}
+		if _, ok := units["init-cluster.service"]; ok {
+			initMasters = append(initMasters, node.Hostname)
+			if _, ok := units["join-master.service"]; ok {
+				t.Errorf("Expected %s to not join the control plane it initializes", node.Hostname)
+			}
+			if filepath.Base(node.CreateIgnPath) != machine.ControlplaneIgnFilename {
+				t.Errorf("Expected %s to use %s, got %s", node.Hostname, machine.ControlplaneIgnFilename, node.CreateIgnPath)
+			}
+			continue
+		}
+		join, ok := units["join-master.service"]
+		if !ok || join.Contents == nil {
+			t.Errorf("Expected %s to join the control plane", node.Hostname)
+			continue
+		}
+		expected := "kubeadm join 192.168.132.100:6443 --token"
+		if !strings.Contains(*join.Contents, expected) ||
+			!strings.Contains(*join.Contents, "--control-plane --certificate-key "+clusterAsset.Kubernetes.CertificateKey) {
+			t.Errorf("Unexpected join-master.service of %s: %s", node.Hostname, *join.Contents)
+		}
+		// Joining masters do not share an ignition file since their network differs
+		expectedFile := "master-" + node.Hostname + ".ign"
+		if filepath.Base(node.CreateIgnPath) != expectedFile {
+			t.Errorf("Expected %s to use %s, got %s", node.Hostname, expectedFile, node.CreateIgnPath)
+		}
+		if _, err := os.Stat(node.CreateIgnPath); err != nil {
+			t.Errorf("Expected ignition file of %s to be written: %v", node.Hostname, err)
+		}
+		if filepath.Base(node.MergeIgnPath) != "master-"+node.Hostname+"-merge.ign" {
+			t.Errorf("Unexpected merge ignition of %s: %s", node.Hostname, node.MergeIgnPath)
+		}
+	}
+	if !reflect.DeepEqual(initMasters, []string{"k8s-master01"}) {
+		t.Errorf("Expected only k8s-master01 to initialize the cluster, got %v", initMasters)
+	}
+	if bytes.Equal(clusterAsset.Master[1].CreateIgnContent, clusterAsset.Master[2].CreateIgnContent) {
+		t.Errorf("Expected the joining masters with different labels to get different ignitions")
+	}
+
+	initConfig := getIgnitionFile(t, clusterAsset.Master[0].CreateIgnContent, "/etc/nkd/init-config.yaml")
+	if !strings.Contains(initConfig, `controlPlaneEndpoint: "192.168.132.100:6443"`) {
+		t.Errorf("Expected the VIP as control plane endpoint, got:\n%s", initConfig)
+	}
+}
+
+func TestGenerateFilesNodePools(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.NodePools = []asset.NodePool{
+		{Name: "gpu", Labels: map[string]string{"pool": "gpu"}, Taints: []string{"gpu=true:NoSchedule"}},
+		{Name: "memory", Labels: map[string]string{"pool": "memory"}},
+	}
+	clusterAsset.Worker = []asset.NodeAsset{
+		{Hostname: "k8s-worker01", Pool: "gpu"},
+		{Hostname: "k8s-worker02", Pool: "memory"},
+		{Hostname: "k8s-worker03", Pool: "gpu"},
+		{Hostname: "k8s-worker04"},
+	}
+	setupGenerateEnv(t, clusterAsset)
+
+	worker := &machine.Worker{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := worker.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating worker files: %v", err)
+	}
+
+	workers := clusterAsset.Worker
+	if workers[0].CreateIgnPath != workers[2].CreateIgnPath {
+		t.Errorf("Expected workers of the same pool to share ignition, got %s and %s",
+			workers[0].CreateIgnPath, workers[2].CreateIgnPath)
+	}
+	if workers[0].CreateIgnPath == workers[1].CreateIgnPath {
+		t.Errorf("Expected workers of different pools to use distinct ignition")
+	}
+	if filepath.Base(workers[3].CreateIgnPath) != machine.WorkerIgnFilename {
+		t.Errorf("Expected default pool to use %s, got %s", machine.WorkerIgnFilename, workers[3].CreateIgnPath)
+	}
+
+	gpuArgs := getIgnitionFile(t, workers[0].CreateIgnContent, "/etc/sysconfig/kubelet")
+	if !strings.Contains(gpuArgs, "--node-labels=pool=gpu") || !strings.Contains(gpuArgs, "--register-with-taints=gpu=true:NoSchedule") {
+		t.Errorf("Unexpected kubelet args for gpu pool: %s", gpuArgs)
+	}
+	memoryArgs := getIgnitionFile(t, workers[1].CreateIgnContent, "/etc/sysconfig/kubelet")
+	if !strings.Contains(memoryArgs, "--node-labels=pool=memory") || strings.Contains(memoryArgs, "--register-with-taints") {
+		t.Errorf("Unexpected kubelet args for memory pool: %s", memoryArgs)
+	}
+}
+
+func TestGenerateFilesNodePoolIgnition(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	overrides := map[string]string{
+		"gpu":    `{"ignition":{"version":"3.2.0"},"storage":{"files":[{"path":"/etc/gpu.conf"}]}}`,
+		"memory": `{"ignition":{"version":"3.2.0"},"storage":{"files":[{"path":"/etc/memory.conf"}]}}`,
+	}
+	for _, name := range []string{"gpu", "memory"} {
+		path := filepath.Join(t.TempDir(), name+".ign")
+		if err := os.WriteFile(path, []byte(overrides[name]), 0644); err != nil {
+			t.Fatalf("Error writing node pool ignition: %v", err)
+		}
+		clusterAsset.NodePools = append(clusterAsset.NodePools, asset.NodePool{Name: name, Ignition: path})
+	}
+	clusterAsset.Worker = []asset.NodeAsset{
+		{Hostname: "k8s-worker01", Pool: "gpu"},
+		{Hostname: "k8s-worker02", Pool: "memory"},
+		{Hostname: "k8s-worker03"},
+	}
+	setupGenerateEnv(t, clusterAsset)
+
+	worker := &machine.Worker{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := worker.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating worker files: %v", err)
+	}
+
+	for i, want := range []string{overrides["gpu"], overrides["memory"], ""} {
+		config := &igntypes.Config{}
+		if err := json.Unmarshal(clusterAsset.Worker[i].CreateIgnContent, config); err != nil {
+			t.Fatalf("Error unmarshaling ignition config: %v", err)
+		}
+		var merged []string
+		for _, resource := range config.Ignition.Config.Merge {
+			decoded, err := dataurl.DecodeString(*resource.Source)
+			if err != nil {
+				t.Fatalf("Error decoding merged config of worker %d: %v", i, err)
+			}
+			merged = append(merged, string(decoded.Data))
+		}
+		if want == "" && len(merged) != 0 {
+			t.Errorf("Expected no merged configs for the default pool, got %v", merged)
+		}
+		if want != "" && !reflect.DeepEqual(merged, []string{want}) {
+			t.Errorf("Expected worker %d to merge %s, got %v", i, want, merged)
+		}
+	}
+}
+
+func TestGenerateNodeLabelsAndTaints(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.NodePools = []asset.NodePool{
+		{Name: "gpu", Labels: map[string]string{"accelerator": "nvidia"}, Taints: []string{"gpu=true:NoSchedule"}},
+	}
+	clusterAsset.Master[0].Labels = map[string]string{"topology.kubernetes.io/zone": "zone-a"}
+	clusterAsset.Worker = []asset.NodeAsset{
+		{Hostname: "k8s-worker01", Pool: "gpu", Labels: map[string]string{"accelerator": "a100"},
+			Taints: []string{"dedicated=ml:NoSchedule"}},
+		{Hostname: "k8s-worker02", Pool: "gpu"},
+		{Hostname: "k8s-worker03", Taints: []string{"dedicated=infra:NoSchedule"}},
+	}
+	setupGenerateEnv(t, clusterAsset)
+
+	worker := &machine.Worker{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := worker.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating worker files: %v", err)
+	}
+	workers := clusterAsset.Worker
+	if workers[0].CreateIgnPath == workers[1].CreateIgnPath {
+		t.Errorf("Expected a worker with its own labels and taints to get its own ignition")
+	}
+
+	tests := []struct {
+		content []byte
+		labels  string
+		taints  string
+	}{
+		{
+			content: workers[0].CreateIgnContent,
+			labels:  "--node-labels=accelerator=a100,upgrade.housekeeper.io/node-pool=gpu",
+			taints:  "--register-with-taints=gpu=true:NoSchedule,dedicated=ml:NoSchedule",
+		},
+		{
+			content: workers[1].CreateIgnContent,
+			labels:  "--node-labels=accelerator=nvidia,upgrade.housekeeper.io/node-pool=gpu",
+			taints:  "--register-with-taints=gpu=true:NoSchedule",
+		},
+		{
+			content: workers[2].CreateIgnContent,
+			taints:  "--register-with-taints=dedicated=infra:NoSchedule",
+		},
+	}
+	for i, tt := range tests {
+		args := getIgnitionFile(t, tt.content, "/etc/sysconfig/kubelet")
+		if tt.labels == "" && strings.Contains(args, "--node-labels") {
+			t.Errorf("Expected no node labels for worker %d, got %s", i, args)
+		}
+		if !strings.Contains(args, tt.labels) || !strings.HasSuffix(strings.TrimSpace(args), tt.taints+"\"") {
+			t.Errorf("Expected worker %d kubelet args with %q and %q, got %s", i, tt.labels, tt.taints, args)
+		}
+	}
+
+	master := &machine.Master{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := master.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating master files: %v", err)
+	}
+	args := getIgnitionFile(t, clusterAsset.Master[0].CreateIgnContent, "/etc/sysconfig/kubelet")
+	if !strings.Contains(args, "--node-labels=topology.kubernetes.io/zone=zone-a") ||
+		strings.Contains(args, "--register-with-taints") {
+		t.Errorf("Unexpected master kubelet args: %s", args)
+	}
+}
+
+func TestSandboxImageMatchesPauseImage(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.Kubernetes.ImageRegistry = "registry.example.com/k8s"
+	clusterAsset.Kubernetes.PauseImage = "pause:3.6"
+	setupGenerateEnv(t, clusterAsset)
+	pauseImage := clusterAsset.Kubernetes.ImageRegistry + "/" + clusterAsset.Kubernetes.PauseImage
+
+	tmplData, err := ignition.GetTmplData(clusterAsset)
+	if err != nil {
+		t.Fatalf("Error getting template data: %v", err)
+	}
+	if tmplData.SandboxImage != pauseImage {
+		t.Errorf("Expected sandbox image %s, got %s", pauseImage, tmplData.SandboxImage)
+	}
+
+	worker := &machine.Worker{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := worker.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating worker files: %v", err)
+	}
+	content := clusterAsset.Worker[0].CreateIgnContent
+
+	// Every runtime must pin its sandbox image to the configured pause image
+	pivot := getIgnitionFile(t, content, "/etc/nkd/node-pivot.sh")
+	for _, expected := range []string{
+		`sandbox_image = "` + pauseImage + `"`,
+		`pause_image = "` + pauseImage + `"`,
+	} {
+		if !strings.Contains(pivot, expected) {
+			t.Errorf("Expected node-pivot.sh to contain %s", expected)
+		}
+	}
+	daemon := getIgnitionFile(t, content, "/etc/isulad/daemon.json")
+	if !strings.Contains(daemon, `"pod-sandbox-image": "`+pauseImage+`"`) {
+		t.Errorf("Expected isulad pod-sandbox-image %s, got %s", pauseImage, daemon)
+	}
+}
+
+func TestWorkerGenerateFilesDryRun(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	setupGenerateEnv(t, clusterAsset)
+
+	tmplData, err := ignition.GetWorkerTmplData(clusterAsset)
+	if err != nil {
+		t.Fatalf("Error getting template data: %v", err)
+	}
+	common := &ignition.Common{
+		UserName: clusterAsset.UserName,
+		NodeType: "worker",
+		TmplData: tmplData,
+		DryRun:   true,
+	}
+	data, err := common.RenderToBytes()
+	if err != nil {
+		t.Fatalf("Error rendering ignition config: %v", err)
+	}
+	config := &igntypes.Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		t.Fatalf("Expected rendered bytes to be an ignition config: %v", err)
+	}
+	if config.Ignition.Version != igntypes.MaxVersion.String() || len(config.Storage.Files) == 0 {
+		t.Errorf("Unexpected ignition config: version %s with %d files",
+			config.Ignition.Version, len(config.Storage.Files))
+	}
+
+	worker := &machine.Worker{ClusterAsset: clusterAsset, DryRun: true}
+	if err := worker.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating worker files: %v", err)
+	}
+	if len(clusterAsset.Worker[0].CreateIgnContent) == 0 {
+		t.Errorf("Expected rendered ignition content in dry run")
+	}
+	ignitionDir := filepath.Join(configmanager.GetPersistDir(), clusterAsset.Cluster_ID, "ignition")
+	if _, err := os.Stat(ignitionDir); !os.IsNotExist(err) {
+		t.Errorf("Expected no ignition files to be written in dry run, got %v", err)
+	}
+}
+
+func TestGenerateExtraFiles(t *testing.T) {
+	extraDir := t.TempDir()
+	writeExtraFile := func(name string, content string, mode os.FileMode) {
+		path := filepath.Join(extraDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Error creating extra files directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), mode); err != nil {
+			t.Fatalf("Error writing extra file: %v", err)
+		}
+	}
+	// 覆盖内置的 /etc/hosts 模板，并新增一个文件
+	writeExtraFile("etc/hosts.template", "127.0.0.1 overlay {{.KubeVersion}}\n", 0644)
+	writeExtraFile("etc/nkd/extra.conf", "extra=true\n", 0600)
+
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.ExtraFilesDir = extraDir
+	setupGenerateEnv(t, clusterAsset)
+
+	worker := &machine.Worker{ClusterAsset: clusterAsset, DryRun: true}
+	if err := worker.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating worker files: %v", err)
+	}
+	content := clusterAsset.Worker[0].CreateIgnContent
+	if hosts := getIgnitionFile(t, content, "/etc/hosts"); hosts != "127.0.0.1 overlay v1.23.10\n" {
+		t.Errorf("Expected the extra /etc/hosts to replace the embedded one, got %q", hosts)
+	}
+	if extra := getIgnitionFile(t, content, "/etc/nkd/extra.conf"); extra != "extra=true\n" {
+		t.Errorf("Unexpected /etc/nkd/extra.conf: %q", extra)
+	}
+
+	config := &igntypes.Config{}
+	if err := json.Unmarshal(content, config); err != nil {
+		t.Fatalf("Error unmarshaling ignition config: %v", err)
+	}
+	hostsFiles := 0
+	for _, file := range config.Storage.Files {
+		switch file.Path {
+		case "/etc/hosts":
+			hostsFiles++
+		case "/etc/nkd/extra.conf":
+			if file.Mode == nil || *file.Mode != 0600 {
+				t.Errorf("Expected mode 0600 of /etc/nkd/extra.conf, got %v", file.Mode)
+			}
+		}
+	}
+	if hostsFiles != 1 {
+		t.Errorf("Expected a single /etc/hosts, got %d", hostsFiles)
+	}
+
+	clusterAsset.ExtraFilesDir = filepath.Join(extraDir, "etc", "nkd", "extra.conf")
+	if err := clusterAsset.Validate(); err == nil || !strings.Contains(err.Error(), "extra-files-dir") {
+		t.Errorf("Expected a validation error for a file as extra-files-dir, got %v", err)
+	}
+}
+
+func TestGenerateBrokenTemplateError(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Error getting working directory: %v", err)
+	}
+	tmpDir := t.TempDir()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("Error changing to temp directory: %v", err)
+	}
+	t.Cleanup(func() {
+		os.Chdir(wd)
+	})
+
+	writeTemplate := func(name string, content string) {
+		fullPath := filepath.Join(tmpDir, "data", name)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatalf("Error creating template directory: %v", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Error writing template: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected string
+	}{
+		{"parse error in storage file", "ignition/worker/files/etc/broken.conf.template", "{{ .NodeName }",
+			"ignition/worker/files/etc/broken.conf.template"},
+		{"execute error in storage file", "ignition/worker/files/etc/broken.conf.template", "{{ .NoSuchField }}",
+			"ignition/worker/files/etc/broken.conf.template"},
+		{"parse error in systemd unit", "ignition/worker/systemd/broken.service.template", "{{ if }}",
+			"ignition/worker/systemd/broken.service.template"},
+	}
+	for _, tt := range tests {
+		os.RemoveAll(filepath.Join(tmpDir, "data"))
+		writeTemplate("ignition/worker/files/etc/hosts.template", "{{ .Hsip }}")
+		writeTemplate("ignition/worker/systemd/kubelet.service", "[Unit]")
+		writeTemplate(tt.file, tt.content)
+
+		generateFile := &ignition.Common{
+			NodeType: "worker",
+			TmplData: &ignition.TmplData{},
+		}
+		err := generateFile.Generate()
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("%s: expected error to mention %s, got %v", tt.name, tt.expected, err)
+		}
+	}
+}
+
+func TestGenerateTimezone(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	setupGenerateEnv(t, clusterAsset)
+	tmplData, err := ignition.GetWorkerTmplData(clusterAsset)
+	if err != nil {
+		t.Fatalf("Error getting template data: %v", err)
+	}
+
+	tests := []struct {
+		timezone string
+		target   string
+	}{
+		{"", "/usr/share/zoneinfo/Asia/Shanghai"},
+		{"UTC", "/usr/share/zoneinfo/UTC"},
+	}
+	for _, tt := range tests {
+		generateFile := &ignition.Common{NodeType: "worker", TmplData: tmplData, Timezone: tt.timezone}
+		if err := generateFile.Generate(); err != nil {
+			t.Fatalf("Error generating ignition config for timezone %q: %v", tt.timezone, err)
+		}
+		links := generateFile.Config.Storage.Links
+		if len(links) != 1 || links[0].Path != "/etc/localtime" || links[0].Target != tt.target {
+			t.Errorf("Expected /etc/localtime to link to %s, got %+v", tt.target, links)
+		}
+	}
+
+	generateFile := &ignition.Common{NodeType: "worker", TmplData: tmplData, Timezone: "../../etc/passwd"}
+	if err := generateFile.Generate(); err == nil {
+		t.Errorf("Expected an error for an invalid timezone")
+	}
+}
+
+func TestGenerateFilesTimezone(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.Timezone = "UTC"
+	setupGenerateEnv(t, clusterAsset)
+
+	master := &machine.Master{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := master.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating master files: %v", err)
+	}
+	worker := &machine.Worker{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := worker.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating worker files: %v", err)
+	}
+
+	for _, node := range []asset.NodeAsset{clusterAsset.Master[0], clusterAsset.Worker[0]} {
+		config := &igntypes.Config{}
+		if err := json.Unmarshal(node.CreateIgnContent, config); err != nil {
+			t.Fatalf("Error unmarshaling ignition config of %s: %v", node.Hostname, err)
+		}
+		links := config.Storage.Links
+		if len(links) != 1 || links[0].Target != "/usr/share/zoneinfo/UTC" {
+			t.Errorf("Expected /etc/localtime of %s to link to /usr/share/zoneinfo/UTC, got %+v", node.Hostname, links)
+		}
+	}
+}
+
+func TestGenerateSSHAuthorizedKeys(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	setupGenerateEnv(t, clusterAsset)
+	tmplData, err := ignition.GetWorkerTmplData(clusterAsset)
+	if err != nil {
+		t.Fatalf("Error getting template data: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		sshKey   string
+		sshKeys  []string
+		expected []igntypes.SSHAuthorizedKey
+	}{
+		{
+			name:     "one key",
+			sshKey:   "ssh-rsa AAAA alice\n",
+			expected: []igntypes.SSHAuthorizedKey{"ssh-rsa AAAA alice"},
+		},
+		{
+			name:    "multiple keys",
+			sshKey:  "ssh-rsa AAAA alice\n\n# bob\nssh-ed25519 BBBB bob\n",
+			sshKeys: []string{" ssh-ed25519 BBBB bob ", "", "ssh-ed25519 CCCC carol"},
+			expected: []igntypes.SSHAuthorizedKey{
+				"ssh-ed25519 BBBB bob", "ssh-ed25519 CCCC carol", "ssh-rsa AAAA alice",
+			},
+		},
+		{
+			name:    "empty",
+			sshKey:  "\n",
+			sshKeys: []string{"", "  "},
+		},
+	}
+	for _, tt := range tests {
+		generateFile := &ignition.Common{NodeType: "worker", TmplData: tmplData, SSHKey: tt.sshKey, SSHKeys: tt.sshKeys}
+		if err := generateFile.Generate(); err != nil {
+			t.Fatalf("Error generating ignition config with %s: %v", tt.name, err)
+		}
+		keys := generateFile.Config.Passwd.Users[0].SSHAuthorizedKeys
+		if len(keys) != len(tt.expected) || (len(keys) > 0 && !reflect.DeepEqual(keys, tt.expected)) {
+			t.Errorf("Expected authorized keys %v with %s, got %v", tt.expected, tt.name, keys)
+		}
+	}
+}
+
+func TestGenerateFilesSSHKeys(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.SSHKeys = []string{"ssh-ed25519 CCCC carol", "ssh-ed25519 DDDD dave\nssh-rsa AAAA test"}
+	setupGenerateEnv(t, clusterAsset)
+
+	master := &machine.Master{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := master.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating master files: %v", err)
+	}
+	worker := &machine.Worker{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := worker.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating worker files: %v", err)
+	}
+	bootstrap := &machine.Bootstrap{ClusterAsset: clusterAsset, Port: "9080"}
+	if err := bootstrap.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating bootstrap files: %v", err)
+	}
+
+	contents := map[string][]byte{
+		"master":    clusterAsset.Master[0].CreateIgnContent,
+		"worker":    clusterAsset.Worker[0].CreateIgnContent,
+		"bootstrap": bootstrap.CreateIgnContent,
+	}
+	expected := []igntypes.SSHAuthorizedKey{
+		"ssh-ed25519 CCCC carol", "ssh-ed25519 DDDD dave", "ssh-rsa AAAA test",
+	}
+	for node, content := range contents {
+		config := &igntypes.Config{}
+		if err := json.Unmarshal(content, config); err != nil {
+			t.Fatalf("Error unmarshaling %s ignition config: %v", node, err)
+		}
+		if keys := config.Passwd.Users[0].SSHAuthorizedKeys; !reflect.DeepEqual(keys, expected) {
+			t.Errorf("Expected %s authorized keys %v, got %v", node, expected, keys)
+		}
+	}
+}
+
+func TestGenerateFilesStaticNetwork(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.Worker = []asset.NodeAsset{
+		{
+			Hostname: "k8s-worker01",
+			Network: asset.NetworkConfig{
+				Interface: "ens3",
+				IP:        "192.168.132.21",
+				Gateway:   "192.168.132.1",
+				Prefix:    24,
+				DNS:       []string{"192.168.132.1", "8.8.8.8"},
+			},
+		},
+		{Hostname: "k8s-worker02"},
+	}
+	setupGenerateEnv(t, clusterAsset)
+
+	worker := &machine.Worker{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := worker.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating worker files: %v", err)
+	}
+
+	keyfilePath := "/etc/NetworkManager/system-connections/ens3.nmconnection"
+	expected := `[connection]
+id=ens3
+type=ethernet
+interface-name=ens3
+
+[ipv4]
+method=manual
+address1=192.168.132.21/24,192.168.132.1
+dns=192.168.132.1;8.8.8.8;
+
+[ipv6]
+method=ignore
+`
+	if keyfile := getIgnitionFile(t, clusterAsset.Worker[0].CreateIgnContent, keyfilePath); keyfile != expected {
+		t.Errorf("Unexpected keyfile content:\n%s", keyfile)
+	}
+
+	config := &igntypes.Config{}
+	if err := json.Unmarshal(clusterAsset.Worker[0].CreateIgnContent, config); err != nil {
+		t.Fatalf("Error unmarshaling ignition config: %v", err)
+	}
+	for _, file := range config.Storage.Files {
+		if file.Path == keyfilePath && (file.Mode == nil || *file.Mode != 0600) {
+			t.Errorf("Expected keyfile mode 0600, got %v", file.Mode)
+		}
+	}
+
+	// Workers without a static network config keep using DHCP
+	if filepath.Base(clusterAsset.Worker[1].CreateIgnPath) != machine.WorkerIgnFilename {
+		t.Errorf("Expected the DHCP worker to use %s, got %s", machine.WorkerIgnFilename, clusterAsset.Worker[1].CreateIgnPath)
+	}
+	config = &igntypes.Config{}
+	if err := json.Unmarshal(clusterAsset.Worker[1].CreateIgnContent, config); err != nil {
+		t.Fatalf("Error unmarshaling ignition config: %v", err)
+	}
+	for _, file := range config.Storage.Files {
+		if strings.HasPrefix(file.Path, "/etc/NetworkManager/system-connections/") {
+			t.Errorf("Expected no keyfile for the DHCP worker, got %s", file.Path)
+		}
+	}
+}
+
+func TestGenerateRegistriesConf(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.Registry = asset.RegistryConfig{
+		Mirrors:  map[string][]string{"docker.io": {"hub.local:5000", "mirror.example.com"}},
+		Insecure: []string{"hub.local:5000"},
+	}
+	setupGenerateEnv(t, clusterAsset)
+
+	worker := &machine.Worker{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := worker.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating worker files: %v", err)
+	}
+
+	expected := `[[registry]]
+prefix = "docker.io"
+location = "docker.io"
+
+[[registry.mirror]]
+location = "hub.local:5000"
+insecure = true
+
+[[registry.mirror]]
+location = "mirror.example.com"
+
+[[registry]]
+prefix = "hub.local:5000"
+location = "hub.local:5000"
+insecure = true
+`
+	confPath := "/etc/containers/registries.conf.d/99-nkd-registries.conf"
+	if conf := getIgnitionFile(t, clusterAsset.Worker[0].CreateIgnContent, confPath); conf != expected {
+		t.Errorf("Unexpected registries config:\n%s", conf)
+	}
+
+	// No drop-in is rendered without a registry config
+	tmplData, err := ignition.GetWorkerTmplData(clusterAsset)
+	if err != nil {
+		t.Fatalf("Error getting template data: %v", err)
+	}
+	generateFile := &ignition.Common{NodeType: "worker", TmplData: tmplData, Registry: &asset.RegistryConfig{}}
+	if err := generateFile.Generate(); err != nil {
+		t.Fatalf("Error generating ignition config: %v", err)
+	}
+	for _, file := range generateFile.Config.Storage.Files {
+		if file.Path == confPath {
+			t.Errorf("Expected no registries config for an empty registry config")
+		}
+	}
+}
+
+func TestPauseImageFallbacks(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.Runtime = "crio"
+	clusterAsset.Kubernetes.PauseImage = "pause:3.6"
+	clusterAsset.Kubernetes.PauseImageFallbacks = []string{"mirror-a.example.com", "mirror-b.example.com:5000"}
+	clusterAsset.Registry = asset.RegistryConfig{
+		Mirrors:  map[string][]string{"registry.example.com": {"cache.example.com"}},
+		Insecure: []string{"mirror-b.example.com:5000"},
+	}
+	setupGenerateEnv(t, clusterAsset)
+
+	tmplData, err := ignition.GetTmplData(clusterAsset)
+	if err != nil {
+		t.Fatalf("Error getting template data: %v", err)
+	}
+	// The pause image itself is still pulled from the image registry first
+	if tmplData.SandboxImage != "registry.example.com/pause:3.6" {
+		t.Errorf("Expected sandbox image registry.example.com/pause:3.6, got %s", tmplData.SandboxImage)
+	}
+
+	worker := &machine.Worker{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := worker.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating worker files: %v", err)
+	}
+
+	// Only the pause repository falls back, the other images of the registry keep their mirrors
+	expected := `[[registry]]
+prefix = "mirror-b.example.com:5000"
+location = "mirror-b.example.com:5000"
+insecure = true
+
+[[registry]]
+prefix = "registry.example.com"
+location = "registry.example.com"
+
+[[registry.mirror]]
+location = "cache.example.com"
+
+[[registry]]
+prefix = "registry.example.com/pause"
+location = "registry.example.com/pause"
+
+[[registry.mirror]]
+location = "cache.example.com/pause"
+
+[[registry.mirror]]
+location = "registry.example.com/pause"
+
+[[registry.mirror]]
+location = "mirror-a.example.com/pause"
+
+[[registry.mirror]]
+location = "mirror-b.example.com:5000/pause"
+insecure = true
+`
+	confPath := "/etc/containers/registries.conf.d/99-nkd-registries.conf"
+	if conf := getIgnitionFile(t, clusterAsset.Worker[0].CreateIgnContent, confPath); conf != expected {
+		t.Errorf("Unexpected registries config:\n%s", conf)
+	}
+	// The configured registry config is not modified
+	if len(clusterAsset.Registry.Mirrors) != 1 {
+		t.Errorf("Expected the registry config to keep its mirrors, got %v", clusterAsset.Registry.Mirrors)
+	}
+}
+
+func TestGenerateSSHHostKeys(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.ProvisionSSHHostKeys = true
+	setupGenerateEnv(t, clusterAsset)
+	if err := clusterAsset.EnsureSSHHostKeys(); err != nil {
+		t.Fatalf("Error generating ssh host keys: %v", err)
+	}
+
+	worker := &machine.Worker{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := worker.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating worker files: %v", err)
+	}
+	if filepath.Base(clusterAsset.Worker[0].CreateIgnPath) != "worker-k8s-worker01.ign" {
+		t.Errorf("Expected a worker with host keys to get its own ignition, got %s", clusterAsset.Worker[0].CreateIgnPath)
+	}
+
+	config := &igntypes.Config{}
+	if err := json.Unmarshal(clusterAsset.Worker[0].CreateIgnContent, config); err != nil {
+		t.Fatalf("Error unmarshaling ignition config: %v", err)
+	}
+	modes := make(map[string]int)
+	for _, file := range config.Storage.Files {
+		if file.Mode != nil {
+			modes[file.Path] = *file.Mode
+		}
+	}
+	firstKeys := make(map[string]string)
+	for _, keyType := range []string{"ed25519", "ecdsa", "rsa"} {
+		keyPath := "/etc/ssh/ssh_host_" + keyType + "_key"
+		if modes[keyPath] != 0600 || modes[keyPath+".pub"] != 0644 {
+			t.Errorf("Expected %s with mode 0600 and its public key with 0644, got %o and %o",
+				keyPath, modes[keyPath], modes[keyPath+".pub"])
+		}
+		key := getIgnitionFile(t, clusterAsset.Worker[0].CreateIgnContent, keyPath)
+		checkSSHHostKeyPair(t, keyPath, key, getIgnitionFile(t, clusterAsset.Worker[0].CreateIgnContent, keyPath+".pub"))
+		firstKeys[keyPath] = key
+	}
+
+	// The keys are stored in the persisted cluster config and reused when the ignition is regenerated
+	persistDir := t.TempDir()
+	if err := clusterAsset.Persist(persistDir); err != nil {
+		t.Fatalf("Error persisting cluster asset: %v", err)
+	}
+	reloaded, err := asset.LoadClusterAsset(filepath.Join(persistDir, "cluster_config.yaml"))
+	if err != nil {
+		t.Fatalf("Error loading cluster asset: %v", err)
+	}
+	if err := reloaded.EnsureSSHHostKeys(); err != nil {
+		t.Fatalf("Error ensuring ssh host keys: %v", err)
+	}
+	reloaded.SSHKey = clusterAsset.SSHKey
+	worker = &machine.Worker{ClusterAsset: reloaded, BootstrapBaseurl: testBootstrapHost}
+	if err := worker.GenerateFiles(); err != nil {
+		t.Fatalf("Error regenerating worker files: %v", err)
+	}
+	for keyPath, key := range firstKeys {
+		if regenerated := getIgnitionFile(t, reloaded.Worker[0].CreateIgnContent, keyPath); regenerated != key {
+			t.Errorf("Expected %s to be stable across regeneration", keyPath)
+		}
+	}
+}
+
+// checkSSHHostKeyPair parses the host key pair the way sshd does and checks the private key signs for the public key
+func checkSSHHostKeyPair(t *testing.T, keyPath string, privateKey string, publicKey string) {
+	t.Helper()
+	signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+	if err != nil {
+		t.Errorf("Error parsing private key %s: %v", keyPath, err)
+		return
+	}
+	fields := strings.Fields(publicKey)
+	if len(fields) != 3 || fields[2] != "k8s-worker01" {
+		t.Errorf("Expected %s.pub in the authorized_keys format with the hostname as comment, got %q", keyPath, publicKey)
+		return
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		t.Errorf("Error decoding public key %s.pub: %v", keyPath, err)
+		return
+	}
+	pub, err := ssh.ParsePublicKey(blob)
+	if err != nil {
+		t.Errorf("Error parsing public key %s.pub: %v", keyPath, err)
+		return
+	}
+	if pub.Type() != fields[0] || !bytes.Equal(signer.PublicKey().Marshal(), pub.Marshal()) {
+		t.Errorf("Expected %s.pub to be the public key of %s, got %s", keyPath, keyPath, pub.Type())
+		return
+	}
+	data := []byte("nestos-kubernetes-deployer")
+	signature, err := signer.Sign(rand.Reader, data)
+	if err != nil {
+		t.Errorf("Error signing with %s: %v", keyPath, err)
+		return
+	}
+	if err := pub.Verify(data, signature); err != nil {
+		t.Errorf("Expected the signature of %s to verify with %s.pub: %v", keyPath, keyPath, err)
+	}
+}
+
+func TestGenerateDisks(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.Worker[0].Disks = []asset.DiskConfig{{
+		Device:    "/dev/vdb",
+		WipeTable: true,
+		Partitions: []asset.PartitionConfig{
+			{Label: "var", Format: "xfs", MountPoint: "/var"},
+			{Label: "swap", SizeMiB: 2048, Format: "swap"},
+		},
+	}}
+	setupGenerateEnv(t, clusterAsset)
+
+	worker := &machine.Worker{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := worker.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating worker files: %v", err)
+	}
+	if filepath.Base(clusterAsset.Worker[0].CreateIgnPath) != "worker-k8s-worker01.ign" {
+		t.Errorf("Expected a worker with disks to get its own ignition, got %s", clusterAsset.Worker[0].CreateIgnPath)
+	}
+
+	config := &igntypes.Config{}
+	if err := json.Unmarshal(clusterAsset.Worker[0].CreateIgnContent, config); err != nil {
+		t.Fatalf("Error unmarshaling ignition config: %v", err)
+	}
+	if len(config.Storage.Disks) != 1 || config.Storage.Disks[0].Device != "/dev/vdb" {
+		t.Fatalf("Expected the disk /dev/vdb, got %+v", config.Storage.Disks)
+	}
+	disk := config.Storage.Disks[0]
+	if disk.WipeTable == nil || !*disk.WipeTable || len(disk.Partitions) != 2 {
+		t.Fatalf("Expected a wiped disk with 2 partitions, got %+v", disk)
+	}
+	if disk.Partitions[0].SizeMiB != nil || *disk.Partitions[1].SizeMiB != 2048 {
+		t.Errorf("Expected the var partition to fill the disk and swap to be 2048 MiB")
+	}
+
+	filesystems := make(map[string]igntypes.Filesystem)
+	for _, fs := range config.Storage.Filesystems {
+		filesystems[fs.Device] = fs
+	}
+	varFS, ok := filesystems["/dev/disk/by-partlabel/var"]
+	if !ok || *varFS.Format != "xfs" || varFS.Path == nil || *varFS.Path != "/var" {
+		t.Errorf("Expected a xfs filesystem at /var, got %+v", varFS)
+	}
+	swapFS, ok := filesystems["/dev/disk/by-partlabel/swap"]
+	if !ok || *swapFS.Format != "swap" || swapFS.Path != nil {
+		t.Errorf("Expected an unmounted swap filesystem, got %+v", swapFS)
+	}
+
+	units := make(map[string]string)
+	for _, unit := range config.Systemd.Units {
+		if unit.Contents != nil {
+			units[unit.Name] = *unit.Contents
+		}
+	}
+	mount, ok := units["var.mount"]
+	if !ok {
+		t.Fatalf("Expected a var.mount unit, got units %v", config.Systemd.Units)
+	}
+	for _, line := range []string{
+		`Requires=systemd-fsck@dev-disk-by\x2dpartlabel-var.service`,
+		"What=/dev/disk/by-partlabel/var",
+		"Where=/var",
+		"Type=xfs",
+		"RequiredBy=local-fs.target",
+	} {
+		if !strings.Contains(mount, line) {
+			t.Errorf("Expected var.mount to contain %q, got:\n%s", line, mount)
+		}
+	}
+	if swap := units[`dev-disk-by\x2dpartlabel-swap.swap`]; !strings.Contains(swap, "What=/dev/disk/by-partlabel/swap") {
+		t.Errorf("Expected a swap unit for the swap partition, got units %v", config.Systemd.Units)
+	}
+}
+
+func TestMergeUnitDropInsIntoConfig(t *testing.T) {
+	config := &igntypes.Config{}
+	config.Systemd.Units = []igntypes.Unit{
+		{
+			Name:    "kubelet.service",
+			Dropins: []igntypes.Dropin{{Name: "10-limits.conf"}},
+		},
+	}
+	ignition.MergeUnitDropInsIntoConfig(config, []asset.UnitDropIn{
+		{Unit: "kubelet.service", Name: "10-limits.conf", Contents: []byte("[Service]\nMemoryMax=2G\n")},
+		{Unit: "kubelet.service", Name: "20-cpu.conf", Contents: []byte("[Service]\nCPUQuota=200%\n")},
+		{Unit: "crio.service", Name: "10-env.conf", Contents: []byte("[Service]\nEnvironment=A=1\n")},
+	})
+
+	if len(config.Systemd.Units) != 2 {
+		t.Fatalf("Expected drop-ins to be merged into 2 units, got %+v", config.Systemd.Units)
+	}
+	kubelet := config.Systemd.Units[0]
+	if kubelet.Name != "kubelet.service" || len(kubelet.Dropins) != 2 {
+		t.Fatalf("Expected 2 drop-ins of kubelet.service, got %+v", kubelet)
+	}
+	if kubelet.Dropins[0].Name != "10-limits.conf" || kubelet.Dropins[0].Contents == nil ||
+		*kubelet.Dropins[0].Contents != "[Service]\nMemoryMax=2G\n" {
+		t.Errorf("Expected 10-limits.conf to be replaced, got %+v", kubelet.Dropins[0])
+	}
+	if kubelet.Dropins[1].Name != "20-cpu.conf" {
+		t.Errorf("Expected 20-cpu.conf to be appended, got %+v", kubelet.Dropins[1])
+	}
+
+	crio := config.Systemd.Units[1]
+	if crio.Name != "crio.service" || crio.Contents != nil || len(crio.Dropins) != 1 || crio.Dropins[0].Name != "10-env.conf" {
+		t.Errorf("Expected a crio.service unit with only the drop-in, got %+v", crio)
+	}
+}
+
+func TestBootstrapGenerateFiles(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	setupGenerateEnv(t, clusterAsset)
+
+	bootstrap := &machine.Bootstrap{ClusterAsset: clusterAsset, Port: "9080"}
+	if err := bootstrap.GenerateFiles(); err == nil {
+		t.Errorf("Expected an error before the node ignition is generated")
+	}
+
+	master := &machine.Master{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := master.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating master files: %v", err)
+	}
+	worker := &machine.Worker{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := worker.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating worker files: %v", err)
+	}
+	if err := bootstrap.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating bootstrap files: %v", err)
+	}
+
+	config := &igntypes.Config{}
+	if err := json.Unmarshal(bootstrap.CreateIgnContent, config); err != nil {
+		t.Fatalf("Error unmarshaling ignition config: %v", err)
+	}
+	units := make(map[string]igntypes.Unit)
+	for _, unit := range config.Systemd.Units {
+		units[unit.Name] = unit
+	}
+	if _, ok := units["kubelet.service"]; ok {
+		t.Errorf("Expected no kubelet on the bootstrap node")
+	}
+	socket, ok := units["nkd-bootstrap-httpd.socket"]
+	if !ok || socket.Enabled == nil || !*socket.Enabled || !strings.Contains(*socket.Contents, "ListenStream=9080") {
+		t.Errorf("Expected nkd-bootstrap-httpd.socket listening on 9080 to be enabled, got %+v", config.Systemd.Units)
+	}
+	if service, ok := units["nkd-bootstrap-httpd@.service"]; !ok || service.Enabled != nil {
+		t.Errorf("Expected the nkd-bootstrap-httpd@.service instances to be started by the socket")
+	}
+	served := map[string][]byte{
+		machine.ControlplaneIgnFilename: clusterAsset.Master[0].CreateIgnContent,
+		machine.WorkerIgnFilename:       clusterAsset.Worker[0].CreateIgnContent,
+	}
+	for name, content := range served {
+		if file := getIgnitionFile(t, bootstrap.CreateIgnContent, "/var/lib/nkd/ignition/"+name); file != string(content) {
+			t.Errorf("Expected the bootstrap node to serve the generated %s", name)
+		}
+	}
+
+	// Bootstrap only services are not enabled on the other nodes
+	for _, nodeType := range []string{"controlplane", "master", "worker"} {
+		for _, service := range ignition.GetEnabledServices(nodeType) {
+			if service == "nkd-bootstrap-httpd.socket" {
+				t.Errorf("Expected nkd-bootstrap-httpd.socket not to be enabled on %s nodes", nodeType)
+			}
+		}
+	}
+}
+
+func TestBootstrapHttpdScript(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	setupGenerateEnv(t, clusterAsset)
+	master := &machine.Master{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := master.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating master files: %v", err)
+	}
+	worker := &machine.Worker{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := worker.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating worker files: %v", err)
+	}
+	bootstrap := &machine.Bootstrap{ClusterAsset: clusterAsset, Port: "9080"}
+	if err := bootstrap.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating bootstrap files: %v", err)
+	}
+
+	// Serve the ignition from a temporary directory instead of /var/lib/nkd/ignition
+	ignitionDir := t.TempDir()
+	workerIgn := clusterAsset.Worker[0].CreateIgnContent
+	if err := os.WriteFile(filepath.Join(ignitionDir, machine.WorkerIgnFilename), workerIgn, 0644); err != nil {
+		t.Fatalf("Error writing worker ignition: %v", err)
+	}
+	script := strings.Replace(getIgnitionFile(t, bootstrap.CreateIgnContent, "/etc/nkd/bootstrap-httpd.sh"),
+		"ignition_dir=/var/lib/nkd/ignition", "ignition_dir="+ignitionDir, 1)
+	scriptPath := filepath.Join(t.TempDir(), "bootstrap-httpd.sh")
+	if err := os.WriteFile(scriptPath, []byte(script), 0755); err != nil {
+		t.Fatalf("Error writing bootstrap httpd script: %v", err)
+	}
+
+	tests := []struct {
+		request string
+		status  string
+		body    []byte
+	}{
+		{"GET /worker.ign HTTP/1.1", "200 OK", workerIgn},
+		{"HEAD /worker.ign HTTP/1.1", "200 OK", nil},
+		{"GET /master.ign HTTP/1.1", "404 Not Found", nil},
+		{"GET /../worker.ign HTTP/1.1", "404 Not Found", nil},
+		{"POST /worker.ign HTTP/1.1", "405 Method Not Allowed", nil},
+	}
+	for _, tt := range tests {
+		request := tt.request + "\r\nHost: bootstrap:9080\r\nAccept: */*\r\n\r\n"
+		req, err := http.ReadRequest(bufio.NewReader(strings.NewReader(request)))
+		if err != nil {
+			t.Fatalf("Error parsing request %s: %v", tt.request, err)
+		}
+		cmd := exec.Command("sh", scriptPath)
+		cmd.Stdin = strings.NewReader(request)
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("Error running the script for %s: %v", tt.request, err)
+		}
+		resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(out)), req)
+		if err != nil {
+			t.Fatalf("Error reading the response to %s: %v\n%s", tt.request, err, out)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("Error reading the response body to %s: %v", tt.request, err)
+		}
+		if resp.Status != tt.status || !bytes.Equal(body, tt.body) {
+			t.Errorf("Expected %s with %d bytes for %s, got %s with %d bytes", tt.status, len(tt.body), tt.request,
+				resp.Status, len(body))
+		}
+		if tt.status == "200 OK" && (resp.Header.Get("Content-Type") != ignition.IgnitionContentType ||
+			resp.ContentLength != int64(len(workerIgn))) {
+			t.Errorf("Unexpected headers for %s: %v", tt.request, resp.Header)
+		}
+	}
+}
+
+func TestValidateIgnition(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	setupGenerateEnv(t, clusterAsset)
+	tmplData, err := ignition.GetWorkerTmplData(clusterAsset)
+	if err != nil {
+		t.Fatalf("Error getting template data: %v", err)
+	}
+	generateFile := &ignition.Common{NodeType: "worker", TmplData: tmplData}
+	if err := generateFile.Generate(); err != nil {
+		t.Fatalf("Error generating ignition config: %v", err)
+	}
+	if err := ignition.Validate(generateFile.Config); err != nil {
+		t.Errorf("Expected the generated config to be valid, got %v", err)
+	}
+
+	// AppendFiles replaces files with the same path, append directly to get duplicates
+	files := generateFile.Config.Storage.Files
+	generateFile.Config.Storage.Files = append(files, ignition.FileWithContents(files[0].Path, 0644, []byte("duplicate")))
+	err = ignition.Validate(generateFile.Config)
+	if err == nil || !strings.Contains(err.Error(), "duplicate entry") ||
+		!strings.Contains(err.Error(), fmt.Sprintf("storage.files.%d", len(files))) {
+		t.Errorf("Expected a duplicate file error with its field, got %v", err)
+	}
+
+	config := &igntypes.Config{Ignition: igntypes.Ignition{Version: "3.2.0"}}
+	config.Storage.Files = []igntypes.File{ignition.FileWithContents("etc/hosts", 0644, nil)}
+	if err := ignition.Validate(config); err == nil {
+		t.Errorf("Expected an error for a relative file path")
+	}
+}
+
+func TestSaveFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "ignition")
+	path := filepath.Join(dir, "worker.ign")
+	config := &igntypes.Config{Ignition: igntypes.Ignition{Version: "3.2.0"}}
+
+	written, err := ignition.SaveFile(config, dir, "worker.ign")
+	if err != nil || !written {
+		t.Fatalf("Expected the first save to write the file, got %v, %v", written, err)
+	}
+	// 回拨修改时间，检查未变化的内容不会重写文件
+	oldTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(path, oldTime, oldTime); err != nil {
+		t.Fatalf("Error setting file times: %v", err)
+	}
+
+	written, err = ignition.SaveFile(config, dir, "worker.ign")
+	if err != nil || written {
+		t.Errorf("Expected the unchanged save to skip the write, got %v, %v", written, err)
+	}
+	if info, err := os.Stat(path); err != nil || !info.ModTime().Equal(oldTime) {
+		t.Errorf("Expected the unchanged file to keep its modification time")
+	}
+
+	config.Passwd.Users = []igntypes.PasswdUser{{Name: "root"}}
+	written, err = ignition.SaveFile(config, dir, "worker.ign")
+	if err != nil || !written {
+		t.Fatalf("Expected the changed save to write the file, got %v, %v", written, err)
+	}
+	saved := &igntypes.Config{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Error reading ignition file: %v", err)
+	}
+	if err := json.Unmarshal(data, saved); err != nil {
+		t.Fatalf("Error unmarshaling ignition file: %v", err)
+	}
+	if len(saved.Passwd.Users) != 1 || saved.Passwd.Users[0].Name != "root" {
+		t.Errorf("Expected the changed config to be saved, got %+v", saved.Passwd)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Errorf("Expected no temporary files left in %s, got %v", dir, entries)
+	}
+}
+
+func TestGenerateMergeIgnition(t *testing.T) {
+	if _, err := ignition.GenerateMergeIgnition("", machine.WorkerIgnFilename, "http", nil, ""); err == nil {
+		t.Errorf("Expected an error for an empty host")
+	}
+	if _, err := ignition.GenerateMergeIgnition(testBootstrapHost, machine.WorkerIgnFilename, "ftp", nil, ""); err == nil {
+		t.Errorf("Expected an error for an unsupported scheme")
+	}
+
+	config, err := ignition.GenerateMergeIgnition(testBootstrapHost, machine.WorkerIgnFilename, "http", nil, "")
+	if err != nil {
+		t.Fatalf("Error generating http merge ignition: %v", err)
+	}
+	merge := config.Ignition.Config.Merge
+	if len(merge) != 1 || *merge[0].Source != "http://192.168.132.1:9080/worker.ign" {
+		t.Errorf("Unexpected http merge source: %+v", merge)
+	}
+	if len(config.Ignition.Security.TLS.CertificateAuthorities) != 0 {
+		t.Errorf("Expected no certificate authorities for http")
+	}
+
+	caCert := []byte("-----BEGIN CERTIFICATE-----\ntest\n-----END CERTIFICATE-----\n")
+	config, err = ignition.GenerateMergeIgnition(testBootstrapHost, machine.WorkerIgnFilename, "https", caCert, "")
+	if err != nil {
+		t.Fatalf("Error generating https merge ignition: %v", err)
+	}
+	merge = config.Ignition.Config.Merge
+	if len(merge) != 1 || *merge[0].Source != "https://192.168.132.1:9080/worker.ign" {
+		t.Errorf("Unexpected https merge source: %+v", merge)
+	}
+	cas := config.Ignition.Security.TLS.CertificateAuthorities
+	if len(cas) != 1 || cas[0].Source == nil {
+		t.Fatalf("Expected one certificate authority, got %+v", cas)
+	}
+	data, err := dataurl.DecodeString(*cas[0].Source)
+	if err != nil {
+		t.Fatalf("Error decoding certificate authority: %v", err)
+	}
+	if string(data.Data) != string(caCert) {
+		t.Errorf("Expected certificate authority %q, got %q", caCert, data.Data)
+	}
+}
+
+func TestIgnitionVersion(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	setupGenerateEnv(t, clusterAsset)
+	tmplData, err := ignition.GetWorkerTmplData(clusterAsset)
+	if err != nil {
+		t.Fatalf("Error getting template data: %v", err)
+	}
+
+	tests := []struct {
+		version  string
+		expected string
+	}{
+		{version: "", expected: igntypes.MaxVersion.String()},
+		{version: "3.1.0", expected: "3.1.0"},
+	}
+	for _, tt := range tests {
+		generateFile := &ignition.Common{NodeType: "worker", TmplData: tmplData, IgnitionVersion: tt.version}
+		if err := generateFile.Generate(); err != nil {
+			t.Fatalf("Error generating ignition of version %q: %v", tt.version, err)
+		}
+		if generateFile.Config.Ignition.Version != tt.expected {
+			t.Errorf("Expected ignition version %s, got %s", tt.expected, generateFile.Config.Ignition.Version)
+		}
+		config, err := ignition.GenerateMergeIgnition(testBootstrapHost, machine.WorkerIgnFilename, "http", nil, tt.version)
+		if err != nil {
+			t.Fatalf("Error generating merge ignition of version %q: %v", tt.version, err)
+		}
+		if config.Ignition.Version != tt.expected {
+			t.Errorf("Expected merge ignition version %s, got %s", tt.expected, config.Ignition.Version)
+		}
+	}
+
+	for _, version := range []string{"2.2.0", "3.1", "3.3.0", "3.1.0-experimental", "v3.1.0"} {
+		generateFile := &ignition.Common{NodeType: "worker", TmplData: tmplData, IgnitionVersion: version}
+		if err := generateFile.Generate(); err == nil {
+			t.Errorf("Expected an error for ignition version %q", version)
+		}
+		if _, err := ignition.GenerateMergeIgnition(testBootstrapHost, machine.WorkerIgnFilename, "http", nil, version); err == nil {
+			t.Errorf("Expected an error generating merge ignition of version %q", version)
+		}
+	}
+}
+
+func TestRenderKubeadmConfig(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.Kubernetes.KubernetesAPIVersion = "v1beta3"
+	clusterAsset.Kubernetes.ApiServerEndpoint = "192.168.132.11:6443"
+	clusterAsset.Kubernetes.APIServerExtraArgs = map[string]string{"audit-log-maxage": "30"}
+	clusterAsset.Kubernetes.ControllerManagerExtraArgs = map[string]string{"node-cidr-mask-size": "24"}
+	clusterAsset.Kubernetes.FeatureGates = map[string]bool{"EphemeralContainers": true, "CSIMigration": false}
+	setupGenerateEnv(t, clusterAsset)
+	configmanager.SetClusterConfig(clusterAsset)
+	defer configmanager.RemoveClusterConfig(clusterAsset.Cluster_ID)
+
+	if _, err := ignition.RenderKubeadmConfig(clusterAsset.Cluster_ID, ignition.NodeRoleWorker); err == nil {
+		t.Errorf("Expected an error rendering kubeadm config for worker nodes")
+	}
+
+	content, err := ignition.RenderKubeadmConfig(clusterAsset.Cluster_ID, ignition.NodeRoleMaster)
+	if err != nil {
+		t.Fatalf("Error rendering kubeadm config: %v", err)
+	}
+	docs := strings.Split(string(content), "\n---\n")
+	if len(docs) != 3 {
+		t.Fatalf("Expected init, cluster and kubelet configuration, got:\n%s", content)
+	}
+
+	var clusterConfig struct {
+		ControlPlaneEndpoint string `yaml:"controlPlaneEndpoint"`
+		APIServer            struct {
+			ExtraArgs map[string]string `yaml:"extraArgs"`
+		} `yaml:"apiServer"`
+		ControllerManager struct {
+			ExtraArgs map[string]string `yaml:"extraArgs"`
+		} `yaml:"controllerManager"`
+		Scheduler struct {
+			ExtraArgs map[string]string `yaml:"extraArgs"`
+		} `yaml:"scheduler"`
+	}
+	if err := yaml.Unmarshal([]byte(docs[1]), &clusterConfig); err != nil {
+		t.Fatalf("Error parsing cluster configuration: %v\n%s", err, docs[1])
+	}
+
+	featureGates := "CSIMigration=false,EphemeralContainers=true"
+	if clusterConfig.ControlPlaneEndpoint != "192.168.132.11:6443" {
+		t.Errorf("Unexpected control plane endpoint %s", clusterConfig.ControlPlaneEndpoint)
+	}
+	if args := clusterConfig.APIServer.ExtraArgs; args["audit-log-maxage"] != "30" || args["feature-gates"] != featureGates {
+		t.Errorf("Unexpected apiserver extra args %v", args)
+	}
+	if args := clusterConfig.ControllerManager.ExtraArgs; args["node-cidr-mask-size"] != "24" ||
+		args["feature-gates"] != featureGates || args["flex-volume-plugin-dir"] == "" {
+		t.Errorf("Unexpected controller manager extra args %v", args)
+	}
+	if args := clusterConfig.Scheduler.ExtraArgs; args["feature-gates"] != featureGates {
+		t.Errorf("Unexpected scheduler extra args %v", args)
+	}
+}
+
+func TestRenderKubeadmConfigExtraArgs(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.Kubernetes.APIServerExtraArgs = map[string]string{
+		"feature-gates":              "EphemeralContainers=true",
+		"--enable-admission-plugins": "NodeRestriction,PodSecurity",
+	}
+	setupGenerateEnv(t, clusterAsset)
+	configmanager.SetClusterConfig(clusterAsset)
+	defer configmanager.RemoveClusterConfig(clusterAsset.Cluster_ID)
+
+	content, err := ignition.RenderKubeadmConfig(clusterAsset.Cluster_ID, ignition.NodeRoleMaster)
+	if err != nil {
+		t.Fatalf("Error rendering kubeadm config: %v", err)
+	}
+	docs := strings.Split(string(content), "\n---\n")
+	var clusterConfig map[string]interface{}
+	if err := yaml.Unmarshal([]byte(docs[1]), &clusterConfig); err != nil {
+		t.Fatalf("Error parsing cluster configuration: %v\n%s", err, docs[1])
+	}
+
+	expected := map[interface{}]interface{}{
+		"extraArgs": map[interface{}]interface{}{
+			"feature-gates":            "EphemeralContainers=true",
+			"enable-admission-plugins": "NodeRestriction,PodSecurity",
+		},
+	}
+	if !reflect.DeepEqual(clusterConfig["apiServer"], expected) {
+		t.Errorf("Expected apiserver %v, got %v", expected, clusterConfig["apiServer"])
+	}
+	// Components without extra args are left out
+	if _, ok := clusterConfig["scheduler"]; ok {
+		t.Errorf("Expected no scheduler section without extra args, got %v", clusterConfig["scheduler"])
+	}
+}
+
+func TestRenderKubeadmConfigV1beta4(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.Kubernetes.KubernetesVersion = "v1.31.1"
+	clusterAsset.Kubernetes.APIServerExtraArgs = map[string]string{"audit-log-maxage": "30"}
+	setupGenerateEnv(t, clusterAsset)
+	configmanager.SetClusterConfig(clusterAsset)
+	defer configmanager.RemoveClusterConfig(clusterAsset.Cluster_ID)
+
+	content, err := ignition.RenderKubeadmConfig(clusterAsset.Cluster_ID, ignition.NodeRoleMaster)
+	if err != nil {
+		t.Fatalf("Error rendering kubeadm config: %v", err)
+	}
+	docs := strings.Split(string(content), "\n---\n")
+	if len(docs) != 3 {
+		t.Fatalf("Expected init, cluster and kubelet configuration, got:\n%s", content)
+	}
+
+	// v1beta4 takes the extra args as a list of name/value pairs
+	type arg struct {
+		Name  string `yaml:"name"`
+		Value string `yaml:"value"`
+	}
+	var initConfig struct {
+		APIVersion       string `yaml:"apiVersion"`
+		NodeRegistration struct {
+			KubeletExtraArgs []arg `yaml:"kubeletExtraArgs"`
+		} `yaml:"nodeRegistration"`
+	}
+	if err := yaml.Unmarshal([]byte(docs[0]), &initConfig); err != nil {
+		t.Fatalf("Error parsing init configuration: %v\n%s", err, docs[0])
+	}
+	if initConfig.APIVersion != "kubeadm.k8s.io/v1beta4" {
+		t.Errorf("Expected kubeadm.k8s.io/v1beta4, got %s", initConfig.APIVersion)
+	}
+	expectedKubeletArgs := []arg{{Name: "volume-plugin-dir", Value: "/opt/libexec/kubernetes/kubelet-plugins/volume/exec/"}}
+	if !reflect.DeepEqual(initConfig.NodeRegistration.KubeletExtraArgs, expectedKubeletArgs) {
+		t.Errorf("Expected kubelet extra args %v, got %v", expectedKubeletArgs, initConfig.NodeRegistration.KubeletExtraArgs)
+	}
+
+	var clusterConfig struct {
+		APIServer struct {
+			ExtraArgs []arg `yaml:"extraArgs"`
+		} `yaml:"apiServer"`
+		ControllerManager struct {
+			ExtraArgs []arg `yaml:"extraArgs"`
+		} `yaml:"controllerManager"`
+	}
+	if err := yaml.Unmarshal([]byte(docs[1]), &clusterConfig); err != nil {
+		t.Fatalf("Error parsing cluster configuration: %v\n%s", err, docs[1])
+	}
+	if !reflect.DeepEqual(clusterConfig.APIServer.ExtraArgs, []arg{{Name: "audit-log-maxage", Value: "30"}}) {
+		t.Errorf("Unexpected apiserver extra args %v", clusterConfig.APIServer.ExtraArgs)
+	}
+	if len(clusterConfig.ControllerManager.ExtraArgs) == 0 {
+		t.Errorf("Expected controller manager extra args, got:\n%s", docs[1])
+	}
+}
+
+func TestRenderKubeadmConfigNetwork(t *testing.T) {
+	tests := []struct {
+		name          string
+		kubeVersion   string
+		serviceSubnet string
+		podSubnet     string
+		serviceRange  string
+		featureGates  string
+	}{
+		{
+			name:          "ipv4",
+			kubeVersion:   "v1.23.10",
+			serviceSubnet: "10.96.0.0/16",
+			podSubnet:     "10.244.0.0/16",
+			serviceRange:  "10.96.0.0/16",
+		},
+		{
+			name:          "ipv6",
+			kubeVersion:   "v1.23.10",
+			serviceSubnet: "fd00:10:96::/112",
+			podSubnet:     "fd00:10:244::/56",
+			serviceRange:  "fd00:10:96::/112",
+		},
+		{
+			name:          "dual-stack",
+			kubeVersion:   "v1.23.10",
+			serviceSubnet: "10.96.0.0/16, fd00:10:96::/112",
+			podSubnet:     "10.244.0.0/16, fd00:10:244::/56",
+			serviceRange:  "10.96.0.0/16,fd00:10:96::/112",
+		},
+		{
+			name:          "dual-stack behind feature gate",
+			kubeVersion:   "v1.20.15",
+			serviceSubnet: "10.96.0.0/16,fd00:10:96::/112",
+			podSubnet:     "10.244.0.0/16,fd00:10:244::/56",
+			serviceRange:  "10.96.0.0/16,fd00:10:96::/112",
+			featureGates:  "IPv6DualStack=true",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterAsset := newTestClusterAsset()
+			clusterAsset.Kubernetes.KubernetesVersion = tt.kubeVersion
+			clusterAsset.Network.ServiceSubnet = tt.serviceSubnet
+			clusterAsset.Network.PodSubnet = tt.podSubnet
+			setupGenerateEnv(t, clusterAsset)
+			configmanager.SetClusterConfig(clusterAsset)
+			defer configmanager.RemoveClusterConfig(clusterAsset.Cluster_ID)
+
+			content, err := ignition.RenderKubeadmConfig(clusterAsset.Cluster_ID, ignition.NodeRoleMaster)
+			if err != nil {
+				t.Fatalf("Error rendering kubeadm config: %v", err)
+			}
+			docs := strings.Split(string(content), "\n---\n")
+			var clusterConfig struct {
+				KubernetesVersion string `yaml:"kubernetesVersion"`
+				APIServer         struct {
+					ExtraArgs map[string]string `yaml:"extraArgs"`
+				} `yaml:"apiServer"`
+				ControllerManager struct {
+					ExtraArgs map[string]string `yaml:"extraArgs"`
+				} `yaml:"controllerManager"`
+				Networking struct {
+					ServiceSubnet string `yaml:"serviceSubnet"`
+					PodSubnet     string `yaml:"podSubnet"`
+				} `yaml:"networking"`
+			}
+			if err := yaml.Unmarshal([]byte(docs[1]), &clusterConfig); err != nil {
+				t.Fatalf("Error parsing cluster configuration: %v\n%s", err, docs[1])
+			}
+
+			if clusterConfig.KubernetesVersion != tt.kubeVersion {
+				t.Errorf("Expected kubernetes version %s, got %s", tt.kubeVersion, clusterConfig.KubernetesVersion)
+			}
+			if clusterConfig.Networking.ServiceSubnet != tt.serviceRange {
+				t.Errorf("Expected service subnet %s, got %s", tt.serviceRange, clusterConfig.Networking.ServiceSubnet)
+			}
+			if podSubnet := asset.NormalizeCIDRList(tt.podSubnet); clusterConfig.Networking.PodSubnet != podSubnet {
+				t.Errorf("Expected pod subnet %s, got %s", podSubnet, clusterConfig.Networking.PodSubnet)
+			}
+			if args := clusterConfig.APIServer.ExtraArgs; args["service-cluster-ip-range"] != tt.serviceRange {
+				t.Errorf("Expected service-cluster-ip-range %s, got %v", tt.serviceRange, args)
+			}
+			for _, args := range []map[string]string{clusterConfig.APIServer.ExtraArgs, clusterConfig.ControllerManager.ExtraArgs} {
+				if args["feature-gates"] != tt.featureGates {
+					t.Errorf("Expected feature gates %q, got %q", tt.featureGates, args["feature-gates"])
+				}
+			}
+		})
+	}
+}
+
+func TestCgroupDriver(t *testing.T) {
+	tests := map[string]string{
+		"":         "systemd",
+		"systemd":  "systemd",
+		"cgroupfs": "cgroupfs",
+	}
+	for driver, expected := range tests {
+		driver, expected := driver, expected
+		t.Run("driver="+driver, func(t *testing.T) {
+			clusterAsset := newTestClusterAsset()
+			clusterAsset.Kubernetes.CgroupDriver = driver
+			setupGenerateEnv(t, clusterAsset)
+			configmanager.SetClusterConfig(clusterAsset)
+
+			content, err := ignition.RenderKubeadmConfig(clusterAsset.Cluster_ID, ignition.NodeRoleMaster)
+			configmanager.RemoveClusterConfig(clusterAsset.Cluster_ID)
+			if err != nil {
+				t.Fatalf("Error rendering kubeadm config with cgroup driver %q: %v", driver, err)
+			}
+			docs := strings.Split(string(content), "\n---\n")
+			var kubeletConfig struct {
+				CgroupDriver string `yaml:"cgroupDriver"`
+			}
+			if err := yaml.Unmarshal([]byte(docs[len(docs)-1]), &kubeletConfig); err != nil {
+				t.Fatalf("Error parsing kubelet configuration: %v", err)
+			}
+			if kubeletConfig.CgroupDriver != expected {
+				t.Errorf("Expected kubelet cgroup driver %s for %q, got %s", expected, driver, kubeletConfig.CgroupDriver)
+			}
+
+			worker := &machine.Worker{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+			if err := worker.GenerateFiles(); err != nil {
+				t.Fatalf("Error generating worker files: %v", err)
+			}
+			daemonConfig := getIgnitionFile(t, clusterAsset.Worker[0].CreateIgnContent, "/etc/isulad/daemon.json")
+			if !strings.Contains(daemonConfig, `"native.cgroupdriver=`+expected+`"`) {
+				t.Errorf("Expected isulad cgroup driver %s for %q, got:\n%s", expected, driver, daemonConfig)
+			}
+		})
+	}
+
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.Kubernetes.CgroupDriver = "cgroupv2"
+	if _, err := ignition.GetTmplData(clusterAsset); err == nil || !strings.Contains(err.Error(), "cgroupv2") {
+		t.Errorf("Expected unsupported cgroup driver error, got %v", err)
+	}
+}
+
+func TestRenderKubeletConfig(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.Kubernetes.KubernetesAPIVersion = "v1beta3"
+	clusterAsset.Kubernetes.Kubelet = asset.KubeletConfig{
+		MaxPods:        250,
+		SystemReserved: map[string]string{"cpu": "500m", "memory": "1Gi"},
+		EvictionHard:   map[string]string{"memory.available": "500Mi"},
+	}
+	setupGenerateEnv(t, clusterAsset)
+	configmanager.SetClusterConfig(clusterAsset)
+	defer configmanager.RemoveClusterConfig(clusterAsset.Cluster_ID)
+
+	content, err := ignition.RenderKubeadmConfig(clusterAsset.Cluster_ID, ignition.NodeRoleMaster)
+	if err != nil {
+		t.Fatalf("Error rendering kubeadm config: %v", err)
+	}
+	docs := strings.Split(string(content), "\n---\n")
+	if len(docs) != 3 {
+		t.Fatalf("Expected init, cluster and kubelet configuration, got:\n%s", content)
+	}
+
+	var kubeletConfig struct {
+		Kind           string            `yaml:"kind"`
+		MaxPods        int32             `yaml:"maxPods"`
+		SystemReserved map[string]string `yaml:"systemReserved"`
+		KubeReserved   map[string]string `yaml:"kubeReserved"`
+		EvictionHard   map[string]string `yaml:"evictionHard"`
+	}
+	if err := yaml.Unmarshal([]byte(docs[2]), &kubeletConfig); err != nil {
+		t.Fatalf("Error parsing kubelet configuration: %v\n%s", err, docs[2])
+	}
+	if kubeletConfig.Kind != "KubeletConfiguration" || kubeletConfig.MaxPods != 250 {
+		t.Errorf("Unexpected kubelet configuration:\n%s", docs[2])
+	}
+	if !reflect.DeepEqual(kubeletConfig.SystemReserved, clusterAsset.Kubernetes.Kubelet.SystemReserved) ||
+		!reflect.DeepEqual(kubeletConfig.EvictionHard, clusterAsset.Kubernetes.Kubelet.EvictionHard) {
+		t.Errorf("Unexpected kubelet reservations and eviction thresholds:\n%s", docs[2])
+	}
+	if kubeletConfig.KubeReserved != nil {
+		t.Errorf("Expected unset kube reserved to be left out, got %v", kubeletConfig.KubeReserved)
+	}
+}
+
+func TestBootstrapServer(t *testing.T) {
+	roleConfigs := map[string]*igntypes.Config{
+		"master.ign": {Ignition: igntypes.Ignition{Version: "3.2.0"}, Passwd: igntypes.Passwd{
+			Users: []igntypes.PasswdUser{{Name: "master"}},
+		}},
+		"worker.ign": {Ignition: igntypes.Ignition{Version: "3.2.0"}, Passwd: igntypes.Passwd{
+			Users: []igntypes.PasswdUser{{Name: "worker"}},
+		}},
+	}
+	server := &ignition.BootstrapServer{}
+	if err := server.SetConfigs(roleConfigs); err != nil {
+		t.Fatalf("Error setting configs: %v", err)
+	}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+	host := strings.TrimPrefix(ts.URL, "http://")
+
+	for role, expected := range roleConfigs {
+		// 节点按合并配置中的地址获取角色配置
+		mergeConfig, err := ignition.GenerateMergeIgnition(host, role, "http", nil, "")
+		if err != nil {
+			t.Fatalf("Error generating merge ignition of %s: %v", role, err)
+		}
+		resp, err := http.Get(*mergeConfig.Ignition.Config.Merge[0].Source)
+		if err != nil {
+			t.Fatalf("Error fetching %s: %v", role, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("Error reading %s: %v", role, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected status 200 fetching %s, got %d", role, resp.StatusCode)
+		}
+		if contentType := resp.Header.Get("Content-Type"); contentType != ignition.IgnitionContentType {
+			t.Errorf("Expected content type %s, got %s", ignition.IgnitionContentType, contentType)
+		}
+		var config igntypes.Config
+		if err := json.Unmarshal(body, &config); err != nil {
+			t.Fatalf("Error decoding %s: %v", role, err)
+		}
+		if len(config.Passwd.Users) != 1 || config.Passwd.Users[0].Name != expected.Passwd.Users[0].Name {
+			t.Errorf("Expected the %s config, got %s", role, body)
+		}
+	}
+
+	resp, err := http.Get(ts.URL + "/unknown.ign")
+	if err != nil {
+		t.Fatalf("Error fetching unknown role: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status 404 for an unknown role, got %d", resp.StatusCode)
+	}
+}
+
+func TestBootstrapServerNodeIgnitions(t *testing.T) {
+	clusterAsset := newTestClusterAsset()
+	clusterAsset.Master = append(clusterAsset.Master, asset.NodeAsset{Hostname: "k8s-master02", IP: "192.168.132.12"})
+	clusterAsset.Kubernetes.CertificateKey = strings.Repeat("ab", 32)
+	setupGenerateEnv(t, clusterAsset)
+	if _, err := machine.NodeIgnitionConfigs(clusterAsset); err == nil {
+		t.Errorf("Expected an error before the node ignition is generated")
+	}
+
+	master := &machine.Master{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := master.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating master files: %v", err)
+	}
+	worker := &machine.Worker{ClusterAsset: clusterAsset, BootstrapBaseurl: testBootstrapHost}
+	if err := worker.GenerateFiles(); err != nil {
+		t.Fatalf("Error generating worker files: %v", err)
+	}
+	configs, err := machine.NodeIgnitionConfigs(clusterAsset)
+	if err != nil {
+		t.Fatalf("Error getting node ignition configs: %v", err)
+	}
+
+	server := &ignition.BootstrapServer{}
+	if err := server.Serve("127.0.0.1:0", configs); err != nil {
+		t.Fatalf("Error serving node ignition configs: %v", err)
+	}
+	defer server.Shutdown(context.Background())
+
+	for _, node := range append(append([]asset.NodeAsset{}, clusterAsset.Master...), clusterAsset.Worker...) {
+		resp, err := http.Get(fmt.Sprintf("http://%s/%s", server.Addr(), filepath.Base(node.CreateIgnPath)))
+		if err != nil {
+			t.Fatalf("Error fetching ignition of %s: %v", node.Hostname, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil || resp.StatusCode != http.StatusOK {
+			t.Fatalf("Error fetching ignition of %s: status %d, %v", node.Hostname, resp.StatusCode, err)
+		}
+		var served, expected igntypes.Config
+		if err := json.Unmarshal(body, &served); err != nil {
+			t.Fatalf("Error decoding ignition of %s: %v", node.Hostname, err)
+		}
+		if err := json.Unmarshal(node.CreateIgnContent, &expected); err != nil {
+			t.Fatalf("Error decoding generated ignition of %s: %v", node.Hostname, err)
+		}
+		if !reflect.DeepEqual(served, expected) {
+			t.Errorf("Expected the generated ignition of %s to be served", node.Hostname)
+		}
+	}
+}
+
+func TestBootstrapServerServeUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "ignition.sock")
+	server := &ignition.BootstrapServer{}
+	if err := server.Serve("unix:"+socket, map[string]*igntypes.Config{
+		"worker.ign": {Ignition: igntypes.Ignition{Version: "3.2.0"}},
+	}); err != nil {
+		t.Fatalf("Error serving on unix socket: %v", err)
+	}
+	defer server.Shutdown(context.Background())
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}
+	resp, err := client.Get((&url.URL{Scheme: "http", Host: "bootstrap", Path: "worker.ign"}).String())
+	if err != nil {
+		t.Fatalf("Error fetching over unix socket: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	if err := server.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Error shutting down: %v", err)
+	}
+	if server.Addr() != nil {
+		t.Errorf("Expected no address after shutdown, got %v", server.Addr())
+	}
+	if _, err := os.Stat(socket); !os.IsNotExist(err) {
+		t.Errorf("Expected the socket to be removed after shutdown, got %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infra_test
+
+import (
+	"errors"
+	"nestos-kubernetes-deployer/pkg/configmanager"
+	"nestos-kubernetes-deployer/pkg/configmanager/asset"
+	"nestos-kubernetes-deployer/pkg/configmanager/globalconfig"
+	"nestos-kubernetes-deployer/pkg/infra"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeDestroyer records the destroyed stages and fails the stages in failures
+type fakeDestroyer struct {
+	stages   []string
+	failures map[string]error
+}
+
+func (d *fakeDestroyer) DestroyStage(clusterID string, stage string) error {
+	d.stages = append(d.stages, stage)
+	return d.failures[stage]
+}
+
+func setupCluster(t *testing.T) string {
+	persistDir := t.TempDir()
+	configmanager.GlobalConfig = &globalconfig.GlobalConfig{PersistDir: persistDir}
+	configmanager.SetClusterConfig(&asset.ClusterAsset{Cluster_ID: "cluster"})
+	clusterDir := filepath.Join(persistDir, "cluster")
+	if err := os.MkdirAll(clusterDir, 0755); err != nil {
+		t.Fatalf("Error creating cluster directory: %v", err)
+	}
+	return clusterDir
+}
+
+func TestDestroyClusterReverseOrder(t *testing.T) {
+	clusterDir := setupCluster(t)
+
+	destroyer := &fakeDestroyer{}
+	if err := infra.DestroyClusterWithDestroyer("cluster", destroyer); err != nil {
+		t.Fatalf("Error destroying cluster: %v", err)
+	}
+	if expected := []string{"worker", "master"}; !reflect.DeepEqual(destroyer.stages, expected) {
+		t.Errorf("Expected stages destroyed in order %v, got %v", expected, destroyer.stages)
+	}
+	if _, err := os.Stat(clusterDir); !os.IsNotExist(err) {
+		t.Errorf("Expected cluster persist directory to be removed, got %v", err)
+	}
+	if _, err := configmanager.GetClusterConfig("cluster"); err == nil {
+		t.Errorf("Expected cluster to be removed from the cluster map")
+	}
+}
+
+func TestDestroyClusterBestEffort(t *testing.T) {
+	clusterDir := setupCluster(t)
+
+	workerErr := errors.New("worker destroy failed")
+	destroyer := &fakeDestroyer{failures: map[string]error{"worker": workerErr}}
+	err := infra.DestroyClusterWithDestroyer("cluster", destroyer)
+	if err == nil {
+		t.Fatalf("Expected an error destroying the cluster")
+	}
+	// The master stage is destroyed although the worker stage failed
+	if expected := []string{"worker", "master"}; !reflect.DeepEqual(destroyer.stages, expected) {
+		t.Errorf("Expected stages destroyed in order %v, got %v", expected, destroyer.stages)
+	}
+	var destroyErrs infra.DestroyErrors
+	if !errors.As(err, &destroyErrs) || len(destroyErrs) != 1 || destroyErrs[0].Stage != "worker" {
+		t.Errorf("Expected only the worker stage to fail, got %v", err)
+	}
+	if !errors.Is(destroyErrs[0], workerErr) {
+		t.Errorf("Expected the stage error to wrap %v", workerErr)
+	}
+	// The terraform state is kept so the destroy can be retried
+	if _, err := os.Stat(clusterDir); err != nil {
+		t.Errorf("Expected cluster persist directory to be kept, got %v", err)
+	}
+	if _, err := configmanager.GetClusterConfig("cluster"); err != nil {
+		t.Errorf("Expected cluster to be kept, got %v", err)
+	}
+}
+
+func TestGenerateWorkerNodePoolImages(t *testing.T) {
+	tests := []struct {
+		platform      string
+		infraPlatform asset.InfraAsset
+		poolImage     string
+		expected      string
+	}{
+		{
+			platform:      "libvirt",
+			infraPlatform: &asset.LibvirtAsset{OSImage: "/images/nestos.qcow2"},
+			poolImage:     "/images/gpu.qcow2",
+			expected:      `default = ["/images/gpu.qcow2", "/images/nestos.qcow2", "/images/nestos.qcow2"]`,
+		},
+		{
+			platform:      "openstack",
+			infraPlatform: &asset.OpenStackAsset{Glance_Name: "nestos"},
+			poolImage:     "nestos-gpu",
+			expected:      `default = ["nestos-gpu", "nestos", "nestos"]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.platform, func(t *testing.T) {
+			clusterDir := setupCluster(t)
+			conf := &asset.ClusterAsset{
+				Cluster_ID:    "cluster",
+				Architecture:  "amd64",
+				Platform:      tt.platform,
+				InfraPlatform: tt.infraPlatform,
+				NodePools: []asset.NodePool{
+					{Name: "gpu", Image: tt.poolImage},
+					{Name: "memory"},
+				},
+				Worker: []asset.NodeAsset{
+					{Hostname: "k8s-worker01", Pool: "gpu"},
+					{Hostname: "k8s-worker02", Pool: "memory"},
+					{Hostname: "k8s-worker03"},
+				},
+			}
+
+			// Templates are read relative to the data directory in non-release builds
+			wd, err := os.Getwd()
+			if err != nil {
+				t.Fatalf("Error getting working directory: %v", err)
+			}
+			if err := os.Chdir("../../data"); err != nil {
+				t.Fatalf("Error changing to data directory: %v", err)
+			}
+			defer os.Chdir(wd)
+
+			if err := (&infra.Infra{}).Generate(conf, "worker"); err != nil {
+				t.Fatalf("Error generating worker terraform config: %v", err)
+			}
+			content, err := os.ReadFile(filepath.Join(clusterDir, "worker", "worker.tf"))
+			if err != nil {
+				t.Fatalf("Error reading worker terraform config: %v", err)
+			}
+			if !strings.Contains(string(content), tt.expected) {
+				t.Errorf("Expected worker images %s, got:\n%s", tt.expected, content)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"nestos-kubernetes-deployer/pkg/configmanager"
+	"nestos-kubernetes-deployer/pkg/configmanager/asset"
+	"nestos-kubernetes-deployer/pkg/preflight"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type fakeRegistryClient struct {
+	images map[string]preflight.ImageStatus
+}
+
+func (c *fakeRegistryClient) CheckImage(image string) (preflight.ImageStatus, error) {
+	status, ok := c.images[image]
+	if !ok {
+		return preflight.ImageCheckFailed, errors.New("registry unreachable")
+	}
+	return status, nil
+}
+
+func TestCheckImages(t *testing.T) {
+	configmanager.SetClusterConfig(&asset.ClusterAsset{
+		Cluster_ID: "cluster",
+		Kubernetes: asset.Kubernetes{
+			KubernetesVersion: "v1.23.10",
+			ImageRegistry:     "registry.example.com",
+			PauseImage:        "pause:3.6",
+			ReleaseImageURL:   "registry.example.com/nestos:latest",
+		},
+	})
+	defer configmanager.RemoveClusterConfig("cluster")
+
+	client := &fakeRegistryClient{images: map[string]preflight.ImageStatus{
+		"registry.example.com/nestos:latest":                    preflight.ImageFound,
+		"registry.example.com/pause:3.6":                        preflight.ImageFound,
+		"registry.example.com/kube-apiserver:v1.23.10":          preflight.ImageFound,
+		"registry.example.com/kube-controller-manager:v1.23.10": preflight.ImageNotFound,
+		"registry.example.com/kube-scheduler:v1.23.10":          preflight.ImageUnauthorized,
+	}}
+
+	expected := map[string]preflight.ImageStatus{
+		"registry.example.com/nestos:latest":                    preflight.ImageFound,
+		"registry.example.com/pause:3.6":                        preflight.ImageFound,
+		"registry.example.com/kube-apiserver:v1.23.10":          preflight.ImageFound,
+		"registry.example.com/kube-controller-manager:v1.23.10": preflight.ImageNotFound,
+		"registry.example.com/kube-scheduler:v1.23.10":          preflight.ImageUnauthorized,
+		"registry.example.com/kube-proxy:v1.23.10":              preflight.ImageCheckFailed,
+		"registry.example.com/etcd:3.5.1-0":                     preflight.ImageCheckFailed,
+		"registry.example.com/coredns:v1.8.6":                   preflight.ImageCheckFailed,
+	}
+
+	results := preflight.CheckImagesWithClient("cluster", client)
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d results, got %+v", len(expected), results)
+	}
+	for _, result := range results {
+		status, ok := expected[result.Image]
+		if !ok {
+			t.Errorf("Unexpected image %s", result.Image)
+			continue
+		}
+		if result.Status != status {
+			t.Errorf("Expected status %s for %s, got %s", status, result.Image, result.Status)
+		}
+		if (result.Status == preflight.ImageCheckFailed) != (result.Err != nil) {
+			t.Errorf("Unexpected error for %s: %v", result.Image, result.Err)
+		}
+	}
+}
+
+func TestCheckImagesUnknownCluster(t *testing.T) {
+	results := preflight.CheckImagesWithClient("unknown", &fakeRegistryClient{})
+	if len(results) != 1 || results[0].Status != preflight.ImageCheckFailed || results[0].Err == nil {
+		t.Errorf("Expected a single failed result, got %+v", results)
+	}
+}
+
+// newTestRegistry serves the manifests of the images, a bearer token requested with the credential
+// is required when the credential is set
+func newTestRegistry(t *testing.T, tls bool, images map[string]bool, auth *preflight.RegistryAuth) *httptest.Server {
+	var server *httptest.Server
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			username, password, ok := r.BasicAuth()
+			if auth == nil || !ok || username != auth.Username || password != auth.Password {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"token": "secret"}`)
+			return
+		}
+		if auth != nil && r.Header.Get("Authorization") != "Bearer secret" {
+			w.Header().Set("Www-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		image := strings.Replace(strings.TrimPrefix(r.URL.Path, "/v2/"), "/manifests/", ":", 1)
+		if r.Method != http.MethodHead || !images[image] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	if tls {
+		server = httptest.NewTLSServer(handler)
+	} else {
+		server = httptest.NewServer(handler)
+	}
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPRegistryClient(t *testing.T) {
+	t.Setenv("REGISTRY_AUTH_FILE", filepath.Join(t.TempDir(), "auth.json"))
+	images := map[string]bool{"kube-proxy:v1.23.10": true, "mirror/pause:3.6": true}
+	plain := strings.TrimPrefix(newTestRegistry(t, false, images, nil).URL, "http://")
+	secure := strings.TrimPrefix(newTestRegistry(t, true, images, nil).URL, "https://")
+
+	tests := []struct {
+		name     string
+		registry asset.RegistryConfig
+		image    string
+		expected preflight.ImageStatus
+	}{
+		{
+			name:     "insecure registry over http",
+			registry: asset.RegistryConfig{Insecure: []string{plain}},
+			image:    plain + "/kube-proxy:v1.23.10",
+			expected: preflight.ImageFound,
+		},
+		{
+			name:     "insecure registry with unverified tls",
+			registry: asset.RegistryConfig{Insecure: []string{secure}},
+			image:    secure + "/kube-proxy:v1.23.10",
+			expected: preflight.ImageFound,
+		},
+		{
+			name:     "image not found",
+			registry: asset.RegistryConfig{Insecure: []string{plain}},
+			image:    plain + "/kube-scheduler:v1.23.10",
+			expected: preflight.ImageNotFound,
+		},
+		{
+			name:     "http is only used for insecure registries",
+			image:    plain + "/kube-proxy:v1.23.10",
+			expected: preflight.ImageCheckFailed,
+		},
+		{
+			name:     "tls is verified for secure registries",
+			image:    secure + "/kube-proxy:v1.23.10",
+			expected: preflight.ImageCheckFailed,
+		},
+		{
+			name: "mirror is tried before the registry",
+			registry: asset.RegistryConfig{
+				Mirrors:  map[string][]string{"registry.invalid/pause": {plain + "/missing", plain + "/mirror/pause"}},
+				Insecure: []string{plain},
+			},
+			image:    "registry.invalid/pause:3.6",
+			expected: preflight.ImageFound,
+		},
+		{
+			name: "registry result without the image in any mirror",
+			registry: asset.RegistryConfig{
+				Mirrors:  map[string][]string{"registry.invalid": {plain + "/missing"}},
+				Insecure: []string{plain},
+			},
+			image:    "registry.invalid/pause:3.6",
+			expected: preflight.ImageCheckFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := preflight.NewRegistryClient(&tt.registry).CheckImage(tt.image)
+			if status != tt.expected {
+				t.Errorf("Expected status %s, got %s: %v", tt.expected, status, err)
+			}
+			if (status == preflight.ImageCheckFailed) != (err != nil) {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestHTTPRegistryClientAuth(t *testing.T) {
+	images := map[string]bool{"kube-proxy:v1.23.10": true}
+	host := strings.TrimPrefix(newTestRegistry(t, false, images,
+		&preflight.RegistryAuth{Username: "user", Password: "pass"}).URL, "http://")
+	registry := &asset.RegistryConfig{Insecure: []string{host}}
+
+	tests := []struct {
+		name     string
+		auths    string
+		expected preflight.ImageStatus
+	}{
+		{name: "anonymous", expected: preflight.ImageUnauthorized},
+		{name: "wrong credential", auths: fmt.Sprintf(`{%q: {"auth": %q}}`, host,
+			base64.StdEncoding.EncodeToString([]byte("user:wrong"))), expected: preflight.ImageUnauthorized},
+		{name: "credential of the registry", auths: fmt.Sprintf(`{%q: {"auth": %q}}`, host,
+			base64.StdEncoding.EncodeToString([]byte("user:pass"))), expected: preflight.ImageFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authFile := filepath.Join(t.TempDir(), "auth.json")
+			if tt.auths != "" {
+				if err := os.WriteFile(authFile, []byte(`{"auths": `+tt.auths+`}`), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			t.Setenv("REGISTRY_AUTH_FILE", authFile)
+
+			status, err := preflight.NewRegistryClient(registry).CheckImage(host + "/kube-proxy:v1.23.10")
+			if err != nil || status != tt.expected {
+				t.Errorf("Expected status %s, got %s: %v", tt.expected, status, err)
+			}
+		})
+	}
+}
+
+func TestLoadAuthFile(t *testing.T) {
+	authFile := filepath.Join(t.TempDir(), "config.json")
+	content := fmt.Sprintf(`{"auths": {"https://index.docker.io/v1/": {"auth": %q}, "registry.example.com/team": {"auth": %q}}}`,
+		base64.StdEncoding.EncodeToString([]byte("hub:secret")), base64.StdEncoding.EncodeToString([]byte("team:p:w")))
+	if err := os.WriteFile(authFile, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	auths, err := preflight.LoadAuthFile(authFile)
+	if err != nil {
+		t.Fatalf("LoadAuthFile failed: %v", err)
+	}
+	expected := map[string]preflight.RegistryAuth{
+		"docker.io":                 {Username: "hub", Password: "secret"},
+		"registry.example.com/team": {Username: "team", Password: "p:w"},
+	}
+	if len(auths) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, auths)
+	}
+	for key, auth := range expected {
+		if auths[key] != auth {
+			t.Errorf("Expected %v for %s, got %v", auth, key, auths[key])
+		}
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokens_test
+
+import (
+	"nestos-kubernetes-deployer/pkg/tokens"
+	"testing"
+)
+
+func TestGenerateBootstrapToken(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		token, err := tokens.GenerateBootstrapToken()
+		if err != nil {
+			t.Fatalf("Error generating bootstrap token: %v", err)
+		}
+		if !tokens.BootstrapTokenPattern.MatchString(token) {
+			t.Errorf("Expected a token matching %s, got %s", tokens.BootstrapTokenPattern, token)
+		}
+		if seen[token] {
+			t.Errorf("Expected unique tokens, got %s twice", token)
+		}
+		seen[token] = true
+	}
+}
+
+// testCACert 的哈希由 openssl 计算：
+// openssl x509 -pubkey -noout | openssl pkey -pubin -outform der | sha256sum
+const testCACert = `-----BEGIN CERTIFICATE-----
+MIIBgDCCASegAwIBAgIUE8bguV9bBkp9U4rTIwbr5geD6vkwCgYIKoZIzj0EAwIw
+FTETMBEGA1UEAwwKa3ViZXJuZXRlczAgFw0yNjEwMTYxMTUyMjBaGA8yMTI2MDky
+MjExNTIyMFowFTETMBEGA1UEAwwKa3ViZXJuZXRlczBZMBMGByqGSM49AgEGCCqG
+SM49AwEHA0IABNSMRX8d3+I0TsAP5SMn0g0fSkbXofiv3GcClK1dN8RvpuPLLRdH
+DGf9zZMOev0tOwVbpaK6DRchhFdwvqC6dG2jUzBRMB0GA1UdDgQWBBQnJdoaybFp
+TMe4HH43ZEHH8Sh5tTAfBgNVHSMEGDAWgBQnJdoaybFpTMe4HH43ZEHH8Sh5tTAP
+BgNVHRMBAf8EBTADAQH/MAoGCCqGSM49BAMCA0cAMEQCID2M4qVrossepwkjGC0N
+eyhyef56y4Q1CBU/qySAEk+9AiB6bO12qDSVF16icwYbsZN78TsfYLHrbY1pxVp6
+MaAJgw==
+-----END CERTIFICATE-----
+`
+
+func TestComputeCACertHash(t *testing.T) {
+	expected := "sha256:6d19b4f01f51f05eb05ed1ec3e2ccdd87d48a07aa724e713ce84c00693d41735"
+	if hash := tokens.ComputeCACertHash([]byte(testCACert)); hash != expected {
+		t.Errorf("Expected hash %s, got %s", expected, hash)
+	}
+	if hash := tokens.ComputeCACertHash([]byte("not a certificate")); hash != "" {
+		t.Errorf("Expected an empty hash for an invalid certificate, got %s", hash)
+	}
+}
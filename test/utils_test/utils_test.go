@@ -0,0 +1,74 @@
+/*
+Copyright 2023 KylinSoft  Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils_test
+
+import (
+	"nestos-kubernetes-deployer/pkg/utils"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestGetCompleteFileTemplateFuncs(t *testing.T) {
+	tmplData := struct {
+		Token   string
+		Config  string
+		Runtime string
+		Name    string
+	}{
+		Token:  "abcdef.0123456789abcdef",
+		Config: "apiVersion: v1\nkind: Config",
+		Name:   "k8s-master01",
+	}
+	content := "token: {{ .Token | b64enc }}\n" +
+		"config: |\n{{ .Config | indent 2 }}\n" +
+		"runtime: {{ .Runtime | default \"isulad\" }}\n" +
+		"name: {{ .Name | quote }}\n"
+
+	name, data, err := utils.GetCompleteFile("config.yaml.template", strings.NewReader(content), tmplData)
+	if err != nil {
+		t.Fatalf("Error rendering template: %v", err)
+	}
+	if name != "config.yaml" {
+		t.Errorf("Expected the rendered file config.yaml, got %s", name)
+	}
+	expected := "token: YWJjZGVmLjAxMjM0NTY3ODlhYmNkZWY=\n" +
+		"config: |\n  apiVersion: v1\n  kind: Config\n" +
+		"runtime: isulad\n" +
+		"name: \"k8s-master01\"\n"
+	if string(data) != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, data)
+	}
+}
+
+func TestRegisterTemplateFuncs(t *testing.T) {
+	utils.RegisterTemplateFuncs(template.FuncMap{
+		"upper": strings.ToUpper,
+	})
+
+	_, data, err := utils.GetCompleteFile("hostname.template", strings.NewReader("{{ .Hostname | upper }}"),
+		map[string]string{"Hostname": "k8s-worker01"})
+	if err != nil {
+		t.Fatalf("Error rendering template: %v", err)
+	}
+	if string(data) != "K8S-WORKER01" {
+		t.Errorf("Expected K8S-WORKER01, got %s", data)
+	}
+	if _, ok := utils.TemplateFuncs()["upper"]; !ok {
+		t.Errorf("Expected the registered function to be listed")
+	}
+}